@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/heshanpadmasiri/javaGo/java"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// analyzeWithCache runs the analysis phase for ctx, reusing the results
+// stored in cacheDir by an earlier run on the same source if there are any.
+// An empty cacheDir disables the cache. Cache failures only produce a warning
+// since the analysis can always be redone.
+func analyzeWithCache(ctx *java.MigrationContext, tree *tree_sitter.Tree, cacheDir string) {
+	if cacheDir == "" {
+		java.AnalyzeTree(ctx, tree)
+		return
+	}
+	path := filepath.Join(cacheDir, ctx.AnalysisKey()+".json")
+	snapshot, err := readAnalysisSnapshot(path)
+	if err == nil {
+		ctx.RestoreAnalysis(snapshot)
+		return
+	}
+	if !os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring analysis cache %s: %v\n", path, err)
+	}
+	java.AnalyzeTree(ctx, tree)
+	if err := writeAnalysisSnapshot(path, ctx.AnalysisSnapshot()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: writing analysis cache failed: %v\n", err)
+	}
+}
+
+func readAnalysisSnapshot(path string) (java.AnalysisSnapshot, error) {
+	var snapshot java.AnalysisSnapshot
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snapshot, err
+	}
+	err = json.Unmarshal(data, &snapshot)
+	return snapshot, err
+}
+
+// writeAnalysisSnapshot writes through a temporary file so a concurrent run
+// never reads a partially written snapshot
+func writeAnalysisSnapshot(path string, snapshot java.AnalysisSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "analysis-*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+// TestAnalysisSnapshotRoundTrip checks that converting with analysis results
+// restored from a serialized snapshot matches a regular migration
+func TestAnalysisSnapshotRoundTrip(t *testing.T) {
+	for _, name := range corpusCases(t) {
+		t.Run(name, func(t *testing.T) {
+			javaSource := []byte(readJavaCase(t, name))
+			expected := migrateCorpusCase(t, name)
+
+			analyzed := java.NewFixture(string(javaSource), corpusConfig(t, name))
+			defer analyzed.Close()
+			java.AnalyzeTree(analyzed.Ctx, analyzed.Tree)
+			data, err := json.Marshal(analyzed.Ctx.AnalysisSnapshot())
+			if err != nil {
+				t.Fatalf("Failed to marshal snapshot: %v", err)
+			}
+			var snapshot java.AnalysisSnapshot
+			if err := json.Unmarshal(data, &snapshot); err != nil {
+				t.Fatalf("Failed to unmarshal snapshot: %v", err)
+			}
+
+			restored := java.NewFixture(string(javaSource), corpusConfig(t, name))
+			defer restored.Close()
+			restored.Ctx.RestoreAnalysis(snapshot)
+			java.ConvertTree(restored.Ctx, restored.Tree)
+			if got := restored.Ctx.Source.ToSource("", gosrc.PackageName); got != expected {
+				t.Errorf("Output with restored analysis differs:\n--- Restored ---\n%s\n--- Expected ---\n%s", got, expected)
+			}
+		})
+	}
+}
+
+func TestAnalyzeWithCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	src := java.JavaClass("Greeter", java.JavaMethod("public String greet(String name)", "return name;"))
+	typeMappings := map[string]string{"String": "string"}
+
+	migrate := func() string {
+		fixture := java.NewFixture(src, java.Config{TypeMappings: typeMappings})
+		defer fixture.Close()
+		analyzeWithCache(fixture.Ctx, fixture.Tree, cacheDir)
+		java.ConvertTree(fixture.Ctx, fixture.Tree)
+		return fixture.Ctx.Source.ToSource("", gosrc.PackageName)
+	}
+
+	first := migrate()
+	entries, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected a single cached snapshot, got %v (%v)", entries, err)
+	}
+	if second := migrate(); second != first {
+		t.Errorf("Output using the cached analysis differs:\n--- Cached ---\n%s\n--- Fresh ---\n%s", second, first)
+	}
+
+	// A corrupt snapshot is ignored and replaced
+	if err := os.WriteFile(entries[0], []byte("{"), 0o644); err != nil {
+		t.Fatalf("Failed to corrupt snapshot: %v", err)
+	}
+	if third := migrate(); third != first {
+		t.Errorf("Output with a corrupt cache differs:\n%s", third)
+	}
+	if _, err := readAnalysisSnapshot(entries[0]); err != nil {
+		t.Errorf("Expected the corrupt snapshot to be rewritten, got %v", err)
+	}
+
+	// Different options must not reuse the snapshot
+	analysisKey := func(cfg java.Config) string {
+		fixture := java.NewFixture(src, cfg)
+		defer fixture.Close()
+		return fixture.Ctx.AnalysisKey()
+	}
+	key := analysisKey(java.Config{TypeMappings: typeMappings})
+	if key == analysisKey(java.Config{}) {
+		t.Errorf("Expected type mappings to be part of the analysis key")
+	}
+	if key == analysisKey(java.Config{TypeMappings: typeMappings, FlatNestedClasses: true}) {
+		t.Errorf("Expected nested class naming to be part of the analysis key")
+	}
+}
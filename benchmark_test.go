@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+// Sizes of the generated benchmark inputs. The large variant is roughly the
+// size of the Ballerina parser sources this tool is used on.
+const (
+	benchSmallMethods = 50
+	benchLargeMethods = 600
+	benchEnumKinds    = 120
+)
+
+// generateParserLikeJava produces a synthetic Java source that resembles a
+// hand written recursive descent parser: a large token kind enum, a parser
+// class with state fields and many methods each dispatching over the kinds.
+// Only constructs supported by the converter are used so the benchmark
+// measures the happy path.
+func generateParserLikeJava(methods int) []byte {
+	sb := strings.Builder{}
+	sb.WriteString("public enum BenchKind {\n")
+	for i := range benchEnumKinds {
+		if i > 0 {
+			sb.WriteString(",\n")
+		}
+		fmt.Fprintf(&sb, "    KIND_%d", i)
+	}
+	sb.WriteString(";\n}\n\n")
+
+	sb.WriteString("public class BenchParser {\n")
+	sb.WriteString("    private int position;\n")
+	sb.WriteString("    private int errorCount;\n")
+	sb.WriteString("    private String source;\n\n")
+	sb.WriteString("    public BenchParser(String source, int position) {\n")
+	sb.WriteString("        this.source = source;\n")
+	sb.WriteString("        this.position = position;\n")
+	sb.WriteString("    }\n\n")
+	for i := range methods {
+		fmt.Fprintf(&sb, "    public int parseRule%d(BenchKind kind, int depth) {\n", i)
+		sb.WriteString("        int result = 0;\n")
+		sb.WriteString("        switch (kind) {\n")
+		for j := range 4 {
+			fmt.Fprintf(&sb, "            case KIND_%d:\n", (i+j)%benchEnumKinds)
+			fmt.Fprintf(&sb, "                result = result + %d;\n", j)
+			sb.WriteString("                break;\n")
+		}
+		sb.WriteString("            default:\n")
+		sb.WriteString("                result = depth;\n")
+		sb.WriteString("        }\n")
+		sb.WriteString("        if (depth > 10) {\n")
+		sb.WriteString("            errorCount = errorCount + 1;\n")
+		sb.WriteString("        } else if (depth > 5) {\n")
+		sb.WriteString("            result = result * 2;\n")
+		sb.WriteString("        } else {\n")
+		fmt.Fprintf(&sb, "            result = result - %d;\n", i)
+		sb.WriteString("        }\n")
+		sb.WriteString("        for (int i = 0; i < depth; i++) {\n")
+		sb.WriteString("            result = result + position;\n")
+		sb.WriteString("        }\n")
+		sb.WriteString("        while (result > 1000) {\n")
+		sb.WriteString("            result = result / 2;\n")
+		sb.WriteString("        }\n")
+		if i > 0 {
+			fmt.Fprintf(&sb, "        return result + parseRule%d(kind, depth + 1);\n", i-1)
+		} else {
+			sb.WriteString("        return result;\n")
+		}
+		sb.WriteString("    }\n\n")
+	}
+	sb.WriteString("}\n")
+	return []byte(sb.String())
+}
+
+func benchmarkParse(b *testing.B, javaSource []byte) {
+	b.SetBytes(int64(len(javaSource)))
+	b.ReportAllocs()
+	for b.Loop() {
+		tree := java.ParseJava(javaSource)
+		tree.Close()
+	}
+}
+
+func benchmarkAnalyze(b *testing.B, javaSource []byte) {
+	tree := java.ParseJava(javaSource)
+	defer tree.Close()
+	b.SetBytes(int64(len(javaSource)))
+	b.ReportAllocs()
+	for b.Loop() {
+		ctx := java.NewMigrationContext(javaSource, "BenchParser.java", nil, nil)
+		java.AnalyzeTree(ctx, tree)
+	}
+}
+
+func benchmarkConvert(b *testing.B, javaSource []byte) {
+	tree := java.ParseJava(javaSource)
+	defer tree.Close()
+	b.SetBytes(int64(len(javaSource)))
+	b.ReportAllocs()
+	for b.Loop() {
+		b.StopTimer()
+		ctx := java.NewMigrationContext(javaSource, "BenchParser.java", nil, nil)
+		java.AnalyzeTree(ctx, tree)
+		b.StartTimer()
+		java.ConvertTree(ctx, tree)
+	}
+}
+
+func benchmarkEmit(b *testing.B, javaSource []byte) {
+	tree := java.ParseJava(javaSource)
+	defer tree.Close()
+	ctx := java.NewMigrationContext(javaSource, "BenchParser.java", nil, nil)
+	java.MigrateTree(ctx, tree)
+	b.SetBytes(int64(len(javaSource)))
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = ctx.Source.ToSource("", "converted")
+	}
+}
+
+func benchmarkEndToEnd(b *testing.B, javaSource []byte) {
+	b.SetBytes(int64(len(javaSource)))
+	b.ReportAllocs()
+	for b.Loop() {
+		tree := java.ParseJava(javaSource)
+		ctx := java.NewMigrationContext(javaSource, "BenchParser.java", nil, nil)
+		java.MigrateTree(ctx, tree)
+		_ = ctx.Source.ToSource("", "converted")
+		tree.Close()
+	}
+}
+
+func BenchmarkMigration(b *testing.B) {
+	inputs := []struct {
+		name    string
+		methods int
+	}{
+		{name: "small", methods: benchSmallMethods},
+		{name: "large", methods: benchLargeMethods},
+	}
+	phases := []struct {
+		name string
+		fn   func(*testing.B, []byte)
+	}{
+		{name: "parse", fn: benchmarkParse},
+		{name: "analyze", fn: benchmarkAnalyze},
+		{name: "convert", fn: benchmarkConvert},
+		{name: "emit", fn: benchmarkEmit},
+		{name: "end_to_end", fn: benchmarkEndToEnd},
+	}
+	for _, input := range inputs {
+		javaSource := generateParserLikeJava(input.methods)
+		for _, phase := range phases {
+			b.Run(input.name+"/"+phase.name, func(b *testing.B) {
+				phase.fn(b, javaSource)
+			})
+		}
+	}
+}
+
+// TestBenchmarkInputMigratesCleanly guards the benchmark input: if the
+// generated source starts failing to migrate, the benchmark would silently
+// measure the error path instead.
+func TestBenchmarkInputMigratesCleanly(t *testing.T) {
+	javaSource := generateParserLikeJava(benchSmallMethods)
+	if lines := strings.Count(string(javaSource), "\n"); lines < 1000 {
+		t.Fatalf("Expected generated benchmark input to have at least 1000 lines, got %d", lines)
+	}
+	tree := java.ParseJava(javaSource)
+	defer tree.Close()
+
+	ctx := java.NewMigrationContext(javaSource, "BenchParser.java", nil, nil)
+	java.MigrateTree(ctx, tree)
+
+	if len(ctx.Errors) != 0 {
+		t.Fatalf("Expected benchmark input to migrate without errors, got %d: %v", len(ctx.Errors), ctx.Errors[0].Message)
+	}
+	// The enum of the input gets String and Ordinal methods on top of the
+	// migrated ones
+	if len(ctx.Source.Methods) != benchSmallMethods+2 {
+		t.Errorf("Expected %d migrated methods, got %d", benchSmallMethods+2, len(ctx.Source.Methods))
+	}
+	if _, err := formatGoCode(ctx.Source.ToSource("", "converted")); err != nil {
+		t.Errorf("Expected benchmark output to be valid Go syntax: %v", err)
+	}
+}
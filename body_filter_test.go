@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+func TestMethodBodyFilters(t *testing.T) {
+	src := java.JavaClass("Tasks",
+		"private int count;",
+		java.JavaMethod("public Tasks(int start)", "count = start;"),
+		java.JavaMethod("public int next()", "count += 1;", "return count;"),
+		// The lambda is unsupported; skipping the body must also skip the error
+		java.JavaMethod("public void run()", "Runnable r = () -> {};"),
+	)
+
+	tests := []struct {
+		name        string
+		cfg         java.Config
+		contains    []string
+		notContains []string
+		stubs       int
+		errors      int
+	}{
+		{
+			name:     "all_bodies",
+			cfg:      java.Config{},
+			contains: []string{"this.count = (this.count + 1)", "count = start"},
+			errors:   1,
+		},
+		{
+			name:        "stub_only",
+			cfg:         java.Config{StubOnly: true},
+			notContains: []string{"count + 1", "count = start"},
+			stubs:       2,
+		},
+		{
+			name:     "only_listed_methods",
+			cfg:      java.Config{OnlyMethods: map[string]bool{"next": true}},
+			contains: []string{"this.count = (this.count + 1)", "count = start"},
+			stubs:    1,
+		},
+		{
+			name:        "manual_method",
+			cfg:         java.Config{ManualMethods: map[string]bool{"next": true}},
+			contains:    []string{"// FIXME: port by hand", "//     count += 1;", "// }"},
+			notContains: []string{"this.count = (this.count + 1)"},
+			stubs:       1,
+			errors:      1,
+		},
+		{
+			name:     "qualified_manual_method",
+			cfg:      java.Config{ManualMethods: map[string]bool{"Tasks.run": true, "Other.next": true}},
+			contains: []string{"//     Runnable r = () -> {};", "this.count = (this.count + 1)"},
+			stubs:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := java.MigrateString(src, tt.cfg)
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("Expected output not to contain %q, got:\n%s", unwanted, got)
+				}
+			}
+			if stubs := strings.Count(got, `panic("not migrated")`); stubs != tt.stubs {
+				t.Errorf("Expected %d stubbed bodies, got %d:\n%s", tt.stubs, stubs, got)
+			}
+			if len(errs) != tt.errors {
+				t.Errorf("Expected %d migration errors, got %d: %v", tt.errors, len(errs), errs)
+			}
+		})
+	}
+}
+
+func TestManualMethodDirective(t *testing.T) {
+	src := java.JavaClass("Bits",
+		"// javago:manual",
+		java.JavaMethod("int mix(int h)", "return h ^ (h >>> 16);"),
+		java.JavaMethod("int twice(int h)", "return mix(mix(h));"),
+	)
+	got, errs := java.MigrateString(src, java.Config{})
+	if len(errs) != 0 {
+		t.Fatalf("Unexpected migration errors: %v", errs)
+	}
+	for _, want := range []string{"mix(h int) int {", "//     return h ^ (h >>> 16);", `panic("not migrated")`, "return this.mix(this.mix(h))"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestParseOnlyMethods(t *testing.T) {
+	if parseOnlyMethods("") != nil {
+		t.Errorf("Expected no filter for an empty -only flag")
+	}
+	got := parseOnlyMethods("next, run,,")
+	if len(got) != 2 || !got["next"] || !got["run"] {
+		t.Errorf("Unexpected -only methods: %v", got)
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// checkModule is the module path of the temporary module generated sources
+// are compiled in
+const checkModule = "javagocheck"
+
+var (
+	// compilerDiagnostic matches the diagnostics go build and go vet print,
+	// such as "./point.go:12:5: undefined: foo"
+	compilerDiagnostic = regexp.MustCompile(`^(?:vet: )?(?:\./)?([^\s:]+\.go):(\d+):(\d+): (.*)$`)
+	// migrationComment matches the comments recording where in the Java
+	// source a Go declaration was migrated from
+	migrationComment = regexp.MustCompile(`migrated from (\S+\.java):(\d+):\d+`)
+)
+
+// checkDiagnostic is a compile diagnostic of a generated file, mapped back
+// to the Java source it was migrated from where possible
+type checkDiagnostic struct {
+	goFile   string // Path of the Go file, relative to the checked directory
+	goLine   int
+	goColumn int
+	javaFile string // Java file of the closest preceding migration comment, empty if there is none
+	javaLine int
+	message  string
+}
+
+func (d checkDiagnostic) String() string {
+	if d.javaFile == "" {
+		return fmt.Sprintf("%s:%d:%d: %s", d.goFile, d.goLine, d.goColumn, d.message)
+	}
+	return fmt.Sprintf("%s:%d: %s (%s:%d:%d)", d.javaFile, d.javaLine, d.message, d.goFile, d.goLine, d.goColumn)
+}
+
+// checkGoFiles compiles and vets files, which map paths relative to the
+// module root to their content, in a temporary module and returns their
+// diagnostics
+func checkGoFiles(files map[string][]byte) ([]checkDiagnostic, error) {
+	dir, err := os.MkdirTemp("", "javago-check-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	goMod := fmt.Sprintf("module %s\n\ngo %s\n", checkModule, goVersion())
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o644); err != nil {
+		return nil, err
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	// Vet only runs on packages that build, so it only adds to build errors
+	// once there are none
+	output, err := runGoCommand(dir, "build", "./...")
+	if err == nil {
+		output, err = runGoCommand(dir, "vet", "./...")
+	}
+	if _, failed := err.(*exec.ExitError); err != nil && !failed {
+		return nil, err
+	}
+	return parseCheckOutput(output, files), nil
+}
+
+// runGoCommand runs the go tool with args in dir and returns its combined
+// output
+func runGoCommand(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	// The generated module has no dependencies, so nothing is downloaded
+	cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local")
+	return cmd.CombinedOutput()
+}
+
+// goVersion returns the language version of the running toolchain, such as
+// 1.24, to declare in the temporary module
+func goVersion() string {
+	version := strings.TrimPrefix(runtime.Version(), "go")
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		// Development toolchains have no release version
+		return "1.24"
+	}
+	return parts[0] + "." + strings.TrimFunc(parts[1], func(r rune) bool { return r < '0' || r > '9' })
+}
+
+// parseCheckOutput extracts the diagnostics from the output of the go tool,
+// mapping each to the Java line of the closest preceding migration comment
+// in its file
+func parseCheckOutput(output []byte, files map[string][]byte) []checkDiagnostic {
+	var diagnostics []checkDiagnostic
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		match := compilerDiagnostic.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+		goLine, _ := strconv.Atoi(match[2])
+		goColumn, _ := strconv.Atoi(match[3])
+		diagnostic := checkDiagnostic{
+			goFile:   filepath.ToSlash(match[1]),
+			goLine:   goLine,
+			goColumn: goColumn,
+			message:  match[4],
+		}
+		diagnostic.javaFile, diagnostic.javaLine = javaOrigin(files[diagnostic.goFile], goLine)
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+// javaOrigin returns the Java file and line recorded by the last migration
+// comment at or before line of the Go source, or an empty file name if
+// there is none
+func javaOrigin(goSource []byte, line int) (string, int) {
+	var javaFile string
+	var javaLine int
+	scanner := bufio.NewScanner(bytes.NewReader(goSource))
+	for i := 1; i <= line && scanner.Scan(); i++ {
+		if match := migrationComment.FindStringSubmatch(scanner.Text()); match != nil {
+			javaFile = match[1]
+			javaLine, _ = strconv.Atoi(match[2])
+		}
+	}
+	return javaFile, javaLine
+}
+
+// reportCheck writes diagnostics to w followed by a per-file count of them,
+// and reports whether every file compiled cleanly
+func reportCheck(w io.Writer, files map[string][]byte, diagnostics []checkDiagnostic) bool {
+	counts := make(map[string]int)
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintf(w, "check: %s\n", diagnostic)
+		counts[diagnostic.goFile]++
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		switch count := counts[name]; count {
+		case 0:
+			fmt.Fprintf(w, "check: %s compiles\n", name)
+		default:
+			fmt.Fprintf(w, "check: %s has %d compile diagnostics\n", name, count)
+		}
+	}
+	return len(diagnostics) == 0
+}
+
+// runCheck checks files and reports the result on stderr, returning the
+// process exit code: 1 if any file has diagnostics
+func runCheck(files map[string][]byte) int {
+	diagnostics, err := checkGoFiles(files)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Fatal: checking generated source failed: %v\n", err)
+		return 1
+	}
+	if !reportCheck(os.Stderr, files, diagnostics) {
+		return 1
+	}
+	return 0
+}
+
+// readGoFiles reads the Go files under dir, keyed by their slash separated
+// path relative to dir
+func readGoFiles(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	return files, err
+}
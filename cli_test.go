@@ -0,0 +1,509 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var (
+	cliBuildOnce sync.Once
+	cliBuildDir  string
+	cliBinary    string
+	cliBuildErr  error
+)
+
+// buildCLI builds the javaGo binary once per test run and returns its path
+func buildCLI(t *testing.T) string {
+	t.Helper()
+	cliBuildOnce.Do(func() {
+		cliBuildDir, cliBuildErr = os.MkdirTemp("", "javago-cli-*")
+		if cliBuildErr != nil {
+			return
+		}
+		cliBinary = filepath.Join(cliBuildDir, "javaGo")
+		output, err := exec.Command("go", "build", "-o", cliBinary, ".").CombinedOutput()
+		if err != nil {
+			cliBuildErr = errors.New(string(output))
+		}
+	})
+	if cliBuildErr != nil {
+		t.Fatalf("Failed to build javaGo binary: %v", cliBuildErr)
+	}
+	return cliBinary
+}
+
+// cleanupCLI removes the binary built by buildCLI, if any
+func cleanupCLI() {
+	if cliBuildDir != "" {
+		os.RemoveAll(cliBuildDir)
+	}
+}
+
+type cliResult struct {
+	exitCode int
+	stdout   string
+	stderr   string
+}
+
+// runCLI runs the javaGo binary with args inside dir
+func runCLI(t *testing.T, dir string, args ...string) cliResult {
+	t.Helper()
+	cmd := exec.Command(buildCLI(t), args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	exitCode := 0
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitCode = exitErr.ExitCode()
+	} else if err != nil {
+		t.Fatalf("Failed to run javaGo: %v", err)
+	}
+	return cliResult{exitCode: exitCode, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+// writeFiles creates each file (path relative to dir) with its content
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(content)
+}
+
+func TestCLI(t *testing.T) {
+	const pointJava = "public record Point(int x, int y) {}"
+	const brokenJava = "class Broken {\n    @interface Marker {}\n    int value;\n}"
+	const strsJava = "package com.example.util;\n\npublic class Strs {\n    public static int twice(int x) { return x * 2; }\n}"
+	const twiceJava = "package com.example.app;\n\nimport com.example.util.Strs;\n\nclass Twice {\n    int run() { return Strs.twice(2); }\n}"
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		args     []string
+		exitCode int
+		// check is run after the binary exits with the expected code
+		check func(t *testing.T, dir string, result cliResult)
+	}{
+		{
+			name:     "no_args_prints_usage",
+			args:     nil,
+			exitCode: 1,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stderr, "Usage: javaGo") {
+					t.Errorf("Expected usage on stderr, got: %s", result.stderr)
+				}
+			},
+		},
+		{
+			name:     "missing_source_file",
+			args:     []string{"Missing.java"},
+			exitCode: 1,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stderr, "reading source file failed") {
+					t.Errorf("Expected read failure on stderr, got: %s", result.stderr)
+				}
+			},
+		},
+		{
+			name:     "source_to_stdout",
+			files:    map[string]string{"Point.java": pointJava},
+			args:     []string{"Point.java"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stdout, "package converted") || !strings.Contains(result.stdout, "type Point struct") {
+					t.Errorf("Expected migrated source on stdout, got: %s", result.stdout)
+				}
+			},
+		},
+		{
+			name:     "output_is_formatted",
+			files:    map[string]string{"Point.java": pointJava},
+			args:     []string{"Point.java"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stdout, "type Point struct {\n\tX int\n") {
+					t.Errorf("Expected gofmt formatted source on stdout, got: %s", result.stdout)
+				}
+			},
+		},
+		{
+			name:     "no_format_keeps_raw_output",
+			files:    map[string]string{"Point.java": pointJava},
+			args:     []string{"-no-format", "Point.java"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stdout, "type Point struct {\n    X int\n") {
+					t.Errorf("Expected unformatted source on stdout, got: %s", result.stdout)
+				}
+			},
+		},
+		{
+			name:     "check_passes",
+			files:    map[string]string{"Point.java": pointJava},
+			args:     []string{"-check", "Point.java", "point.go"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stderr, "check: point.go compiles") {
+					t.Errorf("Expected a clean check on stderr, got: %s", result.stderr)
+				}
+			},
+		},
+		{
+			// Comparisons of Comparable type parameters have to compile
+			// against their cmp.Ordered constraint
+			name:     "check_passes_for_generics",
+			files:    map[string]string{"Generics.java": readFile(t, "testdata/java/generic_classes_and_methods.java")},
+			args:     []string{"-check", "Generics.java", "generics.go"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stderr, "check: generics.go compiles") {
+					t.Errorf("Expected a clean check on stderr, got: %s", result.stderr)
+				}
+			},
+		},
+		{
+			name:     "check_maps_diagnostics_to_java_lines",
+			files:    map[string]string{"Counter.java": "class Counter {\n    int count;\n\n    int next() {\n        return Missing.next(this.count);\n    }\n}"},
+			args:     []string{"-check", "Counter.java"},
+			exitCode: 1,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stdout, "package converted") {
+					t.Errorf("Expected migrated source on stdout, got: %s", result.stdout)
+				}
+				if !strings.Contains(result.stderr, "check: Counter.java:4: undefined: Missing (counter.go:") {
+					t.Errorf("Expected a diagnostic at the Java method on stderr, got: %s", result.stderr)
+				}
+				if !strings.Contains(result.stderr, "check: counter.go has 1 compile diagnostics") {
+					t.Errorf("Expected a per-file summary on stderr, got: %s", result.stderr)
+				}
+			},
+		},
+		{
+			name:     "report_json",
+			files:    map[string]string{"Point.java": pointJava},
+			args:     []string{"-report", "json", "Point.java", "point.go"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				out := readFile(t, filepath.Join(dir, "migration_report.json"))
+				for _, want := range []string{`"java_file": "Point.java"`, `"go_file": "point.go"`, `"todo_density"`} {
+					if !strings.Contains(out, want) {
+						t.Errorf("Expected %s in the report, got: %s", want, out)
+					}
+				}
+			},
+		},
+		{
+			name:     "report_html_for_project",
+			files:    map[string]string{"src/Point.java": pointJava, "src/Main.java": "class Main {}"},
+			args:     []string{"-report", "html", "-out-dir", "out", "src"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				out := readFile(t, filepath.Join(dir, "out", "migration_report.html"))
+				if !strings.Contains(out, "<td>Point.java</td><td>point.go</td>") || !strings.Contains(out, "<td>Main.java</td>") {
+					t.Errorf("Expected a row per file in the report, got: %s", out)
+				}
+			},
+		},
+		{
+			name:     "report_unknown_format",
+			files:    map[string]string{"Point.java": pointJava},
+			args:     []string{"-report", "xml", "Point.java"},
+			exitCode: 1,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stderr, `unknown report format "xml"`) {
+					t.Errorf("Expected an unknown format error on stderr, got: %s", result.stderr)
+				}
+			},
+		},
+		{
+			name:     "source_to_dest_file",
+			files:    map[string]string{"Point.java": pointJava},
+			args:     []string{"Point.java", "point.go"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if result.stdout != "" {
+					t.Errorf("Expected nothing on stdout when writing to a file, got: %s", result.stdout)
+				}
+				if out := readFile(t, filepath.Join(dir, "point.go")); !strings.Contains(out, "type Point struct") {
+					t.Errorf("Expected migrated source in point.go, got: %s", out)
+				}
+			},
+		},
+		{
+			name: "config_in_working_directory",
+			files: map[string]string{
+				"Point.java":  pointJava,
+				"Config.toml": "package_name = \"geometry\"\n",
+			},
+			args:     []string{"Point.java"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stdout, "package geometry") {
+					t.Errorf("Expected package from Config.toml, got: %s", result.stdout)
+				}
+			},
+		},
+		{
+			name: "config_path_flag",
+			files: map[string]string{
+				"Point.java":       pointJava,
+				"Config.toml":      "package_name = \"ignored\"\n",
+				"conf/custom.toml": "package_name = \"custom\"\n",
+			},
+			args:     []string{"-config", "conf/custom.toml", "Point.java"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stdout, "package custom") {
+					t.Errorf("Expected package from -config file, got: %s", result.stdout)
+				}
+			},
+		},
+		{
+			name:     "missing_config_path",
+			files:    map[string]string{"Point.java": pointJava},
+			args:     []string{"-config", "nope.toml", "Point.java"},
+			exitCode: 1,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stderr, "loading config failed") {
+					t.Errorf("Expected config failure on stderr, got: %s", result.stderr)
+				}
+			},
+		},
+		{
+			name:     "errors_are_recovered_by_default",
+			files:    map[string]string{"Broken.java": brokenJava},
+			args:     []string{"Broken.java"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stdout, "// FIXME: Failed to migrate") {
+					t.Errorf("Expected failed migration comment in output, got: %s", result.stdout)
+				}
+			},
+		},
+		{
+			name:     "werror_exits_on_first_error",
+			files:    map[string]string{"Broken.java": brokenJava},
+			args:     []string{"-Werror", "Broken.java", "broken.go"},
+			exitCode: 1,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if _, err := os.Stat(filepath.Join(dir, "broken.go")); err == nil {
+					t.Errorf("Expected no output file to be written in strict mode")
+				}
+			},
+		},
+		{
+			name: "strict_config_exits_on_its_categories",
+			files: map[string]string{
+				"Broken.java": brokenJava,
+				"Config.toml": "[strict]\nexpressions = true\n",
+			},
+			args:     []string{"Broken.java", "broken.go"},
+			exitCode: 1,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if _, err := os.Stat(filepath.Join(dir, "broken.go")); err == nil {
+					t.Errorf("Expected no output file to be written for a strict category")
+				}
+			},
+		},
+		{
+			name: "strict_config_recovers_other_categories",
+			files: map[string]string{
+				"Broken.java": brokenJava,
+				"Config.toml": "[strict]\ntypes = true\nlibrary_calls = true\n",
+			},
+			args:     []string{"Broken.java"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stdout, "// FIXME: Failed to migrate") {
+					t.Errorf("Expected failed migration comment in output, got: %s", result.stdout)
+				}
+			},
+		},
+		{
+			name: "migration_notes_hold_failed_migrations",
+			files: map[string]string{
+				"Broken.java": brokenJava,
+				"Config.toml": "migration_notes = true\n",
+			},
+			args:     []string{"Broken.java", "broken.go"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				checkMigrationNotes(t, dir)
+			},
+		},
+		{
+			name: "migration_notes_in_low_memory_mode",
+			files: map[string]string{
+				"Broken.java": brokenJava,
+				"Config.toml": "migration_notes = true\n",
+			},
+			args:     []string{"-low-memory", "Broken.java", "broken.go"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				checkMigrationNotes(t, dir)
+			},
+		},
+		{
+			name:     "stub_only_skips_bodies",
+			files:    map[string]string{"Counter.java": "class Counter {\n    int next() { return 1; }\n}"},
+			args:     []string{"-stub-only", "Counter.java"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stdout, `panic("not migrated")`) || strings.Contains(result.stdout, "return 1") {
+					t.Errorf("Expected a stubbed method body, got: %s", result.stdout)
+				}
+			},
+		},
+		{
+			name: "out_dir_mirrors_java_packages",
+			files: map[string]string{
+				"src/com/example/geometry/Point.java": "package com.example.geometry;\n\n" + pointJava,
+				"src/com/example/Shapes.java":         "package com.example;\n\nclass Shapes {\n    int count;\n}",
+				"src/Main.java":                       "class Main {}",
+			},
+			args:     []string{"-out-dir", "out", "src"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if out := readFile(t, filepath.Join(dir, "out", "com", "example", "geometry", "point.go")); !strings.Contains(out, "package geometry") || !strings.Contains(out, "type Point struct") {
+					t.Errorf("Expected Point in package geometry, got: %s", out)
+				}
+				if out := readFile(t, filepath.Join(dir, "out", "com", "example", "shapes.go")); !strings.Contains(out, "package example") {
+					t.Errorf("Expected Shapes in package example, got: %s", out)
+				}
+				if out := readFile(t, filepath.Join(dir, "out", "main.go")); !strings.Contains(out, "package converted") {
+					t.Errorf("Expected a file without package declaration in the default package, got: %s", out)
+				}
+			},
+		},
+		{
+			name: "out_dir_qualifies_references_in_a_module",
+			files: map[string]string{
+				"go.mod":                         "module example.org/proj\n\ngo 1.24\n",
+				"src/com/example/util/Strs.java": strsJava,
+				"src/com/example/app/Twice.java": twiceJava,
+			},
+			args:     []string{"-out-dir", "out", "src"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				out := readFile(t, filepath.Join(dir, "out", "com", "example", "app", "twice.go"))
+				if !strings.Contains(out, `"example.org/proj/out/com/example/util"`) || !strings.Contains(out, "return util.Twice(2)") {
+					t.Errorf("Expected the call qualified by the package of the module, got: %s", out)
+				}
+			},
+		},
+		{
+			name: "out_dir_reports_references_outside_a_module",
+			files: map[string]string{
+				"src/com/example/util/Strs.java": strsJava,
+				"src/com/example/app/Twice.java": twiceJava,
+			},
+			args:     []string{"-out-dir", "out", "src"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stderr, "com.example.util.Strs is imported from package com.example.util of the project") {
+					t.Errorf("Expected the unqualified import reported, got: %s", result.stderr)
+				}
+			},
+		},
+		{
+			name: "out_dir_shares_generic_optional",
+			files: map[string]string{
+				"Config.toml":    "optionals = \"generic\"\n",
+				"src/Cache.java": "class Cache {\n    Optional<String> label;\n}",
+				"src/Index.java": "class Index {\n    Optional<Integer> first;\n}",
+			},
+			args:     []string{"-out-dir", "out", "src"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				for _, file := range []string{"cache.go", "index.go"} {
+					if out := readFile(t, filepath.Join(dir, "out", file)); strings.Contains(out, "type Optional[T any] struct") {
+						t.Errorf("Expected %s to leave Optional to optional_support.go, got: %s", file, out)
+					}
+				}
+				if out := readFile(t, filepath.Join(dir, "out", "optional_support.go")); !strings.Contains(out, "package converted") || !strings.Contains(out, "type Optional[T any] struct") {
+					t.Errorf("Expected Optional declared once in the package, got: %s", out)
+				}
+			},
+		},
+		{
+			name:     "out_dir_missing_source_dir",
+			args:     []string{"-out-dir", "out", "missing"},
+			exitCode: 1,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stderr, "migrating project failed") {
+					t.Errorf("Expected project failure on stderr, got: %s", result.stderr)
+				}
+			},
+		},
+		{
+			name:     "watch_requires_source_and_dest",
+			files:    map[string]string{"Point.java": pointJava},
+			args:     []string{"watch", "Point.java"},
+			exitCode: 1,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if !strings.Contains(result.stderr, "Usage: javaGo watch") {
+					t.Errorf("Expected watch usage on stderr, got: %s", result.stderr)
+				}
+			},
+		},
+		{
+			name:     "corpus_run_writes_snapshot",
+			files:    map[string]string{"src/Point.java": pointJava},
+			args:     []string{"corpus", "run", "-snapshot", "snap.json", "src"},
+			exitCode: 0,
+			check: func(t *testing.T, dir string, result cliResult) {
+				if out := readFile(t, filepath.Join(dir, "snap.json")); !strings.Contains(out, "\"Point.java\"") {
+					t.Errorf("Expected snapshot to record Point.java, got: %s", out)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			result := runCLI(t, dir, tt.args...)
+			if result.exitCode != tt.exitCode {
+				t.Fatalf("Expected exit code %d, got %d\nstdout: %s\nstderr: %s", tt.exitCode, result.exitCode, result.stdout, result.stderr)
+			}
+			tt.check(t, dir, result)
+		})
+	}
+}
+
+// checkMigrationNotes checks that the failed migration of Broken.java is
+// described in broken_migration_notes.md rather than in broken.go
+func checkMigrationNotes(t *testing.T, dir string) {
+	t.Helper()
+	goSource := readFile(t, filepath.Join(dir, "broken.go"))
+	if !strings.Contains(goSource, "// See broken_migration_notes.md for details") || strings.Contains(goSource, "Java source:") {
+		t.Errorf("Expected a pointer to the notes instead of the details, got: %s", goSource)
+	}
+	notes := readFile(t, filepath.Join(dir, "broken_migration_notes.md"))
+	if !strings.Contains(notes, "# Migration notes for broken.go") || !strings.Contains(notes, "```java\n@interface Marker {}\n```") {
+		t.Errorf("Expected the details of the failed migration in the notes, got: %s", notes)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/heshanpadmasiri/javaGo/gosrc"
+	"github.com/heshanpadmasiri/javaGo/java"
 	"github.com/pelletier/go-toml/v2"
 )
 
@@ -13,31 +14,91 @@ type config struct {
 	PackageName   string            `toml:"package_name"`
 	LicenseHeader string            `toml:"license_header"`
 	TypeMappings  map[string]string `toml:"type_mappings"`
+	// PackageMappings maps Java package prefixes to the import paths of the
+	// Go packages they are migrated to
+	PackageMappings map[string]string `toml:"package_mappings"`
+	// DeepCopy makes generated Clone and Copy methods copy slice and map
+	// fields instead of sharing them with the original
+	DeepCopy bool `toml:"deep_copy"`
+	// FlatNestedClasses keeps the own name of hoisted static nested classes
+	// instead of prefixing it with the name of the enclosing class
+	FlatNestedClasses bool `toml:"flat_nested_classes"`
+	// ClassLiterals selects how Foo.class is migrated: "reflect" (the
+	// default), "name" or "drop"
+	ClassLiterals string `toml:"class_literals"`
+	// Annotations selects how @interface declarations are migrated:
+	// "struct" (the default) or "skip"
+	Annotations string `toml:"annotations"`
+	// Optionals selects how java.util.Optional is migrated: "pointer" (the
+	// default) or "generic"
+	Optionals string `toml:"optionals"`
+	// SystemProperties maps System.getProperty keys to the Go expressions
+	// they are migrated to, on top of the well-known ones
+	SystemProperties map[string]string `toml:"system_properties"`
+	// Exceptions maps exception names to how throwing them is migrated:
+	// "panic", "error-return" or a Go statement using $error
+	Exceptions map[string]string `toml:"exceptions"`
+	// PrivateHelperFunctions migrates private instance methods that use
+	// neither the fields nor the other methods of the instance to package
+	// functions
+	PrivateHelperFunctions bool `toml:"private_helpers_as_functions"`
+	// ManualMethods lists the Java methods, optionally qualified as
+	// Class.method, whose bodies are kept as comments to port by hand
+	ManualMethods []string `toml:"manual_methods"`
+	// MigrationNotes writes the details of failed migrations to a sibling
+	// foo_migration_notes.md of the Go file foo.go instead of appending them
+	// to it as comments
+	MigrationNotes bool `toml:"migration_notes"`
+	// Strict selects the categories of migration errors that are fatal, as
+	// -Werror does for all of them
+	Strict strictConfig `toml:"strict"`
+	// noFormat writes the generated source as it is instead of formatting
+	// it. It is set by the -no-format flag.
+	noFormat bool
 }
 
-// loadConfig loads migration configuration from Config.toml
-func loadConfig() config {
-	c := config{
-		PackageName:   gosrc.PackageName,
-		LicenseHeader: "",
-	}
+// strictConfig is the strict table of Config.toml, with a key per category
+// of migration errors
+type strictConfig struct {
+	Expressions  bool `toml:"expressions"`
+	Types        bool `toml:"types"`
+	LibraryCalls bool `toml:"library_calls"`
+	Concurrency  bool `toml:"concurrency"`
+}
 
+// loadConfig loads migration configuration from Config.toml in the current
+// working directory, falling back to defaults if it is missing or invalid
+func loadConfig() config {
 	wd, err := os.Getwd()
 	if err != nil {
-		return c
+		return defaultConfig()
+	}
+	c, err := loadConfigFrom(filepath.Join(wd, "Config.toml"))
+	if err != nil {
+		// Config file doesn't exist or is invalid, return defaults
+		return defaultConfig()
 	}
+	return c
+}
 
-	configPath := filepath.Join(wd, "Config.toml")
+func defaultConfig() config {
+	return config{
+		PackageName:   gosrc.PackageName,
+		LicenseHeader: "",
+	}
+}
+
+// loadConfigFrom loads migration configuration from the TOML file at path
+func loadConfigFrom(configPath string) (config, error) {
+	c := defaultConfig()
 	data, err := os.ReadFile(configPath)
 	if err != nil {
-		// Config file doesn't exist, return defaults
-		return c
+		return c, err
 	}
 
 	var fileConfig config
 	if err := toml.Unmarshal(data, &fileConfig); err != nil {
-		// Invalid TOML, return defaults
-		return c
+		return c, err
 	}
 
 	// Use values from file if provided, otherwise keep defaults
@@ -50,6 +111,61 @@ func loadConfig() config {
 	if fileConfig.TypeMappings != nil {
 		c.TypeMappings = fileConfig.TypeMappings
 	}
+	c.PackageMappings = fileConfig.PackageMappings
+	c.DeepCopy = fileConfig.DeepCopy
+	c.FlatNestedClasses = fileConfig.FlatNestedClasses
+	c.ClassLiterals = fileConfig.ClassLiterals
+	c.Annotations = fileConfig.Annotations
+	c.Optionals = fileConfig.Optionals
+	c.SystemProperties = fileConfig.SystemProperties
+	c.Exceptions = fileConfig.Exceptions
+	c.PrivateHelperFunctions = fileConfig.PrivateHelperFunctions
+	c.ManualMethods = fileConfig.ManualMethods
+	c.MigrationNotes = fileConfig.MigrationNotes
+	c.Strict = fileConfig.Strict
 
-	return c
+	return c, nil
+}
+
+// options returns the migration options c configures, which the flags of a
+// run may override
+func (c config) options() java.Options {
+	return java.Options{
+		PackageMappings:        c.PackageMappings,
+		ManualMethods:          c.manualMethods(),
+		DeepCopy:               c.DeepCopy,
+		FlatNestedClasses:      c.FlatNestedClasses,
+		ClassLiterals:          c.ClassLiterals,
+		Annotations:            c.Annotations,
+		Optionals:              c.Optionals,
+		SystemProperties:       c.SystemProperties,
+		ExceptionPolicies:      c.Exceptions,
+		PrivateHelperFunctions: c.PrivateHelperFunctions,
+	}
+}
+
+// manualMethods returns the set of ManualMethods, or nil if there are none
+func (c config) manualMethods() map[string]bool {
+	if len(c.ManualMethods) == 0 {
+		return nil
+	}
+	methods := make(map[string]bool, len(c.ManualMethods))
+	for _, name := range c.ManualMethods {
+		methods[name] = true
+	}
+	return methods
+}
+
+// strictness returns the categories of migration errors that are fatal, all
+// of them with -Werror
+func (c config) strictness(werror bool) java.Strictness {
+	if werror {
+		return java.StrictAll()
+	}
+	return java.Strictness{
+		java.CategoryExpressions:  c.Strict.Expressions,
+		java.CategoryTypes:        c.Strict.Types,
+		java.CategoryLibraryCalls: c.Strict.LibraryCalls,
+		java.CategoryConcurrency:  c.Strict.Concurrency,
+	}
 }
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+// TestConcurrentConversionMatchesSerial checks that converting top-level
+// declarations concurrently (non-strict mode) produces exactly the output of
+// the serial conversion used in strict mode
+func TestConcurrentConversionMatchesSerial(t *testing.T) {
+	for _, name := range corpusCases(t) {
+		t.Run(name, func(t *testing.T) {
+			cfg := corpusConfig(t, name)
+			serial, serialErrs := java.MigrateString(readJavaCase(t, name), cfg)
+			cfg.Strictness = nil
+			concurrent, errs := java.MigrateString(readJavaCase(t, name), cfg)
+			if len(errs) != len(serialErrs) {
+				t.Fatalf("Expected the %d migration errors of the serial conversion, got %v", len(serialErrs), errs)
+			}
+			if concurrent != serial {
+				t.Errorf("Concurrent output differs from serial output:\n--- Concurrent ---\n%s\n--- Serial ---\n%s", concurrent, serial)
+			}
+		})
+	}
+}
+
+func TestConcurrentConversionIsDeterministic(t *testing.T) {
+	src := string(generateParserLikeJava(40)) + "\nenum Late { FIRST, SECOND }\nclass Broken {\n    @interface Marker {}\n}\nclass Another {\n    @interface Marker {}\n}\n"
+	first, firstErrs := java.MigrateString(src, java.Config{})
+	for range 5 {
+		again, againErrs := java.MigrateString(src, java.Config{})
+		if again != first {
+			t.Fatalf("Concurrent conversion produced different output between runs")
+		}
+		if len(againErrs) != len(firstErrs) {
+			t.Fatalf("Expected %d errors, got %d", len(firstErrs), len(againErrs))
+		}
+		for i := range againErrs {
+			if againErrs[i].Location != firstErrs[i].Location || againErrs[i].Line != firstErrs[i].Line {
+				t.Fatalf("Errors are not reported in declaration order: %v vs %v", againErrs[i], firstErrs[i])
+			}
+		}
+	}
+	if len(firstErrs) != 2 || firstErrs[0].Line > firstErrs[1].Line {
+		t.Errorf("Expected two errors in source order, got %v", firstErrs)
+	}
+}
+
+func TestEnumConstantsResolveBeforeDeclaration(t *testing.T) {
+	src := java.JavaClass("User",
+		java.JavaMethod("boolean isActive(int status)", "return status == ACTIVE;"),
+	) + "enum Status { ACTIVE, INACTIVE }\n"
+	got, errs := java.MigrateString(src, java.Config{})
+	if len(errs) != 0 {
+		t.Fatalf("Expected no migration errors, got %v", errs)
+	}
+	if !strings.Contains(got, "status == Status_ACTIVE") {
+		t.Errorf("Expected enum constant declared later in the file to be resolved, got:\n%s", got)
+	}
+}
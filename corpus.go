@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+const defaultCorpusSnapshot = "corpus_snapshot.json"
+
+// corpusFileResult records how well a single Java file migrated
+type corpusFileResult struct {
+	Migrated int  `json:"migrated"` // Number of top level Go declarations produced
+	Failed   int  `json:"failed"`   // Number of members that ended up as FailedMigrations
+	Fixmes   int  `json:"fixmes"`   // Number of FIXME markers in the generated source
+	Crashed  bool `json:"crashed"`  // Migration of the file aborted altogether
+}
+
+// corpusSnapshot maps corpus relative Java file paths to their results
+type corpusSnapshot struct {
+	Files map[string]corpusFileResult `json:"files"`
+}
+
+// runCorpusCommand implements `javaGo corpus run [flags] <dir>` and returns
+// the process exit code
+func runCorpusCommand(args []string) int {
+	if len(args) == 0 || args[0] != "run" {
+		fmt.Fprintf(os.Stderr, "Usage: javaGo corpus run [-snapshot file] [-update] <dir>\n")
+		return 1
+	}
+	flags := flag.NewFlagSet("corpus run", flag.ContinueOnError)
+	snapshotPath := flags.String("snapshot", defaultCorpusSnapshot, "path of the JSON snapshot to compare against")
+	update := flags.Bool("update", false, "overwrite the snapshot with the current results")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 1
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: javaGo corpus run [-snapshot file] [-update] <dir>\n")
+		return 1
+	}
+
+	current, err := runCorpus(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Fatal: running corpus failed: %v\n", err)
+		return 1
+	}
+
+	previous, err := readCorpusSnapshot(*snapshotPath)
+	switch {
+	case os.IsNotExist(err) || *update:
+		if err := writeCorpusSnapshot(*snapshotPath, current); err != nil {
+			fmt.Fprintf(os.Stderr, "Fatal: writing snapshot failed: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Wrote corpus snapshot for %d files to %s\n", len(current.Files), *snapshotPath)
+		return 0
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Fatal: reading snapshot failed: %v\n", err)
+		return 1
+	}
+
+	regressions := compareCorpusSnapshots(previous, current)
+	if len(regressions) > 0 {
+		for _, regression := range regressions {
+			fmt.Fprintf(os.Stderr, "Regression: %s\n", regression)
+		}
+		return 1
+	}
+	fmt.Printf("Corpus of %d files matches snapshot %s\n", len(current.Files), *snapshotPath)
+	return 0
+}
+
+// runCorpus migrates every Java file under dir in non-strict mode and
+// collects per-file results
+func runCorpus(dir string) (corpusSnapshot, error) {
+	snapshot := corpusSnapshot{Files: make(map[string]corpusFileResult)}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".java") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		javaSource, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		snapshot.Files[filepath.ToSlash(rel)] = migrateCorpusFile(javaSource, filepath.Base(path))
+		return nil
+	})
+	return snapshot, err
+}
+
+func migrateCorpusFile(javaSource []byte, fileName string) (result corpusFileResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = corpusFileResult{Crashed: true}
+		}
+	}()
+	tree := java.ParseJava(javaSource)
+	defer tree.Close()
+
+	ctx := java.NewMigrationContext(javaSource, fileName, nil, nil)
+	java.MigrateTree(ctx, tree)
+	source := ctx.Source
+	goSource := source.ToSource("", "converted")
+	return corpusFileResult{
+		Migrated: migratedDeclarations(source),
+		Failed:   len(source.FailedMigrations),
+		Fixmes:   strings.Count(goSource, "FIXME"),
+	}
+}
+
+// groupedConstants returns the number of constants declared in the const
+// groups of source, which count like the variables they used to be
+func groupedConstants(source gosrc.GoSource) int {
+	count := 0
+	for _, group := range source.ConstGroups {
+		count += len(group.Constants)
+	}
+	return count
+}
+
+// compareCorpusSnapshots returns a description of every file whose results
+// got worse between previous and current, sorted by file name
+func compareCorpusSnapshots(previous, current corpusSnapshot) []string {
+	var regressions []string
+	for file, before := range previous.Files {
+		after, ok := current.Files[file]
+		if !ok {
+			regressions = append(regressions, fmt.Sprintf("%s: missing from corpus", file))
+			continue
+		}
+		switch {
+		case after.Crashed && !before.Crashed:
+			regressions = append(regressions, fmt.Sprintf("%s: migration crashed", file))
+		case after.Crashed:
+			// Still crashing, nothing new to report
+		case after.Migrated < before.Migrated:
+			regressions = append(regressions, fmt.Sprintf("%s: migrated declarations dropped from %d to %d", file, before.Migrated, after.Migrated))
+		case after.Failed > before.Failed:
+			regressions = append(regressions, fmt.Sprintf("%s: failed migrations grew from %d to %d", file, before.Failed, after.Failed))
+		case after.Fixmes > before.Fixmes:
+			regressions = append(regressions, fmt.Sprintf("%s: FIXMEs grew from %d to %d", file, before.Fixmes, after.Fixmes))
+		}
+	}
+	sort.Strings(regressions)
+	return regressions
+}
+
+func readCorpusSnapshot(path string) (corpusSnapshot, error) {
+	var snapshot corpusSnapshot
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snapshot, err
+	}
+	err = json.Unmarshal(data, &snapshot)
+	return snapshot, err
+}
+
+func writeCorpusSnapshot(path string, snapshot corpusSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCorpusRun(t *testing.T) {
+	corpusDir := t.TempDir()
+	files := map[string]string{
+		"Point.java":        "public record Point(int x, int y) {}",
+		"nested/Color.java": "public enum Color { RED, GREEN }",
+		"Broken.java":       "class Broken {\n    @interface Marker {}\n    int value;\n}",
+		"notes.txt":         "not java",
+	}
+	for name, content := range files {
+		path := filepath.Join(corpusDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	snapshot, err := runCorpus(corpusDir)
+	if err != nil {
+		t.Fatalf("runCorpus failed: %v", err)
+	}
+	if len(snapshot.Files) != 3 {
+		t.Fatalf("Expected 3 Java files in snapshot, got %d: %v", len(snapshot.Files), snapshot.Files)
+	}
+
+	point := snapshot.Files["Point.java"]
+	if point.Crashed || point.Failed != 0 || point.Migrated == 0 {
+		t.Errorf("Expected Point.java to migrate cleanly, got %+v", point)
+	}
+	if _, ok := snapshot.Files["nested/Color.java"]; !ok {
+		t.Errorf("Expected nested files to be keyed by slash separated relative path, got %v", snapshot.Files)
+	}
+	broken := snapshot.Files["Broken.java"]
+	if broken.Failed != 1 || broken.Fixmes == 0 {
+		t.Errorf("Expected Broken.java to record one failed migration with FIXMEs, got %+v", broken)
+	}
+
+	// Round trip through the snapshot file
+	snapshotPath := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := writeCorpusSnapshot(snapshotPath, snapshot); err != nil {
+		t.Fatalf("writeCorpusSnapshot failed: %v", err)
+	}
+	readBack, err := readCorpusSnapshot(snapshotPath)
+	if err != nil {
+		t.Fatalf("readCorpusSnapshot failed: %v", err)
+	}
+	if regressions := compareCorpusSnapshots(readBack, snapshot); len(regressions) != 0 {
+		t.Errorf("Expected no regressions against own snapshot, got %v", regressions)
+	}
+}
+
+func TestCompareCorpusSnapshots(t *testing.T) {
+	previous := corpusSnapshot{Files: map[string]corpusFileResult{
+		"Same.java":     {Migrated: 3},
+		"Fewer.java":    {Migrated: 5},
+		"Failing.java":  {Migrated: 2, Failed: 1},
+		"Fixme.java":    {Migrated: 2, Fixmes: 1},
+		"Crashing.java": {Migrated: 1},
+		"Removed.java":  {Migrated: 1},
+		"Better.java":   {Migrated: 1, Failed: 2, Fixmes: 2},
+	}}
+	current := corpusSnapshot{Files: map[string]corpusFileResult{
+		"Same.java":     {Migrated: 3},
+		"Fewer.java":    {Migrated: 4},
+		"Failing.java":  {Migrated: 2, Failed: 2},
+		"Fixme.java":    {Migrated: 2, Fixmes: 3},
+		"Crashing.java": {Crashed: true},
+		"Better.java":   {Migrated: 3},
+		"New.java":      {Migrated: 1},
+	}}
+
+	regressions := compareCorpusSnapshots(previous, current)
+	expectedPrefixes := []string{
+		"Crashing.java: migration crashed",
+		"Failing.java: failed migrations grew from 1 to 2",
+		"Fewer.java: migrated declarations dropped from 5 to 4",
+		"Fixme.java: FIXMEs grew from 1 to 3",
+		"Removed.java: missing from corpus",
+	}
+	if len(regressions) != len(expectedPrefixes) {
+		t.Fatalf("Expected %d regressions, got %d: %v", len(expectedPrefixes), len(regressions), regressions)
+	}
+	for i, expected := range expectedPrefixes {
+		if !strings.HasPrefix(regressions[i], expected) {
+			t.Errorf("Expected regression %d to be %q, got %q", i, expected, regressions[i])
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/java"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// TestNodeKindCoverage makes converter coverage of the grammar explicit: every
+// statement and expression kind must be classified, and the report of
+// unsupported and passthrough kinds is logged with -v.
+func TestNodeKindCoverage(t *testing.T) {
+	groups := []struct {
+		name     string
+		kinds    []string
+		classify func(string) (java.KindSupport, bool)
+	}{
+		{name: "statement", kinds: java.GrammarStatementKinds, classify: java.StatementKindSupport},
+		{name: "expression", kinds: java.GrammarExpressionKinds, classify: java.ExpressionKindSupport},
+	}
+	for _, group := range groups {
+		report := map[java.KindSupport][]string{}
+		for _, kind := range group.kinds {
+			support, ok := group.classify(kind)
+			if !ok {
+				t.Errorf("Grammar %s kind %q is not classified; add it to the %s kind support table", group.name, kind, group.name)
+				continue
+			}
+			report[support] = append(report[support], kind)
+		}
+		for _, support := range []java.KindSupport{java.KindUnsupported, java.KindPassthrough} {
+			kinds := report[support]
+			sort.Strings(kinds)
+			t.Logf("%s kinds %s (%d): %s", group.name, support, len(kinds), strings.Join(kinds, ", "))
+		}
+	}
+}
+
+// TestNodeKindCoverageMatchesCorpus cross checks the support tables against
+// the corpus: any statement or expression kind that appears in a case that
+// migrates in strict mode cannot be unsupported.
+func TestNodeKindCoverageMatchesCorpus(t *testing.T) {
+	for _, name := range corpusCases(t) {
+		javaContent, err := os.ReadFile(filepath.Join("testdata", "java", name+".java"))
+		if err != nil {
+			t.Fatalf("Failed to read Java file %s: %v", name, err)
+		}
+		tree := java.ParseJava(javaContent)
+		walkNamedNodes(tree.RootNode(), func(node *tree_sitter.Node) {
+			kind := node.Kind()
+			if !slices.Contains(java.GrammarExpressionKinds, kind) || !isInsideMethodBody(node) {
+				return
+			}
+			if support, _ := java.ExpressionKindSupport(kind); support == java.KindUnsupported {
+				t.Errorf("%s: expression kind %q is migrated by the corpus but classified as unsupported", name, kind)
+			}
+		})
+		tree.Close()
+	}
+}
+
+func walkNamedNodes(node *tree_sitter.Node, fn func(*tree_sitter.Node)) {
+	fn(node)
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		walkNamedNodes(node.NamedChild(i), fn)
+	}
+}
+
+func isInsideMethodBody(node *tree_sitter.Node) bool {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch parent.Kind() {
+		case "block", "constructor_body":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+// diagnosticSnapshot is the stable, serialized form of a java.MigrationError
+type diagnosticSnapshot struct {
+	Code     java.ErrorCode `json:"code"`
+	Category java.Category  `json:"category"`
+	Location string         `json:"location"`
+	Line     int            `json:"line"`
+	Column   int            `json:"column"`
+	NodeKind string         `json:"node_kind"`
+	Message  string         `json:"message"`
+}
+
+// TestDiagnosticsSnapshots migrates every known-bad input in
+// testdata/diagnostics in non-strict mode, or with the configuration of its
+// sibling .toml file, and compares the collected errors against the sibling
+// .json snapshot. Run with -update to regenerate.
+func TestDiagnosticsSnapshots(t *testing.T) {
+	dir := filepath.Join("testdata", "diagnostics")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read %s directory: %v", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".java") {
+			continue
+		}
+		testName := strings.TrimSuffix(entry.Name(), ".java")
+		t.Run(testName, func(t *testing.T) {
+			javaContent, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				t.Fatalf("Failed to read Java file: %v", err)
+			}
+			tree := java.ParseJava(javaContent)
+			defer tree.Close()
+
+			var strictness java.Strictness
+			c, configured := testdataConfig(t, filepath.Join(dir, entry.Name()))
+			if configured {
+				strictness = c.strictness(false)
+			}
+			ctx := java.NewMigrationContext(javaContent, entry.Name(), strictness, c.TypeMappings)
+			java.ApplyOptions(ctx, c.options())
+			java.MigrateTree(ctx, tree)
+
+			snapshots := []diagnosticSnapshot{}
+			for _, err := range ctx.Errors {
+				snapshots = append(snapshots, diagnosticSnapshot{
+					Code:     err.Code,
+					Category: err.Category,
+					Location: err.Location,
+					Line:     err.Line,
+					Column:   err.Column,
+					NodeKind: err.NodeKind,
+					Message:  err.Message,
+				})
+			}
+			if len(snapshots) == 0 {
+				t.Errorf("Expected known-bad input to produce diagnostics")
+			}
+			buf := bytes.Buffer{}
+			encoder := json.NewEncoder(&buf)
+			encoder.SetEscapeHTML(false)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(snapshots); err != nil {
+				t.Fatalf("Failed to marshal diagnostics: %v", err)
+			}
+			got := buf.Bytes()
+
+			snapshotFile := filepath.Join(dir, testName+".json")
+			expected, err := os.ReadFile(snapshotFile)
+			if *update && (err != nil || string(expected) != string(got)) {
+				if err := updateExpectedFile(snapshotFile, string(got)); err != nil {
+					t.Fatalf("Failed to update snapshot: %v", err)
+				}
+				t.Logf("Updated snapshot: %s", snapshotFile)
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to read snapshot %s: %v", snapshotFile, err)
+			}
+			if string(got) != string(expected) {
+				t.Errorf("Diagnostics do not match snapshot:\n--- Got ---\n%s\n--- Expected ---\n%s", got, expected)
+			}
+		})
+	}
+}
@@ -33,7 +33,7 @@ class TestAnnotation {
 		tree := java.ParseJava(javaSource)
 		defer tree.Close()
 
-		ctx := java.NewMigrationContext(javaSource, "test.java", false, nil) // non-strict mode
+		ctx := java.NewMigrationContext(javaSource, "test.java", nil, nil) // non-strict mode
 		java.MigrateTree(ctx, tree)
 
 		// Check that we collected an error
@@ -75,6 +75,36 @@ class TestAnnotation {
 		}
 	})
 
+	t.Run("imports of failed members are dropped", func(t *testing.T) {
+		source := []byte(`
+class Labels {
+    String label(double x) {
+        String s = "value " + x;
+        synchronized (this) {
+            s = s + "!";
+        }
+        return s;
+    }
+
+    int id(int x) {
+        return x;
+    }
+}
+`)
+		tree := java.ParseJava(source)
+		defer tree.Close()
+
+		ctx := java.NewMigrationContext(source, "labels.java", nil, nil)
+		java.MigrateTree(ctx, tree)
+
+		if len(ctx.Source.FailedMigrations) != 1 {
+			t.Fatalf("Expected 1 failed migration, got %d", len(ctx.Source.FailedMigrations))
+		}
+		if len(ctx.Source.Imports) != 0 {
+			t.Errorf("Expected no imports after label failed, got %v", ctx.Source.Imports)
+		}
+	})
+
 	// Note: We can't easily test strict mode calling os.Exit(1) in a unit test
 	// The -Werror flag behavior is tested through integration tests
 }
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+)
+
+// writeFormatted writes what write produces to w, formatted the way gofmt
+// does unless cfg.noFormat is set. Formatting needs the whole output in
+// memory, so write is run against a buffer first. Output that can't be
+// formatted is written as it is, with a warning.
+func writeFormatted(w io.Writer, cfg config, write func(io.Writer) error) error {
+	if cfg.noFormat {
+		return write(w)
+	}
+	var buf bytes.Buffer
+	if err := write(&buf); err != nil {
+		return err
+	}
+	formatted, err := gosrc.Format(buf.Bytes())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+// writeSource writes the Go source file for source to w
+func writeSource(w io.Writer, source *gosrc.GoSource, cfg config) error {
+	return writeFormatted(w, cfg, func(w io.Writer) error {
+		return source.WriteSource(w, cfg.LicenseHeader, cfg.PackageName)
+	})
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"errors"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+// knownFormatIssues lists corpus cases whose emitted source is known not to be
+// valid Go syntax yet, mapped to a short description of the problem. Cases in
+// this list are still checked so that a fix is noticed and the entry removed.
+var knownFormatIssues = map[string]string{}
+
+// formatGap is a known way in which the emitted source differs from its
+// gofmt formatted form. normalize erases the difference from a source.
+type formatGap struct {
+	name      string
+	normalize func(source string) string
+}
+
+// knownFormatGaps lists the differences between the emitted source and its
+// formatted form that are left to the go/format post-pass. Any other
+// difference fails TestFormatIdempotence, and so does a gap no case has
+// anymore, so that a fix is noticed and the entry removed.
+var knownFormatGaps = []formatGap{
+	// Statements are written without the indentation of their block
+	{name: "indentation", normalize: mapLines(func(line string) string {
+		return strings.TrimLeft(line, " \t")
+	})},
+	// gofmt aligns struct fields, trailing comments and const values in
+	// columns
+	{name: "alignment", normalize: mapLines(func(line string) string {
+		indented := strings.TrimLeft(line, " \t")
+		return line[:len(line)-len(indented)] + strings.Join(strings.Fields(indented), " ")
+	})},
+	// Blank lines between declarations are left to go/format to trim
+	{name: "blank lines", normalize: func(source string) string {
+		var lines []string
+		for line := range strings.SplitSeq(source, "\n") {
+			if strings.TrimSpace(line) != "" {
+				lines = append(lines, line)
+			}
+		}
+		return strings.Join(lines, "\n")
+	}},
+}
+
+// mapLines returns a normalization applying fn to each line of a source
+func mapLines(fn func(line string) string) func(string) string {
+	return func(source string) string {
+		lines := strings.Split(source, "\n")
+		for i, line := range lines {
+			lines[i] = fn(line)
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+// normalizeFormatGaps erases the differences of all known gaps but skip
+// from source
+func normalizeFormatGaps(source string, skip string) string {
+	for _, gap := range knownFormatGaps {
+		if gap.name != skip {
+			source = gap.normalize(source)
+		}
+	}
+	return source
+}
+
+// corpusCases returns the names (without extension) of all Java test cases
+func corpusCases(t *testing.T) []string {
+	t.Helper()
+	entries, err := os.ReadDir(filepath.Join("testdata", "java"))
+	if err != nil {
+		t.Fatalf("Failed to read testdata/java directory: %v", err)
+	}
+	var cases []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".java") {
+			continue
+		}
+		cases = append(cases, strings.TrimSuffix(entry.Name(), ".java"))
+	}
+	return cases
+}
+
+// readJavaCase returns the contents of testdata/java/<name>.java
+func readJavaCase(t *testing.T, name string) string {
+	t.Helper()
+	javaContent, err := os.ReadFile(filepath.Join("testdata", "java", name+".java"))
+	if err != nil {
+		t.Fatalf("Failed to read Java file %s: %v", name, err)
+	}
+	return string(javaContent)
+}
+
+// testdataConfig loads the configuration of the Java test case javaFile from
+// its sibling .toml file, which configures the migration of the case the
+// way Config.toml configures a run. It reports false if there is none.
+func testdataConfig(t *testing.T, javaFile string) (config, bool) {
+	t.Helper()
+	configFile := strings.TrimSuffix(javaFile, ".java") + ".toml"
+	if _, err := os.Stat(configFile); errors.Is(err, fs.ErrNotExist) {
+		return defaultConfig(), false
+	}
+	c, err := loadConfigFrom(configFile)
+	if err != nil {
+		t.Fatalf("Failed to load %s: %v", configFile, err)
+	}
+	return c, true
+}
+
+// corpusConfig returns the configuration testdata/java/<name>.java is
+// migrated with: strict mode, unless the configuration of the case says
+// otherwise
+func corpusConfig(t *testing.T, name string) java.Config {
+	t.Helper()
+	cfg := java.Config{FileName: name + ".java", Strictness: java.StrictAll()}
+	c, ok := testdataConfig(t, filepath.Join("testdata", "java", name+".java"))
+	if !ok {
+		return cfg
+	}
+	cfg.Strictness = c.strictness(false)
+	cfg.TypeMappings = c.TypeMappings
+	cfg.PackageMappings = c.PackageMappings
+	cfg.ManualMethods = c.manualMethods()
+	cfg.DeepCopy = c.DeepCopy
+	cfg.FlatNestedClasses = c.FlatNestedClasses
+	cfg.ClassLiterals = c.ClassLiterals
+	cfg.Annotations = c.Annotations
+	cfg.Optionals = c.Optionals
+	cfg.SystemProperties = c.SystemProperties
+	cfg.Exceptions = c.Exceptions
+	cfg.PrivateHelperFunctions = c.PrivateHelperFunctions
+	return cfg
+}
+
+// migrateCorpusCase migrates testdata/java/<name>.java with its configuration
+// and returns the raw, unformatted Go source
+func migrateCorpusCase(t *testing.T, name string) string {
+	t.Helper()
+	goSource, _ := java.MigrateString(readJavaCase(t, name), corpusConfig(t, name))
+	return goSource
+}
+
+// TestFormatIdempotence checks that for every corpus case the emitted source
+// is accepted by go/format and differs from its formatted form only by the
+// known gaps, that formatting is a fixpoint, and that the expected Go files
+// are already in canonical form.
+func TestFormatIdempotence(t *testing.T) {
+	cases := corpusCases(t)
+	gapsSeen := make(map[string]bool)
+	checked := 0
+	t.Cleanup(func() {
+		// Only a run over the whole corpus tells whether a gap is gone
+		if t.Failed() || checked < len(cases) {
+			return
+		}
+		for _, gap := range knownFormatGaps {
+			if !gapsSeen[gap.name] {
+				t.Errorf("No case has the %s gap listed in knownFormatGaps; remove it from the list", gap.name)
+			}
+		}
+	})
+	for _, name := range cases {
+		t.Run(name, func(t *testing.T) {
+			checked++
+			raw := migrateCorpusCase(t, name)
+			formatted, err := format.Source([]byte(raw))
+			knownIssue, isKnown := knownFormatIssues[name]
+			switch {
+			case err != nil && isKnown:
+				t.Skipf("known format issue: %s", knownIssue)
+			case err != nil:
+				t.Fatalf("Emitted source is not valid Go: %v\n%s", err, raw)
+			case isKnown:
+				t.Errorf("Case is listed in knownFormatIssues (%s) but now formats cleanly; remove it from the list", knownIssue)
+			}
+
+			if normalizeFormatGaps(raw, "") != normalizeFormatGaps(string(formatted), "") {
+				t.Errorf("Emitted source differs from its formatted form beyond knownFormatGaps:\n--- Emitted ---\n%s\n--- Formatted ---\n%s", raw, formatted)
+			}
+			for _, gap := range knownFormatGaps {
+				if normalizeFormatGaps(raw, gap.name) != normalizeFormatGaps(string(formatted), gap.name) {
+					gapsSeen[gap.name] = true
+				}
+			}
+
+			reformatted, err := format.Source(formatted)
+			if err != nil {
+				t.Fatalf("Formatted source failed to format again: %v", err)
+			}
+			if string(reformatted) != string(formatted) {
+				t.Errorf("Formatting is not idempotent:\n--- First ---\n%s\n--- Second ---\n%s", formatted, reformatted)
+			}
+
+			expected, err := os.ReadFile(filepath.Join("testdata", "go", name+".go"))
+			if err != nil {
+				t.Fatalf("Failed to read expected Go file: %v", err)
+			}
+			canonical, err := format.Source(expected)
+			if err != nil {
+				t.Fatalf("Expected Go file is not valid Go: %v", err)
+			}
+			if string(canonical) != string(expected) {
+				t.Errorf("Expected Go file is not gofmt formatted; regenerate it with -update")
+			}
+		})
+	}
+}
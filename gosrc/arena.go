@@ -0,0 +1,63 @@
+package gosrc
+
+// arenaChunkSize is the number of nodes of a type allocated at once
+const arenaChunkSize = 256
+
+// Arena allocates the most frequently created node types in chunks, so a
+// large conversion makes a few big allocations instead of millions of tiny
+// ones. Nodes are never reused, so they stay valid for as long as they are
+// referenced; a chunk is freed once none of its nodes are. An Arena is not
+// safe for concurrent use. A nil Arena allocates every node individually.
+type Arena struct {
+	varRefs           []VarRef
+	goExpressions     []GoExpression
+	binaryExpressions []BinaryExpression
+}
+
+// NewArena creates an empty arena
+func NewArena() *Arena {
+	return &Arena{}
+}
+
+// VarRef returns a pointer to an arena allocated copy of v
+func (a *Arena) VarRef(v VarRef) *VarRef {
+	if a == nil {
+		return heapAllocate(v)
+	}
+	return allocate(&a.varRefs, v)
+}
+
+// GoExpression returns a pointer to an arena allocated copy of e
+func (a *Arena) GoExpression(e GoExpression) *GoExpression {
+	if a == nil {
+		return heapAllocate(e)
+	}
+	return allocate(&a.goExpressions, e)
+}
+
+// BinaryExpression returns a pointer to an arena allocated copy of e
+func (a *Arena) BinaryExpression(e BinaryExpression) *BinaryExpression {
+	if a == nil {
+		return heapAllocate(e)
+	}
+	return allocate(&a.binaryExpressions, e)
+}
+
+// allocate appends value to the current chunk, starting a new chunk when it
+// is full. The old chunk is never appended to again, so pointers into it
+// stay valid.
+func allocate[T any](chunk *[]T, value T) *T {
+	if len(*chunk) == cap(*chunk) {
+		*chunk = make([]T, 0, arenaChunkSize)
+	}
+	*chunk = append(*chunk, value)
+	return &(*chunk)[len(*chunk)-1]
+}
+
+// heapAllocate copies value to the heap. Taking the address of the parameter
+// instead would make every argument escape, even on the arena path.
+func heapAllocate[T any](value T) *T {
+	p := new(T)
+	*p = value
+	return p
+}
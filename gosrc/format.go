@@ -0,0 +1,30 @@
+package gosrc
+
+import (
+	"go/format"
+)
+
+// FormatError is returned when generated source is left unformatted because
+// it could not be parsed, which happens with partially migrated sources
+type FormatError struct {
+	Err error
+}
+
+func (e *FormatError) Error() string {
+	return "source left unformatted: " + e.Err.Error()
+}
+
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// Format formats src, a whole Go source file or a list of declarations, the
+// way gofmt does. If src doesn't parse it is returned as it is, along with a
+// FormatError.
+func Format(src []byte) ([]byte, error) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return src, &FormatError{Err: err}
+	}
+	return formatted, nil
+}
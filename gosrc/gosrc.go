@@ -4,6 +4,8 @@ package gosrc
 
 import (
 	"fmt"
+	"io"
+	"slices"
 	"strings"
 )
 
@@ -36,11 +38,13 @@ type (
 type (
 	// GoSource represents a complete Go source file
 	GoSource struct {
+		PackageDoc       []string // Lines of the package doc comment, without the comment markers
 		Imports          []Import
 		Interfaces       []Interface
 		Structs          []Struct
 		Constants        []ModuleConst
 		ConstBlocks      []ConstBlock
+		ConstGroups      []ConstGroup
 		Vars             []ModuleVar
 		Functions        []Function
 		Methods          []Method
@@ -55,11 +59,12 @@ type (
 
 	// Interface represents a Go interface definition
 	Interface struct {
-		Name     string
-		Embeds   []Type
-		Methods  []InterfaceMethod
-		Public   bool
-		Comments []string
+		Name       string
+		TypeParams []TypeParam
+		Embeds     []Type
+		Methods    []InterfaceMethod
+		Public     bool
+		Comments   []string
 	}
 
 	// InterfaceMethod represents a method signature in an interface
@@ -72,11 +77,12 @@ type (
 
 	// Struct represents a Go struct definition
 	Struct struct {
-		Name     string
-		Includes []Type
-		Fields   []StructField
-		Public   bool
-		Comments []string
+		Name       string
+		TypeParams []TypeParam
+		Includes   []Type
+		Fields     []StructField
+		Public     bool
+		Comments   []string
 	}
 
 	// StructField represents a field in a struct
@@ -90,6 +96,7 @@ type (
 	// Function represents a Go function
 	Function struct {
 		Name       string
+		TypeParams []TypeParam // Only allowed on functions, not on methods
 		Params     []Param
 		ReturnType *Type
 		Body       []Statement
@@ -109,11 +116,24 @@ type (
 		Ty   Type
 	}
 
+	// TypeParam represents a type parameter of a generic type or function
+	TypeParam struct {
+		Name       string
+		Constraint Type
+	}
+
 	// ModuleConst represents a module-level constant
 	ModuleConst struct {
-		Name  string
-		Ty    Type
-		Value Expression
+		Name     string
+		Ty       Type
+		Value    Expression
+		Comments []string
+	}
+
+	// ConstGroup represents the constants declared together, such as the
+	// constants of a class, in a single const declaration
+	ConstGroup struct {
+		Constants []ModuleConst
 	}
 
 	// ConstBlock represents a const block with iota
@@ -136,6 +156,7 @@ type (
 		JavaSource   string
 		SExpr        string
 		Location     string
+		NotesFile    string // If set, the details are left to this migration notes file
 	}
 )
 
@@ -149,6 +170,7 @@ type (
 
 	// IfStatement represents an if-else statement
 	IfStatement struct {
+		Init      Statement // Optional, declares variables scoped to the statement
 		Condition Expression
 		Body      []Statement
 		ElseIf    []IfStatement
@@ -217,7 +239,7 @@ type (
 	// CatchClause represents a catch clause in a try statement
 	CatchClause struct {
 		ExceptionType string
-		ExceptionVar  string
+		ExceptionVar  string // Empty if the body doesn't use the exception
 		Body          []Statement
 	}
 
@@ -244,6 +266,7 @@ type (
 	// CallExpression represents a function call
 	CallExpression struct {
 		Function string
+		TypeArgs []Type // Explicit instantiation of a generic function
 		Args     []Expression
 	}
 
@@ -257,14 +280,17 @@ type (
 		Value bool
 	}
 
-	// IntLiteral represents an integer literal
+	// IntLiteral represents an integer literal. Literal is the Go spelling of
+	// the value, if it should not be printed in decimal.
 	IntLiteral struct {
-		Value int
+		Value   int
+		Literal string
 	}
 
 	// Int64Literal represents a 64-bit integer literal
 	Int64Literal struct {
-		Value int64
+		Value   int64
+		Literal string
 	}
 
 	// CharLiteral represents a character literal
@@ -291,6 +317,13 @@ type (
 		Operand  Expression
 	}
 
+	// FuncLiteral represents an anonymous function
+	FuncLiteral struct {
+		Params     []Param
+		ReturnType *Type
+		Body       []Statement
+	}
+
 	// ReturnExpression represents a return expression
 	ReturnExpression struct {
 		Value Expression
@@ -320,77 +353,260 @@ const (
 // NIL is a predefined nil expression
 var NIL = VarRef{Ref: "nil"}
 
+// Append adds all declarations of other after the ones already in s
+func (s *GoSource) Append(other GoSource) {
+	if other.PackageDoc != nil {
+		s.PackageDoc = other.PackageDoc
+	}
+	s.Imports = MergeImports(s.Imports, other.Imports...)
+	s.Interfaces = append(s.Interfaces, other.Interfaces...)
+	s.Structs = append(s.Structs, other.Structs...)
+	s.Constants = append(s.Constants, other.Constants...)
+	s.ConstBlocks = append(s.ConstBlocks, other.ConstBlocks...)
+	s.ConstGroups = append(s.ConstGroups, other.ConstGroups...)
+	s.Vars = append(s.Vars, other.Vars...)
+	s.Functions = append(s.Functions, other.Functions...)
+	s.Methods = append(s.Methods, other.Methods...)
+	s.FailedMigrations = append(s.FailedMigrations, other.FailedMigrations...)
+}
+
+// AddImport adds an import of path unless s already imports it
+func (s *GoSource) AddImport(path string) {
+	s.Imports = MergeImports(s.Imports, Import{PackagePath: path})
+}
+
+// MergeImports adds each of more to imports unless it is already there,
+// keeping imports sorted by path the way gofmt does
+func MergeImports(imports []Import, more ...Import) []Import {
+	for _, imp := range more {
+		i, found := slices.BinarySearchFunc(imports, imp.PackagePath, func(existing Import, path string) int {
+			return strings.Compare(existing.PackagePath, path)
+		})
+		if !found {
+			imports = slices.Insert(imports, i, imp)
+		}
+	}
+	return imports
+}
+
 // ToSource methods for all types
 
+// sourceWriter is implemented by the elements that write their source into
+// a builder shared with the elements they are nested in, so rendering a
+// declaration doesn't build a string for every statement and expression in it
+type sourceWriter interface {
+	writeSource(sb *strings.Builder)
+}
+
+// writeSource writes the source of elem to sb, into sb itself if elem
+// supports it
+func writeSource(sb *strings.Builder, elem SourceElement) {
+	if w, ok := elem.(sourceWriter); ok {
+		w.writeSource(sb)
+		return
+	}
+	sb.WriteString(elem.ToSource())
+}
+
+// sourceOf returns the source of elem, written into a builder of its own
+func sourceOf(elem sourceWriter) string {
+	sb := strings.Builder{}
+	elem.writeSource(&sb)
+	return sb.String()
+}
+
+// writeCondition writes cond, the condition of an if, for or switch, without
+// the parentheses gofmt drops around it. They are kept if cond contains a
+// composite literal, whose braces would otherwise open the body.
+func writeCondition(sb *strings.Builder, cond Expression) {
+	source := cond.ToSource()
+	for isParenthesized(source) && !strings.Contains(source, "{") {
+		source = source[1 : len(source)-1]
+	}
+	sb.WriteString(source)
+}
+
+// isParenthesized reports whether the whole of source is enclosed in a pair
+// of parentheses
+func isParenthesized(source string) bool {
+	if !strings.HasPrefix(source, "(") || !strings.HasSuffix(source, ")") {
+		return false
+	}
+	depth := 0
+	for i, r := range source {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i == len(source)-1
+			}
+		}
+	}
+	return false
+}
+
+// writeBody writes each statement of a block on a line of its own
+func writeBody(sb *strings.Builder, body []Statement) {
+	for _, stmt := range body {
+		writeSource(sb, stmt)
+		sb.WriteString("\n")
+	}
+}
+
 func (s *GoSource) ToSource(licenseHeader, packageName string) string {
 	sb := strings.Builder{}
+	// strings.Builder never returns a write error
+	_ = s.WriteSource(&sb, licenseHeader, packageName)
+	return sb.String()
+}
+
+// WriteSource writes the Go source for s to w one declaration at a time, so
+// only a single declaration is held in memory at once. It returns the first
+// write error encountered.
+func (s *GoSource) WriteSource(w io.Writer, licenseHeader, packageName string) error {
+	if err := WriteHeader(w, licenseHeader, packageName, s.PackageDoc, s.Imports); err != nil {
+		return err
+	}
+	return s.WriteDeclarations(w)
+}
+
+// WriteHeader writes the license header, package doc comment, package clause
+// and imports of a Go source file
+func WriteHeader(w io.Writer, licenseHeader, packageName string, packageDoc []string, imports []Import) error {
+	ew := &errWriter{w: w}
 	if licenseHeader != "" {
-		sb.WriteString(licenseHeader)
+		ew.WriteString(licenseHeader)
 		if !strings.HasSuffix(licenseHeader, "\n") {
-			sb.WriteString("\n")
+			ew.WriteString("\n")
 		}
-		sb.WriteString("\n")
-	}
-	sb.WriteString("package ")
-	sb.WriteString(packageName)
-	sb.WriteString("\n\n")
-	if len(s.Imports) > 0 {
-		sb.WriteString("import (\n")
-		for _, imp := range s.Imports {
-			sb.WriteString("    ")
-			sb.WriteString(imp.ToSource())
-			sb.WriteString("\n")
+		ew.WriteString("\n")
+	}
+	for _, line := range packageDoc {
+		ew.WriteString(strings.TrimRight("// "+line, " "))
+		ew.WriteString("\n")
+	}
+	ew.WriteString("package ")
+	ew.WriteString(packageName)
+	ew.WriteString("\n\n")
+	if len(imports) > 0 {
+		ew.WriteString("import (\n")
+		for _, imp := range imports {
+			ew.WriteString("    ")
+			ew.WriteString(imp.ToSource())
+			ew.WriteString("\n")
 		}
-		sb.WriteString(")\n\n")
+		ew.WriteString(")\n\n")
 	}
+	return ew.err
+}
+
+// WriteDeclarations writes every declaration of s, without the header
+func (s *GoSource) WriteDeclarations(w io.Writer) error {
+	ew := &errWriter{w: w}
 	for _, iface := range s.Interfaces {
-		sb.WriteString(iface.ToSource())
-		sb.WriteString("\n")
+		ew.WriteDeclaration(&iface)
 	}
 	for _, strct := range s.Structs {
-		sb.WriteString(strct.ToSource())
-		sb.WriteString("\n")
+		ew.WriteDeclaration(&strct)
 	}
 	for _, cb := range s.ConstBlocks {
-		sb.WriteString(cb.ToSource())
-		sb.WriteString("\n")
+		ew.WriteDeclaration(&cb)
 	}
 	for _, c := range s.Constants {
-		sb.WriteString(c.ToSource())
-		sb.WriteString("\n")
+		ew.WriteDeclaration(&c)
+	}
+	for _, group := range s.ConstGroups {
+		ew.WriteDeclaration(&group)
 	}
 	for _, v := range s.Vars {
-		sb.WriteString(v.ToSource())
-		sb.WriteString("\n")
+		ew.WriteDeclaration(&v)
 	}
 	for _, fn := range s.Functions {
-		sb.WriteString(fn.ToSource())
-		sb.WriteString("\n")
+		ew.WriteDeclaration(&fn)
 	}
 	for _, method := range s.Methods {
-		sb.WriteString(method.ToSource())
-		sb.WriteString("\n")
+		ew.WriteDeclaration(&method)
 	}
 	// Render failed migrations as comments
 	for _, failed := range s.FailedMigrations {
-		sb.WriteString("// FIXME: Failed to migrate\n")
-		sb.WriteString(fmt.Sprintf("// Location: %s\n", failed.Location))
-		sb.WriteString(fmt.Sprintf("// Error: %s\n", failed.ErrorMessage))
-		if failed.JavaSource != "" {
-			sb.WriteString("// Java source:\n")
-			for line := range strings.SplitSeq(failed.JavaSource, "\n") {
-				sb.WriteString("// " + line + "\n")
-			}
+		ew.WriteDeclaration(&failed)
+	}
+	return ew.err
+}
+
+// errWriter remembers the first write error so WriteSource can emit without
+// checking every call; writes after an error are dropped
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) WriteString(s string) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = io.WriteString(ew.w, s)
+}
+
+// WriteDeclaration writes a top level declaration followed by a blank line.
+// Declarations are written straight into a strings.Builder destination, as
+// when a whole file is rendered by ToSource; other writers get one string per
+// declaration.
+func (ew *errWriter) WriteDeclaration(decl sourceWriter) {
+	if sb, ok := ew.w.(*strings.Builder); ok {
+		decl.writeSource(sb)
+		sb.WriteString("\n")
+		return
+	}
+	ew.WriteString(sourceOf(decl))
+	ew.WriteString("\n")
+}
+
+func (failed *FailedMigration) ToSource() string {
+	return sourceOf(failed)
+}
+
+func (failed *FailedMigration) writeSource(sb *strings.Builder) {
+	sb.WriteString("// FIXME: Failed to migrate\n")
+	sb.WriteString(fmt.Sprintf("// Location: %s\n", failed.Location))
+	if failed.NotesFile != "" {
+		sb.WriteString(fmt.Sprintf("// See %s for details\n", failed.NotesFile))
+		return
+	}
+	// Messages may span lines, all of which have to stay comments
+	sb.WriteString("// Error: " + strings.ReplaceAll(failed.ErrorMessage, "\n", "\n// ") + "\n")
+	if failed.JavaSource != "" {
+		sb.WriteString("// Java source:\n")
+		for line := range strings.SplitSeq(failed.JavaSource, "\n") {
+			sb.WriteString("// " + line + "\n")
 		}
-		if failed.SExpr != "" {
-			sb.WriteString("// S-expression:\n")
-			for line := range strings.SplitSeq(failed.SExpr, "\n") {
-				sb.WriteString("// " + line + "\n")
-			}
+	}
+	if failed.SExpr != "" {
+		sb.WriteString("// S-expression:\n")
+		for line := range strings.SplitSeq(failed.SExpr, "\n") {
+			sb.WriteString("// " + line + "\n")
 		}
-		sb.WriteString("\n")
 	}
-	return sb.String()
+}
+
+// WriteMigrationNotes writes the Markdown notes describing the migrations of
+// the Go file goFile that failed
+func WriteMigrationNotes(w io.Writer, goFile string, failed []FailedMigration) error {
+	ew := &errWriter{w: w}
+	ew.WriteString(fmt.Sprintf("# Migration notes for %s\n", goFile))
+	for _, f := range failed {
+		ew.WriteString("\n## " + f.Location + "\n\n")
+		ew.WriteString("```\n" + f.ErrorMessage + "\n```\n")
+		if f.JavaSource != "" {
+			ew.WriteString("\nJava source:\n\n```java\n" + f.JavaSource + "\n```\n")
+		}
+		if f.SExpr != "" {
+			ew.WriteString("\nS-expression:\n\n```\n" + f.SExpr + "\n```\n")
+		}
+	}
+	return ew.err
 }
 
 func (imp *Import) ToSource() string {
@@ -401,10 +617,14 @@ func (imp *Import) ToSource() string {
 }
 
 func (i *Interface) ToSource() string {
-	sb := strings.Builder{}
-	AddComments(&sb, i.Comments)
+	return sourceOf(i)
+}
+
+func (i *Interface) writeSource(sb *strings.Builder) {
+	AddComments(sb, i.Comments)
 	sb.WriteString("type ")
 	sb.WriteString(ToIdentifier(i.Name, i.Public))
+	writeTypeParams(sb, i.TypeParams)
 	sb.WriteString(" interface {\n")
 	for _, embed := range i.Embeds {
 		sb.WriteString("    ")
@@ -419,7 +639,7 @@ func (i *Interface) ToSource() string {
 			if j > 0 {
 				sb.WriteString(", ")
 			}
-			sb.WriteString(param.ToSource())
+			param.writeSource(sb)
 		}
 		sb.WriteString(")")
 		if method.ReturnType != nil {
@@ -429,11 +649,13 @@ func (i *Interface) ToSource() string {
 		sb.WriteString("\n")
 	}
 	sb.WriteString("}\n")
-	return sb.String()
 }
 
 func (s *Struct) ToSource() string {
-	sb := strings.Builder{}
+	return sourceOf(s)
+}
+
+func (s *Struct) writeSource(sb *strings.Builder) {
 	// Check if this is a type alias (empty fields and comment starting with "type")
 	if len(s.Fields) == 0 && len(s.Includes) == 0 && len(s.Comments) > 0 {
 		// Check if first comment is a type alias declaration
@@ -449,12 +671,13 @@ func (s *Struct) ToSource() string {
 				sb.WriteString(parts[2])
 			}
 			sb.WriteString("\n")
-			return sb.String()
+			return
 		}
 	}
-	AddComments(&sb, s.Comments)
+	AddComments(sb, s.Comments)
 	sb.WriteString("type ")
 	sb.WriteString(ToIdentifier(s.Name, s.Public))
+	writeTypeParams(sb, s.TypeParams)
 	sb.WriteString(" struct {\n")
 	for _, include := range s.Includes {
 		sb.WriteString("    ")
@@ -463,44 +686,55 @@ func (s *Struct) ToSource() string {
 	}
 	for _, field := range s.Fields {
 		sb.WriteString("    ")
-		sb.WriteString(field.ToSource())
+		field.writeSource(sb)
 		sb.WriteString("\n")
 	}
 	sb.WriteString("}\n")
-	return sb.String()
 }
 
 func (f *StructField) ToSource() string {
-	sb := strings.Builder{}
-	AddComments(&sb, f.Comments)
-	sb.WriteString(fmt.Sprintf("%s %s", ToIdentifier(f.Name, f.Public), f.Ty.ToSource()))
-	return sb.String()
+	return sourceOf(f)
+}
+
+func (f *StructField) writeSource(sb *strings.Builder) {
+	AddComments(sb, f.Comments)
+	sb.WriteString(ToIdentifier(f.Name, f.Public))
+	sb.WriteString(" ")
+	sb.WriteString(f.Ty.ToSource())
 }
 
 func (f *Function) ToSource() string {
-	sb := strings.Builder{}
+	return sourceOf(f)
+}
+
+func (f *Function) writeSource(sb *strings.Builder) {
 	sb.WriteString("func ")
 	sb.WriteString(ToIdentifier(f.Name, f.Public))
-	return finishGoFunctionToSource(&sb, f)
+	writeFunctionRest(sb, f)
 }
 
 func (f *Method) ToSource() string {
-	sb := strings.Builder{}
+	return sourceOf(f)
+}
+
+func (f *Method) writeSource(sb *strings.Builder) {
 	sb.WriteString("func ")
 	sb.WriteString("(")
-	sb.WriteString(f.Receiver.ToSource())
+	f.Receiver.writeSource(sb)
 	sb.WriteString(") ")
 	sb.WriteString(ToIdentifier(f.Name, f.Public))
-	return finishGoFunctionToSource(&sb, &f.Function)
+	writeFunctionRest(sb, &f.Function)
 }
 
-func finishGoFunctionToSource(sb *strings.Builder, f *Function) string {
+// writeFunctionRest writes the signature and body of f that follow its name
+func writeFunctionRest(sb *strings.Builder, f *Function) {
+	writeTypeParams(sb, f.TypeParams)
 	sb.WriteString("(")
 	for i, param := range f.Params {
 		if i > 0 {
 			sb.WriteString(", ")
 		}
-		sb.WriteString(param.ToSource())
+		param.writeSource(sb)
 	}
 	sb.WriteString(")")
 	if f.ReturnType != nil {
@@ -509,33 +743,107 @@ func finishGoFunctionToSource(sb *strings.Builder, f *Function) string {
 	}
 	sb.WriteString(" {\n")
 	AddComments(sb, f.Comments)
-	for _, stmt := range f.Body {
-		sb.WriteString(stmt.ToSource())
-		sb.WriteString("\n")
-	}
+	writeBody(sb, f.Body)
 	sb.WriteString("}\n")
-	return sb.String()
 }
 
 func (p *Param) ToSource() string {
-	return fmt.Sprintf("%s %s", p.Name, p.Ty.ToSource())
+	return sourceOf(p)
+}
+
+func (p *Param) writeSource(sb *strings.Builder) {
+	sb.WriteString(p.Name)
+	sb.WriteString(" ")
+	sb.WriteString(p.Ty.ToSource())
+}
+
+func (p *TypeParam) ToSource() string {
+	return fmt.Sprintf("%s %s", p.Name, p.Constraint.ToSource())
+}
+
+// writeTypeParams writes the type parameter list of a generic declaration,
+// such as [K comparable, V any], if it has any
+func writeTypeParams(sb *strings.Builder, params []TypeParam) {
+	if len(params) == 0 {
+		return
+	}
+	sb.WriteString("[")
+	for i, param := range params {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(param.ToSource())
+	}
+	sb.WriteString("]")
+}
+
+// Instantiate returns the type name instantiated with its own type
+// parameters, as needed for receivers of methods of generic types, such as
+// Box[T]
+func Instantiate(name string, params []TypeParam) Type {
+	if len(params) == 0 {
+		return Type(name)
+	}
+	names := make([]string, len(params))
+	for i, param := range params {
+		names[i] = param.Name
+	}
+	return Type(name + "[" + strings.Join(names, ", ") + "]")
 }
 
 func (c *ModuleConst) ToSource() string {
+	return sourceOf(c)
+}
+
+func (c *ModuleConst) writeSource(sb *strings.Builder) {
+	AddComments(sb, c.Comments)
+	sb.WriteString("const ")
+	c.writeSpec(sb)
+}
+
+// writeSpec writes the constant specification of c, without the const
+// keyword
+func (c *ModuleConst) writeSpec(sb *strings.Builder) {
+	sb.WriteString(c.Name)
+	if c.Ty != "" {
+		sb.WriteString(" ")
+		sb.WriteString(c.Ty.ToSource())
+	}
 	if c.Value != nil {
-		return fmt.Sprintf("const %s %s = %s", c.Name, c.Ty.ToSource(), c.Value.ToSource())
+		sb.WriteString(" = ")
+		writeSource(sb, c.Value)
 	}
-	if c.Ty != "" {
-		return fmt.Sprintf("const %s %s", c.Name, c.Ty.ToSource())
+}
+
+func (group *ConstGroup) ToSource() string {
+	return sourceOf(group)
+}
+
+func (group *ConstGroup) writeSource(sb *strings.Builder) {
+	switch len(group.Constants) {
+	case 0:
+		return
+	case 1:
+		group.Constants[0].writeSource(sb)
+		return
+	}
+	sb.WriteString("const (\n")
+	for _, c := range group.Constants {
+		AddComments(sb, c.Comments)
+		c.writeSpec(sb)
+		sb.WriteString("\n")
 	}
-	return fmt.Sprintf("const %s", c.Name)
+	sb.WriteString(")")
 }
 
 func (cb *ConstBlock) ToSource() string {
+	return sourceOf(cb)
+}
+
+func (cb *ConstBlock) writeSource(sb *strings.Builder) {
 	if len(cb.Constants) == 0 {
-		return ""
+		return
 	}
-	sb := strings.Builder{}
 	sb.WriteString("const (\n")
 	for i, constName := range cb.Constants {
 		if i == 0 {
@@ -545,24 +853,29 @@ func (cb *ConstBlock) ToSource() string {
 		}
 	}
 	sb.WriteString(")\n")
-	return sb.String()
 }
 
 func (v *ModuleVar) ToSource() string {
-	sb := strings.Builder{}
-	AddComments(&sb, v.Comments)
-	if v.Value != nil {
-		// If name is "_" and both type and value are present, include type annotation (needed for type assertions)
-		// Otherwise, use type inference (existing behavior for regular vars)
-		if v.Name == "_" && v.Ty != "" {
-			sb.WriteString(fmt.Sprintf("var %s %s = %s", v.Name, v.Ty.ToSource(), v.Value.ToSource()))
-		} else {
-			sb.WriteString(fmt.Sprintf("var %s = %s", v.Name, v.Value.ToSource()))
-		}
-	} else {
-		sb.WriteString(fmt.Sprintf("var %s %s", v.Name, v.Ty.ToSource()))
+	return sourceOf(v)
+}
+
+func (v *ModuleVar) writeSource(sb *strings.Builder) {
+	AddComments(sb, v.Comments)
+	sb.WriteString("var ")
+	sb.WriteString(v.Name)
+	if v.Value == nil {
+		sb.WriteString(" ")
+		sb.WriteString(v.Ty.ToSource())
+		return
 	}
-	return sb.String()
+	// If name is "_" and both type and value are present, include type annotation (needed for type assertions)
+	// Otherwise, use type inference (existing behavior for regular vars)
+	if v.Name == "_" && v.Ty != "" {
+		sb.WriteString(" ")
+		sb.WriteString(v.Ty.ToSource())
+	}
+	sb.WriteString(" = ")
+	writeSource(sb, v.Value)
 }
 
 func (t *Type) ToSource() string {
@@ -580,239 +893,248 @@ func (s *GoStatement) ToSource() string {
 }
 
 func (s *IfStatement) ToSource() string {
-	sb := strings.Builder{}
+	return sourceOf(s)
+}
+
+func (s *IfStatement) writeSource(sb *strings.Builder) {
 	sb.WriteString("if ")
-	sb.WriteString(s.Condition.ToSource())
-	sb.WriteString(" {\n")
-	for _, stmt := range s.Body {
-		sb.WriteString(stmt.ToSource())
-		sb.WriteString("\n")
-	}
+	writeIfHeader(sb, s)
+	writeBody(sb, s.Body)
 	sb.WriteString("}")
 	// Write all else-if chains recursively
-	s.writeElseIfChain(&sb, s.ElseIf)
+	s.writeElseIfChain(sb, s.ElseIf)
 	// Handle the final else block at the top level
 	if len(s.ElseStmts) > 0 {
-		sb.WriteString("else {\n")
-		for _, stmt := range s.ElseStmts {
-			sb.WriteString(stmt.ToSource())
-			sb.WriteString("\n")
-		}
+		sb.WriteString(" else {\n")
+		writeBody(sb, s.ElseStmts)
 		sb.WriteString("}")
 	}
-	return sb.String()
+}
+
+// writeIfHeader writes the init statement and condition of s, and the
+// opening brace of its body
+func writeIfHeader(sb *strings.Builder, s *IfStatement) {
+	if s.Init != nil {
+		writeSource(sb, s.Init)
+		sb.WriteString("; ")
+	}
+	writeCondition(sb, s.Condition)
+	sb.WriteString(" {\n")
 }
 
 func (s *IfStatement) writeElseIfChain(sb *strings.Builder, elseIfs []IfStatement) {
 	for _, elseIf := range elseIfs {
-		sb.WriteString("else if ")
-		sb.WriteString(elseIf.Condition.ToSource())
-		sb.WriteString(" {\n")
-		for _, stmt := range elseIf.Body {
-			sb.WriteString(stmt.ToSource())
-			sb.WriteString("\n")
-		}
+		sb.WriteString(" else if ")
+		writeIfHeader(sb, &elseIf)
+		writeBody(sb, elseIf.Body)
 		sb.WriteString("}")
 		// Recursively handle nested else-if chains
 		s.writeElseIfChain(sb, elseIf.ElseIf)
 		// Handle the final else block at this level
 		if len(elseIf.ElseStmts) > 0 {
-			sb.WriteString("else {\n")
-			for _, stmt := range elseIf.ElseStmts {
-				sb.WriteString(stmt.ToSource())
-				sb.WriteString("\n")
-			}
+			sb.WriteString(" else {\n")
+			writeBody(sb, elseIf.ElseStmts)
 			sb.WriteString("}")
 		}
 	}
 }
 
 func (s *SwitchStatement) ToSource() string {
-	sb := strings.Builder{}
+	return sourceOf(s)
+}
+
+func (s *SwitchStatement) writeSource(sb *strings.Builder) {
 	sb.WriteString("switch ")
-	sb.WriteString(s.Condition.ToSource())
+	writeCondition(sb, s.Condition)
 	sb.WriteString(" {\n")
 	for _, cs := range s.Cases {
 		conditionStr := cs.Condition.ToSource()
 		if conditionStr == "default" {
 			sb.WriteString("default:\n")
-			for _, stmt := range cs.Body {
-				sb.WriteString(stmt.ToSource())
-				sb.WriteString("\n")
-			}
 		} else {
 			sb.WriteString("case ")
-			conditionStr = strings.TrimPrefix(conditionStr, "case ")
-			sb.WriteString(conditionStr)
-			if len(cs.Body) > 0 {
-				sb.WriteString(":\n")
-				for _, stmt := range cs.Body {
-					sb.WriteString(stmt.ToSource())
-					sb.WriteString("\n")
-				}
-			} else {
-				sb.WriteString(",\n")
-			}
+			sb.WriteString(strings.TrimPrefix(conditionStr, "case "))
+			sb.WriteString(":\n")
 		}
+		writeBody(sb, cs.Body)
 	}
 	if len(s.DefaultBody) > 0 {
 		sb.WriteString("default:\n")
-		for _, stmt := range s.DefaultBody {
-			sb.WriteString(stmt.ToSource())
-			sb.WriteString("\n")
-		}
+		writeBody(sb, s.DefaultBody)
 	}
 	sb.WriteString("}")
-	return sb.String()
 }
 
 func (s *ForStatement) ToSource() string {
-	sb := strings.Builder{}
+	return sourceOf(s)
+}
+
+func (s *ForStatement) writeSource(sb *strings.Builder) {
+	if s.Init == nil && s.Post == nil {
+		// A loop with a condition only is a while loop
+		sb.WriteString("for ")
+		if s.Condition != nil {
+			writeCondition(sb, s.Condition)
+			sb.WriteString(" ")
+		}
+		sb.WriteString("{\n")
+		writeBody(sb, s.Body)
+		sb.WriteString("}")
+		return
+	}
 	sb.WriteString("for ")
 	if s.Init != nil {
-		sb.WriteString(s.Init.ToSource())
+		writeSource(sb, s.Init)
 	} else {
 		sb.WriteString("; ")
 	}
 	if s.Condition != nil {
-		sb.WriteString(s.Condition.ToSource())
-		sb.WriteString("; ")
-	} else {
-		sb.WriteString("; ")
+		writeCondition(sb, s.Condition)
 	}
+	sb.WriteString("; ")
 	if s.Post != nil {
-		sb.WriteString(s.Post.ToSource())
+		writeSource(sb, s.Post)
 	} else {
 		sb.WriteString(" ")
 	}
 	sb.WriteString(" {\n")
-	for _, stmt := range s.Body {
-		sb.WriteString(stmt.ToSource())
-		sb.WriteString("\n")
-	}
+	writeBody(sb, s.Body)
 	sb.WriteString("}")
-	return sb.String()
 }
 
 func (s *RangeForStatement) ToSource() string {
-	sb := strings.Builder{}
-	sb.WriteString("for ")
-	if s.IndexVar != "" {
-		sb.WriteString(s.IndexVar)
-	} else {
-		sb.WriteString("_")
+	return sourceOf(s)
+}
+
+func (s *RangeForStatement) writeSource(sb *strings.Builder) {
+	indexVar, valueVar := s.IndexVar, s.ValueVar
+	if indexVar == "" {
+		indexVar = "_"
 	}
-	sb.WriteString(", ")
-	if s.ValueVar != "" {
-		sb.WriteString(s.ValueVar)
-	} else {
-		sb.WriteString("_")
+	switch {
+	case valueVar != "" && valueVar != "_":
+		sb.WriteString("for " + indexVar + ", " + valueVar + " := range ")
+	case indexVar != "_":
+		sb.WriteString("for " + indexVar + " := range ")
+	default:
+		sb.WriteString("for range ")
 	}
-	sb.WriteString(" := range ")
-	sb.WriteString(s.CollectionExpr.ToSource())
+	writeSource(sb, s.CollectionExpr)
 	sb.WriteString(" {\n")
-	for _, stmt := range s.Body {
-		sb.WriteString(stmt.ToSource())
-		sb.WriteString("\n")
-	}
+	writeBody(sb, s.Body)
 	sb.WriteString("}")
-	return sb.String()
 }
 
 func (s *ReturnStatement) ToSource() string {
 	if s.Value == nil {
 		return "return"
 	}
-	return fmt.Sprintf("return %s", s.Value.ToSource())
+	return sourceOf(s)
 }
 
-func (s *VarDeclaration) ToSource() string {
+func (s *ReturnStatement) writeSource(sb *strings.Builder) {
+	sb.WriteString("return")
 	if s.Value != nil {
-		return fmt.Sprintf("%s := %s", s.Name, s.Value.ToSource())
+		sb.WriteString(" ")
+		writeSource(sb, s.Value)
 	}
-	return fmt.Sprintf("var %s %s", s.Name, s.Ty.ToSource())
+}
+
+func (s *VarDeclaration) ToSource() string {
+	return sourceOf(s)
+}
+
+func (s *VarDeclaration) writeSource(sb *strings.Builder) {
+	if s.Value != nil {
+		sb.WriteString(s.Name)
+		sb.WriteString(" := ")
+		writeSource(sb, s.Value)
+		return
+	}
+	sb.WriteString("var ")
+	sb.WriteString(s.Name)
+	sb.WriteString(" ")
+	sb.WriteString(s.Ty.ToSource())
 }
 
 func (s *AssignStatement) ToSource() string {
-	return fmt.Sprintf("%s = %s", toSource(&s.Ref), toSource(s.Value))
+	return sourceOf(s)
+}
+
+func (s *AssignStatement) writeSource(sb *strings.Builder) {
+	sb.WriteString(s.Ref.Ref)
+	sb.WriteString(" = ")
+	if s.Value == nil {
+		sb.WriteString("<NIL>")
+		return
+	}
+	writeSource(sb, s.Value)
 }
 
 func (s *CallStatement) ToSource() string {
 	return s.Exp.ToSource()
 }
 
+func (s *CallStatement) writeSource(sb *strings.Builder) {
+	writeSource(sb, s.Exp)
+}
+
 func (s *TryStatement) ToSource() string {
-	sb := strings.Builder{}
-	// Wrap try body in an IIFE with defer/recover
+	return sourceOf(s)
+}
+
+func (s *TryStatement) writeSource(sb *strings.Builder) {
+	// Wrap try body in an IIFE, so resources deferred in it are closed at
+	// its end, with a defer/recover handling the catch clauses
 	sb.WriteString("func() {\n")
-	// Add defer with recover
-	sb.WriteString("    defer func() {\n")
-	sb.WriteString("        if r := recover(); r != nil {\n")
-	// Handle catch clauses
 	if len(s.CatchClauses) > 0 {
+		sb.WriteString("    defer func() {\n")
+		sb.WriteString("        if r := recover(); r != nil {\n")
 		for i, catch := range s.CatchClauses {
+			// The recovered value is bound to the catch variable if the
+			// body uses it
+			exceptionVar := catch.ExceptionVar
+			if exceptionVar == "" {
+				exceptionVar = "_"
+			}
 			if i == 0 {
-				sb.WriteString(fmt.Sprintf("            if _, ok := r.(%s); ok {\n", catch.ExceptionType))
+				sb.WriteString(fmt.Sprintf("            if %s, ok := r.(%s); ok {\n", exceptionVar, catch.ExceptionType))
 			} else {
-				sb.WriteString(fmt.Sprintf("            } else if _, ok := r.(%s); ok {\n", catch.ExceptionType))
-			}
-			// Write catch body
-			for _, stmt := range catch.Body {
-				stmtSource := stmt.ToSource()
-				// Indent each line
-				lines := strings.SplitSeq(stmtSource, "\n")
-				for line := range lines {
-					if strings.TrimSpace(line) != "" {
-						sb.WriteString("                ")
-						sb.WriteString(line)
-						sb.WriteString("\n")
-					}
-				}
+				sb.WriteString(fmt.Sprintf("            } else if %s, ok := r.(%s); ok {\n", exceptionVar, catch.ExceptionType))
 			}
+			writeIndentedBody(sb, catch.Body, "                ")
 		}
 		sb.WriteString("            } else {\n")
 		sb.WriteString("                panic(r) // re-panic if it's not a handled exception\n")
 		sb.WriteString("            }\n")
-	} else {
-		// No catch clauses, just re-panic
-		sb.WriteString("            panic(r)\n")
-	}
-	sb.WriteString("        }\n")
-	sb.WriteString("    }()\n")
-	// Write try body
-	for _, stmt := range s.TryBody {
-		stmtSource := stmt.ToSource()
-		// Indent each line
-		lines := strings.Split(stmtSource, "\n")
-		for _, line := range lines {
+		sb.WriteString("        }\n")
+		sb.WriteString("    }()\n")
+	}
+	writeIndentedBody(sb, s.TryBody, "    ")
+	sb.WriteString("}()\n")
+	writeIndentedBody(sb, s.FinallyBody, "")
+}
+
+// writeIndentedBody writes the non-blank lines of each statement of a block
+// with indent before them
+func writeIndentedBody(sb *strings.Builder, body []Statement, indent string) {
+	for _, stmt := range body {
+		for line := range strings.SplitSeq(stmt.ToSource(), "\n") {
 			if strings.TrimSpace(line) != "" {
-				sb.WriteString("    ")
+				sb.WriteString(indent)
 				sb.WriteString(line)
 				sb.WriteString("\n")
 			}
 		}
 	}
-	sb.WriteString("}()\n")
-	// Write finally block if present
-	if len(s.FinallyBody) > 0 {
-		for _, stmt := range s.FinallyBody {
-			stmtSource := stmt.ToSource()
-			lines := strings.Split(stmtSource, "\n")
-			for _, line := range lines {
-				if strings.TrimSpace(line) != "" {
-					sb.WriteString(line)
-					sb.WriteString("\n")
-				}
-			}
-		}
-	}
-	return sb.String()
 }
 
 func (s *CommentStmt) ToSource() string {
-	sb := strings.Builder{}
-	AddComments(&sb, s.Comments)
-	return sb.String()
+	return sourceOf(s)
+}
+
+func (s *CommentStmt) writeSource(sb *strings.Builder) {
+	AddComments(sb, s.Comments)
 }
 
 // Expression ToSource methods
@@ -822,21 +1144,40 @@ func (e *GoExpression) ToSource() string {
 }
 
 func (e *CastExpression) ToSource() string {
-	return fmt.Sprintf("%s(%s)", e.Ty.ToSource(), e.Value.ToSource())
+	return sourceOf(e)
+}
+
+func (e *CastExpression) writeSource(sb *strings.Builder) {
+	sb.WriteString(e.Ty.ToSource())
+	sb.WriteString("(")
+	writeSource(sb, e.Value)
+	sb.WriteString(")")
 }
 
 func (e *CallExpression) ToSource() string {
-	sb := strings.Builder{}
+	return sourceOf(e)
+}
+
+func (e *CallExpression) writeSource(sb *strings.Builder) {
 	sb.WriteString(e.Function)
+	if len(e.TypeArgs) > 0 {
+		sb.WriteString("[")
+		for i, ty := range e.TypeArgs {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(ty.ToSource())
+		}
+		sb.WriteString("]")
+	}
 	sb.WriteString("(")
 	for i, arg := range e.Args {
 		if i > 0 {
 			sb.WriteString(", ")
 		}
-		sb.WriteString(arg.ToSource())
+		writeSource(sb, arg)
 	}
 	sb.WriteString(")")
-	return sb.String()
 }
 
 func (e *VarRef) ToSource() string {
@@ -848,10 +1189,16 @@ func (e *BooleanLiteral) ToSource() string {
 }
 
 func (e *IntLiteral) ToSource() string {
+	if e.Literal != "" {
+		return e.Literal
+	}
 	return fmt.Sprintf("%d", e.Value)
 }
 
 func (e *Int64Literal) ToSource() string {
+	if e.Literal != "" {
+		return fmt.Sprintf("int64(%s)", e.Literal)
+	}
 	return fmt.Sprintf("int64(%d)", e.Value)
 }
 
@@ -860,7 +1207,10 @@ func (e *CharLiteral) ToSource() string {
 }
 
 func (e *ArrayLiteral) ToSource() string {
-	sb := strings.Builder{}
+	return sourceOf(e)
+}
+
+func (e *ArrayLiteral) writeSource(sb *strings.Builder) {
 	// Ensure elementType has [] prefix for slice literals
 	elementTypeStr := e.ElementType.ToSource()
 	if !strings.HasPrefix(elementTypeStr, "[]") {
@@ -872,25 +1222,71 @@ func (e *ArrayLiteral) ToSource() string {
 		if i > 0 {
 			sb.WriteString(", ")
 		}
-		sb.WriteString(elem.ToSource())
+		writeSource(sb, elem)
 	}
 	sb.WriteString("}")
-	return sb.String()
 }
 
 func (e *BinaryExpression) ToSource() string {
-	return fmt.Sprintf("(%s %s %s)", e.Left.ToSource(), e.Operator, e.Right.ToSource())
+	return sourceOf(e)
+}
+
+func (e *BinaryExpression) writeSource(sb *strings.Builder) {
+	sb.WriteString("(")
+	writeSource(sb, e.Left)
+	sb.WriteString(" ")
+	sb.WriteString(e.Operator)
+	sb.WriteString(" ")
+	writeSource(sb, e.Right)
+	sb.WriteString(")")
 }
 
 func (e *UnaryExpression) ToSource() string {
-	return fmt.Sprintf("(%s%s)", e.Operator, e.Operand.ToSource())
+	return sourceOf(e)
+}
+
+func (e *UnaryExpression) writeSource(sb *strings.Builder) {
+	sb.WriteString("(")
+	sb.WriteString(e.Operator)
+	writeSource(sb, e.Operand)
+	sb.WriteString(")")
+}
+
+func (e *FuncLiteral) ToSource() string {
+	return sourceOf(e)
+}
+
+func (e *FuncLiteral) writeSource(sb *strings.Builder) {
+	sb.WriteString("func(")
+	for i, param := range e.Params {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		param.writeSource(sb)
+	}
+	sb.WriteString(")")
+	if e.ReturnType != nil {
+		sb.WriteString(" ")
+		sb.WriteString(e.ReturnType.ToSource())
+	}
+	sb.WriteString(" {\n")
+	writeBody(sb, e.Body)
+	sb.WriteString("}")
 }
 
 func (e *ReturnExpression) ToSource() string {
 	if e.Value == nil {
 		return "return"
 	}
-	return fmt.Sprintf("return %s", e.Value.ToSource())
+	return sourceOf(e)
+}
+
+func (e *ReturnExpression) writeSource(sb *strings.Builder) {
+	sb.WriteString("return")
+	if e.Value != nil {
+		sb.WriteString(" ")
+		writeSource(sb, e.Value)
+	}
 }
 
 func (e *UnhandledExpression) ToSource() string {
@@ -899,37 +1295,31 @@ func (e *UnhandledExpression) ToSource() string {
 
 // Helper functions
 
-// ToIdentifier converts a name to a public or private identifier
+// ToIdentifier converts a name to a public or private identifier. Empty
+// names are returned as is.
 func ToIdentifier(name string, public bool) string {
-	first := name[0]
-	if first >= 'a' && first <= 'z' && public {
-		first = first - 'a' + 'A'
-	} else if first >= 'A' && first <= 'Z' && !public {
-		first = first - 'A' + 'a'
+	if public {
+		return CapitalizeFirstLetter(name)
 	}
-	return string(first) + name[1:]
+	return LowercaseFirstLetter(name)
 }
 
 // TODO: move thse to a common string utils package
-// CapitalizeFirstLetter capitalizes the first letter of a string
+// CapitalizeFirstLetter capitalizes the first letter of a string. Only ASCII
+// letters are changed, so the rest of a UTF-8 name is never split.
 func CapitalizeFirstLetter(name string) string {
-	first := name[0]
-	if first >= 'a' && first <= 'z' {
-		first = first - 'a' + 'A'
+	if len(name) == 0 || name[0] < 'a' || name[0] > 'z' {
+		return name
 	}
-	return string(first) + name[1:]
+	return string(name[0]-'a'+'A') + name[1:]
 }
 
 // LowercaseFirstLetter lowercases the first letter of a string
 func LowercaseFirstLetter(name string) string {
-	if len(name) == 0 {
+	if len(name) == 0 || name[0] < 'A' || name[0] > 'Z' {
 		return name
 	}
-	first := name[0]
-	if first >= 'A' && first <= 'Z' {
-		first = first - 'A' + 'a'
-	}
-	return string(first) + name[1:]
+	return string(name[0]-'A'+'a') + name[1:]
 }
 
 // AddComments adds comment lines to a string builder
@@ -940,10 +1330,3 @@ func AddComments(sb *strings.Builder, comments []string) {
 		sb.WriteString("\n")
 	}
 }
-
-func toSource(elem SourceElement) string {
-	if elem == nil {
-		return "<NIL>"
-	}
-	return elem.ToSource()
-}
@@ -0,0 +1,201 @@
+package gosrc
+
+import (
+	"go/scanner"
+	"go/token"
+	"strings"
+)
+
+// Rewriter transforms trees of statements and expressions bottom-up: the
+// children of a node are rewritten before the node itself is handed to the
+// hooks, and whatever a hook returns takes the place of the node. Nil hooks
+// leave nodes as they are. Nodes are copied rather than changed in place.
+// Raw Go source in GoStatement and GoExpression is opaque to the traversal,
+// hooks can rewrite it with RewriteReferences.
+type Rewriter struct {
+	OnStatement  func(Statement) Statement
+	OnExpression func(Expression) Expression
+}
+
+// Statements returns the rewritten stmts
+func (r Rewriter) Statements(stmts []Statement) []Statement {
+	if stmts == nil {
+		return nil
+	}
+	rewritten := make([]Statement, 0, len(stmts))
+	for _, stmt := range stmts {
+		rewritten = append(rewritten, r.Statement(stmt))
+	}
+	return rewritten
+}
+
+// Statement returns the rewritten stmt
+func (r Rewriter) Statement(stmt Statement) Statement {
+	if stmt == nil {
+		return nil
+	}
+	switch s := stmt.(type) {
+	case *IfStatement:
+		rewritten := r.ifStatement(*s)
+		stmt = &rewritten
+	case *SwitchStatement:
+		cases := make([]SwitchCase, 0, len(s.Cases))
+		for _, c := range s.Cases {
+			cases = append(cases, SwitchCase{Condition: r.Expression(c.Condition), Body: r.Statements(c.Body)})
+		}
+		stmt = &SwitchStatement{Condition: r.Expression(s.Condition), Cases: cases, DefaultBody: r.Statements(s.DefaultBody)}
+	case *ForStatement:
+		stmt = &ForStatement{Init: r.Statement(s.Init), Condition: r.Expression(s.Condition), Post: r.Statement(s.Post), Body: r.Statements(s.Body)}
+	case *RangeForStatement:
+		stmt = &RangeForStatement{IndexVar: s.IndexVar, ValueVar: s.ValueVar, CollectionExpr: r.Expression(s.CollectionExpr), Body: r.Statements(s.Body)}
+	case *ReturnStatement:
+		stmt = &ReturnStatement{Value: r.Expression(s.Value)}
+	case *VarDeclaration:
+		stmt = &VarDeclaration{Name: s.Name, Ty: s.Ty, Value: r.Expression(s.Value)}
+	case *AssignStatement:
+		// The target is left to OnStatement, an expression hook would turn
+		// it into a value
+		stmt = &AssignStatement{Ref: s.Ref, Value: r.Expression(s.Value)}
+	case *CallStatement:
+		stmt = &CallStatement{Exp: r.Expression(s.Exp)}
+	case *TryStatement:
+		clauses := make([]CatchClause, 0, len(s.CatchClauses))
+		for _, clause := range s.CatchClauses {
+			clauses = append(clauses, CatchClause{ExceptionType: clause.ExceptionType, ExceptionVar: clause.ExceptionVar, Body: r.Statements(clause.Body)})
+		}
+		stmt = &TryStatement{TryBody: r.Statements(s.TryBody), CatchClauses: clauses, FinallyBody: r.Statements(s.FinallyBody)}
+	}
+	if r.OnStatement != nil {
+		return r.OnStatement(stmt)
+	}
+	return stmt
+}
+
+// ifStatement rewrites an if statement and the else-if chain hanging off it
+func (r Rewriter) ifStatement(s IfStatement) IfStatement {
+	var elseIfs []IfStatement
+	for _, elseIf := range s.ElseIf {
+		elseIfs = append(elseIfs, r.ifStatement(elseIf))
+	}
+	return IfStatement{Init: r.Statement(s.Init), Condition: r.Expression(s.Condition), Body: r.Statements(s.Body), ElseIf: elseIfs, ElseStmts: r.Statements(s.ElseStmts)}
+}
+
+// Expressions returns the rewritten exprs
+func (r Rewriter) Expressions(exprs []Expression) []Expression {
+	if exprs == nil {
+		return nil
+	}
+	rewritten := make([]Expression, 0, len(exprs))
+	for _, expr := range exprs {
+		rewritten = append(rewritten, r.Expression(expr))
+	}
+	return rewritten
+}
+
+// Expression returns the rewritten expr
+func (r Rewriter) Expression(expr Expression) Expression {
+	if expr == nil {
+		return nil
+	}
+	switch e := expr.(type) {
+	case *CastExpression:
+		expr = &CastExpression{Ty: e.Ty, Value: r.Expression(e.Value)}
+	case *CallExpression:
+		expr = &CallExpression{Function: e.Function, TypeArgs: e.TypeArgs, Args: r.Expressions(e.Args)}
+	case *ArrayLiteral:
+		expr = &ArrayLiteral{ElementType: e.ElementType, Elements: r.Expressions(e.Elements)}
+	case *BinaryExpression:
+		expr = &BinaryExpression{Left: r.Expression(e.Left), Operator: e.Operator, Right: r.Expression(e.Right)}
+	case *UnaryExpression:
+		expr = &UnaryExpression{Operator: e.Operator, Operand: r.Expression(e.Operand)}
+	case *FuncLiteral:
+		expr = &FuncLiteral{Params: e.Params, ReturnType: e.ReturnType, Body: r.Statements(e.Body)}
+	case *ReturnExpression:
+		expr = &ReturnExpression{Value: r.Expression(e.Value)}
+	}
+	if r.OnExpression != nil {
+		return r.OnExpression(expr)
+	}
+	return expr
+}
+
+// Reference is a selector on an identifier in raw Go source, such as
+// this.count or this.next()
+type Reference struct {
+	Receiver string
+	Name     string
+	Call     bool // Whether the selector is called, as in this.next()
+}
+
+// RewriteReferences replaces the references in source, the selectors on an
+// identifier, with what rewrite returns for them if it returns true. Source is
+// split into Go tokens, so names inside longer identifiers, strings and
+// comments are never mistaken for references.
+func RewriteReferences(source string, rewrite func(Reference) (string, bool)) string {
+	tokens := scanTokens(source)
+	var sb strings.Builder
+	copied := 0
+	for i := 0; i+2 < len(tokens); i++ {
+		receiver, period, name := tokens[i], tokens[i+1], tokens[i+2]
+		if receiver.tok != token.IDENT || period.tok != token.PERIOD || name.tok != token.IDENT {
+			continue
+		}
+		if i > 0 && tokens[i-1].tok == token.PERIOD {
+			// Part of a longer chain, which starts at its first identifier
+			continue
+		}
+		reference := Reference{
+			Receiver: receiver.lit,
+			Name:     name.lit,
+			Call:     i+3 < len(tokens) && tokens[i+3].tok == token.LPAREN,
+		}
+		replacement, ok := rewrite(reference)
+		if !ok {
+			continue
+		}
+		sb.WriteString(source[copied:receiver.offset])
+		sb.WriteString(replacement)
+		copied = name.offset + len(name.lit)
+		i += 2
+	}
+	sb.WriteString(source[copied:])
+	return sb.String()
+}
+
+// scannedToken is a Go token of raw source and where it starts
+type scannedToken struct {
+	offset int
+	tok    token.Token
+	lit    string
+}
+
+// scanTokens splits source into Go tokens
+func scanTokens(source string) []scannedToken {
+	var tokens []scannedToken
+	fileSet := token.NewFileSet()
+	file := fileSet.AddFile("", fileSet.Base(), len(source))
+	var s scanner.Scanner
+	// Raw source may still hold Java spellings, which are copied as they are
+	s.Init(file, []byte(source), func(token.Position, string) {}, 0)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return tokens
+		}
+		tokens = append(tokens, scannedToken{offset: file.Offset(pos), tok: tok, lit: lit})
+	}
+}
+
+// hasIdentifier reports whether the identifier name is one of the tokens of
+// source
+func hasIdentifier(source, name string) bool {
+	if !strings.Contains(source, name) {
+		return false
+	}
+	for _, scanned := range scanTokens(source) {
+		if scanned.tok == token.IDENT && scanned.lit == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package gosrc
+
+// Walker visits trees of statements and expressions top-down: the hook for a
+// node is called before its children are visited, and the children are
+// skipped if it returns false. Nil hooks visit every node. Like Rewriter,
+// raw Go source in GoStatement and GoExpression is opaque to the traversal.
+type Walker struct {
+	OnStatement  func(Statement) bool
+	OnExpression func(Expression) bool
+}
+
+// Statements visits stmts in order
+func (w Walker) Statements(stmts []Statement) {
+	for _, stmt := range stmts {
+		w.Statement(stmt)
+	}
+}
+
+// Statement visits stmt and the statements and expressions nested in it
+func (w Walker) Statement(stmt Statement) {
+	if stmt == nil || (w.OnStatement != nil && !w.OnStatement(stmt)) {
+		return
+	}
+	switch s := stmt.(type) {
+	case *IfStatement:
+		w.ifStatement(*s)
+	case *SwitchStatement:
+		w.Expression(s.Condition)
+		for _, c := range s.Cases {
+			w.Expression(c.Condition)
+			w.Statements(c.Body)
+		}
+		w.Statements(s.DefaultBody)
+	case *ForStatement:
+		w.Statement(s.Init)
+		w.Expression(s.Condition)
+		w.Statement(s.Post)
+		w.Statements(s.Body)
+	case *RangeForStatement:
+		w.Expression(s.CollectionExpr)
+		w.Statements(s.Body)
+	case *ReturnStatement:
+		w.Expression(s.Value)
+	case *VarDeclaration:
+		w.Expression(s.Value)
+	case *AssignStatement:
+		// Unlike Rewriter, the target is visited: reading it can't turn it
+		// into a value
+		w.Expression(&s.Ref)
+		w.Expression(s.Value)
+	case *CallStatement:
+		w.Expression(s.Exp)
+	case *TryStatement:
+		w.Statements(s.TryBody)
+		for _, clause := range s.CatchClauses {
+			w.Statements(clause.Body)
+		}
+		w.Statements(s.FinallyBody)
+	}
+}
+
+// ifStatement visits an if statement and the else-if chain hanging off it
+func (w Walker) ifStatement(s IfStatement) {
+	w.Statement(s.Init)
+	w.Expression(s.Condition)
+	w.Statements(s.Body)
+	for _, elseIf := range s.ElseIf {
+		w.ifStatement(elseIf)
+	}
+	w.Statements(s.ElseStmts)
+}
+
+// Expressions visits exprs in order
+func (w Walker) Expressions(exprs []Expression) {
+	for _, expr := range exprs {
+		w.Expression(expr)
+	}
+}
+
+// Expression visits expr and the expressions and statements nested in it
+func (w Walker) Expression(expr Expression) {
+	if expr == nil || (w.OnExpression != nil && !w.OnExpression(expr)) {
+		return
+	}
+	switch e := expr.(type) {
+	case *CastExpression:
+		w.Expression(e.Value)
+	case *CallExpression:
+		w.Expressions(e.Args)
+	case *ArrayLiteral:
+		w.Expressions(e.Elements)
+	case *BinaryExpression:
+		w.Expression(e.Left)
+		w.Expression(e.Right)
+	case *UnaryExpression:
+		w.Expression(e.Operand)
+	case *FuncLiteral:
+		w.Statements(e.Body)
+	case *ReturnExpression:
+		w.Expression(e.Value)
+	}
+}
+
+// UsesIdentifier reports whether the identifier name appears in stmts, be it
+// declared, assigned or read. Raw Go source is split into tokens, so names
+// inside longer identifiers, strings and comments don't count.
+func UsesIdentifier(stmts []Statement, name string) bool {
+	found := false
+	uses := func(sources ...string) bool {
+		for _, source := range sources {
+			found = found || hasIdentifier(source, name)
+		}
+		return !found
+	}
+	Walker{
+		OnStatement: func(stmt Statement) bool {
+			switch s := stmt.(type) {
+			case *GoStatement:
+				return uses(s.Source)
+			case *VarDeclaration:
+				return uses(s.Name)
+			case *RangeForStatement:
+				return uses(s.IndexVar, s.ValueVar)
+			case *TryStatement:
+				for _, clause := range s.CatchClauses {
+					uses(clause.ExceptionVar)
+				}
+			}
+			return !found
+		},
+		OnExpression: func(expr Expression) bool {
+			switch e := expr.(type) {
+			case *GoExpression:
+				return uses(e.Source)
+			case *VarRef:
+				return uses(e.Ref)
+			case *CallExpression:
+				return uses(e.Function)
+			case *UnhandledExpression:
+				return uses(e.Text)
+			case *FuncLiteral:
+				for _, param := range e.Params {
+					uses(param.Name)
+				}
+			}
+			return !found
+		},
+	}.Statements(stmts)
+	return found
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+)
+
+// walkFixture is a loop counting the even values of items, with a nested if
+// and a call in its body
+func walkFixture() []gosrc.Statement {
+	return []gosrc.Statement{
+		&gosrc.VarDeclaration{Name: "count", Value: &gosrc.VarRef{Ref: "0"}},
+		&gosrc.RangeForStatement{
+			IndexVar:       "_",
+			ValueVar:       "item",
+			CollectionExpr: &gosrc.VarRef{Ref: "items"},
+			Body: []gosrc.Statement{
+				&gosrc.IfStatement{
+					Condition: &gosrc.BinaryExpression{
+						Left:     &gosrc.BinaryExpression{Left: &gosrc.VarRef{Ref: "item"}, Operator: "%", Right: &gosrc.VarRef{Ref: "2"}},
+						Operator: "==",
+						Right:    &gosrc.VarRef{Ref: "0"},
+					},
+					Body: []gosrc.Statement{&gosrc.GoStatement{Source: `count++ // "total"`}},
+				},
+			},
+		},
+		&gosrc.CallStatement{Exp: &gosrc.CallExpression{Function: "fmt.Println", Args: []gosrc.Expression{&gosrc.VarRef{Ref: "count"}}}},
+	}
+}
+
+func TestWalkerVisitsTopDown(t *testing.T) {
+	var visited []string
+	gosrc.Walker{
+		OnStatement: func(stmt gosrc.Statement) bool {
+			visited = append(visited, strings.TrimPrefix(strings.Split(stmt.ToSource(), "\n")[0], "\t"))
+			return true
+		},
+		OnExpression: func(expr gosrc.Expression) bool {
+			visited = append(visited, expr.ToSource())
+			// Operands of comparisons are skipped
+			binary, ok := expr.(*gosrc.BinaryExpression)
+			return !ok || binary.Operator != "=="
+		},
+	}.Statements(walkFixture())
+
+	want := []string{
+		"count := 0",
+		"0",
+		"for _, item := range items {",
+		"items",
+		"if (item % 2) == 0 {",
+		"((item % 2) == 0)",
+		`count++ // "total"`,
+		"fmt.Println(count)",
+		"fmt.Println(count)",
+		"count",
+	}
+	if strings.Join(visited, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected to visit:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(visited, "\n"))
+	}
+}
+
+func TestRewriterRewritesBottomUp(t *testing.T) {
+	rewritten := gosrc.Rewriter{
+		OnExpression: func(expr gosrc.Expression) gosrc.Expression {
+			if ref, ok := expr.(*gosrc.VarRef); ok && ref.Ref == "item" {
+				return &gosrc.VarRef{Ref: "value"}
+			}
+			return expr
+		},
+	}.Statements(walkFixture())
+
+	got := rewritten[1].ToSource()
+	if !strings.Contains(got, "if (value % 2) == 0 {") {
+		t.Errorf("Expected item to be renamed in the nested condition, got:\n%s", got)
+	}
+	if original := walkFixture()[1].ToSource(); strings.Contains(original, "value") {
+		t.Errorf("Expected the original statements to be left as they are, got:\n%s", original)
+	}
+}
+
+func TestUsesIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "item", want: true},
+		{name: "count", want: true},
+		{name: "items", want: true},
+		{name: "fmt", want: true},
+		// Only inside a comment
+		{name: "total", want: false},
+		// Only part of longer identifiers
+		{name: "it", want: false},
+		{name: "Println", want: true},
+	}
+	stmts := walkFixture()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gosrc.UsesIdentifier(stmts, tt.name); got != tt.want {
+				t.Errorf("UsesIdentifier(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteReferences(t *testing.T) {
+	source := `this.count = this.next(this.step) + other.count // this.count`
+	got := gosrc.RewriteReferences(source, func(ref gosrc.Reference) (string, bool) {
+		if ref.Receiver != "this" {
+			return "", false
+		}
+		if ref.Call {
+			return "self." + strings.ToUpper(ref.Name[:1]) + ref.Name[1:], true
+		}
+		return "self.get_" + ref.Name + "()", true
+	})
+	want := `self.get_count() = self.Next(self.get_step()) + other.count // this.count`
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+func TestIncrementalMigrator(t *testing.T) {
+	base := java.JavaClass("Calculator",
+		"private int total;",
+		"public Calculator(int start) { total = start; }",
+		java.JavaMethod("public int add(int x)", "total += x;", "return total;"),
+		java.JavaMethod("public int sub(int x)", "total -= x;", "return total;"),
+		java.JavaMethod("public int get()", "return total;"),
+	)
+	edits := []struct {
+		name   string
+		source string
+		// parsed is the number of signatures that differ from the previous
+		// edit and so have to be analyzed again
+		parsed int
+	}{
+		{"initial", base, 4},
+		{"unchanged", base, 0},
+		{"edit_method_body", strings.Replace(base, "total -= x;", "total = total - x;", 1), 1},
+		{"rename_method", strings.Replace(base, "public int get()", "public int current()", 1), 2},
+		{"add_method", strings.Replace(base, "private int total;", "private int total;\n    "+java.JavaMethod("public void reset()", "total = 0;"), 1), 2},
+		{"back_to_base", base, 0},
+	}
+
+	migrator := java.NewIncrementalMigrator("Calculator.java", nil, nil, java.Options{})
+	defer migrator.Close()
+	for _, edit := range edits {
+		t.Run(edit.name, func(t *testing.T) {
+			ctx := migrator.Migrate([]byte(edit.source))
+			got := ctx.Source.ToSource("", gosrc.PackageName)
+			expected, _ := java.MigrateString(edit.source, java.Config{FileName: "Calculator.java"})
+			if got != expected {
+				t.Errorf("Incremental output differs from a fresh migration:\n--- Incremental ---\n%s\n--- Fresh ---\n%s", got, expected)
+			}
+			reused, parsed := migrator.LastAnalysis()
+			if parsed != edit.parsed {
+				t.Errorf("Expected %d signatures to be parsed, got %d (%d reused)", edit.parsed, parsed, reused)
+			}
+		})
+	}
+}
+
+func TestMigrateWatched(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "point.go")
+	migrator := java.NewIncrementalMigrator("Point.java", nil, nil, java.Options{})
+	defer migrator.Close()
+
+	cfg := defaultConfig()
+	for _, src := range []string{"public record Point(int x, int y) {}", "public record Point(int x, int y, int z) {}"} {
+		if err := migrateWatched(migrator, []byte(src), dest, cfg); err != nil {
+			t.Fatalf("migrateWatched failed: %v", err)
+		}
+	}
+	content, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if !strings.Contains(string(content), "Z int") {
+		t.Errorf("Expected the latest version of the source to be migrated, got:\n%s", content)
+	}
+}
+
+func TestMigrateWatchedMatchesOneShot(t *testing.T) {
+	const configToml = `class_literals = "name"
+optionals = "generic"
+private_helpers_as_functions = true
+manual_methods = ["legacy"]
+
+[package_mappings]
+"com.example.util" = "example.org/util"
+`
+	const src = `package com.example.app;
+
+import com.example.util.Strs;
+import java.util.Optional;
+
+public class Shapes {
+    private int sides;
+
+    String kind() { return Shapes.class.getName(); }
+    Optional<String> label() { return Optional.of("shape"); }
+    private int twice(int x) { return x * 2; }
+    int doubled() { return twice(sides); }
+    int legacy() { return Strs.twice(sides); }
+}
+`
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"Config.toml": configToml, "Shapes.java": src})
+	if result := runCLI(t, dir, "-config", "Config.toml", "Shapes.java", "oneshot.go"); result.exitCode != 0 {
+		t.Fatalf("One-shot migration failed with exit code %d: %s", result.exitCode, result.stderr)
+	}
+	cfg, err := loadConfigFrom(filepath.Join(dir, "Config.toml"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	watch := func(cfg config, dest string) string {
+		migrator := java.NewIncrementalMigrator("Shapes.java", nil, cfg.TypeMappings, cfg.options())
+		defer migrator.Close()
+		if err := migrateWatched(migrator, []byte(src), filepath.Join(dir, dest), cfg); err != nil {
+			t.Fatalf("migrateWatched failed: %v", err)
+		}
+		return readFile(t, filepath.Join(dir, dest))
+	}
+
+	oneShot := readFile(t, filepath.Join(dir, "oneshot.go"))
+	if watched := watch(cfg, "watched.go"); watched != oneShot {
+		t.Errorf("Watch output differs from one-shot output:\n--- Watched ---\n%s\n--- One-shot ---\n%s", watched, oneShot)
+	}
+	if watch(defaultConfig(), "default.go") == oneShot {
+		t.Errorf("Expected the config to change the output, got the default migration:\n%s", oneShot)
+	}
+}
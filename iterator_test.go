@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+func TestIteratorLoops(t *testing.T) {
+	tests := []struct {
+		name      string
+		stmts     []string
+		contains  string
+		rangeLoop bool
+	}{
+		{
+			name:      "while_loop",
+			stmts:     []string{"Iterator<String> it = names.iterator();", "while (it.hasNext()) { String name = it.next(); total += name.length(); }"},
+			contains:  "for _, name := range this.names {",
+			rangeLoop: true,
+		},
+		{
+			name:     "iterator_used_after_loop",
+			stmts:    []string{"Iterator<String> it = names.iterator();", "while (it.hasNext()) { String name = it.next(); break; }", "if (it.hasNext()) { total++; }"},
+			contains: "it := IteratorOf(this.names)",
+		},
+		{
+			name:     "next_called_twice",
+			stmts:    []string{"for (Iterator<String> it = names.iterator(); it.hasNext();) { String name = it.next(); it.next(); }"},
+			contains: "it.Next()",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmts := append([]string{"int total = 0;"}, tt.stmts...)
+			src := java.JavaClass("Roster",
+				"private List<String> names;",
+				java.JavaMethod("int total()", append(stmts, "return total;")...),
+			)
+			got, errs := java.MigrateString(src, java.Config{})
+			if len(errs) != 0 {
+				t.Fatalf("Unexpected errors: %v", errs)
+			}
+			if !strings.Contains(got, tt.contains) {
+				t.Errorf("Expected %q in:\n%s", tt.contains, got)
+			}
+			if strings.Contains(got, "range this.names") != tt.rangeLoop {
+				t.Errorf("Expected range loop: %v, got:\n%s", tt.rangeLoop, got)
+			}
+		})
+	}
+}
+
+func TestIteratorRemoveIsReported(t *testing.T) {
+	src := java.JavaClass("Roster",
+		"private List<String> names;",
+		java.JavaMethod("void prune()", "Iterator<String> it = names.iterator();", "while (it.hasNext()) { String name = it.next(); it.remove(); }"),
+	)
+	_, errs := java.MigrateString(src, java.Config{})
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, "Iterator.remove") {
+		t.Fatalf("Expected Iterator.remove to be reported, got: %v", errs)
+	}
+}
+
+func TestDeclaredIteratorIsNotGenerated(t *testing.T) {
+	src := "public class Roster {\n" +
+		"    interface Iterator<T> { boolean hasNext(); T next(); }\n" +
+		"    private Iterator<String> names;\n" +
+		"}\n"
+	got, _ := java.MigrateString(src, java.Config{})
+	if strings.Contains(got, "func IteratorOf") {
+		t.Errorf("Expected no iterator support for a file declaring Iterator, got:\n%s", got)
+	}
+}
@@ -0,0 +1,154 @@
+package java
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"slices"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+)
+
+// analysisSnapshotVersion must be bumped whenever the analysis phase or the
+// snapshot format changes, so stale snapshots on disk are not reused
+const analysisSnapshotVersion = "14"
+
+// AnalysisSnapshot is the serializable result of the analysis phase for a
+// single file. Signatures are keyed by the start byte of their declaration,
+// which is only meaningful for the exact source the snapshot was taken from;
+// use AnalysisKey to find the right snapshot.
+type AnalysisSnapshot struct {
+	Methods         map[string][]FunctionData         `json:"methods"`
+	Constructors    map[gosrc.Type][]FunctionData     `json:"constructors"`
+	MethodSigs      map[uint]SignatureSnapshot        `json:"method_signatures"`
+	ConstructorSigs map[uint]SignatureSnapshot        `json:"constructor_signatures"`
+	AbstractClasses map[string]bool                   `json:"abstract_classes"`
+	Classes         map[string]bool                   `json:"classes"`
+	EnumConstants   map[string]string                 `json:"enum_constants"`
+	EnumOrdinals    map[string][]string               `json:"enum_ordinals"`
+	EnumToString    map[string]bool                   `json:"enum_to_string"`
+	TypeNames       map[string]string                 `json:"type_names"`
+	InnerClasses    map[string]string                 `json:"inner_classes"`
+	Fields          map[string]map[string]FieldSymbol `json:"fields"`
+	DefaultMethods  map[string]map[string]string      `json:"default_methods"`
+	Supertypes      map[string][]gosrc.Type           `json:"supertypes"`
+}
+
+// SignatureSnapshot is the serializable form of a parsed method or
+// constructor signature
+type SignatureSnapshot struct {
+	Name       string            `json:"name"`
+	StructName string            `json:"struct_name,omitempty"`
+	TypeParams []gosrc.TypeParam `json:"type_params,omitempty"`
+	Params     []gosrc.Param     `json:"params"`
+	ReturnTy   *gosrc.Type       `json:"return_type,omitempty"`
+	IsPublic   bool              `json:"public,omitempty"`
+	IsStatic   bool              `json:"static,omitempty"`
+	IsAbstract bool              `json:"abstract,omitempty"`
+	Factory    string            `json:"factory,omitempty"`
+	Throws     bool              `json:"throws,omitempty"`
+	ValueTy    *gosrc.Type       `json:"value_type,omitempty"`
+}
+
+// AnalysisKey returns the key identifying the analysis results of the source
+// of ctx under the options of ctx that affect the analysis
+func (ctx *MigrationContext) AnalysisKey() string {
+	hash := sha256.New()
+	hash.Write([]byte(analysisSnapshotVersion + "\x00"))
+	for _, from := range slices.Sorted(maps.Keys(ctx.TypeMappings)) {
+		hash.Write([]byte(from + "\x00" + ctx.TypeMappings[from] + "\x00"))
+	}
+	for _, from := range slices.Sorted(maps.Keys(ctx.PackageMappings)) {
+		hash.Write([]byte("package\x00" + from + "\x00" + ctx.PackageMappings[from] + "\x00"))
+	}
+	if ctx.FlatNestedClasses {
+		hash.Write([]byte("flat_nested_classes\x00"))
+	}
+	hash.Write([]byte("optionals\x00" + ctx.Optionals + "\x00"))
+	hash.Write(ctx.JavaSource)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// AnalysisSnapshot captures the results of AnalyzeTree on ctx
+func (ctx *MigrationContext) AnalysisSnapshot() AnalysisSnapshot {
+	snapshot := AnalysisSnapshot{
+		Methods:         ctx.Methods,
+		Constructors:    ctx.Constructors,
+		MethodSigs:      make(map[uint]SignatureSnapshot, len(ctx.MethodMetadataCache)),
+		ConstructorSigs: make(map[uint]SignatureSnapshot, len(ctx.ConstructorMetadataCache)),
+		AbstractClasses: ctx.AbstractClasses,
+		Classes:         ctx.Classes,
+		EnumConstants:   ctx.EnumConstants,
+		EnumOrdinals:    ctx.EnumOrdinals,
+		EnumToString:    ctx.EnumToString,
+		TypeNames:       ctx.TypeNames,
+		InnerClasses:    ctx.InnerClasses,
+		Fields:          ctx.Fields,
+		DefaultMethods:  ctx.DefaultMethods,
+		Supertypes:      ctx.Supertypes,
+	}
+	for key, metadata := range ctx.MethodMetadataCache {
+		snapshot.MethodSigs[key] = SignatureSnapshot{
+			Name:       metadata.name,
+			TypeParams: metadata.typeParams,
+			Params:     metadata.params,
+			ReturnTy:   metadata.returnTy,
+			IsPublic:   metadata.isPublic,
+			IsStatic:   metadata.isStatic,
+			IsAbstract: metadata.isAbstract,
+			StructName: metadata.factoryOf,
+			Factory:    metadata.factory,
+			Throws:     metadata.throws,
+			ValueTy:    metadata.valueTy,
+		}
+	}
+	for key, metadata := range ctx.ConstructorMetadataCache {
+		snapshot.ConstructorSigs[key] = SignatureSnapshot{
+			Name:       metadata.name,
+			StructName: metadata.structName,
+			Params:     metadata.params,
+			IsPublic:   metadata.isPublic,
+		}
+	}
+	return snapshot
+}
+
+// RestoreAnalysis loads a snapshot taken from the same source into ctx in
+// place of running AnalyzeTree
+func (ctx *MigrationContext) RestoreAnalysis(snapshot AnalysisSnapshot) {
+	maps.Copy(ctx.Methods, snapshot.Methods)
+	maps.Copy(ctx.Constructors, snapshot.Constructors)
+	maps.Copy(ctx.AbstractClasses, snapshot.AbstractClasses)
+	maps.Copy(ctx.Classes, snapshot.Classes)
+	maps.Copy(ctx.EnumConstants, snapshot.EnumConstants)
+	maps.Copy(ctx.EnumOrdinals, snapshot.EnumOrdinals)
+	maps.Copy(ctx.EnumToString, snapshot.EnumToString)
+	maps.Copy(ctx.TypeNames, snapshot.TypeNames)
+	maps.Copy(ctx.InnerClasses, snapshot.InnerClasses)
+	maps.Copy(ctx.Fields, snapshot.Fields)
+	maps.Copy(ctx.DefaultMethods, snapshot.DefaultMethods)
+	maps.Copy(ctx.Supertypes, snapshot.Supertypes)
+	for key, sig := range snapshot.MethodSigs {
+		ctx.MethodMetadataCache[key] = methodMetadata{
+			name:       sig.Name,
+			typeParams: sig.TypeParams,
+			params:     sig.Params,
+			returnTy:   sig.ReturnTy,
+			isPublic:   sig.IsPublic,
+			isStatic:   sig.IsStatic,
+			isAbstract: sig.IsAbstract,
+			factoryOf:  sig.StructName,
+			factory:    sig.Factory,
+			throws:     sig.Throws,
+			valueTy:    sig.ValueTy,
+		}
+	}
+	for key, sig := range snapshot.ConstructorSigs {
+		ctx.ConstructorMetadataCache[key] = constructorMetadata{
+			structName: sig.StructName,
+			params:     sig.Params,
+			isPublic:   sig.IsPublic,
+			name:       sig.Name,
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Ways of migrating annotation type declarations, selected by
+// MigrationContext.Annotations
+const (
+	// AnnotationsStruct converts @interface Foo to a struct with a field per
+	// element, and a constructor filling in the element defaults. It is the
+	// default.
+	AnnotationsStruct = "struct"
+	// AnnotationsSkip drops @interface Foo and reports a diagnostic
+	AnnotationsSkip = "skip"
+)
+
+// migrateAnnotationTypeDeclaration migrates @interface declarations the way
+// ctx.Annotations asks for. Go has no annotations, so uses of them are
+// ignored either way.
+func migrateAnnotationTypeDeclaration(ctx *MigrationContext, annotationNode *tree_sitter.Node) {
+	annotationName := identifierText(ctx, annotationNode, annotationNode.ChildByFieldName("name"), "annotation_type_declaration")
+	if ctx.Annotations == AnnotationsSkip {
+		reportDiagnostic(ctx, annotationNode, ErrAnnotation,
+			fmt.Sprintf("annotation type %s was skipped since annotation declarations are configured to be skipped", annotationName))
+		return
+	}
+	var modifiers modifiers
+	IterateChildren(annotationNode, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "modifiers" {
+			modifiers = ParseModifiers(ctx.nodeText(child))
+		}
+	})
+	structName := gosrc.ToIdentifier(annotationName, modifiers.isPublic())
+
+	var fields []gosrc.StructField
+	var defaults []string
+	IterateChildren(annotationNode.ChildByFieldName("body"), func(member *tree_sitter.Node) {
+		switch nodeKind(member) {
+		case "{", "}", ";", "line_comment", "block_comment":
+			return
+		}
+		failed := tryMigrateMember(ctx, fmt.Sprintf("annotation %s.%s", annotationName, nodeKind(member)), member, func() {
+			switch nodeKind(member) {
+			case "annotation_type_element_declaration":
+				field, value := convertAnnotationElement(ctx, member)
+				fields = append(fields, field)
+				if value != nil {
+					defaults = append(defaults, field.Name+": "+value.ToSource())
+				}
+			case "class_declaration":
+				migrateClassDeclaration(ctx, member)
+			case "record_declaration":
+				migrateRecordDeclaration(ctx, member)
+			case "enum_declaration":
+				migrateEnumDeclaration(ctx, member)
+			case "interface_declaration":
+				migrateInterfaceDeclaration(ctx, member)
+			default:
+				UnhandledChild(ctx, member, "annotation_type_body")
+			}
+		})
+		if failed != nil {
+			ctx.Source.FailedMigrations = append(ctx.Source.FailedMigrations, *failed)
+		}
+	})
+
+	ctx.Source.Structs = append(ctx.Source.Structs, gosrc.Struct{
+		Name:     structName,
+		Fields:   fields,
+		Public:   modifiers.isPublic(),
+		Comments: []string{getMigrationComment(ctx, annotationNode)},
+	})
+	if len(defaults) == 0 {
+		// The zero value already is the annotation without arguments
+		return
+	}
+	ty := gosrc.Type(structName)
+	name := constructorName(ctx, modifiers.isPublic(), ty)
+	ctx.Source.Functions = append(ctx.Source.Functions, gosrc.Function{
+		Name:       name,
+		ReturnType: &ty,
+		Body: []gosrc.Statement{&gosrc.ReturnStatement{
+			Value: ctx.arena.GoExpression(gosrc.GoExpression{Source: structName + "{" + strings.Join(defaults, ", ") + "}"}),
+		}},
+		Comments: []string{getMigrationComment(ctx, annotationNode)},
+		Public:   modifiers.isPublic(),
+	})
+}
+
+// convertAnnotationElement converts an annotation element to a struct field
+// and its default value, if it has one
+func convertAnnotationElement(ctx *MigrationContext, elementNode *tree_sitter.Node) (gosrc.StructField, gosrc.Expression) {
+	name := identifierText(ctx, elementNode, elementNode.ChildByFieldName("name"), "annotation_type_element_declaration")
+	typeNode := elementNode.ChildByFieldName("type")
+	ty, ok := TryParseType(ctx, typeNode)
+	if !ok {
+		FatalError(ctx, typeNode, "unable to parse type in annotation_type_element_declaration", "annotation_type_element_declaration")
+	}
+	field := gosrc.StructField{Name: gosrc.CapitalizeFirstLetter(name), Ty: ty, Public: true}
+	valueNode := elementNode.ChildByFieldName("value")
+	if valueNode == nil {
+		return field, nil
+	}
+	if nodeKind(valueNode) == "element_value_array_initializer" {
+		return field, convertArrayInitializer(ctx, valueNode, ty)
+	}
+	value, init := convertExpression(ctx, valueNode)
+	if len(init) > 0 {
+		FatalError(ctx, valueNode, "unexpected statements in annotation element default", "annotation_type_element_declaration")
+	}
+	if strings.HasPrefix(string(ty), "[]") {
+		// A single value stands for an array holding only it
+		value = &gosrc.ArrayLiteral{ElementType: ty, Elements: []gosrc.Expression{value}}
+	}
+	return field, value
+}
@@ -0,0 +1,304 @@
+package java
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// anonymousClassBody returns the class body of an anonymous class created by
+// expression, or nil if expression creates an instance of a named class
+func anonymousClassBody(expression *tree_sitter.Node) *tree_sitter.Node {
+	var body *tree_sitter.Node
+	IterateChildren(expression, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "class_body" {
+			body = child
+		}
+	})
+	return body
+}
+
+// anonymousClassType returns the Java name of the interface or class an
+// anonymous class created by expression implements, without type arguments
+func anonymousClassType(ctx *MigrationContext, expression *tree_sitter.Node) string {
+	typeNode := expression.ChildByFieldName("type")
+	if nodeKind(typeNode) == "generic_type" {
+		typeNode = typeNode.NamedChild(0)
+	}
+	return ctx.nodeText(typeNode)
+}
+
+// isAnonymousFunction reports whether the anonymous class created by
+// expression is migrated to a function value rather than a struct
+func isAnonymousFunction(ctx *MigrationContext, expression *tree_sitter.Node) bool {
+	return ctx.isFunctionalInterface(anonymousClassType(ctx, expression))
+}
+
+// convertAnonymousClass converts the creation of an anonymous class. An
+// anonymous functional interface becomes a function literal. Any other
+// anonymous class becomes a struct named after the class it is declared in,
+// whose fields hold the instance it is created in and the locals it captures,
+// and the creation becomes a pointer to a value of it.
+func convertAnonymousClass(ctx *MigrationContext, expression, body *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	javaName := anonymousClassType(ctx, expression)
+	if isAnonymousFunction(ctx, expression) {
+		return convertAnonymousFunction(ctx, body, javaName), nil
+	}
+	if _, isClass := ctx.Constructors[gosrc.Type(javaName)]; isClass || ctx.AbstractClasses[javaName] {
+		FatalError(ctx, expression, fmt.Sprintf("anonymous subclasses of %s are not supported", javaName), "object_creation_expression")
+	}
+	if len(argumentNodes(expression.ChildByFieldName("arguments"))) > 0 {
+		FatalError(ctx, expression, "anonymous classes with constructor arguments are not supported", "object_creation_expression")
+	}
+
+	structName := anonymousClassName(ctx, expression, javaName)
+	selfType := gosrc.Type("*" + structName)
+	var fields []gosrc.StructField
+	var values []string
+	if outerType, ok := anonymousOuterType(ctx, expression); ok {
+		fields = append(fields, gosrc.StructField{Name: outerFieldName, Ty: gosrc.Type("*" + outerType)})
+		values = append(values, outerFieldName+": "+gosrc.SelfRef)
+	}
+	for _, captured := range capturedLocals(ctx, expression, body) {
+		fields = append(fields, captured)
+		value := captured.Name
+		if ref, ok := capturedReference(ctx, expression, captured.Name); ok {
+			value = ref
+		}
+		values = append(values, gosrc.ToIdentifier(captured.Name, false)+": "+value)
+	}
+	var methods []gosrc.Method
+	IterateChildren(body, func(child *tree_sitter.Node) {
+		switch nodeKind(child) {
+		case "{", "}", "block_comment", "line_comment":
+		case "field_declaration":
+			field, initExpr, initStmts, mods := convertFieldDeclaration(ctx, child)
+			if mods&STATIC != 0 {
+				UnhandledChild(ctx, child, "anonymous class_body")
+			}
+			if initStmts != nil {
+				FatalError(ctx, child, "field initializers that need statements are not supported in anonymous classes", "class_body")
+			}
+			fields = append(fields, field)
+			if initExpr != nil {
+				values = append(values, gosrc.ToIdentifier(field.Name, field.Public)+": "+initExpr.ToSource())
+			}
+		case "method_declaration":
+			function, isStatic := convertMethodDeclaration(ctx, child)
+			if isStatic {
+				UnhandledChild(ctx, child, "anonymous class_body")
+			}
+			methods = append(methods, gosrc.Method{
+				Function: function,
+				Receiver: gosrc.Param{Name: gosrc.SelfRef, Ty: selfType},
+			})
+		default:
+			UnhandledChild(ctx, child, "anonymous class_body")
+		}
+	})
+
+	ctx.Source.Structs = append(ctx.Source.Structs, gosrc.Struct{
+		Name:     structName,
+		Fields:   fields,
+		Comments: []string{fmt.Sprintf("%s is an anonymous %s", structName, javaName), getMigrationComment(ctx, expression)},
+	})
+	ctx.Source.Methods = append(ctx.Source.Methods, methods...)
+	return ctx.arena.GoExpression(gosrc.GoExpression{
+		Source: "&" + structName + "{" + strings.Join(values, ", ") + "}",
+	}), nil
+}
+
+// convertAnonymousFunction converts the body of an anonymous functional
+// interface javaName to a function literal of its single method. The literal
+// is a closure, so captured locals are used as they are.
+func convertAnonymousFunction(ctx *MigrationContext, body *tree_sitter.Node, javaName string) gosrc.Expression {
+	var methodNode *tree_sitter.Node
+	IterateChildren(body, func(child *tree_sitter.Node) {
+		switch nodeKind(child) {
+		case "{", "}", "block_comment", "line_comment":
+		case "method_declaration":
+			if methodNode != nil || ctx.nodeText(child.ChildByFieldName("name")) != functionalInterfaces[javaName].method {
+				FatalError(ctx, child, fmt.Sprintf("anonymous %s can only declare %s", javaName, functionalInterfaces[javaName].method), "class_body")
+			}
+			methodNode = child
+		default:
+			FatalError(ctx, child, fmt.Sprintf("anonymous %s can only declare %s", javaName, functionalInterfaces[javaName].method), "class_body")
+		}
+	})
+	if methodNode == nil {
+		FatalError(ctx, body, fmt.Sprintf("anonymous %s does not declare %s", javaName, functionalInterfaces[javaName].method), "class_body")
+	}
+	metadata := getMethodMetadata(ctx, methodNode)
+	var statements []gosrc.Statement
+	switch {
+	case skipsMethodBody(ctx, methodNode):
+		statements = stubBody()
+	case isManualMethod(ctx, methodNode):
+		statements = manualBody(ctx, methodNode)
+	default:
+		statements = convertStatementBlock(ctx, methodNode.ChildByFieldName("body"))
+	}
+	return &gosrc.FuncLiteral{
+		Params:     metadata.params,
+		ReturnType: metadata.returnTy,
+		Body:       statements,
+	}
+}
+
+// anonymousClassName returns the Go name of the struct an anonymous class
+// created by expression migrates to: the name of the class it is declared in
+// and of the type it implements, numbered in declaration order like the
+// classes javac generates for them
+func anonymousClassName(ctx *MigrationContext, expression *tree_sitter.Node, javaName string) string {
+	typeNode := enclosingTypeDeclaration(expression)
+	if typeNode == nil {
+		FatalError(ctx, expression, "anonymous class outside of a type declaration", "object_creation_expression")
+	}
+	index := 0
+	var count func(node *tree_sitter.Node)
+	count = func(node *tree_sitter.Node) {
+		if node.StartByte() > expression.StartByte() {
+			return
+		}
+		if nodeKind(node) == "object_creation_expression" && anonymousClassBody(node) != nil && !isAnonymousFunction(ctx, node) {
+			index++
+		}
+		IterateChildren(node, count)
+	}
+	count(typeNode.ChildByFieldName("body"))
+	return gosrc.LowercaseFirstLetter(goTypeName(ctx, typeNode)) + gosrc.CapitalizeFirstLetter(javaName) + strconv.Itoa(index)
+}
+
+// enclosingAnonymousClass returns the creation expression of the innermost
+// anonymous class migrated to a struct whose methods node is in. Field
+// initializers are evaluated where the anonymous class is created, so they
+// are not in the class.
+func enclosingAnonymousClass(ctx *MigrationContext, node *tree_sitter.Node) *tree_sitter.Node {
+	inMethod := false
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch nodeKind(parent) {
+		case "method_declaration":
+			inMethod = true
+		case "class_body":
+			creation := parent.Parent()
+			if nodeKind(creation) != "object_creation_expression" {
+				return nil
+			}
+			if inMethod && !isAnonymousFunction(ctx, creation) {
+				return creation
+			}
+			inMethod = false
+		}
+	}
+	return nil
+}
+
+// anonymousOuterType returns the Go name of the type of the instance an
+// anonymous class created by creation is created in, which its struct keeps
+// in its outer field like an inner class. There is none in static members,
+// nor in interfaces, enums and abstract classes, whose methods are not
+// migrated to methods of a struct.
+func anonymousOuterType(ctx *MigrationContext, creation *tree_sitter.Node) (string, bool) {
+	if enclosing := enclosingAnonymousClass(ctx, creation); enclosing != nil {
+		return anonymousClassName(ctx, enclosing, anonymousClassType(ctx, enclosing)), true
+	}
+	typeNode := enclosingTypeDeclaration(creation)
+	if typeNode == nil || inStaticMember(ctx, creation) {
+		return "", false
+	}
+	switch nodeKind(typeNode) {
+	case "class_declaration":
+		if ctx.AbstractClasses[ctx.nodeText(typeNode.ChildByFieldName("name"))] {
+			return "", false
+		}
+	case "record_declaration":
+	default:
+		return "", false
+	}
+	return goTypeName(ctx, typeNode), true
+}
+
+// anonymousFieldReference returns the Go expression of the instance field a
+// bare name refers to at node, in a method of the anonymous class created by
+// creation: a field of its own struct, or else a field of the instance it is
+// created in, reached through its outer field
+func anonymousFieldReference(ctx *MigrationContext, node, creation *tree_sitter.Node, name string) (string, bool) {
+	body := anonymousClassBody(creation)
+	if typeNode := declaredType(ctx, node, name); typeNode != nil && typeNode.StartByte() >= body.StartByte() && typeNode.EndByte() <= body.EndByte() {
+		return gosrc.SelfRef + "." + gosrc.ToIdentifier(name, declarationModifiers(ctx, typeNode.Parent()).isPublic()), true
+	}
+	if _, ok := anonymousOuterType(ctx, creation); !ok {
+		return "", false
+	}
+	ref, ok := implicitFieldReference(ctx, creation, name)
+	if !ok {
+		return "", false
+	}
+	return gosrc.SelfRef + "." + outerFieldName + strings.TrimPrefix(ref, gosrc.SelfRef), true
+}
+
+// capturedType returns the type node of the local variable or parameter name
+// that an anonymous class created by creation captures if it is referred to
+// at node, or nil if name is not captured by it
+func capturedType(ctx *MigrationContext, node, creation *tree_sitter.Node, name string) *tree_sitter.Node {
+	typeNode := declaredType(ctx, node, name)
+	if typeNode == nil || nodeKind(typeNode.Parent()) == "field_declaration" {
+		return nil
+	}
+	// Names declared inside the class are not captured by it
+	body := anonymousClassBody(creation)
+	if typeNode.StartByte() >= body.StartByte() && typeNode.EndByte() <= body.EndByte() {
+		return nil
+	}
+	return typeNode
+}
+
+// capturedReference returns the Go expression of the local name captured by
+// the anonymous class node is in, which is held by a field of its struct
+func capturedReference(ctx *MigrationContext, node *tree_sitter.Node, name string) (string, bool) {
+	creation := enclosingAnonymousClass(ctx, node)
+	if creation == nil || capturedType(ctx, node, creation, name) == nil {
+		return "", false
+	}
+	return gosrc.SelfRef + "." + gosrc.ToIdentifier(name, false), true
+}
+
+// capturedLocals returns the fields that hold the locals the anonymous class
+// created by creation captures, in the order they are first referred to
+func capturedLocals(ctx *MigrationContext, creation, body *tree_sitter.Node) []gosrc.StructField {
+	var fields []gosrc.StructField
+	seen := make(map[string]bool)
+	var visit func(node *tree_sitter.Node)
+	visit = func(node *tree_sitter.Node) {
+		if nodeKind(node) == "identifier" && isNameReference(node) {
+			name := ctx.nodeText(node)
+			if typeNode := capturedType(ctx, node, creation, name); typeNode != nil && !seen[name] {
+				seen[name] = true
+				ty, ok := TryParseType(ctx, typeNode)
+				if !ok {
+					FatalError(ctx, typeNode, "unable to parse type of captured local "+name, "object_creation_expression")
+				}
+				fields = append(fields, gosrc.StructField{Name: name, Ty: ty})
+			}
+		}
+		IterateChildren(node, visit)
+	}
+	visit(body)
+	return fields
+}
+
+// isNameReference reports whether the identifier node refers to a variable,
+// rather than naming a declaration, a method or a field
+func isNameReference(node *tree_sitter.Node) bool {
+	parent := node.Parent()
+	for _, field := range []string{"name", "field"} {
+		if named := parent.ChildByFieldName(field); named != nil && named.StartByte() == node.StartByte() {
+			return false
+		}
+	}
+	return true
+}
@@ -2,6 +2,7 @@ package java
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -18,34 +19,54 @@ type classConversionResult struct {
 	Methods   []gosrc.Method
 }
 
+// collectAbstractClass records classNode in ctx.AbstractClasses if it is abstract
+func collectAbstractClass(ctx *MigrationContext, classNode *tree_sitter.Node) {
+	nameNode := classNode.ChildByFieldName("name")
+	if nameNode == nil {
+		return
+	}
+	IterateChildren(classNode, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "modifiers" && ParseModifiers(ctx.nodeText(child))&ABSTRACT != 0 {
+			ctx.AbstractClasses[ctx.nodeText(nameNode)] = true
+		}
+	})
+}
+
 func migrateClassDeclaration(ctx *MigrationContext, classNode *tree_sitter.Node) {
-	var className string
+	className := identifierText(ctx, classNode, classNode.ChildByFieldName("name"), "class_declaration")
 	var modifiers modifiers
 	var includes []gosrc.Type
 	var implementedInterfaces []gosrc.Type
+	var typeParams []gosrc.TypeParam
+	var typeParamsNode *tree_sitter.Node
 	isAbstract := false
 	IterateChildren(classNode, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "modifiers":
-			modifiers = ParseModifiers(child.Utf8Text(ctx.JavaSource))
+			modifiers = ParseModifiers(ctx.nodeText(child))
 			isAbstract = modifiers&ABSTRACT != 0
-		case "identifier":
-			className = child.Utf8Text(ctx.JavaSource)
+		case "type_parameters":
+			typeParams = parseTypeParameters(ctx, child)
+			typeParamsNode = child
 		case "superclass":
 			ty, ok := TryParseType(ctx, child.Child(1))
 			if ok {
-				includes = append(includes, ty)
+				// The superclass is embedded by value, so a new instance
+				// has one
+				includes = append(includes, structType(ty))
 			} else {
 				UnhandledChild(ctx, child, "superclass")
 			}
 		case "super_interfaces":
 			// Parse implements clause - iterate through children to find type_list
 			IterateChildren(child, func(superinterfacesChild *tree_sitter.Node) {
-				if superinterfacesChild.Kind() == "type_list" {
+				if nodeKind(superinterfacesChild) == "type_list" {
 					// Iterate through the type_list to get individual types
 					IterateChildren(superinterfacesChild, func(typeChild *tree_sitter.Node) {
 						ty, ok := TryParseType(ctx, typeChild)
-						if ok {
+						// Cloneable is a marker for Object.clone(), which
+						// is replaced by a generated Clone method
+						if ok && ty != "Cloneable" {
 							implementedInterfaces = append(implementedInterfaces, ty)
 						}
 					})
@@ -53,6 +74,10 @@ func migrateClassDeclaration(ctx *MigrationContext, classNode *tree_sitter.Node)
 			})
 		case "class_body":
 			if isAbstract {
+				if typeParamsNode != nil {
+					// The generated Data, Base and Methods types are not generic
+					UnhandledChild(ctx, typeParamsNode, "abstract class_declaration")
+				}
 				ctx.AbstractClasses[className] = true
 				convertAbstractClass(ctx, className, modifiers, includes, child)
 			} else {
@@ -75,10 +100,11 @@ func migrateClassDeclaration(ctx *MigrationContext, classNode *tree_sitter.Node)
 				if extendsAbstract {
 					structName = gosrc.CapitalizeFirstLetter(className)
 				} else {
-					structName = gosrc.ToIdentifier(className, modifiers.isPublic())
+					// Static nested classes are hoisted under a prefixed name
+					structName = goTypeName(ctx, classNode)
 				}
 				isPublicClass := modifiers&PUBLIC != 0
-				result := convertClassBody(ctx, structName, child, false, isPublicClass)
+				result := convertClassBody(ctx, structName, typeParams, child, false, isPublicClass)
 				ctx.Source.Functions = append(ctx.Source.Functions, result.Functions...)
 				for i := range result.Methods {
 					method := &result.Methods[i]
@@ -87,32 +113,39 @@ func migrateClassDeclaration(ctx *MigrationContext, classNode *tree_sitter.Node)
 						method.Name = gosrc.CapitalizeFirstLetter(method.Name)
 						method.Public = true
 						// Update receiver type to use capitalized struct name
-						method.Receiver.Ty = gosrc.Type("*" + structName)
+						method.Receiver.Ty = "*" + gosrc.Instantiate(structName, typeParams)
 						// Use single lowercase letter for receiver name (Go convention: first letter of type)
 						receiverName := strings.ToLower(string(structName[0]))
 						method.Receiver.Name = receiverName
 					}
 					ctx.Source.Methods = append(ctx.Source.Methods, *method)
 				}
+				importConstraints(ctx, typeParams)
 				ctx.Source.Structs = append(ctx.Source.Structs, gosrc.Struct{
-					Name:     structName,
-					Fields:   result.Fields,
-					Comments: result.Comments,
-					Public:   extendsAbstract || (modifiers&PUBLIC != 0),
-					Includes: embeddedTypes,
+					Name:       structName,
+					TypeParams: typeParams,
+					Fields:     result.Fields,
+					Comments:   result.Comments,
+					Public:     extendsAbstract || (modifiers&PUBLIC != 0),
+					Includes:   embeddedTypes,
 				})
-				// Generate type assertions for implemented interfaces
+				// Generate type assertions for implemented interfaces. Generic
+				// structs have no instantiation that is known to be valid.
+				if len(typeParams) > 0 {
+					implementedInterfaces = nil
+				}
 				for _, ifaceType := range implementedInterfaces {
 					// Create type assertion: var _ InterfaceName = &StructName{}
 					ctx.Source.Vars = append(ctx.Source.Vars, gosrc.ModuleVar{
 						Name:  "_",
 						Ty:    ifaceType,
-						Value: &gosrc.VarRef{Ref: "&" + structName + "{}"},
+						Value: ctx.arena.VarRef(gosrc.VarRef{Ref: "&" + structName + "{}"}),
 					})
 				}
 			}
 		// ignored
 		case "class":
+		case "identifier":
 		case "line_comment":
 		case "block_comment":
 		default:
@@ -128,17 +161,18 @@ func convertAbstractClass(ctx *MigrationContext, className string, modifiers mod
 	var defaultMethods []gosrc.Function
 	var comments []string
 	fieldInitValues := map[string]gosrc.Expression{}
+	statics := newStaticInit(ctx, classBody)
 
 	IterateChildren(classBody, func(child *tree_sitter.Node) {
 		// Skip ignored tokens
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "{", "}", "block_comment", "line_comment":
 			return
 		}
 
 		// Wrap member migration in error recovery
-		failed := tryMigrateMember(ctx, fmt.Sprintf("abstract class %s.%s", className, child.Kind()), child, func() {
-			switch child.Kind() {
+		failed := tryMigrateMember(ctx, fmt.Sprintf("abstract class %s.%s", className, nodeKind(child)), child, func() {
+			switch nodeKind(child) {
 			case "class_declaration":
 				migrateClassDeclaration(ctx, child)
 			case "record_declaration":
@@ -146,13 +180,9 @@ func convertAbstractClass(ctx *MigrationContext, className string, modifiers mod
 			case "enum_declaration":
 				migrateEnumDeclaration(ctx, child)
 			case "field_declaration":
-				field, initExpr, mods := convertFieldDeclaration(ctx, child)
+				field, initExpr, initStmts, mods := convertFieldDeclaration(ctx, child)
 				if mods&STATIC != 0 {
-					ctx.Source.Vars = append(ctx.Source.Vars, gosrc.ModuleVar{
-						Name:  field.Name,
-						Ty:    field.Ty,
-						Value: initExpr,
-					})
+					statics.addField(ctx, child, field, initExpr, initStmts)
 				} else {
 					if initExpr != nil {
 						Assert("mutiple initializations for field"+field.Name, fieldInitValues[field.Name] == nil)
@@ -173,6 +203,8 @@ func convertAbstractClass(ctx *MigrationContext, className string, modifiers mod
 				}
 			case "constructor_declaration":
 				// Abstract classes can have constructors, but we'll skip them for now
+			case "static_initializer":
+				statics.addInitializer(ctx, child)
 			default:
 				UnhandledChild(ctx, child, "class_body")
 			}
@@ -184,6 +216,8 @@ func convertAbstractClass(ctx *MigrationContext, className string, modifiers mod
 		}
 	})
 
+	statics.finish(ctx)
+
 	// Generate FooData interface
 	dataInterfaceName := gosrc.CapitalizeFirstLetter(className) + "Data"
 	var dataMethods []gosrc.InterfaceMethod
@@ -243,7 +277,7 @@ func convertAbstractClass(ctx *MigrationContext, className string, modifiers mod
 				Params:     []gosrc.Param{},
 				ReturnType: &field.Ty,
 				Body: []gosrc.Statement{
-					&gosrc.ReturnStatement{Value: &gosrc.VarRef{Ref: "b." + gosrc.ToIdentifier(field.Name, true)}},
+					&gosrc.ReturnStatement{Value: ctx.arena.VarRef(gosrc.VarRef{Ref: "b." + gosrc.ToIdentifier(field.Name, true)})},
 				},
 				Public: true,
 			},
@@ -260,7 +294,7 @@ func convertAbstractClass(ctx *MigrationContext, className string, modifiers mod
 				Body: []gosrc.Statement{
 					&gosrc.AssignStatement{
 						Ref:   gosrc.VarRef{Ref: "b." + gosrc.ToIdentifier(field.Name, true)},
-						Value: &gosrc.VarRef{Ref: gosrc.ToIdentifier(field.Name, false)},
+						Value: ctx.arena.VarRef(gosrc.VarRef{Ref: gosrc.ToIdentifier(field.Name, false)}),
 					},
 				},
 				Public: true,
@@ -337,8 +371,9 @@ func convertAbstractClass(ctx *MigrationContext, className string, modifiers mod
 	})
 }
 
+// convertMethodBodyForDefaultMethod rewrites the body of a default method of
+// an abstract class to reach the instance through m.Self
 func convertMethodBodyForDefaultMethod(ctx *MigrationContext, body []gosrc.Statement, className string, fields []gosrc.StructField) []gosrc.Statement {
-	var converted []gosrc.Statement
 	oldInDefaultMethod := ctx.InDefaultMethod
 	oldDefaultMethodSelf := ctx.DefaultMethodSelf
 	ctx.InDefaultMethod = true
@@ -347,207 +382,122 @@ func convertMethodBodyForDefaultMethod(ctx *MigrationContext, body []gosrc.State
 		ctx.InDefaultMethod = oldInDefaultMethod
 		ctx.DefaultMethodSelf = oldDefaultMethodSelf
 	}()
-	// Build map of field names for quick lookup
-	fieldMap := make(map[string]bool)
+	fieldNames := make(map[string]bool)
 	for _, field := range fields {
-		fieldMap[field.Name] = true
+		fieldNames[field.Name] = true
 	}
-	for _, stmt := range body {
-		converted = append(converted, convertStatementForDefaultMethod(ctx, stmt, className, fieldMap))
+	return defaultMethodRewriter(ctx, fieldNames).Statements(body)
+}
+
+// defaultMethodRewriter rewrites the references to this in the body of a
+// default method to go through ctx.DefaultMethodSelf, the interface the
+// instance is reached by: fields through their getters and setters, methods
+// by their exported names. fields are the names of the fields of the class,
+// which bare calls are not made on.
+func defaultMethodRewriter(ctx *MigrationContext, fields map[string]bool) gosrc.Rewriter {
+	return gosrc.Rewriter{
+		OnStatement: func(stmt gosrc.Statement) gosrc.Statement {
+			return rewriteDefaultMethodStatement(ctx, stmt)
+		},
+		OnExpression: func(expr gosrc.Expression) gosrc.Expression {
+			return rewriteDefaultMethodExpression(ctx, expr, fields)
+		},
 	}
-	return converted
 }
 
-func convertStatementForDefaultMethod(ctx *MigrationContext, stmt gosrc.Statement, className string, fieldMap map[string]bool) gosrc.Statement {
+func rewriteDefaultMethodStatement(ctx *MigrationContext, stmt gosrc.Statement) gosrc.Statement {
 	switch s := stmt.(type) {
 	case *gosrc.GoStatement:
-		// Replace this.field with m.Self.GetField() and this.method() with m.Self.gosrc.Method()
-		source := s.Source
-		// Simple string replacement for common patterns
-		// This is a simplified version - in production you'd want a more sophisticated AST-based approach
-		source = strings.ReplaceAll(source, "this.", ctx.DefaultMethodSelf+".")
-		return &gosrc.GoStatement{Source: source}
-	case *gosrc.ReturnStatement:
-		if s.Value != nil {
-			return &gosrc.ReturnStatement{Value: convertExpressionForDefaultMethod(ctx, s.Value, className, fieldMap)}
-		}
-		return s
+		return &gosrc.GoStatement{Source: rewriteSelfReferences(ctx, s.Source)}
 	case *gosrc.AssignStatement:
-		// Convert field assignments: this.field = value -> m.Self.SetField(value)
-		refStr := s.Ref.ToSource()
-		if strings.HasPrefix(refStr, "this.") {
-			// For now, keep as assignment - we'll need more sophisticated handling
-			return &gosrc.AssignStatement{
-				Ref:   gosrc.VarRef{Ref: strings.ReplaceAll(refStr, "this.", ctx.DefaultMethodSelf+".")},
-				Value: convertExpressionForDefaultMethod(ctx, s.Value, className, fieldMap),
-			}
+		field, isField := strings.CutPrefix(s.Ref.Ref, gosrc.SelfRef+".")
+		if !isField || strings.ContainsAny(field, ".[(") {
+			return &gosrc.AssignStatement{Ref: gosrc.VarRef{Ref: rewriteSelfReferences(ctx, s.Ref.Ref)}, Value: s.Value}
 		}
-		return &gosrc.AssignStatement{
-			Ref:   s.Ref,
-			Value: convertExpressionForDefaultMethod(ctx, s.Value, className, fieldMap),
-		}
-	case *gosrc.IfStatement:
-		return &gosrc.IfStatement{
-			Condition: convertExpressionForDefaultMethod(ctx, s.Condition, className, fieldMap),
-			Body:      convertStatementsForDefaultMethod(ctx, s.Body, className, fieldMap),
-			ElseIf:    convertElseIfsForDefaultMethod(ctx, s.ElseIf, className, fieldMap),
-			ElseStmts: convertStatementsForDefaultMethod(ctx, s.ElseStmts, className, fieldMap),
-		}
-	case *gosrc.ForStatement:
-		var initStmt gosrc.Statement
-		if s.Init != nil {
-			initStmt = convertStatementForDefaultMethod(ctx, s.Init, className, fieldMap)
-		}
-		var postStmt gosrc.Statement
-		if s.Post != nil {
-			postStmt = convertStatementForDefaultMethod(ctx, s.Post, className, fieldMap)
-		}
-		return &gosrc.ForStatement{
-			Init:      initStmt,
-			Condition: convertExpressionForDefaultMethod(ctx, s.Condition, className, fieldMap),
-			Post:      postStmt,
-			Body:      convertStatementsForDefaultMethod(ctx, s.Body, className, fieldMap),
-		}
-	case *gosrc.CallStatement:
-		return &gosrc.CallStatement{
-			Exp: convertExpressionForDefaultMethod(ctx, s.Exp, className, fieldMap),
-		}
-	case *gosrc.VarDeclaration:
-		if s.Value != nil {
-			return &gosrc.VarDeclaration{
-				Name:  s.Name,
-				Ty:    s.Ty,
-				Value: convertExpressionForDefaultMethod(ctx, s.Value, className, fieldMap),
-			}
-		}
-		return s
+		// this.field = value -> m.Self.SetField(value)
+		return &gosrc.CallStatement{Exp: &gosrc.CallExpression{
+			Function: ctx.DefaultMethodSelf + ".Set" + gosrc.CapitalizeFirstLetter(field),
+			Args:     []gosrc.Expression{s.Value},
+		}}
 	default:
-		// For other statement types, try to convert recursively if possible
 		return stmt
 	}
 }
 
-func convertStatementsForDefaultMethod(ctx *MigrationContext, stmts []gosrc.Statement, className string, fieldMap map[string]bool) []gosrc.Statement {
-	var converted []gosrc.Statement
-	for _, stmt := range stmts {
-		converted = append(converted, convertStatementForDefaultMethod(ctx, stmt, className, fieldMap))
-	}
-	return converted
-}
-
-func convertElseIfsForDefaultMethod(ctx *MigrationContext, elseIfs []gosrc.IfStatement, className string, fieldMap map[string]bool) []gosrc.IfStatement {
-	var converted []gosrc.IfStatement
-	for _, elseIf := range elseIfs {
-		converted = append(converted, gosrc.IfStatement{
-			Condition: convertExpressionForDefaultMethod(ctx, elseIf.Condition, className, fieldMap),
-			Body:      convertStatementsForDefaultMethod(ctx, elseIf.Body, className, fieldMap),
-			ElseIf:    convertElseIfsForDefaultMethod(ctx, elseIf.ElseIf, className, fieldMap),
-			ElseStmts: convertStatementsForDefaultMethod(ctx, elseIf.ElseStmts, className, fieldMap),
-		})
-	}
-	return converted
-}
-
-func convertExpressionForDefaultMethod(ctx *MigrationContext, expr gosrc.Expression, className string, fieldMap map[string]bool) gosrc.Expression {
+func rewriteDefaultMethodExpression(ctx *MigrationContext, expr gosrc.Expression, fields map[string]bool) gosrc.Expression {
 	switch e := expr.(type) {
 	case *gosrc.VarRef:
-		ref := e.Ref
-
-		fieldName, shouldConvertToGetter := strings.CutPrefix(ref, "this.")
-		if shouldConvertToGetter {
-			capitalized := gosrc.CapitalizeFirstLetter(fieldName)
-			return &gosrc.VarRef{Ref: ctx.DefaultMethodSelf + ".Get" + capitalized + "()"}
-		}
-		// Check if this is a bare field reference
-		if fieldMap[ref] {
-			// Convert bare field reference to getter: field -> m.Self.GetField()
-			capitalized := gosrc.CapitalizeFirstLetter(ref)
-			return &gosrc.VarRef{Ref: ctx.DefaultMethodSelf + ".Get" + capitalized + "()"}
+		if e.Ref == gosrc.SelfRef {
+			return ctx.arena.VarRef(gosrc.VarRef{Ref: ctx.DefaultMethodSelf})
 		}
-		ref = strings.ReplaceAll(ref, "this.", ctx.DefaultMethodSelf+".")
-		return &gosrc.VarRef{Ref: ref}
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: rewriteSelfReferences(ctx, e.Ref)})
+	case *gosrc.GoExpression:
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: rewriteSelfReferences(ctx, e.Source)})
 	case *gosrc.CallExpression:
-		funcName := e.Function
-		funcName, isSelfMethodRef := strings.CutPrefix(funcName, "this.")
-
+		if ctx.isDefaultMethodFunction(e.Function) {
+			// Calls of default methods already take the instance
+			return expr
+		}
+		funcName, isSelfMethodRef := strings.CutPrefix(e.Function, gosrc.SelfRef+".")
 		// Lookup converted method name for overloading
-		convertedFuncName, ok, _ := getConvertedMethodName(ctx, funcName, len(e.Args))
-		if ok {
+		if convertedFuncName, ok, _ := getConvertedMethodName(ctx, funcName, len(e.Args)); ok {
 			funcName = convertedFuncName
 		}
-
-		if isSelfMethodRef {
+		switch {
+		case isSelfMethodRef:
 			funcName = ctx.DefaultMethodSelf + "." + gosrc.CapitalizeFirstLetter(funcName)
-		} else if funcName == "this" {
+		case funcName == gosrc.SelfRef:
 			funcName = ctx.DefaultMethodSelf
-		} else if !strings.Contains(funcName, ".") && !fieldMap[funcName] {
+		case !strings.Contains(funcName, ".") && !fields[funcName]:
 			// Bare method call (not a field) - assume it's a method on self
 			funcName = ctx.DefaultMethodSelf + "." + gosrc.CapitalizeFirstLetter(funcName)
+		default:
+			funcName = e.Function
 		}
-		var convertedArgs []gosrc.Expression
-		for _, arg := range e.Args {
-			convertedArgs = append(convertedArgs, convertExpressionForDefaultMethod(ctx, arg, className, fieldMap))
-		}
-		return &gosrc.CallExpression{
-			Function: funcName,
-			Args:     convertedArgs,
-		}
-	case *gosrc.BinaryExpression:
-		return &gosrc.BinaryExpression{
-			Left:     convertExpressionForDefaultMethod(ctx, e.Left, className, fieldMap),
-			Operator: e.Operator,
-			Right:    convertExpressionForDefaultMethod(ctx, e.Right, className, fieldMap),
-		}
-	case *gosrc.UnaryExpression:
-		return &gosrc.UnaryExpression{
-			Operator: e.Operator,
-			Operand:  convertExpressionForDefaultMethod(ctx, e.Operand, className, fieldMap),
-		}
-	case *gosrc.GoExpression:
-		source := e.Source
-		// Replace this.method() with m.Self.gosrc.Method() (capitalized)
-		// Pattern: this.methodName( -> m.Self.MethodName(
-		source = strings.ReplaceAll(source, "this.", ctx.DefaultMethodSelf+".")
-		// Capitalize method names after m.Self.
-		if strings.Contains(source, ctx.DefaultMethodSelf+".") {
-			// Find method calls like m.Self.method( and capitalize method name
-			parts := strings.Split(source, ctx.DefaultMethodSelf+".")
-			if len(parts) > 1 {
-				for i := 1; i < len(parts); i++ {
-					// Find the method name (up to the opening parenthesis or end)
-					methodPart := parts[i]
-					methodEnd := strings.IndexAny(methodPart, "(")
-					if methodEnd > 0 {
-						methodName := methodPart[:methodEnd]
-						capitalized := gosrc.CapitalizeFirstLetter(methodName)
-						parts[i] = capitalized + methodPart[methodEnd:]
-					} else {
-						parts[i] = gosrc.CapitalizeFirstLetter(methodPart)
-					}
-				}
-				source = strings.Join(parts, ctx.DefaultMethodSelf+".")
-			}
-		}
-		return &gosrc.GoExpression{Source: source}
+		return &gosrc.CallExpression{Function: funcName, TypeArgs: e.TypeArgs, Args: e.Args}
 	default:
 		return expr
 	}
 }
 
-func convertClassBody(ctx *MigrationContext, structName string, classBody *tree_sitter.Node, isAbstract bool, isPublicClass bool) classConversionResult {
+// rewriteSelfReferences rewrites the selectors on this in the Go source of a
+// default method: this.field becomes a call of the getter of the field and
+// this.method(...) a call of the exported method
+func rewriteSelfReferences(ctx *MigrationContext, source string) string {
+	return gosrc.RewriteReferences(source, func(ref gosrc.Reference) (string, bool) {
+		if ref.Receiver != gosrc.SelfRef {
+			return "", false
+		}
+		name := gosrc.CapitalizeFirstLetter(ref.Name)
+		if ref.Call {
+			return ctx.DefaultMethodSelf + "." + name, true
+		}
+		return ctx.DefaultMethodSelf + ".Get" + name + "()", true
+	})
+}
+
+func convertClassBody(ctx *MigrationContext, structName string, typeParams []gosrc.TypeParam, classBody *tree_sitter.Node, isAbstract bool, isPublicClass bool) classConversionResult {
 	var result classConversionResult
-	fieldInitValues := map[string]gosrc.Expression{}
+	selfType := gosrc.Instantiate(structName, typeParams)
+	fieldInits := map[string][]gosrc.Statement{}
+	statics := newStaticInit(ctx, classBody)
+	initializers := instanceInitializers(classBody)
 	hasConstructor := false
+	var copyMethods []string
+	outerType, isInner := ctx.InnerClasses[structName]
+	if isInner {
+		result.Fields = append(result.Fields, gosrc.StructField{Name: outerFieldName, Ty: gosrc.Type("*" + outerType)})
+	}
 	IterateChildren(classBody, func(child *tree_sitter.Node) {
 		// Skip ignored tokens
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "{", "}", "block_comment", "line_comment":
 			return
 		}
 
 		// Wrap member migration in error recovery
-		failed := tryMigrateMember(ctx, fmt.Sprintf("class %s.%s", structName, child.Kind()), child, func() {
-			switch child.Kind() {
+		failed := tryMigrateMember(ctx, fmt.Sprintf("class %s.%s", structName, nodeKind(child)), child, func() {
+			switch nodeKind(child) {
 			case "class_declaration":
 				migrateClassDeclaration(ctx, child)
 			case "record_declaration":
@@ -555,29 +505,45 @@ func convertClassBody(ctx *MigrationContext, structName string, classBody *tree_
 			case "enum_declaration":
 				migrateEnumDeclaration(ctx, child)
 			case "field_declaration":
-				field, initExpr, mods := convertFieldDeclaration(ctx, child)
+				field, initExpr, initStmts, mods := convertFieldDeclaration(ctx, child)
 				// If field is static final, add as module-level var
 				if mods&STATIC != 0 {
-					ctx.Source.Vars = append(ctx.Source.Vars, gosrc.ModuleVar{
-						Name:     field.Name,
-						Ty:       field.Ty,
-						Value:    initExpr,
-						Comments: field.Comments,
-					})
+					statics.addField(ctx, child, field, initExpr, initStmts)
 				} else {
 					// Regular field
 					if initExpr != nil {
-						Assert("mutiple initializations for field"+field.Name, fieldInitValues[field.Name] == nil)
-						fieldInitValues[field.Name] = initExpr
+						initStmts = []gosrc.Statement{&gosrc.AssignStatement{Ref: gosrc.VarRef{Ref: fieldTarget(field.Name, mods)}, Value: initExpr}}
+					}
+					if initStmts != nil {
+						// Keyed by the Go name, since these become this.<name> assignments
+						goName := gosrc.ToIdentifier(field.Name, field.Public)
+						Assert("mutiple initializations for field"+field.Name, fieldInits[goName] == nil)
+						fieldInits[goName] = initStmts
 					}
 					result.Fields = append(result.Fields, field)
 				}
 			case "constructor_declaration":
-				result.Functions = append(result.Functions, convertConstructor(ctx, &fieldInitValues, structName, child, isPublicClass))
+				constructor := convertConstructor(ctx, &fieldInits, initializers, structName, typeParams, child, isPublicClass)
+				if isInner {
+					addOuterParam(&constructor, outerType)
+				}
+				result.Functions = append(result.Functions, constructor)
 				hasConstructor = true
+				if isCopyConstructor(ctx, child) {
+					copyMethods = append(copyMethods, copyMethodName)
+				}
 			case "compact_constructor_declaration":
 				// Compact constructors are handled in migrateRecordDeclaration, skip here
+			case "block":
+				// Instance initializers are folded into every constructor
+			case "static_initializer":
+				statics.addInitializer(ctx, child)
 			case "method_declaration":
+				if isCloneMethod(ctx, child) {
+					// Generated once all fields are known
+					copyMethods = append(copyMethods, cloneMethodName)
+					return
+				}
 				function, isStatic := convertMethodDeclaration(ctx, child)
 				if isStatic {
 					result.Functions = append(result.Functions, function)
@@ -586,7 +552,7 @@ func convertClassBody(ctx *MigrationContext, structName string, classBody *tree_
 						Function: function,
 						Receiver: gosrc.Param{
 							Name: gosrc.SelfRef,
-							Ty:   gosrc.Type("*" + structName),
+							Ty:   "*" + selfType,
 						},
 					})
 				}
@@ -601,9 +567,19 @@ func convertClassBody(ctx *MigrationContext, structName string, classBody *tree_
 		}
 	})
 
+	statics.finish(ctx)
+
+	for _, name := range copyMethods {
+		result.Methods = append(result.Methods, fieldwiseCopyMethod(ctx, name, string(selfType), result.Fields))
+	}
+
 	// Generate default no-arg constructor if none exists and class is not abstract
 	if !hasConstructor && !isAbstract {
-		result.Functions = append(result.Functions, convertConstructor(ctx, &fieldInitValues, structName, nil, isPublicClass))
+		constructor := convertConstructor(ctx, &fieldInits, initializers, structName, typeParams, nil, isPublicClass)
+		if isInner {
+			addOuterParam(&constructor, outerType)
+		}
+		result.Functions = append(result.Functions, constructor)
 	}
 
 	return result
@@ -616,11 +592,16 @@ func convertMethodDeclaration(ctx *MigrationContext, methodNode *tree_sitter.Nod
 
 type methodMetadata struct {
 	name       string
+	typeParams []gosrc.TypeParam
 	params     []gosrc.Param
 	returnTy   *gosrc.Type
 	isPublic   bool
 	isStatic   bool
 	isAbstract bool
+	factoryOf  string      // Go name of the struct a static factory method creates, if it is one
+	factory    string      // Java name of a static factory method
+	throws     bool        // Whether the method declares checked exceptions, making it return an error
+	valueTy    *gosrc.Type // The return type of the method without the error added for throws
 }
 
 func (methodMetadata methodMetadata) toFunctionData() FunctionData {
@@ -628,10 +609,18 @@ func (methodMetadata methodMetadata) toFunctionData() FunctionData {
 	for _, param := range methodMetadata.params {
 		argTypes = append(argTypes, param.Ty)
 	}
+	var resultType gosrc.Type
+	if methodMetadata.throws && methodMetadata.valueTy != nil {
+		resultType = *methodMetadata.valueTy
+	}
 
 	return FunctionData{
 		Name:          methodMetadata.name,
 		ArgumentTypes: argTypes,
+		Factory:       methodMetadata.factory,
+		Throws:        methodMetadata.throws,
+		ResultType:    resultType,
+		Static:        methodMetadata.isStatic,
 	}
 }
 
@@ -657,38 +646,46 @@ func (constructorMetadata constructorMetadata) toFunctionData() FunctionData {
 // getMethodMetadata retrieves cached method metadata.
 // Panics if metadata is not in cache (programming error).
 func getMethodMetadata(ctx *MigrationContext, methodNode *tree_sitter.Node) methodMetadata {
-	nodeId := methodNode.Id()
-	metadata, exists := ctx.MethodMetadataCache[nodeId]
+	nodeKey := methodNode.StartByte()
+	metadata, exists := ctx.MethodMetadataCache[nodeKey]
 	if !exists {
-		panic(fmt.Sprintf("Method metadata not found in cache for node ID %d. This is a programming error - analyzeNode should have been called first.", nodeId))
+		// Signatures that failed analysis are parsed again so the error is
+		// reported for this method
+		parseMethodSignature(ctx, methodNode)
+		panic(fmt.Sprintf("Method metadata not found in cache for node at byte %d. This is a programming error - analyzeNode should have been called first.", nodeKey))
 	}
 	return metadata
 }
 
+// toStringMethodName is the Go name of Java toString methods
+const toStringMethodName = "String"
+
 func parseMethodSignature(ctx *MigrationContext, methodNode *tree_sitter.Node) methodMetadata {
 	var modifiers modifiers
+	var typeParams []gosrc.TypeParam
 	var params []gosrc.Param
-	var name string
 	var returnType *gosrc.Type
 	var hasThrows bool
+	name := identifierText(ctx, methodNode, methodNode.ChildByFieldName("name"), "method_declaration")
 	IterateChildren(methodNode, func(child *tree_sitter.Node) {
 		ty, isType := TryParseType(ctx, child)
 		if isType {
 			returnType = &ty
 			return
 		}
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "modifiers":
-			modifiers = ParseModifiers(child.Utf8Text(ctx.JavaSource))
+			modifiers = ParseModifiers(ctx.nodeText(child))
+		case "type_parameters":
+			typeParams = parseTypeParameters(ctx, child)
 		case "formal_parameters":
 			params = convertFormalParameters(ctx, child)
-		case "identifier":
-			name = child.Utf8Text(ctx.JavaSource)
 		case "void_type":
 			returnType = nil
 		case "throws":
 			hasThrows = true
 		// ignored
+		case "identifier":
 		case "block":
 		case ";":
 		case "line_comment":
@@ -698,7 +695,16 @@ func parseMethodSignature(ctx *MigrationContext, methodNode *tree_sitter.Node) m
 		}
 	})
 
+	isStatic := modifiers&STATIC != 0
+	var factoryOf, factory string
+	if isStatic && returnType != nil {
+		if structName, ok := factoryStructName(ctx, methodNode, *returnType); ok {
+			factoryOf = structName
+		}
+	}
+
 	// Modify return type if method throws exceptions
+	valueTy := returnType
 	if hasThrows {
 		if returnType == nil {
 			// void method with exception -> error
@@ -712,32 +718,45 @@ func parseMethodSignature(ctx *MigrationContext, methodNode *tree_sitter.Node) m
 	}
 
 	isAbstract := modifiers&ABSTRACT != 0
-	isStatic := modifiers&STATIC != 0
-	name = gosrc.ToIdentifier(name, modifiers.isPublic())
+	switch {
+	case factoryOf != "":
+		factory = name
+		name = factoryName(factoryOf, name, modifiers.isPublic())
+	case name == "toString" && len(params) == 0 && !isStatic:
+		// Satisfy fmt.Stringer so fmt prints the object the way Java does
+		name = toStringMethodName
+	case name == "clone" && len(params) == 0 && !isStatic:
+		name = cloneMethodName
+	default:
+		name = gosrc.ToIdentifier(name, modifiers.isPublic())
+	}
 	return methodMetadata{
 		name:       name,
+		typeParams: typeParams,
 		params:     params,
 		returnTy:   returnType,
 		isPublic:   modifiers.isPublic(),
 		isStatic:   isStatic,
 		isAbstract: isAbstract,
+		factoryOf:  factoryOf,
+		factory:    factory,
+		throws:     hasThrows,
+		valueTy:    valueTy,
 	}
 }
 
 func parseConstructorSignature(ctx *MigrationContext, constructorNode *tree_sitter.Node) constructorMetadata {
 	var modifiers modifiers
 	var params []gosrc.Param
-	var structName string
 
 	IterateChildren(constructorNode, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "modifiers":
-			modifiers = ParseModifiers(child.Utf8Text(ctx.JavaSource))
+			modifiers = ParseModifiers(ctx.nodeText(child))
 		case "formal_parameters":
 			params = convertFormalParameters(ctx, child)
-		case "identifier":
-			structName = child.Utf8Text(ctx.JavaSource)
 		// ignored
+		case "identifier":
 		case "constructor_body":
 		case "line_comment":
 		case "block_comment":
@@ -747,7 +766,13 @@ func parseConstructorSignature(ctx *MigrationContext, constructorNode *tree_sitt
 	})
 
 	// Convert struct name using identifier rules
+	structName := identifierText(ctx, constructorNode, constructorNode.ChildByFieldName("name"), "constructor_declaration")
 	structName = gosrc.ToIdentifier(structName, modifiers.isPublic())
+	if classNode := enclosingTypeDeclaration(constructorNode); classNode != nil {
+		if goName, ok := ctx.resolveTypeName(qualifiedTypeName(ctx, classNode)); ok {
+			structName = goName
+		}
+	}
 
 	// Generate constructor name based on struct name and parameter types
 	// This name includes parameter types (e.g., "newTypeFromString") so it should be unique
@@ -757,7 +782,7 @@ func parseConstructorSignature(ctx *MigrationContext, constructorNode *tree_sitt
 	if len(params) > 0 {
 		nameBuilder.WriteString("From")
 		for _, param := range params {
-			nameBuilder.WriteString(gosrc.CapitalizeFirstLetter(param.Ty.ToSource()))
+			nameBuilder.WriteString(typeNamePart(param.Ty))
 		}
 	}
 	constructorName := nameBuilder.String()
@@ -776,13 +801,23 @@ func convertMethodDeclarationWithAbstract(ctx *MigrationContext, methodNode *tre
 	name := methodMetadata.name
 	returnType := methodMetadata.returnTy
 	isAbstract := methodMetadata.isAbstract
-	isStatic := methodMetadata.isStatic
+	// Helpers don't use the instance, so they become functions like static methods
+	isStatic := methodMetadata.isStatic || isHelperMethod(ctx, methodNode)
 	isPublic := methodMetadata.isPublic
 
 	var body []gosrc.Statement
 	blockNode := methodNode.ChildByFieldName("body")
-	if blockNode != nil {
+	switch {
+	case blockNode == nil:
+	case skipsMethodBody(ctx, methodNode):
+		body = stubBody()
+	case isManualMethod(ctx, methodNode):
+		body = manualBody(ctx, methodNode)
+	default:
 		body = convertStatementBlock(ctx, blockNode)
+		if methodMetadata.throws && methodMetadata.valueTy == nil {
+			body = returnNilError(body)
+		}
 	}
 
 	// If method is abstract and has no body, add panic statement (for non-abstract class methods)
@@ -791,19 +826,128 @@ func convertMethodDeclarationWithAbstract(ctx *MigrationContext, methodNode *tre
 	}
 
 	// Add migration comment
-	migrationComment := getMigrationComment(ctx, methodNode)
+	comments := []string{getMigrationComment(ctx, methodNode)}
+
+	var typeParams []gosrc.TypeParam
+	switch {
+	case len(methodMetadata.typeParams) == 0:
+	case isStatic:
+		typeParams = methodMetadata.typeParams
+		importConstraints(ctx, typeParams)
+	default:
+		params, returnType = eraseSignatureTypeParams(params, returnType, methodMetadata.typeParams)
+		comments = append(comments, erasedTypeParamsComment(methodMetadata.typeParams))
+	}
 
 	return gosrc.Function{
 		Name:       name,
+		TypeParams: typeParams,
 		Params:     params,
 		ReturnType: returnType,
 		Body:       body,
 		Public:     isPublic,
-		Comments:   []string{migrationComment},
+		Comments:   comments,
 	}, isStatic, isAbstract
 }
 
-func convertConstructor(ctx *MigrationContext, fieldInitValues *map[string]gosrc.Expression, structName string, constructorNode *tree_sitter.Node, isPublicClass bool) gosrc.Function {
+// eraseSignatureTypeParams erases the type parameters of a generic instance
+// method from its signature, since Go methods can't have type parameters
+func eraseSignatureTypeParams(params []gosrc.Param, returnType *gosrc.Type, typeParams []gosrc.TypeParam) ([]gosrc.Param, *gosrc.Type) {
+	erased := make([]gosrc.Param, len(params))
+	for i, param := range params {
+		erased[i] = gosrc.Param{Name: param.Name, Ty: eraseTypeParams(param.Ty, typeParams)}
+	}
+	if returnType != nil {
+		erasedReturn := eraseTypeParams(*returnType, typeParams)
+		returnType = &erasedReturn
+	}
+	return erased, returnType
+}
+
+// erasedTypeParamsComment explains why the types of a migrated generic
+// instance method are less specific than in Java
+func erasedTypeParamsComment(typeParams []gosrc.TypeParam) string {
+	names := make([]string, len(typeParams))
+	for i, param := range typeParams {
+		names[i] = param.Name
+	}
+	return fmt.Sprintf("FIXME: type parameters <%s> were replaced by their constraints since Go methods can't have type parameters", strings.Join(names, ", "))
+}
+
+// skipsMethodBody reports whether the body of methodNode should be replaced by
+// a stub because of StubOnly or OnlyMethods. The signature is taken from the
+// analysis cache, so a skipped body is never walked.
+func skipsMethodBody(ctx *MigrationContext, methodNode *tree_sitter.Node) bool {
+	switch {
+	case ctx.StubOnly:
+		return true
+	case ctx.OnlyMethods == nil:
+		return false
+	default:
+		nameNode := methodNode.ChildByFieldName("name")
+		return nameNode == nil || !ctx.OnlyMethods[ctx.nodeText(nameNode)]
+	}
+}
+
+// stubBody is the body emitted for methods whose body is not converted
+func stubBody() []gosrc.Statement {
+	return []gosrc.Statement{&gosrc.GoStatement{Source: "panic(\"not migrated\")"}}
+}
+
+// manualMethodDirective is the line comment marking the method declared right
+// after it as one ported by hand
+const manualMethodDirective = "// javago:manual"
+
+// isManualMethod reports whether methodNode is to be ported by hand, because
+// it follows manualMethodDirective or ManualMethods lists its Java name, on
+// its own or qualified by the name of its class
+func isManualMethod(ctx *MigrationContext, methodNode *tree_sitter.Node) bool {
+	if previous := methodNode.PrevNamedSibling(); previous != nil && nodeKind(previous) == "line_comment" &&
+		strings.TrimSpace(ctx.nodeText(previous)) == manualMethodDirective {
+		return true
+	}
+	nameNode := methodNode.ChildByFieldName("name")
+	if len(ctx.ManualMethods) == 0 || nameNode == nil {
+		return false
+	}
+	name := ctx.nodeText(nameNode)
+	if ctx.ManualMethods[name] {
+		return true
+	}
+	for parent := methodNode.Parent(); parent != nil; parent = parent.Parent() {
+		switch nodeKind(parent) {
+		case "class_declaration", "interface_declaration", "enum_declaration", "record_declaration":
+			return ctx.ManualMethods[ctx.nodeText(parent.ChildByFieldName("name"))+"."+name]
+		case "object_creation_expression":
+			return false
+		}
+	}
+	return false
+}
+
+// manualBody is the body emitted for methods ported by hand: their Java body
+// as comments, followed by a stub
+func manualBody(ctx *MigrationContext, methodNode *tree_sitter.Node) []gosrc.Statement {
+	comments := []string{"FIXME: port by hand, the Java body is"}
+	indent := int(methodNode.StartPosition().Column)
+	for line := range strings.SplitSeq(ctx.nodeText(methodNode.ChildByFieldName("body")), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			continue
+		}
+		// The first line starts after the signature, the others are indented
+		// like the declaration
+		if whitespace := len(line) - len(strings.TrimLeft(line, " \t")); whitespace >= indent {
+			line = line[indent:]
+		} else {
+			line = line[whitespace:]
+		}
+		comments = append(comments, line)
+	}
+	return append([]gosrc.Statement{&gosrc.CommentStmt{Comments: comments}}, stubBody()...)
+}
+
+func convertConstructor(ctx *MigrationContext, fieldInits *map[string][]gosrc.Statement, initializers []*tree_sitter.Node, structName string, typeParams []gosrc.TypeParam, constructorNode *tree_sitter.Node, isPublicClass bool) gosrc.Function {
 	var modifiers modifiers
 	var params []gosrc.Param
 	var name string
@@ -811,9 +955,11 @@ func convertConstructor(ctx *MigrationContext, fieldInitValues *map[string]gosrc
 
 	// Use cached metadata if available (when constructorNode is not nil)
 	if constructorNode != nil {
-		metadata, hasCached := ctx.ConstructorMetadataCache[constructorNode.Id()]
+		metadata, hasCached := ctx.ConstructorMetadataCache[constructorNode.StartByte()]
 		if !hasCached {
-			panic(fmt.Sprintf("Constructor metadata not found in cache for node ID %d. This is a programming error - analyzeNode should have been called first.", constructorNode.Id()))
+			// Reports the error that made the analysis skip this constructor
+			parseConstructorSignature(ctx, constructorNode)
+			panic(fmt.Sprintf("Constructor metadata not found in cache for node at byte %d. This is a programming error - analyzeNode should have been called first.", constructorNode.StartByte()))
 		}
 		// Use cached metadata
 		params = metadata.params
@@ -829,37 +975,65 @@ func convertConstructor(ctx *MigrationContext, fieldInitValues *map[string]gosrc
 		name = constructorName(ctx, modifiers.isPublic(), gosrc.Type(structName), params...)
 	}
 
-	body = append(body, &gosrc.GoStatement{Source: fmt.Sprintf("%s := %s{};", gosrc.SelfRef, structName)})
+	// The instance is built behind a pointer, like the receivers of its
+	// methods, so methods called and references to this taken while it is
+	// built all see the same instance the constructor returns
+	selfType := gosrc.Instantiate(structName, typeParams)
+	newSelf := &gosrc.GoStatement{Source: fmt.Sprintf("%s := &%s{}", gosrc.SelfRef, selfType)}
+	var bodyNode, delegation *tree_sitter.Node
+	if constructorNode != nil {
+		bodyNode = constructorNode.ChildByFieldName("body")
+		delegation = delegatedConstructorInvocation(bodyNode)
+	}
 
 	// Process constructor body if present
-	if constructorNode != nil {
-		bodyNode := constructorNode.ChildByFieldName("body")
+	switch {
+	case delegation != nil && !ctx.StubOnly:
+		// The constructor delegated to by this(...) builds the instance,
+		// initializing the fields and running the initializers
+		body = append(body, convertDelegatedConstructor(ctx, delegation, structName, typeParams, name)...)
+		body = append(body, convertConstructorBody(ctx, nil, nil, bodyNode)...)
+	case constructorNode != nil && !ctx.StubOnly:
+		body = append(body, newSelf)
 		if bodyNode != nil {
-			body = append(body, convertConstructorBody(ctx, fieldInitValues, bodyNode)...)
+			body = append(body, convertConstructorBody(ctx, fieldInits, initializers, bodyNode)...)
 		}
-	} else {
-		// Default constructor
-		body = append(body, fieldInitStmts(fieldInitValues)...)
+	case constructorNode == nil && !ctx.StubOnly:
+		body = append(body, newSelf)
+		body = append(body, fieldInitStmts(fieldInits)...)
+		body = append(body, initializerStmts(ctx, initializers)...)
+	default:
+		// A stubbed constructor only initializes fields
+		body = append(body, newSelf)
+		body = append(body, fieldInitStmts(fieldInits)...)
 	}
 
-	body = append(body, &gosrc.ReturnStatement{Value: &gosrc.VarRef{Ref: gosrc.SelfRef}})
-	retTy := gosrc.Type(structName)
+	body = append(body, &gosrc.ReturnStatement{Value: ctx.arena.VarRef(gosrc.VarRef{Ref: gosrc.SelfRef})})
+	importConstraints(ctx, typeParams)
+	returnType := gosrc.Type("*" + selfType)
 	return gosrc.Function{
 		Name:       name,
+		TypeParams: typeParams,
 		Params:     params,
-		ReturnType: &retTy,
+		ReturnType: &returnType,
 		Body:       body,
 		Public:     modifiers&PUBLIC != 0,
 	}
 }
 
-func convertConstructorBody(ctx *MigrationContext, fieldInitValues *map[string]gosrc.Expression, bodyNode *tree_sitter.Node) []gosrc.Statement {
-	body := fieldInitStmts(fieldInitValues)
+func convertConstructorBody(ctx *MigrationContext, fieldInits *map[string][]gosrc.Statement, initializers []*tree_sitter.Node, bodyNode *tree_sitter.Node) []gosrc.Statement {
+	body := fieldInitStmts(fieldInits)
+	body = append(body, initializerStmts(ctx, initializers)...)
+	delegation := delegatedConstructorInvocation(bodyNode)
 	IterateChildren(bodyNode, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "explicit_constructor_invocation":
+			if delegation != nil && child.Id() == delegation.Id() {
+				// Converted to the construction of this
+				return
+			}
 			body = append(body, convertExplicitConstructorInvocation(ctx, child)...)
-		case "expression_statement":
+		case "expression_statement", "local_variable_declaration":
 			body = append(body, convertStatement(ctx, child)...)
 			// ignored
 		case "{":
@@ -873,24 +1047,104 @@ func convertConstructorBody(ctx *MigrationContext, fieldInitValues *map[string]g
 	return body
 }
 
-func fieldInitStmts(fieldInitValues *map[string]gosrc.Expression) []gosrc.Statement {
-	if fieldInitValues == nil {
+// delegatedConstructorInvocation returns the this(...) call bodyNode, the body
+// of a constructor, starts with, or nil if it doesn't delegate to another
+// constructor
+func delegatedConstructorInvocation(bodyNode *tree_sitter.Node) *tree_sitter.Node {
+	var invocation *tree_sitter.Node
+	IterateChildrenWhile(bodyNode, func(child *tree_sitter.Node) bool {
+		switch nodeKind(child) {
+		case "{", "line_comment", "block_comment":
+			return true
+		case "explicit_constructor_invocation":
+			if nodeKind(child.ChildByFieldName("constructor")) == "this" {
+				invocation = child
+			}
+		}
+		return false
+	})
+	return invocation
+}
+
+// convertDelegatedConstructor converts this(...), the invocation a
+// constructor named current starts with, to the declaration of this as the
+// instance built by the constructor it delegates to. The constructor is
+// chosen by the number of arguments among the others of the class, since Java
+// doesn't allow a constructor to delegate to itself.
+func convertDelegatedConstructor(ctx *MigrationContext, invocation *tree_sitter.Node, structName string, typeParams []gosrc.TypeParam, current string) []gosrc.Statement {
+	argsNode := invocation.ChildByFieldName("arguments")
+	args, initStmts := convertArguments(ctx, argsNode)
+	constructors := ctx.Constructors[gosrc.Type(structName)]
+	constructors = slices.DeleteFunc(slices.Clone(withoutFactories(constructors)), func(fn FunctionData) bool {
+		return fn.Name == current
+	})
+	name, found, multipleMatches := resolveOverload(ctx, constructors, argsNode)
+	switch {
+	case !found:
+		FatalError(ctx, invocation, fmt.Sprintf("no other constructor of %s takes %d arguments", structName, len(args)), "explicit_constructor_invocation")
+	case multipleMatches:
+		comment := fmt.Sprintf("FIXME: more than one possible constructor for %s with %d arguments", structName, len(args))
+		initStmts = append(initStmts, &gosrc.CommentStmt{Comments: []string{comment}})
+	default:
+		paramTypes := argumentTypes(constructors, name)
+		args = passArrayArguments(ctx, argsNode, coerceArguments(ctx, argsNode, args, paramTypes), paramTypes)
+	}
+	if _, isInner := ctx.InnerClasses[structName]; isInner {
+		// The instance belongs to the same outer instance
+		args = append([]gosrc.Expression{ctx.arena.VarRef(gosrc.VarRef{Ref: outerFieldName})}, args...)
+	}
+	var typeArgs []gosrc.Type
+	for _, param := range typeParams {
+		typeArgs = append(typeArgs, gosrc.Type(param.Name))
+	}
+	return append(initStmts, &gosrc.VarDeclaration{
+		Name:  gosrc.SelfRef,
+		Value: &gosrc.CallExpression{Function: name, TypeArgs: typeArgs, Args: args},
+	})
+}
+
+// instanceInitializers returns the instance initializer blocks of classBody
+// in declaration order
+func instanceInitializers(classBody *tree_sitter.Node) []*tree_sitter.Node {
+	var initializers []*tree_sitter.Node
+	IterateChildren(classBody, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "block" {
+			initializers = append(initializers, child)
+		}
+	})
+	return initializers
+}
+
+// initializerStmts converts the instance initializer blocks of a class. Java
+// runs them in every constructor, after the field initializers, so they are
+// converted again for each one.
+func initializerStmts(ctx *MigrationContext, initializers []*tree_sitter.Node) []gosrc.Statement {
+	var body []gosrc.Statement
+	for _, initializer := range initializers {
+		body = append(body, convertStatementBlock(ctx, initializer)...)
+	}
+	return body
+}
+
+// fieldInitStmts returns the statements initializing the instance fields,
+// in the order of their names
+func fieldInitStmts(fieldInits *map[string][]gosrc.Statement) []gosrc.Statement {
+	if fieldInits == nil {
 		return nil
 	}
 	var body []gosrc.Statement
 
 	// Sort field names for consistent output
-	fieldNames := make([]string, 0, len(*fieldInitValues))
-	for fieldName := range *fieldInitValues {
+	fieldNames := make([]string, 0, len(*fieldInits))
+	for fieldName := range *fieldInits {
 		fieldNames = append(fieldNames, fieldName)
 	}
 	sort.Strings(fieldNames)
 
 	for _, fieldName := range fieldNames {
-		initExpr := (*fieldInitValues)[fieldName]
-		body = append(body, &gosrc.AssignStatement{Ref: gosrc.VarRef{Ref: gosrc.SelfRef + "." + fieldName}, Value: initExpr})
+		body = append(body, (*fieldInits)[fieldName]...)
 	}
-	if len(*fieldInitValues) > 0 {
+	if len(*fieldInits) > 0 {
 		body = append(body, &gosrc.CommentStmt{Comments: []string{"Default field initializations"}})
 	}
 	return body
@@ -0,0 +1,84 @@
+package java
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Ways of migrating class literals, selected by MigrationContext.ClassLiterals
+const (
+	// ClassLiteralsReflect converts Foo.class to reflect.TypeFor[Foo](). It
+	// is the default.
+	ClassLiteralsReflect = "reflect"
+	// ClassLiteralsName converts Foo.class to the string "Foo", which is
+	// enough for loggers and error messages
+	ClassLiteralsName = "name"
+	// ClassLiteralsDrop converts Foo.class to nil and reports a diagnostic
+	ClassLiteralsDrop = "drop"
+)
+
+// convertClassLiteral converts Foo.class the way ctx.ClassLiterals asks for
+func convertClassLiteral(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	typeNode := expression.NamedChild(0)
+	switch ctx.ClassLiterals {
+	case ClassLiteralsName:
+		return classNameLiteral(ctx, typeNode), nil
+	case ClassLiteralsDrop:
+		reportDiagnostic(ctx, expression, ErrClassLiteral,
+			fmt.Sprintf("%s was dropped since class literals are configured to be dropped", ctx.nodeText(expression)))
+		return &gosrc.NIL, nil
+	default:
+		return reflectTypeFor(ctx, typeNode), nil
+	}
+}
+
+// reflectTypeFor returns the reflect.Type of the Java type typeNode
+func reflectTypeFor(ctx *MigrationContext, typeNode *tree_sitter.Node) gosrc.Expression {
+	ty, ok := TryParseType(ctx, typeNode)
+	if !ok {
+		FatalError(ctx, typeNode, "unable to parse type in class_literal", "class_literal")
+	}
+	ctx.Source.AddImport("reflect")
+	return &gosrc.CallExpression{Function: fmt.Sprintf("reflect.TypeFor[%s]", ty)}
+}
+
+// convertClassLiteralMethod converts Foo.class.getSimpleName() and
+// Foo.class.getName() to string constants, whatever ctx.ClassLiterals is
+func convertClassLiteralMethod(ctx *MigrationContext, classLiteral *tree_sitter.Node, name string) (gosrc.Expression, bool) {
+	typeNode := classLiteral.NamedChild(0)
+	switch name {
+	case "getSimpleName":
+		return classNameLiteral(ctx, typeNode), true
+	case "getName", "getCanonicalName", "getTypeName":
+		qualified := ctx.nodeText(typeNode)
+		if javaPackage := packageOf(ctx, classLiteral); javaPackage != "" && nodeKind(typeNode) == "type_identifier" {
+			qualified = javaPackage + "." + qualified
+		}
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: strconv.Quote(qualified)}), true
+	default:
+		return nil, false
+	}
+}
+
+// classNameLiteral returns the simple Java name of a class as a string
+// literal
+func classNameLiteral(ctx *MigrationContext, typeNode *tree_sitter.Node) gosrc.Expression {
+	name := ctx.nodeText(typeNode)
+	if nodeKind(typeNode) == "scoped_type_identifier" {
+		name = ctx.nodeText(typeNode.NamedChild(typeNode.NamedChildCount() - 1))
+	}
+	return ctx.arena.GoExpression(gosrc.GoExpression{Source: strconv.Quote(name)})
+}
+
+// packageOf returns the Java package of the file node is in
+func packageOf(ctx *MigrationContext, node *tree_sitter.Node) string {
+	root := node
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+	return packageDeclarationName(root, ctx.JavaSource)
+}
@@ -0,0 +1,114 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+const (
+	// cloneMethodName is the Go name of Java clone methods
+	cloneMethodName = "Clone"
+	// copyMethodName is the method generated for classes with a copy constructor
+	copyMethodName = "Copy"
+)
+
+// isCloneMethod reports whether methodNode overrides Object.clone()
+func isCloneMethod(ctx *MigrationContext, methodNode *tree_sitter.Node) bool {
+	nameNode := methodNode.ChildByFieldName("name")
+	params := methodNode.ChildByFieldName("parameters")
+	return nameNode != nil && ctx.nodeText(nameNode) == "clone" &&
+		params != nil && params.NamedChildCount() == 0 &&
+		!HasModifier(ctx, methodNode, "static")
+}
+
+// isCopyConstructor reports whether constructorNode takes a single parameter
+// of the class it constructs
+func isCopyConstructor(ctx *MigrationContext, constructorNode *tree_sitter.Node) bool {
+	params := constructorNode.ChildByFieldName("parameters")
+	if params == nil || params.NamedChildCount() != 1 {
+		return false
+	}
+	param := params.NamedChild(0)
+	typeNode := param.ChildByFieldName("type")
+	return nodeKind(param) == "formal_parameter" && typeNode != nil &&
+		ctx.nodeText(typeNode) == ctx.nodeText(constructorNode.ChildByFieldName("name"))
+}
+
+// fieldwiseCopyMethod generates a method named name that returns a field-wise
+// copy of the receiver. Java clone bodies usually go through super.clone(),
+// which has no Go counterpart, so the body is generated instead of converted.
+// With ctx.DeepCopy, slice and map fields are copied as well instead of being
+// shared with the original.
+func fieldwiseCopyMethod(ctx *MigrationContext, name, structName string, fields []gosrc.StructField) gosrc.Method {
+	body := []gosrc.Statement{
+		&gosrc.VarDeclaration{Name: "clone", Value: &gosrc.VarRef{Ref: "*" + gosrc.SelfRef}},
+	}
+	if ctx.DeepCopy {
+		for _, field := range fields {
+			if copyFunction, ok := collectionCopyFunction(ctx, field.Ty); ok {
+				fieldName := gosrc.ToIdentifier(field.Name, field.Public)
+				body = append(body, &gosrc.AssignStatement{
+					Ref: gosrc.VarRef{Ref: "clone." + fieldName},
+					Value: &gosrc.CallExpression{
+						Function: copyFunction,
+						Args:     []gosrc.Expression{&gosrc.VarRef{Ref: gosrc.SelfRef + "." + fieldName}},
+					},
+				})
+			}
+		}
+	}
+	body = append(body, &gosrc.ReturnStatement{Value: &gosrc.VarRef{Ref: "&clone"}})
+	// Instances of classes are held by pointers
+	returnTy := gosrc.Type("*" + structName)
+	return gosrc.Method{
+		Function: gosrc.Function{
+			Name:       name,
+			ReturnType: &returnTy,
+			Body:       body,
+			Public:     true,
+		},
+		Receiver: gosrc.Param{
+			Name: gosrc.SelfRef,
+			Ty:   gosrc.Type("*" + structName),
+		},
+	}
+}
+
+// collectionCopyFunction returns the function that copies a value of type ty,
+// if ty is a slice or a map
+func collectionCopyFunction(ctx *MigrationContext, ty gosrc.Type) (string, bool) {
+	switch {
+	case strings.HasPrefix(string(ty), "[]"):
+		ctx.Source.AddImport("slices")
+		return "slices.Clone", true
+	case strings.HasPrefix(string(ty), "map["):
+		ctx.Source.AddImport("maps")
+		return "maps.Clone", true
+	default:
+		return "", false
+	}
+}
+
+// convertCloneCall converts obj.clone() to a call of the Clone method of a
+// class in this file, or to slices.Clone if no class declares one since
+// arrays are the most common target of clone()
+func convertCloneCall(ctx *MigrationContext, objectNode *tree_sitter.Node, objectText string) (gosrc.Expression, []gosrc.Statement) {
+	var object gosrc.Expression
+	var initStmts []gosrc.Statement
+	if containsInvocation(objectNode) {
+		// Already converted as the receiver of the call
+		object = &gosrc.VarRef{Ref: objectText}
+	} else {
+		object, initStmts = convertExpression(ctx, objectNode)
+	}
+	if _, declared := ctx.Methods[cloneMethodName]; declared {
+		return &gosrc.CallExpression{Function: object.ToSource() + "." + cloneMethodName}, initStmts
+	}
+	ctx.Source.AddImport("slices")
+	return &gosrc.CallExpression{
+		Function: "slices.Clone",
+		Args:     []gosrc.Expression{object},
+	}, initStmts
+}
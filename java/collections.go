@@ -0,0 +1,95 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// convertCollectionsMethod converts calls of the static helpers of
+// java.util.Collections that work on the slice a list migrates to. It
+// returns false for the helpers without a translation.
+func convertCollectionsMethod(ctx *MigrationContext, expression *tree_sitter.Node, name string) (gosrc.Expression, []gosrc.Statement, bool) {
+	argsNode := expression.ChildByFieldName("arguments")
+	argNodes := argumentNodes(argsNode)
+	switch {
+	case name == "binarySearch" && (len(argNodes) == 2 || len(argNodes) == 3):
+	case name == "shuffle" && len(argNodes) == 1:
+	case name == "frequency" && len(argNodes) == 2:
+	default:
+		return nil, nil, false
+	}
+	args, initStmts := convertArguments(ctx, argsNode)
+	list := listSource(ctx, argNodes[0], args[0])
+	switch name {
+	case "binarySearch":
+		converted, stmts := convertBinarySearch(ctx, list, args[1:])
+		return converted, append(initStmts, stmts...), true
+	case "shuffle":
+		return convertShuffle(ctx, list), initStmts, true
+	default:
+		converted, stmts := convertFrequency(ctx, list, args[1])
+		return converted, append(initStmts, stmts...), true
+	}
+}
+
+// listSource returns the Go source of the slice the list argument argNode
+// converted to arg holds, dereferencing list parameters
+func listSource(ctx *MigrationContext, argNode *tree_sitter.Node, arg gosrc.Expression) string {
+	if arrayArgumentKind(ctx, argNode) == slicePointer {
+		return "*" + arg.ToSource()
+	}
+	return arg.ToSource()
+}
+
+// convertBinarySearch converts Collections.binarySearch(list, key), with an
+// optional comparator, to slices.BinarySearch. Like Java, the result is the
+// index of the key if it is found and -(insertion point) - 1 otherwise.
+func convertBinarySearch(ctx *MigrationContext, list string, args []gosrc.Expression) (gosrc.Expression, []gosrc.Statement) {
+	ctx.Source.AddImport("slices")
+	search := "slices.BinarySearch"
+	searchArgs := list + ", " + args[0].ToSource()
+	if len(args) == 2 {
+		// Comparators migrate to func(a, b T) int, as BinarySearchFunc takes
+		search = "slices.BinarySearchFunc"
+		searchArgs += ", " + args[1].ToSource()
+	}
+	index := ctx.tempName("index")
+	found := ctx.tempName("found")
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: index}), []gosrc.Statement{
+		&gosrc.GoStatement{Source: fmt.Sprintf("%s, %s := %s(%s)", index, found, search, searchArgs)},
+		&gosrc.GoStatement{Source: fmt.Sprintf("if !%s {\n%s = -%s - 1\n}", found, index, index)},
+	}
+}
+
+// convertShuffle converts Collections.shuffle(list) to rand.Shuffle, swapping
+// the elements in place so a list passed as a parameter is shuffled in the
+// slice the caller holds
+func convertShuffle(ctx *MigrationContext, list string) gosrc.Expression {
+	ctx.Source.AddImport("math/rand")
+	elements := list
+	if strings.HasPrefix(list, "*") {
+		elements = "(" + list + ")"
+	}
+	return &gosrc.CallExpression{
+		Function: "rand.Shuffle",
+		Args: []gosrc.Expression{
+			ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("len(%s)", list)}),
+			ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("func(i, j int) {\n%s[i], %s[j] = %s[j], %s[i]\n}", elements, elements, elements, elements)}),
+		},
+	}
+}
+
+// convertFrequency converts Collections.frequency(list, value) to a loop
+// counting the elements of list equal to value
+func convertFrequency(ctx *MigrationContext, list string, value gosrc.Expression) (gosrc.Expression, []gosrc.Statement) {
+	count := ctx.tempName("frequency")
+	element := ctx.tempName("element")
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: count}), []gosrc.Statement{
+		&gosrc.VarDeclaration{Name: count, Value: &gosrc.VarRef{Ref: "0"}},
+		&gosrc.GoStatement{Source: fmt.Sprintf("for _, %s := range %s {\nif %s == %s {\n%s++\n}\n}", element, list, element, value.ToSource(), count)},
+	}
+}
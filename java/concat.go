@@ -0,0 +1,147 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// stringConcatOperands flattens a chain of + whose result is a string into
+// its operands. Java adds left to right, so in 1 + 2 + "a" the numeric sum
+// 1 + 2 is a single operand. It returns false if node is not a string.
+func stringConcatOperands(ctx *MigrationContext, node *tree_sitter.Node) ([]*tree_sitter.Node, bool) {
+	if nodeKind(node) != "binary_expression" || ctx.nodeText(node.ChildByFieldName("operator")) != "+" {
+		return []*tree_sitter.Node{node}, isStringOperand(ctx, node)
+	}
+	left, right := node.ChildByFieldName("left"), node.ChildByFieldName("right")
+	operands, isString := stringConcatOperands(ctx, left)
+	if !isString && !isStringOperand(ctx, right) {
+		return []*tree_sitter.Node{node}, false
+	}
+	return append(operands, right), true
+}
+
+// stringMethods are methods of the Java standard library that return a
+// string whatever their receiver
+var stringMethods = map[string]bool{
+	"toString":      true,
+	"substring":     true,
+	"trim":          true,
+	"strip":         true,
+	"toUpperCase":   true,
+	"toLowerCase":   true,
+	"repeat":        true,
+	"replace":       true,
+	"concat":        true,
+	"getSimpleName": true,
+	"getMessage":    true,
+	"getProperty":   true,
+	"getenv":        true,
+	"lineSeparator": true,
+	"intern":        true,
+}
+
+// isStringOperand reports whether node is known to be a string
+func isStringOperand(ctx *MigrationContext, node *tree_sitter.Node) bool {
+	switch nodeKind(node) {
+	case "string_literal":
+		return true
+	case "method_invocation":
+		name := ctx.nodeText(node.ChildByFieldName("name"))
+		if name == "valueOf" || name == "format" {
+			// Integer.valueOf and friends return boxed numbers
+			object := node.ChildByFieldName("object")
+			return object != nil && ctx.nodeText(object) == "String"
+		}
+		if name == "name" {
+			_, ok := enumOfNameCall(ctx, node)
+			return ok
+		}
+		if stringMethods[name] {
+			return true
+		}
+		// Methods returning strings, and elements of lists of strings
+		if ty, ok := invokedReturnType(ctx, node); ok {
+			return ty == gosrc.TypeString
+		}
+		ty, ok := listMethodType(ctx, node)
+		return ok && ty == gosrc.TypeString
+	case "switch_expression":
+		ty, ok := switchExpressionType(ctx, node)
+		return ok && ty == gosrc.TypeString
+	case "ternary_expression":
+		ty, ok := valueType(ctx, node)
+		return ok && ty == gosrc.TypeString
+	case "parenthesized_expression":
+		return isStringOperand(ctx, node.NamedChild(0))
+	}
+	ty, ok := staticType(ctx, node)
+	return ok && ty == gosrc.TypeString
+}
+
+// convertStringConcat converts a chain of string concatenations. A chain of
+// strings stays a chain of +, while one that mixes in other values becomes a
+// single fmt.Sprintf with the string literals in its format, since Go doesn't
+// convert operands of + to strings.
+func convertStringConcat(ctx *MigrationContext, operands []*tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	var initStmts []gosrc.Statement
+	converted := make([]gosrc.Expression, len(operands))
+	allStrings := true
+	for i, operand := range operands {
+		value, valueInit := convertExpression(ctx, operand)
+		converted[i] = value
+		initStmts = append(initStmts, valueInit...)
+		allStrings = allStrings && isStringOperand(ctx, operand)
+	}
+	if allStrings {
+		concat := converted[0]
+		for _, value := range converted[1:] {
+			concat = ctx.arena.BinaryExpression(gosrc.BinaryExpression{Left: concat, Operator: "+", Right: value})
+		}
+		return concat, initStmts
+	}
+	format := strings.Builder{}
+	var args []gosrc.Expression
+	for i, operand := range operands {
+		source := converted[i].ToSource()
+		switch {
+		case nodeKind(operand) == "string_literal" && !strings.HasPrefix(source, `"""`):
+			format.WriteString(strings.ReplaceAll(source[1:len(source)-1], "%", "%%"))
+			continue
+		case nodeKind(operand) == "character_literal":
+			format.WriteString("%c")
+		case isStringOperand(ctx, operand):
+			format.WriteString("%s")
+		default:
+			format.WriteString(formatVerb(ctx, operand))
+		}
+		args = append(args, converted[i])
+	}
+	ctx.Source.AddImport("fmt")
+	return &gosrc.CallExpression{
+		Function: "fmt.Sprintf",
+		Args:     append([]gosrc.Expression{ctx.arena.GoExpression(gosrc.GoExpression{Source: `"` + format.String() + `"`})}, args...),
+	}, initStmts
+}
+
+// formatVerb returns the fmt verb printing the value of node the way Java
+// converts it to a string
+func formatVerb(ctx *MigrationContext, node *tree_sitter.Node) string {
+	if nodeKind(node) == "identifier" {
+		// char migrates to int, but Java prints it as a character
+		if typeNode := declaredType(ctx, node, ctx.nodeText(node)); typeNode != nil && ctx.nodeText(typeNode) == "char" {
+			return "%c"
+		}
+	}
+	ty, ok := staticType(ctx, node)
+	switch {
+	case nodeKind(node) == "decimal_integer_literal":
+		return "%d"
+	case ok && (ty == gosrc.TypeInt || ty == "int64"):
+		return "%d"
+	default:
+		return "%v"
+	}
+}
@@ -0,0 +1,128 @@
+package java
+
+import (
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// constantFields returns the Java names of the static fields of classBody
+// that are compile-time constants: final fields of a primitive type or
+// String initialized with a constant expression. They become Go constants.
+// Floating point fields are left out: Go rejects converting a constant to an
+// integer type if that drops its fraction, which Java casts do.
+func constantFields(ctx *MigrationContext, classBody *tree_sitter.Node) map[string]bool {
+	var className string
+	if nameNode := classBody.Parent().ChildByFieldName("name"); nameNode != nil {
+		className = ctx.nodeText(nameNode)
+	}
+	initializers := make(map[string]*tree_sitter.Node)
+	IterateChildren(classBody, func(member *tree_sitter.Node) {
+		typeNode := member.ChildByFieldName("type")
+		if nodeKind(member) != "field_declaration" || !constantType(ctx, typeNode) || nodeKind(typeNode) == "floating_point_type" {
+			return
+		}
+		if mods := declarationModifiers(ctx, member); mods&STATIC == 0 || mods&FINAL == 0 {
+			return
+		}
+		if value := member.ChildByFieldName("declarator").ChildByFieldName("value"); value != nil {
+			initializers[staticFieldName(ctx, member)] = value
+		}
+	})
+
+	// Constants may refer to constants declared after them, so fields are
+	// added until none of the rest turns out to be constant
+	constants := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for name, value := range initializers {
+			if !constants[name] && isConstantExpression(ctx, value, className, constants) {
+				constants[name] = true
+				changed = true
+			}
+		}
+	}
+	return constants
+}
+
+// isGoConstant reports whether node migrates to a Go constant expression:
+// a constant expression made of literals and the constants of the class
+// enclosing it
+func isGoConstant(ctx *MigrationContext, node *tree_sitter.Node) bool {
+	classNode := enclosingTypeDeclaration(node)
+	if nodeKind(classNode) != "class_declaration" {
+		return isConstantExpression(ctx, node, "", nil)
+	}
+	className := ctx.nodeText(classNode.ChildByFieldName("name"))
+	return isConstantExpression(ctx, node, className, constantFields(ctx, classNode.ChildByFieldName("body")))
+}
+
+// isConstantLocal reports whether the local variable declared by declNode
+// with the value valueNode is a constant, like the constant fields of
+// constantFields
+func isConstantLocal(ctx *MigrationContext, declNode, valueNode *tree_sitter.Node) bool {
+	typeNode := declNode.ChildByFieldName("type")
+	if declarationModifiers(ctx, declNode)&FINAL == 0 || !constantType(ctx, typeNode) || nodeKind(typeNode) == "floating_point_type" {
+		return false
+	}
+	return isGoConstant(ctx, valueNode)
+}
+
+// constantType reports whether a field of the Java type typeNode can be a
+// constant
+func constantType(ctx *MigrationContext, typeNode *tree_sitter.Node) bool {
+	switch nodeKind(typeNode) {
+	case "integral_type", "floating_point_type", "boolean_type":
+		return true
+	case "type_identifier":
+		return ctx.nodeText(typeNode) == "String"
+	default:
+		return false
+	}
+}
+
+// isConstantExpression reports whether node is a constant expression that
+// stays one once migrated: literals, and operators and casts applied to them
+// or to the constants of className. String concatenations are constant only
+// if all of their operands are strings, since they become fmt.Sprintf calls
+// otherwise, and casts only if they don't truncate a floating point value.
+func isConstantExpression(ctx *MigrationContext, node *tree_sitter.Node, className string, constants map[string]bool) bool {
+	switch nodeKind(node) {
+	case "decimal_integer_literal", "hex_integer_literal", "octal_integer_literal", "binary_integer_literal",
+		"decimal_floating_point_literal", "hex_floating_point_literal", "character_literal", "string_literal",
+		"true", "false":
+		return true
+	case "parenthesized_expression":
+		return isConstantExpression(ctx, node.NamedChild(0), className, constants)
+	case "unary_expression":
+		return isConstantExpression(ctx, node.ChildByFieldName("operand"), className, constants)
+	case "binary_expression":
+		if operands, isConcat := stringConcatOperands(ctx, node); isConcat {
+			for _, operand := range operands {
+				if !isStringOperand(ctx, operand) || !isConstantExpression(ctx, operand, className, constants) {
+					return false
+				}
+			}
+			return true
+		}
+		return isConstantExpression(ctx, node.ChildByFieldName("left"), className, constants) &&
+			isConstantExpression(ctx, node.ChildByFieldName("right"), className, constants)
+	case "cast_expression":
+		typeNode, value := node.ChildByFieldName("type"), node.ChildByFieldName("value")
+		if valueTy, ok := valueType(ctx, value); ok && valueTy == gosrc.TypeFloat64 && nodeKind(typeNode) == "integral_type" {
+			// Java truncates the fraction, Go rejects constants that lose it
+			return false
+		}
+		return constantType(ctx, typeNode) && isConstantExpression(ctx, value, className, constants)
+	case "identifier":
+		if typeNode := declaredType(ctx, node, ctx.nodeText(node)); typeNode != nil && nodeKind(typeNode.Parent()) != "field_declaration" {
+			// A local variable or parameter shadows the constant
+			return false
+		}
+		return constants[ctx.nodeText(node)]
+	case "field_access":
+		return ctx.nodeText(node.ChildByFieldName("object")) == className && constants[ctx.nodeText(node.ChildByFieldName("field"))]
+	default:
+		return false
+	}
+}
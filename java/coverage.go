@@ -0,0 +1,110 @@
+package java
+
+//go:generate go run gen_node_kinds.go
+
+// KindSupport describes how the converter handles a tree-sitter node kind
+type KindSupport int
+
+const (
+	// KindUnsupported kinds abort the migration of the enclosing member
+	KindUnsupported KindSupport = iota
+	// KindPassthrough kinds are copied to the output as raw Java text
+	KindPassthrough
+	// KindConverted kinds have a dedicated converter
+	KindConverted
+)
+
+func (s KindSupport) String() string {
+	switch s {
+	case KindPassthrough:
+		return "passthrough"
+	case KindConverted:
+		return "converted"
+	default:
+		return "unsupported"
+	}
+}
+
+// statementKindSupport classifies every statement kind of the grammar. Keep
+// this in sync with convertStatement.
+var statementKindSupport = map[string]KindSupport{
+	";":                            KindConverted,
+	"assert_statement":             KindConverted,
+	"break_statement":              KindConverted,
+	"continue_statement":           KindConverted,
+	"enhanced_for_statement":       KindConverted,
+	"expression_statement":         KindConverted,
+	"for_statement":                KindConverted,
+	"if_statement":                 KindConverted,
+	"local_variable_declaration":   KindConverted,
+	"return_statement":             KindConverted,
+	"switch_expression":            KindConverted,
+	"throw_statement":              KindConverted,
+	"try_statement":                KindConverted,
+	"while_statement":              KindConverted,
+	"yield_statement":              KindConverted,
+	"annotation_type_declaration":  KindUnsupported,
+	"block":                        KindUnsupported,
+	"class_declaration":            KindUnsupported,
+	"do_statement":                 KindUnsupported,
+	"enum_declaration":             KindUnsupported,
+	"import_declaration":           KindUnsupported,
+	"interface_declaration":        KindUnsupported,
+	"labeled_statement":            KindUnsupported,
+	"module_declaration":           KindUnsupported,
+	"package_declaration":          KindUnsupported,
+	"record_declaration":           KindUnsupported,
+	"synchronized_statement":       KindUnsupported,
+	"try_with_resources_statement": KindConverted,
+}
+
+// expressionKindSupport classifies every expression kind of the grammar. Keep
+// this in sync with buildExpressionConverters.
+var expressionKindSupport = map[string]KindSupport{
+	"array_creation_expression":      KindConverted,
+	"assignment_expression":          KindConverted,
+	"binary_expression":              KindConverted,
+	"binary_integer_literal":         KindConverted,
+	"cast_expression":                KindConverted,
+	"character_literal":              KindConverted,
+	"decimal_integer_literal":        KindConverted,
+	"false":                          KindConverted,
+	"field_access":                   KindConverted,
+	"hex_integer_literal":            KindConverted,
+	"identifier":                     KindConverted,
+	"instanceof_expression":          KindConverted,
+	"method_invocation":              KindConverted,
+	"method_reference":               KindConverted,
+	"null_literal":                   KindConverted,
+	"object_creation_expression":     KindConverted,
+	"octal_integer_literal":          KindConverted,
+	"parenthesized_expression":       KindConverted,
+	"string_literal":                 KindConverted,
+	"switch_expression":              KindConverted,
+	"ternary_expression":             KindConverted,
+	"this":                           KindConverted,
+	"true":                           KindConverted,
+	"unary_expression":               KindConverted,
+	"array_access":                   KindPassthrough,
+	"decimal_floating_point_literal": KindPassthrough,
+	"update_expression":              KindConverted,
+	"class_literal":                  KindConverted,
+	// Only lambdas passed to stream operations, which are inlined into loops
+	"lambda_expression":          KindConverted,
+	"hex_floating_point_literal": KindUnsupported,
+	"template_expression":        KindUnsupported,
+}
+
+// StatementKindSupport reports how convertStatement handles kind. The second
+// result is false if kind has not been classified at all.
+func StatementKindSupport(kind string) (KindSupport, bool) {
+	support, ok := statementKindSupport[kind]
+	return support, ok
+}
+
+// ExpressionKindSupport reports how convertExpression handles kind. The second
+// result is false if kind has not been classified at all.
+func ExpressionKindSupport(kind string) (KindSupport, bool) {
+	support, ok := expressionKindSupport[kind]
+	return support, ok
+}
@@ -0,0 +1,229 @@
+package java
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// collectSupertypes records in ctx.Supertypes the interfaces typeNode
+// implements or extends and the class it extends, so calls of default methods
+// on its instances and overloads taking them can be resolved
+func collectSupertypes(ctx *MigrationContext, typeNode *tree_sitter.Node) {
+	var supertypes []gosrc.Type
+	addSupertype := func(node *tree_sitter.Node) {
+		if ty, ok := TryParseType(ctx, node); ok {
+			supertypes = append(supertypes, ty)
+		}
+	}
+	IterateChildren(typeNode, func(child *tree_sitter.Node) {
+		switch nodeKind(child) {
+		case "superclass":
+			addSupertype(child.NamedChild(0))
+		case "super_interfaces", "extends_interfaces":
+			IterateChildren(child, func(list *tree_sitter.Node) {
+				if nodeKind(list) == "type_list" {
+					IterateChildren(list, addSupertype)
+				}
+			})
+		}
+	})
+	if len(supertypes) > 0 {
+		ctx.Supertypes[goTypeName(ctx, typeNode)] = supertypes
+	}
+}
+
+// collectDefaultMethod records in ctx.DefaultMethods the function methodNode
+// is migrated to if it is a default method of an interface
+func collectDefaultMethod(ctx *MigrationContext, methodNode *tree_sitter.Node) {
+	body := methodNode.Parent()
+	if body == nil || nodeKind(body) != "interface_body" || !HasModifier(ctx, methodNode, "default") {
+		return
+	}
+	interfaceName := goTypeName(ctx, body.Parent())
+	name := ctx.MethodMetadataCache[methodNode.StartByte()].name
+	if ctx.DefaultMethods[interfaceName] == nil {
+		ctx.DefaultMethods[interfaceName] = make(map[string]string)
+	}
+	ctx.DefaultMethods[interfaceName][name] = gosrc.CapitalizeFirstLetter(name)
+}
+
+// defaultMethod returns the function the default method name of ty, or of
+// the interfaces ty implements, is migrated to, along with the type
+// arguments of the interface declaring it as ty implements it. Type
+// arguments are nil if ty is that interface, since Go infers them.
+func (ctx *MigrationContext) defaultMethod(ty gosrc.Type, name string, seen map[string]bool) (string, []gosrc.Type, bool) {
+	typeName, _ := splitTypeArguments(structType(ty))
+	if seen[typeName] {
+		return "", nil, false
+	}
+	seen[typeName] = true
+	if function, ok := ctx.DefaultMethods[typeName][name]; ok {
+		return function, nil, true
+	}
+	for _, supertype := range ctx.directSupertypes(typeName) {
+		function, typeArgs, ok := ctx.defaultMethod(supertype, name, seen)
+		if !ok {
+			continue
+		}
+		if typeArgs == nil {
+			_, typeArgs = splitTypeArguments(supertype)
+		}
+		return function, typeArgs, true
+	}
+	return "", nil, false
+}
+
+// directSupertypes returns the interfaces the type typeName implements or
+// extends and the class it extends
+func (ctx *MigrationContext) directSupertypes(typeName string) []gosrc.Type {
+	return ctx.Supertypes[typeName]
+}
+
+// isSubtype reports whether ty is target, or implements or extends it
+// directly or through other supertypes
+func (ctx *MigrationContext) isSubtype(ty, target gosrc.Type) bool {
+	targetName, _ := splitTypeArguments(structType(target))
+	seen := make(map[string]bool)
+	var visit func(ty gosrc.Type) bool
+	visit = func(ty gosrc.Type) bool {
+		typeName, _ := splitTypeArguments(structType(ty))
+		if typeName == targetName {
+			return true
+		}
+		if seen[typeName] {
+			return false
+		}
+		seen[typeName] = true
+		return slices.ContainsFunc(ctx.directSupertypes(typeName), visit)
+	}
+	return visit(ty)
+}
+
+// isDefaultMethodFunction reports whether function is the function a default
+// method is migrated to
+func (ctx *MigrationContext) isDefaultMethodFunction(function string) bool {
+	for _, functions := range ctx.DefaultMethods {
+		for _, each := range functions {
+			if each == function {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// defaultMethodCall resolves a call of the method name on the receiver
+// objectText to the function of a default method, returning the function,
+// its type arguments and the receiver to pass as its first argument
+func defaultMethodCall(ctx *MigrationContext, expression *tree_sitter.Node, name, objectText string) (string, []gosrc.Type, gosrc.Expression, bool) {
+	if len(ctx.DefaultMethods) == 0 {
+		return "", nil, nil, false
+	}
+	var receiverType gosrc.Type
+	var receiver gosrc.Expression
+	switch objectText {
+	case "", "this":
+		ty, ok := selfType(ctx, expression)
+		if !ok {
+			return "", nil, nil, false
+		}
+		receiverType, receiver = ty, ctx.arena.VarRef(gosrc.VarRef{Ref: gosrc.SelfRef})
+	default:
+		ty, ok := staticType(ctx, expression.ChildByFieldName("object"))
+		if !ok {
+			return "", nil, nil, false
+		}
+		receiverType, receiver = ty, ctx.arena.VarRef(gosrc.VarRef{Ref: objectText})
+	}
+	function, typeArgs, ok := ctx.defaultMethod(receiverType, name, map[string]bool{})
+	if !ok {
+		return "", nil, nil, false
+	}
+	if _, receiverArgs := splitTypeArguments(receiverType); len(receiverArgs) > 0 {
+		// The type arguments of the interface are in terms of the type
+		// parameters of the class, so they are left to inference
+		typeArgs = nil
+	}
+	return function, typeArgs, receiver, true
+}
+
+// selfType returns the Go name of the type this refers to at node, unless
+// node is in the body of an anonymous class
+func selfType(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Type, bool) {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch kind := nodeKind(parent); {
+		case kind == "class_body" && parent.Parent() != nil && nodeKind(parent.Parent()) == "object_creation_expression":
+			return "", false
+		case slices.Contains(typeDeclarationKinds, kind):
+			return gosrc.Type(goTypeName(ctx, parent)), true
+		}
+	}
+	return "", false
+}
+
+// splitTypeArguments splits an instantiated generic type such as
+// Pair[string, []int] into its name and type arguments
+func splitTypeArguments(ty gosrc.Type) (string, []gosrc.Type) {
+	name, rest, ok := strings.Cut(string(ty), "[")
+	if !ok || name == "" {
+		return string(ty), nil
+	}
+	rest = strings.TrimSuffix(rest, "]")
+	var typeArgs []gosrc.Type
+	depth, start := 0, 0
+	for i, r := range rest {
+		switch r {
+		case '[', '(', '{':
+			depth++
+		case ']', ')', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				typeArgs = append(typeArgs, gosrc.Type(strings.TrimSpace(rest[start:i])))
+				start = i + 1
+			}
+		}
+	}
+	return name, append(typeArgs, gosrc.Type(strings.TrimSpace(rest[start:])))
+}
+
+// overrideDispatch returns the statement a default method migrated to
+// function starts with, which calls the method of the same name of this
+// instead if the implementing type overrides it
+func overrideDispatch(function string, params []gosrc.Param, returnType *gosrc.Type) gosrc.Statement {
+	override, ok := unusedName("override", params), unusedName("ok", params)
+	signature := make([]string, len(params))
+	args := make([]string, len(params))
+	for i, param := range params {
+		signature[i] = param.ToSource()
+		args[i] = param.Name
+		if isVariadic(param.Ty) {
+			args[i] += "..."
+		}
+	}
+	method := fmt.Sprintf("%s(%s)", function, strings.Join(signature, ", "))
+	call := fmt.Sprintf("%s.%s(%s)", override, function, strings.Join(args, ", "))
+	if returnType != nil {
+		method += " " + returnType.ToSource()
+		call = "return " + call
+	} else {
+		call += "\n\treturn"
+	}
+	return &gosrc.GoStatement{Source: fmt.Sprintf("if %s, %s := %s.(interface{ %s }); %s {\n\t%s\n}",
+		override, ok, gosrc.SelfRef, method, ok, call)}
+}
+
+// unusedName returns base, with a numeric suffix if one of params is named
+// base
+func unusedName(base string, params []gosrc.Param) string {
+	name := base
+	for i := 2; slices.ContainsFunc(params, func(param gosrc.Param) bool { return param.Name == name }); i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	return name
+}
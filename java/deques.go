@@ -0,0 +1,172 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// dequeClasses are the java.util stacks, queues and deques that migrate to
+// slices
+var dequeClasses = map[string]bool{
+	"ArrayDeque": true,
+	"Deque":      true,
+	"Queue":      true,
+	"LinkedList": true,
+	"Stack":      true,
+}
+
+// dequeEnd is the end of a slice a deque operation works on
+type dequeEnd int
+
+const (
+	front dequeEnd = iota
+	back
+)
+
+// dequeOperation is what a method of a deque does: insert at an end, or
+// remove or look at the element at an end. Polling operations yield null
+// rather than throw when the deque is empty.
+type dequeOperation struct {
+	end     dequeEnd
+	insert  bool
+	remove  bool
+	polling bool
+}
+
+// dequeOperations are the operations of the methods of java.util.Deque and
+// java.util.Queue. Deques used as stacks push to and pop from the front.
+var dequeOperations = map[string]dequeOperation{
+	"push":        {end: front, insert: true},
+	"addFirst":    {end: front, insert: true},
+	"offerFirst":  {end: front, insert: true},
+	"addLast":     {end: back, insert: true},
+	"offerLast":   {end: back, insert: true},
+	"offer":       {end: back, insert: true},
+	"pop":         {end: front, remove: true},
+	"remove":      {end: front, remove: true},
+	"removeFirst": {end: front, remove: true},
+	"removeLast":  {end: back, remove: true},
+	"poll":        {end: front, remove: true, polling: true},
+	"pollFirst":   {end: front, remove: true, polling: true},
+	"pollLast":    {end: back, remove: true, polling: true},
+	"element":     {end: front},
+	"getFirst":    {end: front},
+	"getLast":     {end: back},
+	"peek":        {end: front, polling: true},
+	"peekFirst":   {end: front, polling: true},
+	"peekLast":    {end: back, polling: true},
+}
+
+// stackOperations are the operations of the methods of java.util.Stack,
+// whose top is the back of the slice
+var stackOperations = map[string]dequeOperation{
+	"push": {end: back, insert: true},
+	"pop":  {end: back, remove: true},
+	"peek": {end: back},
+}
+
+// dequeClass returns the Java class a stack, queue or deque expression is
+// declared as, or false if it is not one
+func dequeClass(ctx *MigrationContext, expression *tree_sitter.Node) (string, bool) {
+	typeNode := assignedType(ctx, expression)
+	if typeNode == nil {
+		return "", false
+	}
+	class := ctx.nodeText(baseTypeNode(typeNode))
+	return class, dequeClasses[class]
+}
+
+// baseTypeNode returns the type node of typeNode without its type arguments
+func baseTypeNode(typeNode *tree_sitter.Node) *tree_sitter.Node {
+	if nodeKind(typeNode) == "generic_type" {
+		return typeNode.NamedChild(0)
+	}
+	return typeNode
+}
+
+// convertDequeMethod converts a call of a stack, queue or deque method on
+// receiver to slice operations. If used is false the result of the call is
+// unused, and only the statements changing the slice are returned. It
+// returns false for methods without a translation.
+func convertDequeMethod(ctx *MigrationContext, expression *tree_sitter.Node, name, receiver string, used bool) (gosrc.Expression, []gosrc.Statement, bool) {
+	objectNode := expression.ChildByFieldName("object")
+	class, ok := dequeClass(ctx, objectNode)
+	if !ok {
+		return nil, nil, false
+	}
+	argsNode := expression.ChildByFieldName("arguments")
+	args, initStmts := convertArguments(ctx, argsNode)
+	target, slice := sliceOperands(ctx, objectNode, receiver)
+	if name == "empty" && class == "Stack" && len(args) == 0 {
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("(len(%s) == 0)", target)}), initStmts, true
+	}
+	operations := dequeOperations
+	if class == "Stack" {
+		operations = stackOperations
+	}
+	operation, ok := operations[name]
+	if !ok || (len(args) == 1) != operation.insert || len(args) > 1 {
+		return nil, nil, false
+	}
+	sliceRef := gosrc.VarRef{Ref: target}
+	if operation.insert {
+		value := &gosrc.CallExpression{Function: "append", Args: []gosrc.Expression{&gosrc.VarRef{Ref: target}, args[0]}}
+		if operation.end == front {
+			ctx.Source.AddImport("slices")
+			value = &gosrc.CallExpression{Function: "slices.Insert", Args: []gosrc.Expression{&gosrc.VarRef{Ref: target}, &gosrc.IntLiteral{Value: 0}, args[0]}}
+		}
+		initStmts = append(initStmts, &gosrc.AssignStatement{Ref: sliceRef, Value: value})
+		if strings.HasPrefix(name, "offer") {
+			// Slices have no capacity restrictions, so offers always succeed
+			return &gosrc.BooleanLiteral{Value: true}, initStmts, true
+		}
+		return args[0], initStmts, true
+	}
+	element, rest, tempBase := slice+"[0]", slice+"[1:]", "front"
+	if operation.end == back {
+		element, rest, tempBase = fmt.Sprintf("%s[len(%s)-1]", slice, target), fmt.Sprintf("%s[:len(%s)-1]", slice, target), "back"
+	}
+	var removeStmts []gosrc.Statement
+	if operation.remove {
+		removeStmts = []gosrc.Statement{&gosrc.AssignStatement{Ref: sliceRef, Value: &gosrc.VarRef{Ref: rest}}}
+	}
+	switch {
+	case !used && operation.polling:
+		if len(removeStmts) == 0 {
+			return nil, initStmts, true
+		}
+		return nil, append(initStmts, &gosrc.IfStatement{
+			Condition: &gosrc.VarRef{Ref: fmt.Sprintf("len(%s) > 0", target)},
+			Body:      removeStmts,
+		}), true
+	case !used:
+		// Like Java, taking from an empty deque fails
+		if len(removeStmts) == 0 {
+			removeStmts = []gosrc.Statement{&gosrc.GoStatement{Source: "_ = " + element}}
+		}
+		return nil, append(initStmts, removeStmts...), true
+	case operation.polling:
+		// The zero value stands in for null when the deque is empty
+		elementType, ok := staticType(ctx, objectNode)
+		if !ok || !strings.HasPrefix(string(elementType), "[]") {
+			return nil, nil, false
+		}
+		temp := ctx.tempName(tempBase)
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: temp}), append(initStmts,
+			&gosrc.GoStatement{Source: fmt.Sprintf("var %s %s", temp, strings.TrimPrefix(string(elementType), "[]"))},
+			&gosrc.IfStatement{
+				Condition: &gosrc.VarRef{Ref: fmt.Sprintf("len(%s) > 0", target)},
+				Body:      append([]gosrc.Statement{&gosrc.AssignStatement{Ref: gosrc.VarRef{Ref: temp}, Value: &gosrc.VarRef{Ref: element}}}, removeStmts...),
+			}), true
+	case len(removeStmts) == 0:
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: element}), initStmts, true
+	default:
+		temp := ctx.tempName(tempBase)
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: temp}), append(append(initStmts,
+			&gosrc.VarDeclaration{Name: temp, Value: &gosrc.VarRef{Ref: element}}), removeStmts...), true
+	}
+}
@@ -3,6 +3,7 @@ package java
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/heshanpadmasiri/javaGo/gosrc"
@@ -13,6 +14,9 @@ import (
 type EnumConstant struct {
 	name      string
 	arguments []gosrc.Expression
+	initStmts []gosrc.Statement // Statements computing the arguments, such as those of a ternary
+	body      *tree_sitter.Node // The class body of a constant overriding methods of the enum
+	methods   []constantMethod  // The methods declared in body
 }
 
 // TODO: this is mostly ai slop which is good enough for now. But we should be able to do better.
@@ -27,21 +31,24 @@ func extractEnumConstant(ctx *MigrationContext, node *tree_sitter.Node) *EnumCon
 	// First try: Get name field from node
 	constantNameNode := node.ChildByFieldName("name")
 	if constantNameNode != nil {
-		constantName := constantNameNode.Utf8Text(ctx.JavaSource)
+		constantName := ctx.nodeText(constantNameNode)
 		var args []gosrc.Expression
+		var initStmts []gosrc.Statement
 		argsNode := node.ChildByFieldName("arguments")
 		if argsNode != nil {
-			args = convertArgumentList(ctx, argsNode)
+			args, initStmts = convertArguments(ctx, argsNode)
 		}
 		return &EnumConstant{
 			name:      constantName,
 			arguments: args,
+			initStmts: initStmts,
+			body:      node.ChildByFieldName("body"),
 		}
 	}
 
 	// Second try: If node is identifier, use its text as name
-	if node.Kind() == "identifier" {
-		constantName := node.Utf8Text(ctx.JavaSource)
+	if nodeKind(node) == "identifier" {
+		constantName := ctx.nodeText(node)
 		return &EnumConstant{
 			name:      constantName,
 			arguments: []gosrc.Expression{},
@@ -51,8 +58,8 @@ func extractEnumConstant(ctx *MigrationContext, node *tree_sitter.Node) *EnumCon
 	// Third try: Iterate children looking for identifier node
 	var constantName string
 	IterateChildren(node, func(child *tree_sitter.Node) {
-		if child.Kind() == "identifier" && constantName == "" {
-			constantName = child.Utf8Text(ctx.JavaSource)
+		if nodeKind(child) == "identifier" && constantName == "" {
+			constantName = ctx.nodeText(child)
 		}
 	})
 
@@ -67,19 +74,78 @@ func extractEnumConstant(ctx *MigrationContext, node *tree_sitter.Node) *EnumCon
 	return nil
 }
 
+// enumIsPublic reports whether an enum is exported. Enums are public by
+// default in Java (unless explicitly private/protected), so if no access
+// modifier is present we default to public.
+func enumIsPublic(modifiers modifiers) bool {
+	if modifiers&(PUBLIC|PRIVATE|PROTECTED) == 0 {
+		return true
+	}
+	return modifiers.isPublic()
+}
+
+// collectEnumConstants records the prefixed names of the constants declared
+// in enumNode's body so they can be referenced before the enum is converted
+func collectEnumConstants(ctx *MigrationContext, enumNode *tree_sitter.Node) {
+	nameNode := enumNode.ChildByFieldName("name")
+	if nameNode == nil {
+		return
+	}
+	var modifiers modifiers
+	IterateChildren(enumNode, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "modifiers" {
+			modifiers = ParseModifiers(ctx.nodeText(child))
+		}
+	})
+	enumTypeName := gosrc.ToIdentifier(ctx.nodeText(nameNode), enumIsPublic(modifiers))
+	var ordinals []string
+	IterateChildren(enumNode.ChildByFieldName("body"), func(child *tree_sitter.Node) {
+		if nodeKind(child) != "enum_constant" {
+			return
+		}
+		if constantNameNode := child.ChildByFieldName("name"); constantNameNode != nil {
+			constantName := ctx.nodeText(constantNameNode)
+			ctx.EnumConstants[constantName] = enumTypeName + "_" + constantName
+			ordinals = append(ordinals, enumTypeName+"_"+constantName)
+		}
+	})
+	if body := enumNode.ChildByFieldName("body"); !declaresEnumFields(body) && !declaresConstantBodies(body) {
+		// Enums without fields become integers counting up from zero
+		ctx.EnumOrdinals[enumTypeName] = ordinals
+		if declaresToString(ctx, body) {
+			ctx.EnumToString[enumTypeName] = true
+		}
+	}
+}
+
+// declaresEnumFields reports whether the enum body enumBody declares fields,
+// which makes the enum a struct rather than an integer
+func declaresEnumFields(enumBody *tree_sitter.Node) bool {
+	declares := false
+	IterateChildren(enumBody, func(child *tree_sitter.Node) {
+		if nodeKind(child) != "enum_body_declarations" {
+			return
+		}
+		IterateChildren(child, func(member *tree_sitter.Node) {
+			declares = declares || nodeKind(member) == "field_declaration"
+		})
+	})
+	return declares
+}
+
 func migrateEnumDeclaration(ctx *MigrationContext, enumNode *tree_sitter.Node) {
-	var enumName string
+	enumName := identifierText(ctx, enumNode, enumNode.ChildByFieldName("name"), "enum_declaration")
 	var modifiers modifiers
 	var enumConstants []EnumConstant
 	var enumBody *tree_sitter.Node
 	var hasFields bool
 
 	IterateChildren(enumNode, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "modifiers":
-			modifiers = ParseModifiers(child.Utf8Text(ctx.JavaSource))
+			modifiers = ParseModifiers(ctx.nodeText(child))
 		case "identifier":
-			enumName = child.Utf8Text(ctx.JavaSource)
+			// The name is read before iterating
 		case "enum_constants":
 			// Parse enum constants list
 			IterateChildren(child, func(constantChild *tree_sitter.Node) {
@@ -96,7 +162,7 @@ func migrateEnumDeclaration(ctx *MigrationContext, enumNode *tree_sitter.Node) {
 			enumBody = child
 			// Parse constants and check for fields in the body
 			IterateChildren(child, func(bodyChild *tree_sitter.Node) {
-				switch bodyChild.Kind() {
+				switch nodeKind(bodyChild) {
 				case "field_declaration":
 					hasFields = true
 				case "enum_constant":
@@ -107,7 +173,7 @@ func migrateEnumDeclaration(ctx *MigrationContext, enumNode *tree_sitter.Node) {
 				case "identifier":
 					if len(enumConstants) == 0 {
 						// Might be a constant name if we haven't found any constants yet
-						constantName := bodyChild.Utf8Text(ctx.JavaSource)
+						constantName := ctx.nodeText(bodyChild)
 						enumConstants = append(enumConstants, EnumConstant{
 							name:      constantName,
 							arguments: []gosrc.Expression{},
@@ -116,7 +182,7 @@ func migrateEnumDeclaration(ctx *MigrationContext, enumNode *tree_sitter.Node) {
 				}
 				// Also check nested nodes for field_declaration (in case of nested structures)
 				IterateChildrenWhile(bodyChild, func(nestedChild *tree_sitter.Node) bool {
-					if nestedChild.Kind() == "field_declaration" {
+					if nodeKind(nestedChild) == "field_declaration" {
 						hasFields = true
 						return false
 					} else {
@@ -130,22 +196,12 @@ func migrateEnumDeclaration(ctx *MigrationContext, enumNode *tree_sitter.Node) {
 				enumBody = child
 				// Parse constants and check for fields in the body
 				IterateChildren(child, func(bodyChild *tree_sitter.Node) {
-					switch bodyChild.Kind() {
+					switch nodeKind(bodyChild) {
 					case "field_declaration":
 						hasFields = true
 					case "enum_constant":
-						constantNameNode := bodyChild.ChildByFieldName("name")
-						if constantNameNode != nil {
-							constantName := constantNameNode.Utf8Text(ctx.JavaSource)
-							var args []gosrc.Expression
-							argsNode := bodyChild.ChildByFieldName("arguments")
-							if argsNode != nil {
-								args = convertArgumentList(ctx, argsNode)
-							}
-							enumConstants = append(enumConstants, EnumConstant{
-								name:      constantName,
-								arguments: args,
-							})
+						if bodyChild.ChildByFieldName("name") != nil {
+							enumConstants = append(enumConstants, *extractEnumConstant(ctx, bodyChild))
 						}
 					}
 				})
@@ -156,22 +212,10 @@ func migrateEnumDeclaration(ctx *MigrationContext, enumNode *tree_sitter.Node) {
 				enumBody = child
 				// Parse constants and check for fields in the body
 				IterateChildren(child, func(bodyChild *tree_sitter.Node) {
-					if bodyChild.Kind() == "field_declaration" {
+					if nodeKind(bodyChild) == "field_declaration" {
 						hasFields = true
-					} else if bodyChild.Kind() == "enum_constant" {
-						constantNameNode := bodyChild.ChildByFieldName("name")
-						if constantNameNode != nil {
-							constantName := constantNameNode.Utf8Text(ctx.JavaSource)
-							var args []gosrc.Expression
-							argsNode := bodyChild.ChildByFieldName("arguments")
-							if argsNode != nil {
-								args = convertArgumentList(ctx, argsNode)
-							}
-							enumConstants = append(enumConstants, EnumConstant{
-								name:      constantName,
-								arguments: args,
-							})
-						}
+					} else if nodeKind(bodyChild) == "enum_constant" && bodyChild.ChildByFieldName("name") != nil {
+						enumConstants = append(enumConstants, *extractEnumConstant(ctx, bodyChild))
 					}
 				})
 			}
@@ -192,19 +236,13 @@ func migrateEnumDeclaration(ctx *MigrationContext, enumNode *tree_sitter.Node) {
 		}
 	})
 
-	// Enums are public by default in Java (unless explicitly private/protected)
-	// If no access modifier is present, default to public
-	isPublic := modifiers.isPublic()
-	hasAccessModifier := (modifiers&PUBLIC != 0) || (modifiers&PRIVATE != 0) || (modifiers&PROTECTED != 0)
-	if !hasAccessModifier {
-		isPublic = true
-	}
+	isPublic := enumIsPublic(modifiers)
 	enumTypeName := gosrc.ToIdentifier(enumName, isPublic)
 
 	// Re-check for fields in enum body if we have one (fields might come after constants)
 	if enumBody != nil && !hasFields {
 		IterateChildrenWhile(enumBody, func(bodyChild *tree_sitter.Node) bool {
-			if bodyChild.Kind() == "field_declaration" {
+			if nodeKind(bodyChild) == "field_declaration" {
 				hasFields = true
 				return false
 			} else {
@@ -213,12 +251,7 @@ func migrateEnumDeclaration(ctx *MigrationContext, enumNode *tree_sitter.Node) {
 		})
 	}
 
-	// Recalculate enumTypeName with correct public flag if needed
-	if !hasAccessModifier {
-		enumTypeName = gosrc.ToIdentifier(enumName, isPublic)
-	}
-
-	if hasFields {
+	if hasFields || declaresConstantBodies(enumBody) {
 		// Complex enum: generate struct and var declarations
 		convertComplexEnum(ctx, enumTypeName, enumConstants, enumBody, modifiers, isPublic)
 	} else {
@@ -250,6 +283,9 @@ func convertSimpleEnum(ctx *MigrationContext, enumTypeName string, enumConstants
 			TypeName:  enumTypeName,
 			Constants: prefixedConstants,
 		})
+		ctx.Source.Methods = append(ctx.Source.Methods, enumStringMethod(ctx, enumTypeName, enumConstants, ctx.enumNameMethod(enumTypeName)))
+		ctx.Source.Methods = append(ctx.Source.Methods, enumOrdinalMethod(enumTypeName))
+		ctx.Source.Functions = append(ctx.Source.Functions, enumValues(enumTypeName, enumConstants), enumParse(ctx, enumTypeName, enumConstants))
 	}
 
 	// Parse and convert methods from enum body
@@ -259,14 +295,14 @@ func convertSimpleEnum(ctx *MigrationContext, enumTypeName string, enumConstants
 		findMethods = func(node *tree_sitter.Node) {
 			IterateChildren(node, func(bodyChild *tree_sitter.Node) {
 				// Skip ignored tokens
-				switch bodyChild.Kind() {
+				switch nodeKind(bodyChild) {
 				case "enum_constant", "{", "}", ";", ",", "line_comment", "block_comment", "field_declaration":
 					return
 				}
 
 				// Wrap member migration in error recovery
-				failed := tryMigrateMember(ctx, fmt.Sprintf("enum %s.%s", enumTypeName, bodyChild.Kind()), bodyChild, func() {
-					switch bodyChild.Kind() {
+				failed := tryMigrateMember(ctx, fmt.Sprintf("enum %s.%s", enumTypeName, nodeKind(bodyChild)), bodyChild, func() {
+					switch nodeKind(bodyChild) {
 					case "method_declaration":
 						// Handle methods similar to class methods
 						function, isStatic := convertMethodDeclaration(ctx, bodyChild)
@@ -300,6 +336,84 @@ func convertSimpleEnum(ctx *MigrationContext, enumTypeName string, enumConstants
 	}
 }
 
+// enumStringMethod returns the String method of the simple enum
+// enumTypeName, which returns the Java name of each constant so printing an
+// enum value reads the way it does in Java. It has a value receiver, unlike
+// the migrated methods, so fmt finds it on enum values. Enums declaring their
+// own toString get it as the method name instead, which name() is migrated
+// to.
+func enumStringMethod(ctx *MigrationContext, enumTypeName string, enumConstants []EnumConstant, name string) gosrc.Method {
+	self := ctx.arena.VarRef(gosrc.VarRef{Ref: gosrc.SelfRef})
+	var cases []gosrc.SwitchCase
+	for _, constant := range enumConstants {
+		cases = append(cases, gosrc.SwitchCase{
+			Condition: ctx.arena.VarRef(gosrc.VarRef{Ref: enumTypeName + "_" + constant.name}),
+			Body: []gosrc.Statement{&gosrc.ReturnStatement{
+				Value: ctx.arena.GoExpression(gosrc.GoExpression{Source: strconv.Quote(constant.name)}),
+			}},
+		})
+	}
+	// Values outside the constants can only come from conversions, print
+	// them the way fmt prints the underlying integer
+	ctx.Source.AddImport("fmt")
+	defaultName := ctx.arena.GoExpression(gosrc.GoExpression{
+		Source: fmt.Sprintf(`fmt.Sprintf("%s(%%d)", uint(%s))`, enumTypeName, gosrc.SelfRef),
+	})
+	returnType := gosrc.TypeString
+	return gosrc.Method{
+		Function: gosrc.Function{
+			Name:       name,
+			ReturnType: &returnType,
+			Body: []gosrc.Statement{&gosrc.SwitchStatement{
+				Condition:   self,
+				Cases:       cases,
+				DefaultBody: []gosrc.Statement{&gosrc.ReturnStatement{Value: defaultName}},
+			}},
+			Public: true,
+		},
+		Receiver: gosrc.Param{Name: gosrc.SelfRef, Ty: gosrc.Type(enumTypeName)},
+	}
+}
+
+// enumOrdinalMethod returns the Ordinal method of the simple enum
+// enumTypeName. The constants count up from zero in declaration order, so
+// the ordinal of a constant is its value.
+func enumOrdinalMethod(enumTypeName string) gosrc.Method {
+	returnType := gosrc.TypeInt
+	return gosrc.Method{
+		Function: gosrc.Function{
+			Name:       ordinalMethodName,
+			ReturnType: &returnType,
+			Body: []gosrc.Statement{&gosrc.ReturnStatement{
+				Value: &gosrc.CastExpression{Ty: gosrc.TypeInt, Value: &gosrc.VarRef{Ref: gosrc.SelfRef}},
+			}},
+			Public: true,
+		},
+		Receiver: gosrc.Param{Name: gosrc.SelfRef, Ty: gosrc.Type(enumTypeName)},
+	}
+}
+
+// declaresToString reports whether the enum body enumBody declares its own
+// toString method, which is migrated to String instead
+func declaresToString(ctx *MigrationContext, enumBody *tree_sitter.Node) bool {
+	if enumBody == nil {
+		return false
+	}
+	declares := false
+	IterateChildren(enumBody, func(child *tree_sitter.Node) {
+		if nodeKind(child) != "enum_body_declarations" {
+			return
+		}
+		IterateChildren(child, func(member *tree_sitter.Node) {
+			if nodeKind(member) == "method_declaration" && ctx.nodeText(member.ChildByFieldName("name")) == "toString" &&
+				member.ChildByFieldName("parameters").NamedChildCount() == 0 {
+				declares = true
+			}
+		})
+	})
+	return declares
+}
+
 func convertComplexEnum(ctx *MigrationContext, enumTypeName string, enumConstants []EnumConstant, enumBody *tree_sitter.Node, modifiers modifiers, isPublic bool) {
 	// First, track enum constants so they can be referenced in method bodies
 	for _, constant := range enumConstants {
@@ -308,6 +422,25 @@ func convertComplexEnum(ctx *MigrationContext, enumTypeName string, enumConstant
 		ctx.EnumConstants[constant.name] = prefixedName
 	}
 
+	// Constants with bodies give their own implementations of methods
+	for i, constant := range enumConstants {
+		if constant.body == nil {
+			continue
+		}
+		failed := tryMigrateMember(ctx, fmt.Sprintf("enum %s.%s", enumTypeName, constant.name), constant.body, func() {
+			enumConstants[i].methods = convertConstantMethods(ctx, enumTypeName, constant.body)
+		})
+		if failed != nil {
+			ctx.Source.FailedMigrations = append(ctx.Source.FailedMigrations, *failed)
+		}
+	}
+	overridden := overriddenMethods(enumConstants)
+	isOverridden := make(map[string]bool)
+	for _, method := range overridden {
+		isOverridden[method.name] = true
+	}
+	declared := make(map[string]bool)
+
 	// Parse fields from enum body
 	var fields []gosrc.StructField
 
@@ -316,16 +449,16 @@ func convertComplexEnum(ctx *MigrationContext, enumTypeName string, enumConstant
 	findFieldsAndMethods = func(node *tree_sitter.Node) {
 		IterateChildren(node, func(child *tree_sitter.Node) {
 			// Skip ignored tokens
-			switch child.Kind() {
+			switch nodeKind(child) {
 			case "enum_constant", "{", "}", ";", ",", "line_comment", "block_comment", "constructor_declaration":
 				return
 			}
 
 			// Wrap member migration in error recovery
-			failed := tryMigrateMember(ctx, fmt.Sprintf("enum %s.%s", enumTypeName, child.Kind()), child, func() {
-				switch child.Kind() {
+			failed := tryMigrateMember(ctx, fmt.Sprintf("enum %s.%s", enumTypeName, nodeKind(child)), child, func() {
+				switch nodeKind(child) {
 				case "field_declaration":
-					field, _, _ := convertFieldDeclaration(ctx, child)
+					field, _, _, _ := convertFieldDeclaration(ctx, child)
 					fields = append(fields, field)
 				case "method_declaration":
 					// Handle methods similar to class methods
@@ -333,6 +466,10 @@ func convertComplexEnum(ctx *MigrationContext, enumTypeName string, enumConstant
 					if isStatic {
 						ctx.Source.Functions = append(ctx.Source.Functions, function)
 					} else {
+						if isOverridden[function.Name] {
+							function.Body = append(dispatchToConstant(function), function.Body...)
+						}
+						declared[function.Name] = true
 						ctx.Source.Methods = append(ctx.Source.Methods, gosrc.Method{
 							Function: function,
 							Receiver: gosrc.Param{
@@ -360,11 +497,18 @@ func convertComplexEnum(ctx *MigrationContext, enumTypeName string, enumConstant
 	if enumBody != nil {
 		findFieldsAndMethods(enumBody)
 	}
+	methodFields := make([]gosrc.StructField, len(overridden))
+	for i, method := range overridden {
+		methodFields[i] = gosrc.StructField{Name: methodField(method.name), Ty: funcType(method.literal)}
+		if !declared[method.name] {
+			ctx.Source.Methods = append(ctx.Source.Methods, constantOnlyMethod(enumTypeName, method))
+		}
+	}
 
 	// Generate struct type
 	ctx.Source.Structs = append(ctx.Source.Structs, gosrc.Struct{
 		Name:     enumTypeName,
-		Fields:   fields,
+		Fields:   append(fields, methodFields...),
 		Comments: []string{},
 		Public:   isPublic,
 		Includes: []gosrc.Type{},
@@ -377,28 +521,30 @@ func convertComplexEnum(ctx *MigrationContext, enumTypeName string, enumConstant
 		fieldNames[i] = gosrc.ToIdentifier(field.Name, field.Public)
 	}
 
+	var initBody []gosrc.Statement
 	for _, constant := range enumConstants {
 		prefixedName := enumTypeName + "_" + constant.name
 		// Create struct literal with constructor arguments
-		var structLiteral gosrc.Expression
+		var values []string
 		if len(constant.arguments) > 0 && len(constant.arguments) == len(fieldNames) {
 			// Create struct literal with field names and values
-			sb := strings.Builder{}
-			sb.WriteString(enumTypeName)
-			sb.WriteString("{")
 			for i, arg := range constant.arguments {
-				if i > 0 {
-					sb.WriteString(", ")
-				}
-				sb.WriteString(fieldNames[i])
-				sb.WriteString(": ")
-				sb.WriteString(arg.ToSource())
+				values = append(values, fieldNames[i]+": "+arg.ToSource())
 			}
-			sb.WriteString("}")
-			structLiteral = &gosrc.VarRef{Ref: sb.String()}
-		} else {
-			// Empty struct or mismatch - use empty struct
-			structLiteral = &gosrc.VarRef{Ref: enumTypeName + "{}"}
+		}
+		for _, method := range constant.methods {
+			values = append(values, methodField(method.name)+": "+method.literal.ToSource())
+		}
+		structLiteral := ctx.arena.VarRef(gosrc.VarRef{Ref: enumTypeName + "{" + strings.Join(values, ", ") + "}"})
+		if constant.initStmts != nil {
+			// Module variables have no statements to go before them
+			ctx.Source.Vars = append(ctx.Source.Vars, gosrc.ModuleVar{
+				Name:     prefixedName,
+				Ty:       gosrc.Type(enumTypeName),
+				Comments: []string{"Assigned in init since its arguments need statements"},
+			})
+			initBody = append(initBody, liftedFieldInit(prefixedName, structLiteral, constant.initStmts)...)
+			continue
 		}
 		ctx.Source.Vars = append(ctx.Source.Vars, gosrc.ModuleVar{
 			Name:  prefixedName,
@@ -406,4 +552,11 @@ func convertComplexEnum(ctx *MigrationContext, enumTypeName string, enumConstant
 			Value: structLiteral,
 		})
 	}
+	if len(initBody) > 0 {
+		ctx.Source.Functions = append(ctx.Source.Functions, gosrc.Function{
+			Name:     "init",
+			Body:     initBody,
+			Comments: []string{getMigrationComment(ctx, enumBody.Parent())},
+		})
+	}
 }
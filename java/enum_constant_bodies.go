@@ -0,0 +1,133 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// constantMethod is a method declared in the body of an enum constant,
+// migrated to a function literal held by a field of the enum struct
+type constantMethod struct {
+	name    string
+	literal *gosrc.FuncLiteral
+}
+
+// declaresConstantBodies reports whether a constant of the enum body
+// enumBody has a body of its own, which makes the enum a struct holding the
+// methods the constants override
+func declaresConstantBodies(enumBody *tree_sitter.Node) bool {
+	declares := false
+	IterateChildren(enumBody, func(child *tree_sitter.Node) {
+		declares = declares || (nodeKind(child) == "enum_constant" && child.ChildByFieldName("body") != nil)
+	})
+	return declares
+}
+
+// methodField returns the name of the field of an enum struct holding the
+// implementation of the method methodName given by a constant
+func methodField(methodName string) string {
+	return gosrc.LowercaseFirstLetter(methodName) + "Func"
+}
+
+// convertConstantMethods converts the methods declared in the body of an
+// enum constant to function literals taking the constant as their first
+// parameter, since a literal in the declaration of the constant can't refer
+// to it
+func convertConstantMethods(ctx *MigrationContext, enumTypeName string, body *tree_sitter.Node) []constantMethod {
+	var methods []constantMethod
+	IterateChildren(body, func(child *tree_sitter.Node) {
+		switch nodeKind(child) {
+		case "{", "}", "block_comment", "line_comment":
+		case "method_declaration":
+			function, isStatic := convertMethodDeclaration(ctx, child)
+			if isStatic {
+				UnhandledChild(ctx, child, "enum constant class_body")
+			}
+			params := append([]gosrc.Param{{Name: gosrc.SelfRef, Ty: gosrc.Type("*" + enumTypeName)}}, function.Params...)
+			methods = append(methods, constantMethod{
+				name:    function.Name,
+				literal: &gosrc.FuncLiteral{Params: params, ReturnType: function.ReturnType, Body: function.Body},
+			})
+		default:
+			FatalError(ctx, child, "enum constant bodies can only declare methods", "class_body")
+		}
+	})
+	return methods
+}
+
+// overriddenMethods returns the methods the constants declare, the first
+// declaration of each name in declaration order
+func overriddenMethods(enumConstants []EnumConstant) []constantMethod {
+	var methods []constantMethod
+	seen := make(map[string]bool)
+	for _, constant := range enumConstants {
+		for _, method := range constant.methods {
+			if !seen[method.name] {
+				seen[method.name] = true
+				methods = append(methods, method)
+			}
+		}
+	}
+	return methods
+}
+
+// funcType returns the type of the function literal
+func funcType(literal *gosrc.FuncLiteral) gosrc.Type {
+	paramTypes := make([]string, len(literal.Params))
+	for i, param := range literal.Params {
+		paramTypes[i] = string(param.Ty)
+	}
+	ty := "func(" + strings.Join(paramTypes, ", ") + ")"
+	if literal.ReturnType != nil {
+		ty += " " + string(*literal.ReturnType)
+	}
+	return gosrc.Type(ty)
+}
+
+// constantOnlyMethod returns the method of the enum enumTypeName calling the
+// method a constant declares without the enum declaring it, which only the
+// constants declaring it can call
+func constantOnlyMethod(enumTypeName string, method constantMethod) gosrc.Method {
+	function := gosrc.Function{
+		Name:       method.name,
+		Params:     method.literal.Params[1:],
+		ReturnType: method.literal.ReturnType,
+	}
+	function.Body = callConstantMethod(function)
+	return gosrc.Method{
+		Function: function,
+		Receiver: gosrc.Param{Name: gosrc.SelfRef, Ty: gosrc.Type("*" + enumTypeName)},
+	}
+}
+
+// dispatchToConstant returns the statements that make the method function
+// of an enum call the implementation of the constant it is called on, if it
+// gives one
+func dispatchToConstant(function gosrc.Function) []gosrc.Statement {
+	return []gosrc.Statement{&gosrc.IfStatement{
+		Condition: &gosrc.GoExpression{Source: gosrc.SelfRef + "." + methodField(function.Name) + " != nil"},
+		Body:      callConstantMethod(function),
+	}}
+}
+
+// callConstantMethod returns the statements returning the result of the
+// implementation of the method function given by the constant it is called
+// on
+func callConstantMethod(function gosrc.Function) []gosrc.Statement {
+	args := []gosrc.Expression{&gosrc.VarRef{Ref: gosrc.SelfRef}}
+	for _, param := range function.Params {
+		arg := param.Name
+		if strings.HasPrefix(string(param.Ty), "...") {
+			arg += "..."
+		}
+		args = append(args, &gosrc.VarRef{Ref: arg})
+	}
+	call := &gosrc.CallExpression{Function: gosrc.SelfRef + "." + methodField(function.Name), Args: args}
+	if function.ReturnType == nil {
+		return []gosrc.Statement{&gosrc.CallStatement{Exp: call}, &gosrc.ReturnStatement{}}
+	}
+	return []gosrc.Statement{&gosrc.ReturnStatement{Value: call}}
+}
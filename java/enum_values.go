@@ -0,0 +1,172 @@
+package java
+
+import (
+	"fmt"
+	"go/token"
+	"strconv"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// nameMethodName is the name of the method generated for the name() of
+// simple enums that declare their own toString, which becomes String
+const nameMethodName = "Name"
+
+// enumValuesFunction returns the name of the function returning the
+// constants of the simple enum enumTypeName, which values() is migrated to
+func enumValuesFunction(enumTypeName string) string {
+	return enumTypeName + "Values"
+}
+
+// enumParseFunction returns the name of the function returning the constant
+// of the simple enum enumTypeName with a given name, which valueOf(name) is
+// migrated to
+func enumParseFunction(enumTypeName string) string {
+	return gosrc.ToIdentifier("parse"+gosrc.CapitalizeFirstLetter(enumTypeName), token.IsExported(enumTypeName))
+}
+
+// enumNameMethod returns the method name() of values of the simple enum
+// enumTypeName is migrated to: String, unless the enum declares its own
+// toString
+func (ctx *MigrationContext) enumNameMethod(enumTypeName string) string {
+	if ctx.EnumToString[enumTypeName] {
+		return nameMethodName
+	}
+	return toStringMethodName
+}
+
+// enumValues returns the function returning a new slice of the constants of
+// the simple enum enumTypeName in declaration order, as values() returns a
+// new array
+func enumValues(enumTypeName string, enumConstants []EnumConstant) gosrc.Function {
+	values := make([]gosrc.Expression, len(enumConstants))
+	for i, constant := range enumConstants {
+		values[i] = &gosrc.VarRef{Ref: enumTypeName + "_" + constant.name}
+	}
+	returnType := gosrc.Type("[]" + enumTypeName)
+	return gosrc.Function{
+		Name:       enumValuesFunction(enumTypeName),
+		ReturnType: &returnType,
+		Body: []gosrc.Statement{&gosrc.ReturnStatement{
+			Value: &gosrc.ArrayLiteral{ElementType: gosrc.Type(enumTypeName), Elements: values},
+		}},
+		Public: token.IsExported(enumTypeName),
+	}
+}
+
+// enumParse returns the function returning the constant of the simple enum
+// enumTypeName named name. Like valueOf, it panics if there is none.
+func enumParse(ctx *MigrationContext, enumTypeName string, enumConstants []EnumConstant) gosrc.Function {
+	var cases []gosrc.SwitchCase
+	for _, constant := range enumConstants {
+		cases = append(cases, gosrc.SwitchCase{
+			Condition: ctx.arena.GoExpression(gosrc.GoExpression{Source: strconv.Quote(constant.name)}),
+			Body: []gosrc.Statement{&gosrc.ReturnStatement{
+				Value: ctx.arena.VarRef(gosrc.VarRef{Ref: enumTypeName + "_" + constant.name}),
+			}},
+		})
+	}
+	ctx.Source.AddImport("fmt")
+	returnType := gosrc.Type(enumTypeName)
+	return gosrc.Function{
+		Name:       enumParseFunction(enumTypeName),
+		Params:     []gosrc.Param{{Name: "name", Ty: gosrc.TypeString}},
+		ReturnType: &returnType,
+		Body: []gosrc.Statement{
+			&gosrc.SwitchStatement{Condition: ctx.arena.VarRef(gosrc.VarRef{Ref: "name"}), Cases: cases},
+			&gosrc.GoStatement{Source: fmt.Sprintf(`panic(fmt.Sprintf("no enum constant %s.%%s", name))`, enumTypeName)},
+		},
+		Public: token.IsExported(enumTypeName),
+	}
+}
+
+// simpleEnum returns the Go name of the enum migrated to an integer type
+// that the type javaName refers to at node
+func (ctx *MigrationContext) simpleEnum(node *tree_sitter.Node, javaName string) (string, bool) {
+	name := javaName
+	if goName, ok := ctx.resolveTypeReference(node, javaName); ok {
+		name = goName
+	}
+	_, ok := ctx.EnumOrdinals[name]
+	return name, ok
+}
+
+// convertEnumStaticCall converts Status.values() and Status.valueOf(name) on
+// a simple enum to calls of the functions generated for them
+func convertEnumStaticCall(ctx *MigrationContext, expression *tree_sitter.Node, name string) (gosrc.Expression, []gosrc.Statement, bool) {
+	object := expression.ChildByFieldName("object")
+	argsNode := expression.ChildByFieldName("arguments")
+	if !isTypeQualifier(ctx, object) {
+		return nil, nil, false
+	}
+	enumTypeName, ok := ctx.simpleEnum(expression, ctx.nodeText(object))
+	if !ok {
+		return nil, nil, false
+	}
+	switch argCount := len(argumentNodes(argsNode)); {
+	case name == "values" && argCount == 0:
+		return &gosrc.CallExpression{Function: enumValuesFunction(enumTypeName)}, nil, true
+	case name == "valueOf" && argCount == 1:
+		args, initStmts := convertArguments(ctx, argsNode)
+		return &gosrc.CallExpression{Function: enumParseFunction(enumTypeName), Args: args}, initStmts, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// enumStaticCallType returns the type of the value Status.values() or
+// Status.valueOf(name) on a simple enum returns
+func enumStaticCallType(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Type, bool) {
+	object := expression.ChildByFieldName("object")
+	if !isTypeQualifier(ctx, object) {
+		return "", false
+	}
+	enumTypeName, ok := ctx.simpleEnum(expression, ctx.nodeText(object))
+	if !ok {
+		return "", false
+	}
+	switch name, argCount := ctx.nodeText(expression.ChildByFieldName("name")), len(argumentNodes(expression.ChildByFieldName("arguments"))); {
+	case name == "values" && argCount == 0:
+		return gosrc.Type("[]" + enumTypeName), true
+	case name == "valueOf" && argCount == 1:
+		return gosrc.Type(enumTypeName), true
+	default:
+		return "", false
+	}
+}
+
+// enumOfNameCall returns the simple enum whose name() the method invocation
+// expression calls, on its object or, when unqualified, on the enum declaring
+// it
+func enumOfNameCall(ctx *MigrationContext, expression *tree_sitter.Node) (string, bool) {
+	if len(argumentNodes(expression.ChildByFieldName("arguments"))) != 0 {
+		return "", false
+	}
+	var ty gosrc.Type
+	var ok bool
+	switch object := expression.ChildByFieldName("object"); object {
+	case nil:
+		ty, ok = selfType(ctx, expression)
+	default:
+		ty, ok = valueType(ctx, object)
+	}
+	if !ok {
+		return "", false
+	}
+	return ctx.simpleEnum(expression, string(ty))
+}
+
+// convertEnumNameCall converts value.name() on a value of a simple enum to a
+// call of the method returning the Java names of its constants
+func convertEnumNameCall(ctx *MigrationContext, expression *tree_sitter.Node, objectText string) (gosrc.Expression, bool) {
+	enumTypeName, ok := enumOfNameCall(ctx, expression)
+	if !ok {
+		return nil, false
+	}
+	if expression.ChildByFieldName("object") == nil {
+		objectText = gosrc.SelfRef
+	}
+	return &gosrc.CallExpression{Function: objectText + "." + ctx.enumNameMethod(enumTypeName)}, true
+}
@@ -0,0 +1,138 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// standardExceptions are the exceptions of the Java standard library. They
+// migrate to error values, while exceptions declared in the migrated code
+// keep their own types.
+var standardExceptions = map[string]bool{
+	"Throwable":                       true,
+	"Exception":                       true,
+	"Error":                           true,
+	"RuntimeException":                true,
+	"IllegalArgumentException":        true,
+	"IllegalStateException":           true,
+	"NullPointerException":            true,
+	"IndexOutOfBoundsException":       true,
+	"ArrayIndexOutOfBoundsException":  true,
+	"StringIndexOutOfBoundsException": true,
+	"UnsupportedOperationException":   true,
+	"ArithmeticException":             true,
+	"ClassCastException":              true,
+	"NumberFormatException":           true,
+	"NoSuchElementException":          true,
+	"ConcurrentModificationException": true,
+	"CloneNotSupportedException":      true,
+	"InterruptedException":            true,
+	"IOException":                     true,
+	"UncheckedIOException":            true,
+	"FileNotFoundException":           true,
+	"AssertionError":                  true,
+}
+
+// isThrowableType reports whether the Java type name names an exception
+func isThrowableType(name string) bool {
+	return standardExceptions[name] || strings.HasSuffix(name, "Exception") || strings.HasSuffix(name, "Error")
+}
+
+// isThrowable reports whether node evaluates to an exception
+func isThrowable(ctx *MigrationContext, node *tree_sitter.Node) bool {
+	switch nodeKind(node) {
+	case "identifier":
+		typeNode := declaredType(ctx, node, ctx.nodeText(node))
+		if typeNode == nil {
+			return false
+		}
+		// Multi-catch parameters are a union of exception types
+		for _, alternative := range strings.Split(ctx.nodeText(typeNode), "|") {
+			if !isThrowableType(strings.TrimSpace(alternative)) {
+				return false
+			}
+		}
+		return true
+	case "object_creation_expression":
+		return isThrowableType(ctx.nodeText(node.ChildByFieldName("type")))
+	case "method_invocation":
+		return ctx.nodeText(node.ChildByFieldName("name")) == "getCause"
+	case "parenthesized_expression":
+		return isThrowable(ctx, node.NamedChild(0))
+	default:
+		return false
+	}
+}
+
+// convertExceptionCreation converts the creation of a standard exception to
+// an error value. A cause is wrapped with %w so errors.Is, errors.As and
+// errors.Unwrap see it, the way getCause does in Java.
+func convertExceptionCreation(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	args := stringArguments(ctx, argNodes)
+	switch {
+	case len(args) == 0:
+		ctx.Source.AddImport("errors")
+		name := gosrc.GoExpression{Source: `"` + ctx.nodeText(expression.ChildByFieldName("type")) + `"`}
+		return &gosrc.CallExpression{Function: "errors.New", Args: []gosrc.Expression{&name}}, nil
+	case len(args) == 1 && isThrowable(ctx, argNodes[0]):
+		return errorf(ctx, "%w", args[0]), nil
+	case len(args) == 1:
+		if call, ok := args[0].(*gosrc.CallExpression); ok && call.Function == "fmt.Sprintf" {
+			// The message is already formatted
+			return &gosrc.CallExpression{Function: "fmt.Errorf", Args: call.Args}, nil
+		}
+		ctx.Source.AddImport("errors")
+		return &gosrc.CallExpression{Function: "errors.New", Args: args}, nil
+	case len(args) == 2 && nodeKind(argNodes[0]) == "string_literal" && !strings.HasPrefix(ctx.nodeText(argNodes[0]), `"""`):
+		message := args[0].ToSource()
+		return errorf(ctx, strings.ReplaceAll(message[1:len(message)-1], "%", "%%")+": %w", args[1]), nil
+	case len(args) == 2:
+		if call, ok := args[0].(*gosrc.CallExpression); ok && call.Function == "fmt.Sprintf" {
+			format := call.Args[0].ToSource()
+			format = format[:len(format)-1] + `: %w"`
+			return errorf(ctx, format, append(call.Args[1:], args[1])...), nil
+		}
+		return errorf(ctx, "%s: %w", args...), nil
+	default:
+		// Exceptions with more arguments control stack traces, which Go
+		// errors don't have
+		return errorf(ctx, "%s: %w", args[:2]...), nil
+	}
+}
+
+// errorf returns a call of fmt.Errorf with the format string literal format
+func errorf(ctx *MigrationContext, format string, args ...gosrc.Expression) gosrc.Expression {
+	ctx.Source.AddImport("fmt")
+	if !strings.HasPrefix(format, `"`) {
+		format = `"` + format + `"`
+	}
+	formatArg := gosrc.GoExpression{Source: format}
+	return &gosrc.CallExpression{Function: "fmt.Errorf", Args: append([]gosrc.Expression{&formatArg}, args...)}
+}
+
+// convertExceptionMethod converts a call of a Throwable method on receiver,
+// the Go source of an error. It returns false for methods without a
+// translation.
+func convertExceptionMethod(ctx *MigrationContext, expression *tree_sitter.Node, name, receiver string) (gosrc.Expression, bool) {
+	if expression.ChildByFieldName("arguments").NamedChildCount() != 0 {
+		return nil, false
+	}
+	self := &gosrc.VarRef{Ref: receiver}
+	switch name {
+	case "getMessage", "getLocalizedMessage", "toString":
+		return &gosrc.CallExpression{Function: receiver + ".Error"}, true
+	case "getCause":
+		ctx.Source.AddImport("errors")
+		return &gosrc.CallExpression{Function: "errors.Unwrap", Args: []gosrc.Expression{self}}, true
+	case "printStackTrace":
+		ctx.Source.AddImport("fmt")
+		ctx.Source.AddImport("os")
+		return &gosrc.CallExpression{Function: "fmt.Fprintln", Args: []gosrc.Expression{&gosrc.VarRef{Ref: "os.Stderr"}, self}}, true
+	default:
+		return nil, false
+	}
+}
@@ -1,7 +1,10 @@
 package java
 
 import (
+	"cmp"
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -11,9 +14,20 @@ import (
 )
 
 func convertArgumentList(ctx *MigrationContext, argList *tree_sitter.Node) []gosrc.Expression {
+	args, initStmts := convertArguments(ctx, argList)
+	if len(initStmts) > 0 {
+		FatalError(ctx, argList, "unexpected statements in argument list expression", "argument_list")
+	}
+	return args
+}
+
+// convertArguments converts the arguments of a call along with the statements
+// that must run before it, such as those computing the value of a ternary
+func convertArguments(ctx *MigrationContext, argList *tree_sitter.Node) ([]gosrc.Expression, []gosrc.Statement) {
 	var args []gosrc.Expression
+	var initStmts []gosrc.Statement
 	IterateChildren(argList, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		// ignored
 		case "(":
 		case ")":
@@ -22,23 +36,27 @@ func convertArgumentList(ctx *MigrationContext, argList *tree_sitter.Node) []gos
 		case "block_comment":
 		default:
 			exp, init := convertExpression(ctx, child)
-			if len(init) > 0 {
-				FatalError(ctx, child, "unexpected statements in argument list expression", "argument_list")
-			}
+			initStmts = append(initStmts, init...)
 			args = append(args, exp)
 		}
 	})
-	return args
+	return args, initStmts
 }
 
-func convertArrayInitializer(ctx *MigrationContext, initNode *tree_sitter.Node) []gosrc.Expression {
+// convertArrayInitializer converts an array_initializer of the slice type
+// ty. Nested initializers, as in {{1, 2}, {3}}, have no type of their own, so
+// they are converted with the element type of ty.
+func convertArrayInitializer(ctx *MigrationContext, initNode *tree_sitter.Node, ty gosrc.Type) *gosrc.ArrayLiteral {
+	elementType := gosrc.Type(strings.TrimPrefix(string(ty), "[]"))
 	var elements []gosrc.Expression
 	IterateChildren(initNode, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "{", "}", ",":
 			// Structural tokens - ignore
 		case "line_comment":
 		case "block_comment":
+		case "array_initializer":
+			elements = append(elements, convertArrayInitializer(ctx, child, elementType))
 		default:
 			// Any other node is an element expression
 			exp, init := convertExpression(ctx, child)
@@ -48,7 +66,7 @@ func convertArrayInitializer(ctx *MigrationContext, initNode *tree_sitter.Node)
 			elements = append(elements, exp)
 		}
 	})
-	return elements
+	return &gosrc.ArrayLiteral{ElementType: ty, Elements: elements}
 }
 
 func convertAssignmentExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
@@ -59,15 +77,16 @@ func convertAssignmentExpression(ctx *MigrationContext, expression *tree_sitter.
 	// Check if this is a compound assignment by looking for operators like |=, &=, etc.
 	var operator string
 	IterateChildren(expression, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "|=", "&=", "^=", "<<=", ">>=", "+=", "-=", "*=", "/=", "%=":
-			operator = child.Utf8Text(ctx.JavaSource)
+			operator = ctx.nodeText(child)
 		}
 	})
 
 	leftExp, leftInit := convertExpression(ctx, refNode)
 	rightExp, rightInit := convertExpression(ctx, valueNode)
 	stmts := append(leftInit, rightInit...)
+	targetTy, hasTargetTy := staticType(ctx, refNode)
 	var valueExp gosrc.Expression
 	if operator != "" {
 		// This is a compound assignment: x op= y -> x = x op y
@@ -80,21 +99,45 @@ func convertAssignmentExpression(ctx *MigrationContext, expression *tree_sitter.
 			baseOp = ">>"
 		}
 
-		valueExp = &gosrc.BinaryExpression{
-			Left:     leftExp,
-			Operator: baseOp,
-			Right:    rightExp,
-		}
+		valueExp = convertCompoundValue(ctx, valueNode, leftExp, baseOp, rightExp, targetTy)
 	} else {
 		// Regular assignment
 		valueExp = rightExp
+		if hasTargetTy {
+			valueExp = coerceNumeric(ctx, valueNode, rightExp, targetTy)
+		}
 	}
 
+	// Go assignments are statements, so the assignment is lifted into the
+	// init statements and its value is the assigned variable
+	ref := gosrc.VarRef{Ref: leftExp.ToSource()}
 	stmts = append(stmts, &gosrc.AssignStatement{
-		Ref:   gosrc.VarRef{Ref: leftExp.ToSource()},
+		Ref:   ref,
 		Value: valueExp,
 	})
-	return nil, stmts
+	return ctx.arena.VarRef(ref), stmts
+}
+
+// convertCompoundValue returns the value x op y assigned by x op= y, where x
+// is of type targetTy if it is known. Java computes x op y in the promoted
+// type of x and y and narrows the result back to the type of x.
+func convertCompoundValue(ctx *MigrationContext, valueNode *tree_sitter.Node, left gosrc.Expression, operator string, right gosrc.Expression, targetTy gosrc.Type) gosrc.Expression {
+	valueTy, ok := valueType(ctx, valueNode)
+	promoted, isNumeric := promotedType(targetTy, valueTy)
+	switch {
+	case !ok || !isNumeric || operator == "<<" || operator == ">>":
+	case promoted == targetTy:
+		right = coerceNumeric(ctx, valueNode, right, targetTy)
+		return arithmeticExpression(ctx, left, operator, right, targetTy)
+	default:
+		widened := arithmeticExpression(ctx, &gosrc.CastExpression{Ty: promoted, Value: left}, operator, right, promoted)
+		return &gosrc.CastExpression{Ty: targetTy, Value: widened}
+	}
+	return ctx.arena.BinaryExpression(gosrc.BinaryExpression{
+		Left:     left,
+		Operator: operator,
+		Right:    right,
+	})
 }
 
 func convertArrayCreationExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
@@ -104,32 +147,27 @@ func convertArrayCreationExpression(ctx *MigrationContext, expression *tree_sitt
 		FatalError(ctx, typeNode, "unable to parse type in array_creation_expression", "array_creation_expression")
 	}
 
-	// Check for dimensions to make it an array type
-	dimensionsNode := expression.ChildByFieldName("dimensions")
-	if dimensionsNode != nil {
-		// Add [] prefix to make it an array type
-		ty = gosrc.Type("[]" + ty.ToSource())
-	}
-
 	valueNode := expression.ChildByFieldName("value")
 	if valueNode == nil {
 		// No initializer: return nil
-		return &gosrc.GoExpression{Source: "nil"}, nil
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: "nil"}), nil
 	}
 
-	// Has initializer: new gosrc.Type[] { ... }
-	elements := convertArrayInitializer(ctx, valueNode)
-	return &gosrc.ArrayLiteral{
-		ElementType: ty,
-		Elements:    elements,
-	}, nil
+	// Has initializer: new gosrc.Type[][] { ... }, with a [] prefix for every
+	// dimension
+	dimensions := 0
+	for _, dimensionsNode := range expression.ChildrenByFieldName("dimensions", expression.Walk()) {
+		dimensions += strings.Count(ctx.nodeText(&dimensionsNode), "[")
+	}
+	ty = gosrc.Type(strings.Repeat("[]", max(dimensions, 1)) + ty.ToSource())
+	return convertArrayInitializer(ctx, valueNode, ty), nil
 }
 
-func handleFailedToFindConstructor(ty gosrc.Type) (gosrc.Expression, []gosrc.Statement) {
+func handleFailedToFindConstructor(qualifier string, ty gosrc.Type) (gosrc.Expression, []gosrc.Statement) {
 	// Generate no-args constructor name
 	// Assume constructor is always public: NewTypeName()
 	typeName := ty.ToSource()
-	constructorName := "New" + gosrc.CapitalizeFirstLetter(typeName)
+	constructorName := qualifier + "New" + gosrc.CapitalizeFirstLetter(typeName)
 
 	// Call the no-args constructor with a FIXME comment
 	comment := fmt.Sprintf("FIXME: failed to find constructor for %s", ty)
@@ -149,7 +187,7 @@ func extractTypeArguments(ctx *MigrationContext, expression *tree_sitter.Node) [
 	typeArgsNode := expression.ChildByFieldName("type").ChildByFieldName("type_arguments")
 	if typeArgsNode != nil {
 		IterateChildren(typeArgsNode, func(child *tree_sitter.Node) {
-			switch child.Kind() {
+			switch nodeKind(child) {
 			case "type_identifier":
 				childTy, ok := TryParseType(ctx, child)
 				if ok {
@@ -162,6 +200,12 @@ func extractTypeArguments(ctx *MigrationContext, expression *tree_sitter.Node) [
 			}
 		})
 	}
+	if len(types) == 0 {
+		// The diamond operator takes the type arguments of the declaration
+		for _, ty := range creationTypeArguments(ctx, expression, expression.ChildByFieldName("type")) {
+			types = append(types, string(ty))
+		}
+	}
 	return types
 }
 
@@ -175,9 +219,9 @@ func convertArrayListCreationExpression(ctx *MigrationContext, expression *tree_
 	}
 
 	// Convert to Go slice: make([]Type, 0)
-	return &gosrc.GoExpression{
+	return ctx.arena.GoExpression(gosrc.GoExpression{
 		Source: fmt.Sprintf("make([]%s, 0)", elementType),
-	}, nil
+	}), nil
 }
 
 // TODO: ai slop revist this later
@@ -189,10 +233,7 @@ func convertHashSetCreationExpression(ctx *MigrationContext, expression *tree_si
 		elementType = types[0]
 	}
 
-	// Convert to Go map with bool values: make(map[Type]bool)
-	return &gosrc.GoExpression{
-		Source: fmt.Sprintf("make(map[%s]bool)", elementType),
-	}, nil
+	return convertSetCreation(ctx, expression, elementType)
 }
 
 // TODO: ai slop revist this later
@@ -209,24 +250,38 @@ func convertHashMapCreationExpression(ctx *MigrationContext, expression *tree_si
 	}
 
 	// Convert to Go map: make(map[keyType]valueType)
-	return &gosrc.GoExpression{
+	return ctx.arena.GoExpression(gosrc.GoExpression{
 		Source: fmt.Sprintf("make(map[%s]%s)", keyType, valueType),
-	}, nil
+	}), nil
 }
 
 func convertObjectCreationExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	if body := anonymousClassBody(expression); body != nil {
+		return convertAnonymousClass(ctx, expression, body)
+	}
 	ty, isType := TryParseType(ctx, expression.ChildByFieldName("type"))
 	if !isType {
 		FatalError(ctx, expression.ChildByFieldName("type"), "unable to parse type in object_creation_expression", "object_creation_expression")
 	}
+	if class := ctx.nodeText(baseTypeNode(expression.ChildByFieldName("type"))); class == "ArrayList" || dequeClasses[class] {
+		// Diamonds take the element type of the declaration
+		return convertArrayListCreationExpression(ctx, expression)
+	}
 	if ty.IsArray() {
-		return &gosrc.GoExpression{
+		return ctx.arena.GoExpression(gosrc.GoExpression{
 			Source: fmt.Sprintf("make(%s, 0)", ty),
-		}, nil
+		}), nil
+	}
+
+	if ty == stringBuilderType {
+		return convertStringBuilderCreation(ctx, expression)
 	}
 
 	// Check for ArrayList creation: new ArrayList<>() or new ArrayList<Type>()
-	typeText := expression.ChildByFieldName("type").Utf8Text(ctx.JavaSource)
+	typeText := ctx.nodeText(expression.ChildByFieldName("type"))
+	if standardExceptions[typeText] {
+		return convertExceptionCreation(ctx, expression)
+	}
 	if strings.Contains(typeText, "ArrayList") {
 		return convertArrayListCreationExpression(ctx, expression)
 	}
@@ -242,82 +297,131 @@ func convertObjectCreationExpression(ctx *MigrationContext, expression *tree_sit
 		return convertHashSetCreationExpression(ctx, expression)
 	}
 
-	// Check for HashMap creation: new HashMap<>() or new HashMap<K, V>()
-	if strings.Contains(typeText, "HashMap") {
+	// Check for HashMap creation: new HashMap<>() or new HashMap<K, V>(),
+	// and EnumMap creation, whose argument is the class of the keys
+	if strings.Contains(typeText, "HashMap") || strings.Contains(typeText, "EnumMap") {
 		return convertHashMapCreationExpression(ctx, expression)
 	}
 
 	// Get arguments from the object creation expression
 	argsNode := expression.ChildByFieldName("arguments")
 	var args []gosrc.Expression
+	var initStmts []gosrc.Statement
 	if argsNode != nil {
-		args = convertArgumentList(ctx, argsNode)
+		args, initStmts = convertArguments(ctx, argsNode)
 	}
 
+	// Constructors are registered under the struct name, and return pointers
+	ty = structType(ty)
+	// Generic classes are registered under their plain name
+	var typeArgs []gosrc.Type
+	if typeNode := expression.ChildByFieldName("type"); nodeKind(typeNode) == "generic_type" {
+		baseTy, _, _ := strings.Cut(string(ty), "[")
+		ty = gosrc.Type(baseTy)
+		typeArgs = creationTypeArguments(ctx, expression, typeNode)
+	}
+	// Types imported from other Go packages are built by the constructors
+	// of those packages
+	qualifier, ty := ctx.splitImportedType(ty)
+
 	// Look up constructors for this type
-	// Try with the type as-is first, then try with lowercase first letter (for non-public classes)
 	constructors, hasConstructors := ctx.Constructors[ty]
-	if !hasConstructors {
-		// Try with lowercase first letter for non-public classes
-		lowercaseTy := gosrc.Type(gosrc.LowercaseFirstLetter(string(ty)))
-		constructors, hasConstructors = ctx.Constructors[lowercaseTy]
-	}
 	if !hasConstructors {
 		// No constructors registered for this type
-		return handleFailedToFindConstructor(ty)
+		return handleFailedToFindConstructor(qualifier, ty)
 	}
 
 	// Try to find matching constructor by parameter count
-	constructorName, found, multipleMatch := tryGuessOverloadedMethod(constructors, len(args))
+	constructorName, found, multipleMatch := resolveOverload(ctx, withoutFactories(constructors), argsNode)
 
 	if !found {
 		// No constructor with matching number of parameters
-		return handleFailedToFindConstructor(ty)
+		return handleFailedToFindConstructor(qualifier, ty)
+	}
+
+	if !multipleMatch {
+		paramTypes := argumentTypes(constructors, constructorName)
+		args = passArrayArguments(ctx, argsNode, coerceArguments(ctx, argsNode, args, paramTypes), paramTypes)
+	}
+
+	if outerType, ok := ctx.innerClassOuterType(ty); ok {
+		// Inner classes are constructed with the instance they belong to
+		outer, outerInit := outerInstanceArgument(ctx, expression, outerType)
+		args = append([]gosrc.Expression{outer}, args...)
+		initStmts = append(outerInit, initStmts...)
 	}
 
 	// Generate constructor call
 	callExpr := &gosrc.CallExpression{
-		Function: constructorName,
+		Function: qualifier + constructorName,
+		TypeArgs: typeArgs,
 		Args:     args,
 	}
 
 	if multipleMatch {
 		// Multiple constructors match - add FIXME comment as init statement
 		comment := fmt.Sprintf("FIXME: more than one possible constructor for %s", ty)
-		return callExpr, []gosrc.Statement{
-			&gosrc.CommentStmt{Comments: []string{comment}},
-		}
+		return callExpr, append(initStmts, &gosrc.CommentStmt{Comments: []string{comment}})
 	}
 
 	// Exactly one constructor matches - return clean call
-	return callExpr, nil
+	return callExpr, initStmts
+}
+
+// creationTypeArguments returns the type arguments a generic class is
+// instantiated with by new Foo<Bar>(), or by new Foo<>() in the declaration
+// of a variable of type Foo<Bar>. Go can't infer type arguments from the
+// declared type, so they are always passed explicitly.
+func creationTypeArguments(ctx *MigrationContext, expression, typeNode *tree_sitter.Node) []gosrc.Type {
+	var typeArgsNode *tree_sitter.Node
+	IterateChildren(typeNode, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "type_arguments" {
+			typeArgsNode = child
+		}
+	})
+	if typeArgsNode == nil {
+		return nil
+	}
+	if typeArgs := parseTypeArguments(ctx, typeArgsNode); len(typeArgs) > 0 {
+		return typeArgs
+	}
+	// The diamond operator takes the type arguments of the declaration
+	declarator := expression.Parent()
+	if nodeKind(declarator) != "variable_declarator" {
+		return nil
+	}
+	declaredType := declarator.Parent().ChildByFieldName("type")
+	if declaredType == nil || nodeKind(declaredType) != "generic_type" {
+		return nil
+	}
+	var typeArgs []gosrc.Type
+	IterateChildren(declaredType, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "type_arguments" {
+			typeArgs = parseTypeArguments(ctx, child)
+		}
+	})
+	return typeArgs
 }
 
 func convertIdentifier(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
-	identName := expression.Utf8Text(ctx.JavaSource)
+	identName := ctx.nodeText(expression)
 	// Check if this is an enum constant reference
 	if prefixedName, ok := ctx.EnumConstants[identName]; ok {
-		return &gosrc.VarRef{
+		return ctx.arena.VarRef(gosrc.VarRef{
 			Ref: prefixedName,
-		}, nil
+		}), nil
 	}
-	return &gosrc.VarRef{
-		Ref: identName,
-	}, nil
-}
-
-func convertInstanceofExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
-	valueNode := expression.ChildByFieldName("left")
-	valueExp, initStmts := convertExpression(ctx, valueNode)
-	Assert("condition expression is expected to be simple", len(initStmts) == 0)
-	typeNode := expression.ChildByFieldName("right")
-	ty, ok := TryParseType(ctx, typeNode)
-	if !ok {
-		FatalError(ctx, typeNode, "unable to parse type in instanceof_expression", "instanceof_expression")
+	// Locals captured by an anonymous class
+	if ref, ok := capturedReference(ctx, expression, identName); ok {
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: ref}), nil
 	}
-	return &gosrc.GoExpression{
-		Source: fmt.Sprintf("%s.(%s)", valueExp.ToSource(), ty.ToSource()),
-	}, nil
+	// Fields of this, or of the enclosing instance of an inner class
+	if ref, ok := implicitFieldReference(ctx, expression, identName); ok {
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: ref}), nil
+	}
+	return ctx.arena.VarRef(gosrc.VarRef{
+		Ref: identName,
+	}), nil
 }
 
 func convertCastExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
@@ -328,6 +432,12 @@ func convertCastExpression(ctx *MigrationContext, expression *tree_sitter.Node)
 	}
 	valueNode := expression.ChildByFieldName("value")
 	valueExp, initStmts := convertExpression(ctx, valueNode)
+	if valueTy, ok := valueType(ctx, valueNode); ok && valueTy == gosrc.TypeFloat64 && nodeKind(typeNode) == "integral_type" && isGoConstant(ctx, valueNode) {
+		// Java truncates the fraction, while Go rejects converting a
+		// constant that has one, so the value is truncated at run time
+		ctx.Source.AddImport("math")
+		valueExp = &gosrc.CallExpression{Function: "math.Trunc", Args: []gosrc.Expression{valueExp}}
+	}
 	return &gosrc.CastExpression{
 		Ty:    ty,
 		Value: valueExp,
@@ -339,9 +449,9 @@ func convertUnaryExpression(ctx *MigrationContext, expression *tree_sitter.Node)
 	operand, initStmts := convertExpression(ctx, operandNode)
 	var operator string
 	IterateChildren(expression, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "!", "+", "-", "~":
-			operator = child.Utf8Text(ctx.JavaSource)
+			operator = ctx.nodeText(child)
 		}
 	})
 	Assert("unary expression operator not found", operator != "")
@@ -358,24 +468,24 @@ func convertMethodReference(ctx *MigrationContext, expression *tree_sitter.Node)
 	methodNode := expression.ChildByFieldName("method")
 
 	if objectNode != nil && methodNode != nil {
-		objectText := objectNode.Utf8Text(ctx.JavaSource)
-		methodText := methodNode.Utf8Text(ctx.JavaSource)
+		objectText := ctx.nodeText(objectNode)
+		methodText := ctx.nodeText(methodNode)
 
 		// Check if this is an array constructor: gosrc.Type[]::new
 		if methodText == "new" && strings.HasSuffix(objectText, "[]") {
 			// Extract the element type
 			elementType := strings.TrimSuffix(objectText, "[]")
 			// Convert to Go: make([]gosrc.Type, 0)
-			return &gosrc.GoExpression{
+			return ctx.arena.GoExpression(gosrc.GoExpression{
 				Source: fmt.Sprintf("make([]%s, 0)", elementType),
-			}, nil
+			}), nil
 		}
 	}
 
 	// Fallback: return as-is (may need more sophisticated handling)
-	return &gosrc.GoExpression{
-		Source: expression.Utf8Text(ctx.JavaSource),
-	}, nil
+	return ctx.arena.GoExpression(gosrc.GoExpression{
+		Source: ctx.nodeText(expression),
+	}), nil
 }
 
 func convertFieldAccess(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
@@ -383,30 +493,99 @@ func convertFieldAccess(ctx *MigrationContext, expression *tree_sitter.Node) (go
 	field := expression.ChildByFieldName("field")
 
 	if object != nil && field != nil {
-		objectText := object.Utf8Text(ctx.JavaSource)
-		fieldText := field.Utf8Text(ctx.JavaSource)
+		if nodeKind(field) == "this" {
+			ref, _ := convertQualifiedThis(ctx, expression)
+			return ctx.arena.VarRef(gosrc.VarRef{Ref: ref}), nil
+		}
+		fieldText := ctx.nodeText(field)
+		if isQualifiedThis(object) {
+			// Outer.this.field is a field of an enclosing instance
+			ref, outerType := convertQualifiedThis(ctx, object)
+			if field, ok := ctx.Fields[outerType][fieldText]; ok {
+				fieldText = field.Name
+			}
+			return ctx.arena.VarRef(gosrc.VarRef{Ref: ref + "." + fieldText}), nil
+		}
+		if fieldText == "length" {
+			if converted, initStmts, ok := convertArrayLength(ctx, object); ok {
+				return converted, initStmts
+			}
+		}
+		if containsInvocation(object) {
+			// a.getB().c: the receiver is a converted call, not a type name
+			objectText, initStmts := convertReceiver(ctx, object)
+			return ctx.arena.VarRef(gosrc.VarRef{Ref: objectText + "." + fieldText}), initStmts
+		}
+		objectText := ctx.nodeText(object)
 
+		// Outer.Inner refers to a nested type, which is hoisted to the top level
+		if goName, ok := ctx.resolveTypeReference(expression, ctx.nodeText(expression)); ok {
+			return ctx.arena.VarRef(gosrc.VarRef{Ref: goName}), nil
+		}
+		// Static fields of classes of the file are module variables
+		if nodeKind(object) == "identifier" && ctx.isStaticField(expression, objectText, fieldText) {
+			return ctx.arena.VarRef(gosrc.VarRef{Ref: fieldText}), nil
+		}
+		// Outer.Inner.CONSTANT is a constant of a nested enum
+		if goName, ok := ctx.resolveTypeReference(expression, objectText); ok {
+			return ctx.arena.VarRef(gosrc.VarRef{Ref: goName + "_" + fieldText}), nil
+		}
+		// Fields of this, or of a parameter or local of a migrated class
+		// type, use the Go name of the field, which may have been exported
+		if nodeKind(object) == "this" {
+			if field, ok := enclosingField(ctx, expression, fieldText); ok {
+				return ctx.arena.VarRef(gosrc.VarRef{Ref: gosrc.SelfRef + "." + field.Name}), nil
+			}
+		}
+		if field, ok := fieldOfStaticType(ctx, object, fieldText); ok {
+			if nodeKind(object) == "field_access" {
+				objectExpr, _ := convertFieldAccess(ctx, object)
+				objectText = objectExpr.ToSource()
+			}
+			return ctx.arena.VarRef(gosrc.VarRef{Ref: objectText + "." + field.Name}), nil
+		}
 		// Check if this looks like an enum constant (object is type name, field is uppercase)
 		// Heuristic: if object starts with uppercase, it's likely a type/enum reference
 		if len(objectText) > 0 && objectText[0] >= 'A' && objectText[0] <= 'Z' {
 			// Enum constant: Foo.BAR → Foo_BAR
-			return &gosrc.VarRef{
+			return ctx.arena.VarRef(gosrc.VarRef{
 				Ref: objectText + "_" + fieldText,
-			}, nil
+			}), nil
 		}
 		// Regular field access: keep dot notation
-		return &gosrc.VarRef{
+		return ctx.arena.VarRef(gosrc.VarRef{
 			Ref: objectText + "." + fieldText,
-		}, nil
+		}), nil
 	}
 
 	// Fallback to original text
-	return &gosrc.VarRef{
-		Ref: expression.Utf8Text(ctx.JavaSource),
-	}, nil
+	return ctx.arena.VarRef(gosrc.VarRef{
+		Ref: ctx.nodeText(expression),
+	}), nil
+}
+
+// convertArrayLength converts array.length to the length of the slice the
+// array migrates to, dereferencing array parameters
+func convertArrayLength(ctx *MigrationContext, array *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement, bool) {
+	if arrayArgumentKind(ctx, array) == notArray {
+		if ty, ok := valueType(ctx, array); !ok || !IsArrayOrSliceType(ty) {
+			return nil, nil, false
+		}
+	}
+	converted, initStmts := convertExpression(ctx, array)
+	return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("len(%s)", listSource(ctx, array, converted))}), initStmts, true
 }
 
 func convertBinaryExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	if operands, ok := stringConcatOperands(ctx, expression); ok && !isStringConversion(ctx, operands) {
+		return convertStringConcat(ctx, operands)
+	}
+	if converted, initStmts, ok := nullMapComparison(ctx, expression); ok {
+		return converted, initStmts
+	}
+	if converted, initStmts, ok := nullStringComparison(ctx, expression); ok {
+		return converted, initStmts
+	}
 	leftNode := expression.ChildByFieldName("left")
 	left, leftInit := convertExpression(ctx, leftNode)
 	rightNode := expression.ChildByFieldName("right")
@@ -414,35 +593,244 @@ func convertBinaryExpression(ctx *MigrationContext, expression *tree_sitter.Node
 	stms := append(leftInit, rightInit...)
 	var operator string
 	IterateChildren(expression, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "||", "&&", "==", "!=", "<", "<=", ">", ">=", "+", "-", "*", "/", "%":
-			operator = child.Utf8Text(ctx.JavaSource)
+			operator = ctx.nodeText(child)
 		case "<<", ">>", ">>>":
 			// Bit shift operators
-			operator = child.Utf8Text(ctx.JavaSource)
+			operator = ctx.nodeText(child)
 			// Go uses >> for both signed and unsigned right shift
 			if operator == ">>>" {
 				operator = ">>"
 			}
 		case "|", "&", "^":
 			// Bitwise operators
-			operator = child.Utf8Text(ctx.JavaSource)
+			operator = ctx.nodeText(child)
 		}
 	})
 	Assert("binary expression operator not found", operator != "")
-	return &gosrc.BinaryExpression{
+	if (operator == "&&" || operator == "||") && len(rightInit) > 0 {
+		return shortCircuit(ctx, left, operator, rigth, leftInit, rightInit)
+	}
+	if operator == "==" || operator == "!=" {
+		if note, ok := stringIdentityNote(ctx, expression); ok {
+			stms = append(stms, note)
+		}
+	}
+	// "" + x is the Java idiom for converting x to a string
+	switch {
+	case operator == "+" && isEmptyStringLiteral(ctx, leftNode):
+		return stringConversion(ctx, rigth), stms
+	case operator == "+" && isEmptyStringLiteral(ctx, rightNode):
+		return stringConversion(ctx, left), stms
+	case operator == "%":
+		ty, _ := valueType(ctx, expression)
+		left, rigth = coerceBinaryOperands(ctx, expression, left, rigth)
+		return arithmeticExpression(ctx, left, operator, rigth, ty), stms
+	case operator != "<<" && operator != ">>" && operator != "&&" && operator != "||":
+		// Go has no implicit numeric conversions
+		left, rigth = coerceBinaryOperands(ctx, expression, left, rigth)
+	}
+	return ctx.arena.BinaryExpression(gosrc.BinaryExpression{
 		Left:     left,
 		Operator: operator,
 		Right:    rigth,
-	}, stms
+	}), stms
+}
+
+// shortCircuit converts left && right or left || right whose right operand
+// needs statements. They are placed in an if taken only when Java evaluates
+// the right operand, which sets a variable holding the value of the
+// expression.
+func shortCircuit(ctx *MigrationContext, left gosrc.Expression, operator string, right gosrc.Expression, leftInit, rightInit []gosrc.Statement) (gosrc.Expression, []gosrc.Statement) {
+	result := ctx.tempName("cond")
+	condition := result
+	if operator == "||" {
+		condition = "!" + result
+	}
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: result}), append(leftInit,
+		&gosrc.VarDeclaration{Name: result, Value: left},
+		&gosrc.IfStatement{
+			Condition: &gosrc.VarRef{Ref: condition},
+			Body:      append(rightInit, &gosrc.AssignStatement{Ref: gosrc.VarRef{Ref: result}, Value: right}),
+		})
+}
+
+// isStringConversion reports whether operands are those of "" + x or x + ""
+func isStringConversion(ctx *MigrationContext, operands []*tree_sitter.Node) bool {
+	return len(operands) == 2 && (isEmptyStringLiteral(ctx, operands[0]) || isEmptyStringLiteral(ctx, operands[1]))
+}
+
+func isEmptyStringLiteral(ctx *MigrationContext, node *tree_sitter.Node) bool {
+	return nodeKind(node) == "string_literal" && ctx.nodeText(node) == `""`
 }
 
 func convertMethodInvocation(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
-	name := expression.ChildByFieldName("name").Utf8Text(ctx.JavaSource)
+	if expression.ChildByFieldName("object") == nil {
+		name := ctx.nodeText(expression.ChildByFieldName("name"))
+		// Methods of the enclosing instance of an inner class
+		if receiver, ok := outerMethodReceiver(ctx, expression, name); ok {
+			return convertMethodCall(ctx, expression, name, receiver)
+		}
+	}
+	// Stream pipelines are lowered as a whole, before any of their
+	// operations is converted on its own
+	if pipeline, ok := findStreamPipeline(ctx, expression); ok {
+		return convertStreamPipeline(ctx, pipeline)
+	}
+	objectText, receiverInit := convertReceiver(ctx, expression.ChildByFieldName("object"))
+	converted, initStmts := convertMethodInvocationOn(ctx, expression, objectText)
+	return converted, append(receiverInit, initStmts...)
+}
+
+// convertReceiver returns the Go source of the object a method or field is
+// accessed on. Receivers that are themselves calls, as in a.getB().getC(), are
+// converted link by link so every call in the chain is resolved like a
+// standalone one; anything else keeps its Java spelling for the callers to
+// resolve.
+func convertReceiver(ctx *MigrationContext, objectNode *tree_sitter.Node) (string, []gosrc.Statement) {
+	switch {
+	case objectNode == nil:
+		return "", nil
+	case isQualifiedThis(objectNode):
+		ref, _ := convertQualifiedThis(ctx, objectNode)
+		return ref, nil
+	case containsInvocation(objectNode):
+		converted, initStmts := convertExpression(ctx, objectNode)
+		return converted.ToSource(), initStmts
+	case nodeKind(objectNode) == "identifier":
+		if ref, ok := capturedReference(ctx, objectNode, ctx.nodeText(objectNode)); ok {
+			return ref, nil
+		}
+		if ref, ok := implicitFieldReference(ctx, objectNode, ctx.nodeText(objectNode)); ok {
+			return ref, nil
+		}
+		return ctx.nodeText(objectNode), nil
+	default:
+		return ctx.nodeText(objectNode), nil
+	}
+}
+
+// containsInvocation reports whether the receiver chain ending at node has a
+// method invocation in it
+func containsInvocation(node *tree_sitter.Node) bool {
+	switch nodeKind(node) {
+	case "method_invocation":
+		return true
+	case "field_access":
+		return containsInvocation(node.ChildByFieldName("object"))
+	case "array_access":
+		return containsInvocation(node.ChildByFieldName("array"))
+	case "parenthesized_expression":
+		return node.NamedChildCount() == 1 && containsInvocation(node.NamedChild(0))
+	default:
+		return false
+	}
+}
+
+// convertMethodInvocationOn converts a method invocation whose receiver has
+// already been converted to objectText
+func convertMethodInvocationOn(ctx *MigrationContext, expression *tree_sitter.Node, objectText string) (gosrc.Expression, []gosrc.Statement) {
+	name := ctx.nodeText(expression.ChildByFieldName("name"))
 	objectNode := expression.ChildByFieldName("object")
-	objectText := ""
-	if objectNode != nil {
-		objectText = objectNode.Utf8Text(ctx.JavaSource)
+	if converted, ok := convertMapEntryMethod(ctx, name, objectText); ok {
+		return converted, nil
+	}
+	switch {
+	case objectNode != nil && isFunctionCall(ctx, objectNode, name):
+		return convertFunctionCall(ctx, expression, objectText)
+	case name == "ordinal":
+		if _, _, ok := ordinalReceiver(ctx, expression); ok {
+			return convertOrdinalCall(ctx, expression, objectText), nil
+		}
+	case name == "name":
+		if converted, ok := convertEnumNameCall(ctx, expression, objectText); ok {
+			return converted, nil
+		}
+	case name == "values" || name == "valueOf":
+		if converted, initStmts, ok := convertEnumStaticCall(ctx, expression, name); ok {
+			return converted, initStmts
+		}
+	case name == "iterator" && objectNode != nil:
+		if converted, ok := convertCollectionIterator(ctx, expression, objectText); ok {
+			return converted, nil
+		}
+	case objectNode != nil && isIteratorExpression(ctx, objectNode):
+		if converted, ok := convertIteratorMethod(ctx, expression, name, objectText); ok {
+			return converted, nil
+		}
+	case isListExpression(ctx, objectNode):
+		if converted, initStmts, ok := convertSliceMethod(ctx, expression, name, objectText, true); ok {
+			return converted, initStmts
+		}
+	case isSetExpression(ctx, objectNode):
+		if converted, initStmts, ok := convertSetMethod(ctx, expression, name, objectText, true); ok {
+			return converted, initStmts
+		}
+		if converted, initStmts, ok := convertMapMethod(ctx, expression, name, objectText); ok {
+			return converted, initStmts
+		}
+	case objectText == "Set" && name == "of":
+		if converted, initStmts, ok := convertSetFactory(ctx, expression); ok {
+			return converted, initStmts
+		}
+	case isMapExpression(ctx, objectNode):
+		if converted, initStmts, ok := convertMapMethod(ctx, expression, name, objectText); ok {
+			return converted, initStmts
+		}
+	case objectNode != nil && isClassExpression(ctx, objectNode):
+		if nodeKind(objectNode) == "class_literal" {
+			if converted, ok := convertClassLiteralMethod(ctx, objectNode, name); ok {
+				return converted, nil
+			}
+			objectText = reflectTypeFor(ctx, objectNode.NamedChild(0)).ToSource()
+		}
+		return convertClassMethod(ctx, expression, objectNode, name, objectText)
+	case objectNode != nil && isThrowable(ctx, objectNode):
+		if converted, ok := convertExceptionMethod(ctx, expression, name, objectText); ok {
+			return converted, nil
+		}
+	case objectText == "Class":
+		return unsupportedReflection(ctx, expression), nil
+	case objectText == "Collections":
+		if converted, initStmts, ok := convertCollectionsMethod(ctx, expression, name); ok {
+			return converted, initStmts
+		}
+	case objectText == "Optional":
+		if converted, initStmts, ok := convertOptionalFactory(ctx, expression, name); ok {
+			return converted, initStmts
+		}
+	case objectNode != nil && isOptionalExpression(ctx, objectNode):
+		elementType, _ := optionalOf(ctx, objectNode)
+		if converted, initStmts, ok := convertOptionalMethod(ctx, expression, name, objectText, elementType); ok {
+			return converted, initStmts
+		}
+	case objectText == "EnumSet":
+		if converted, initStmts, ok := convertEnumSetMethod(ctx, expression, name); ok {
+			return converted, initStmts
+		}
+	case objectText == "System":
+		if converted, initStmts, ok := convertSystemMethod(ctx, expression, name); ok {
+			return converted, initStmts
+		}
+	case objectNode != nil && isStringBuilder(ctx, objectNode):
+		if converted, initStmts, ok := convertStringBuilderMethod(ctx, expression, name, objectText); ok {
+			return converted, initStmts
+		}
+	case objectText == "String":
+		if converted, initStmts, ok := convertStringStaticMethod(ctx, expression, name); ok {
+			return converted, initStmts
+		}
+	case name == "compareTo" && objectNode != nil && isOrderedTypeParameter(ctx, objectNode):
+		// Values of cmp.Ordered type parameters have no methods
+		if args, initStmts := convertArguments(ctx, expression.ChildByFieldName("arguments")); len(args) == 1 {
+			ctx.Source.AddImport("cmp")
+			return &gosrc.CallExpression{Function: "cmp.Compare", Args: []gosrc.Expression{&gosrc.VarRef{Ref: objectText}, args[0]}}, initStmts
+		}
+	case objectNode != nil && isStringOperand(ctx, objectNode):
+		if converted, initStmts, ok := convertStringMethod(ctx, expression, name, objectText); ok {
+			return converted, initStmts
+		}
 	}
 
 	switch name {
@@ -453,47 +841,59 @@ func convertMethodInvocation(ctx *MigrationContext, expression *tree_sitter.Node
 			args := convertArgumentList(ctx, argsNode)
 			if len(args) > 0 {
 				// Convert: "active".equals(s) -> "active" == s
-				return &gosrc.BinaryExpression{
+				return ctx.arena.BinaryExpression(gosrc.BinaryExpression{
 					Left:     &gosrc.VarRef{Ref: objectText},
 					Operator: "==",
 					Right:    args[0],
-				}, nil
+				}), nil
 			}
 		}
+	case "getClass":
+		if argsNode := expression.ChildByFieldName("arguments"); argsNode.NamedChildCount() == 0 {
+			return convertGetClass(ctx, objectText), nil
+		}
+		return convertMethodCall(ctx, expression, name, objectText)
 	case "size":
-		return &gosrc.GoExpression{
+		if hasNonCollectionType(ctx, objectNode) {
+			return convertMethodCall(ctx, expression, name, objectText)
+		}
+		return ctx.arena.GoExpression(gosrc.GoExpression{
 			Source: fmt.Sprintf("len(%s)", objectText),
-		}, nil
+		}), nil
 	case "asList":
-		// Arrays.asList(...) -> []gosrc.Type{...}
-		// Only handle if object is "Arrays"
-		if objectText == "Arrays" {
-			argsNode := expression.ChildByFieldName("arguments")
-			if argsNode != nil {
-				args := convertArgumentList(ctx, argsNode)
-				if len(args) > 0 {
-					// Convert arguments to slice literal
-					// Use interface{} as element type (could be improved with type inference)
-					return &gosrc.ArrayLiteral{
-						ElementType: gosrc.Type("interface{}"),
-						Elements:    args,
-					}, nil
-				}
-			}
-			return &gosrc.GoExpression{
-				Source: "[]interface{}{}",
-			}, nil
+		if isAsList(ctx, expression) {
+			return convertAsList(ctx, expression)
 		}
 	case "toArray":
 		// list.toArray(gosrc.Type[]::new) -> convert to slice
 		// The method reference is already handled, so this should work
 		// For now, return the object as a slice (assuming it's already a slice)
-		return &gosrc.GoExpression{
+		return ctx.arena.GoExpression(gosrc.GoExpression{
 			Source: objectText,
-		}, nil
+		}), nil
+	case "emptyList", "emptyMap":
+		if typeArgs := explicitTypeArguments(ctx, expression); objectText == "Collections" && typeArgs != nil {
+			return convertTypedEmptyCollection(ctx, name, typeArgs, expression)
+		}
+		return convertMethodCall(ctx, expression, name, objectText)
+	case "clone":
+		if argsNode := expression.ChildByFieldName("arguments"); objectNode != nil && argsNode != nil && argsNode.NamedChildCount() == 0 {
+			return convertCloneCall(ctx, objectNode, objectText)
+		}
+		return convertMethodCall(ctx, expression, name, objectText)
+	case "valueOf":
+		if objectText == "String" {
+			return convertStringValueOf(ctx, expression)
+		}
+		return convertMethodCall(ctx, expression, name, objectText)
+	case "toString":
+		if argsNode := expression.ChildByFieldName("arguments"); argsNode == nil || argsNode.NamedChildCount() == 0 {
+			return convertToStringCall(objectText)
+		}
+		return convertMethodCall(ctx, expression, name, objectText)
 	case "add":
-		// Only handle collection.add() - not this.add()
-		if objectText != "this" {
+		// Only handle collection.add() - not this.add() or add() of a class
+		if objectNode != nil && objectText != "this" && !hasNonCollectionType(ctx, objectNode) {
 			argsNode := expression.ChildByFieldName("arguments")
 			var initStmts []gosrc.Statement
 			ref := gosrc.VarRef{Ref: objectText}
@@ -511,181 +911,711 @@ func convertMethodInvocation(ctx *MigrationContext, expression *tree_sitter.Node
 		}
 		fallthrough
 	default:
-		argsNode := expression.ChildByFieldName("arguments")
-		var args []gosrc.Expression
-		if argsNode != nil {
-			args = convertArgumentList(ctx, argsNode)
-		}
+		return convertMethodCall(ctx, expression, name, objectText)
+	}
+	// Fallback
+	return ctx.arena.GoExpression(gosrc.GoExpression{
+		Source: ctx.nodeText(expression),
+	}), nil
+}
 
-		convertedName, found, multipleMatches := getConvertedMethodName(ctx, name, len(args))
-		if !found {
-			convertedName = name
-		}
+// convertMethodCall converts a call to a method that has no dedicated
+// translation, resolving overloads from the analyzed method signatures
+func convertMethodCall(ctx *MigrationContext, expression *tree_sitter.Node, name, objectText string) (gosrc.Expression, []gosrc.Statement) {
+	if converted, initStmts, ok := convertFactoryCall(ctx, expression, name, objectText); ok {
+		return converted, initStmts
+	}
+	argsNode := expression.ChildByFieldName("arguments")
+	var args []gosrc.Expression
+	var initStmts []gosrc.Statement
+	if argsNode != nil {
+		args, initStmts = convertArguments(ctx, argsNode)
+	}
 
-		var initStmts []gosrc.Statement
-		if multipleMatches {
-			comment := fmt.Sprintf("FIXME: more than one possible method for %s with %d arguments", name, len(args))
-			initStmts = append(initStmts, &gosrc.CommentStmt{Comments: []string{comment}})
-		}
+	convertedName, found, multipleMatches := invokedMethodName(ctx, name, argsNode)
+	if !found {
+		convertedName = name
+	}
+	if found && !multipleMatches {
+		paramTypes := argumentTypes(trackedMethods(ctx, name), convertedName)
+		args = passArrayArguments(ctx, argsNode, coerceArguments(ctx, argsNode, args, paramTypes), paramTypes)
+	}
 
-		if objectText == "this" && name == "name" {
-			if name == "name" {
-				return &gosrc.GoExpression{
-					Source: fmt.Sprintf("%s.Name()", gosrc.SelfRef),
-				}, nil
-			}
-		}
-		if prefixedName, ok := ctx.EnumConstants[objectText]; ok {
-			// We turn these into methods on the enum type alias
-			fnName := prefixedName + "." + convertedName
-			callExpr := gosrc.CallExpression{
-				Function: fnName,
-				Args:     args,
-			}
-			return &callExpr, initStmts
-		}
-		var fnName string
-		if objectText == "" {
-			fnName = gosrc.SelfRef + "." + convertedName
-		} else {
-			fnName = objectText + "." + convertedName
+	if multipleMatches {
+		comment := fmt.Sprintf("FIXME: more than one possible method for %s with %d arguments", name, len(args))
+		initStmts = append(initStmts, &gosrc.CommentStmt{Comments: []string{comment}})
+	}
+
+	if objectText == "this" && name == "name" {
+		if name == "name" {
+			return ctx.arena.GoExpression(gosrc.GoExpression{
+				Source: fmt.Sprintf("%s.Name()", gosrc.SelfRef),
+			}), nil
 		}
+	}
+	if prefixedName, ok := ctx.EnumConstants[objectText]; ok {
+		// We turn these into methods on the enum type alias
+		fnName := prefixedName + "." + convertedName
 		callExpr := gosrc.CallExpression{
 			Function: fnName,
 			Args:     args,
 		}
 		return &callExpr, initStmts
 	}
-	// Fallback
-	return &gosrc.GoExpression{
-		Source: expression.Utf8Text(ctx.JavaSource),
+	var fnName string
+	var typeArgs []gosrc.Type
+	defaultFunction, defaultTypeArgs, receiver, isDefault := defaultMethodCall(ctx, expression, convertedName, objectText)
+	staticFunction, isStatic := staticCallFunction(ctx, expression, name, convertedName, objectText, found && (!multipleMatches || allStatic(ctx, name)))
+	switch {
+	case (objectText == "" || objectText == "this") && isHelperCall(ctx, expression, name):
+		fnName = convertedName
+		typeArgs = explicitTypeArguments(ctx, expression)
+	case isStatic:
+		// Static methods are package functions, whatever class they are
+		// called through
+		fnName = staticFunction
+		typeArgs = explicitTypeArguments(ctx, expression)
+	case isDefault:
+		// Default methods are functions taking the receiver, which dispatch
+		// to the method of the receiver if its type overrides them
+		fnName = defaultFunction
+		typeArgs = defaultTypeArgs
+		args = append([]gosrc.Expression{receiver}, args...)
+	case objectText == "" || objectText == "this":
+		// Go methods can't have type parameters, so type witnesses on
+		// methods of this class are dropped
+		fnName = gosrc.SelfRef + "." + convertedName
+	default:
+		if goName, ok := ctx.resolveTypeReference(expression, objectText); ok {
+			objectText = goName
+		}
+		fnName = objectText + "." + convertedName
+		typeArgs = explicitTypeArguments(ctx, expression)
+	}
+	callExpr := gosrc.CallExpression{
+		Function: fnName,
+		TypeArgs: typeArgs,
+		Args:     args,
+	}
+	if function, throws := throwingFunction(ctx, name, convertedName); found && !multipleMatches && throws {
+		value, checkStmts := checkedCall(ctx, expression, &callExpr, function)
+		return value, append(initStmts, checkStmts...)
+	}
+	return &callExpr, initStmts
+}
+
+// explicitTypeArguments returns the type witnesses of a generic method call
+// such as Collections.<String>emptyList(), or nil if it has none
+func explicitTypeArguments(ctx *MigrationContext, expression *tree_sitter.Node) []gosrc.Type {
+	typeArgsNode := expression.ChildByFieldName("type_arguments")
+	if typeArgsNode == nil {
+		return nil
+	}
+	return parseTypeArguments(ctx, typeArgsNode)
+}
+
+// convertTypedEmptyCollection converts Collections.<T>emptyList() and
+// Collections.<K, V>emptyMap() to empty literals of the witnessed type
+func convertTypedEmptyCollection(ctx *MigrationContext, name string, typeArgs []gosrc.Type, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	switch {
+	case name == "emptyList" && len(typeArgs) == 1:
+		return &gosrc.ArrayLiteral{ElementType: typeArgs[0]}, nil
+	case name == "emptyMap" && len(typeArgs) == 2:
+		return ctx.arena.GoExpression(gosrc.GoExpression{
+			Source: fmt.Sprintf("map[%s]%s{}", typeArgs[0], typeArgs[1]),
+		}), nil
+	default:
+		FatalError(ctx, expression, fmt.Sprintf("unexpected type arguments for Collections.%s", name), "method_invocation")
+	}
+	panic("unreachable")
+}
+
+// convertStringValueOf converts String.valueOf(x) to fmt.Sprint(x)
+func convertStringValueOf(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	args := convertArgumentList(ctx, expression.ChildByFieldName("arguments"))
+	return stringConversion(ctx, args...), nil
+}
+
+// convertToStringCall converts obj.toString() to a call of the String method
+// that Java toString methods are migrated to
+func convertToStringCall(receiver string) (gosrc.Expression, []gosrc.Statement) {
+	if receiver == "" {
+		receiver = gosrc.SelfRef
+	}
+	return &gosrc.CallExpression{
+		Function: receiver + "." + toStringMethodName,
 	}, nil
 }
 
+// stringConversion converts values to a string the way fmt.Sprint does,
+// which uses the String method of values that have one
+func stringConversion(ctx *MigrationContext, values ...gosrc.Expression) gosrc.Expression {
+	ctx.Source.AddImport("fmt")
+	return &gosrc.CallExpression{
+		Function: "fmt.Sprint",
+		Args:     values,
+	}
+}
+
+// expressionConverter converts a single expression kind
+type expressionConverter func(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement)
+
+// expressionConverters maps grammar symbol ids to the converter of that kind,
+// so convertExpression dispatches with an index instead of comparing the kind
+// against every case. Populated in init since the converters themselves
+// recurse into convertExpression.
+var expressionConverters []expressionConverter
+
+func init() {
+	expressionConverters = buildExpressionConverters()
+}
+
+func buildExpressionConverters() []expressionConverter {
+	byKind := map[string]expressionConverter{
+		"this":                           convertThis,
+		"assignment_expression":          convertAssignmentExpression,
+		"ternary_expression":             convertTernaryExpression,
+		"array_creation_expression":      convertArrayCreationExpression,
+		"instanceof_expression":          convertInstanceofExpression,
+		"update_expression":              convertUpdateExpression,
+		"switch_expression":              convertSwitchExpression,
+		"class_literal":                  convertClassLiteral,
+		"identifier":                     convertIdentifier,
+		"array_access":                   convertArrayAccess,
+		"object_creation_expression":     convertObjectCreationExpression,
+		"field_access":                   convertFieldAccess,
+		"method_invocation":              convertMethodInvocation,
+		"return":                         convertReturnExpression,
+		"parenthesized_expression":       convertParenthesizedExpression,
+		"binary_expression":              convertBinaryExpression,
+		"character_literal":              convertCharacterLiteral,
+		"string_literal":                 convertStringLiteral,
+		"null_literal":                   convertNullLiteral,
+		"true":                           convertBooleanLiteral,
+		"false":                          convertBooleanLiteral,
+		"decimal_integer_literal":        convertIntegerLiteral,
+		"decimal_floating_point_literal": convertVerbatimExpression,
+		"hex_integer_literal":            convertIntegerLiteral,
+		"octal_integer_literal":          convertIntegerLiteral,
+		"binary_integer_literal":         convertIntegerLiteral,
+		"unary_expression":               convertUnaryExpression,
+		"cast_expression":                convertCastExpression,
+		"method_reference":               convertMethodReference,
+	}
+	// Several symbol ids can share a kind name (e.g. aliased and anonymous
+	// symbols), so map every id rather than looking each name up once
+	names := javaKindNames()
+	converters := make([]expressionConverter, len(names))
+	for id, name := range names {
+		converters[id] = byKind[name]
+	}
+	return converters
+}
+
 func convertExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
-	switch expression.Kind() {
-	case "this":
-		return &gosrc.GoExpression{Source: "this"}, nil
+	if id := int(expression.KindId()); id < len(expressionConverters) {
+		if convert := expressionConverters[id]; convert != nil {
+			return convert(ctx, expression)
+		}
+	}
+	UnhandledChild(ctx, expression, "expression")
+	panic("unreachable")
+}
+
+func convertTernaryExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	if valueNode, fallbackNode, ok := nullCoalescingOperands(ctx, expression); ok {
+		return convertNullCoalescing(ctx, valueNode, fallbackNode)
+	}
+	ty, ok := ternaryType(ctx, expression)
+	if !ok {
+		FatalError(ctx, expression, "unable to infer the type of ternary_expression", "ternary_expression")
+	}
+	result := ctx.tempName("ternaryResult")
+	initStmts := []gosrc.Statement{&gosrc.VarDeclaration{Name: result, Ty: ty}}
+	initStmts = append(initStmts, convertTernaryAssignment(ctx, expression, result)...)
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: result}), initStmts
+}
+
+// convertTernaryAssignment converts a ternary into an if assigning target
+// the consequence or the alternative. Only the branch that is taken is
+// evaluated, and a ternary alternative becomes an else if.
+func convertTernaryAssignment(ctx *MigrationContext, expression *tree_sitter.Node, target string) []gosrc.Statement {
+	condition, initStmts := convertExpression(ctx, expression.ChildByFieldName("condition"))
+	ifStatement := gosrc.IfStatement{
+		Condition: condition,
+		Body:      ternaryBranch(ctx, expression.ChildByFieldName("consequence"), target),
+	}
+	alternative := unwrapParentheses(expression.ChildByFieldName("alternative"))
+	elseStmts := ternaryBranch(ctx, alternative, target)
+	if nested, ok := elseStmts[0].(*gosrc.IfStatement); ok && len(elseStmts) == 1 && nodeKind(alternative) == "ternary_expression" {
+		ifStatement.ElseIf = append([]gosrc.IfStatement{{Condition: nested.Condition, Body: nested.Body}}, nested.ElseIf...)
+		ifStatement.ElseStmts = nested.ElseStmts
+	} else {
+		ifStatement.ElseStmts = elseStmts
+	}
+	return append(initStmts, &ifStatement)
+}
+
+// ternaryBranch returns the statements assigning the value of a branch of a
+// ternary to target
+func ternaryBranch(ctx *MigrationContext, branch *tree_sitter.Node, target string) []gosrc.Statement {
+	branch = unwrapParentheses(branch)
+	if nodeKind(branch) == "ternary_expression" && !isNullCoalescing(ctx, branch) {
+		return convertTernaryAssignment(ctx, branch, target)
+	}
+	value, initStmts := convertExpression(ctx, branch)
+	return append(initStmts, &gosrc.AssignStatement{Ref: gosrc.VarRef{Ref: target}, Value: value})
+}
+
+// isNullCoalescing reports whether the ternary expression is converted by
+// convertNullCoalescing
+func isNullCoalescing(ctx *MigrationContext, expression *tree_sitter.Node) bool {
+	_, _, ok := nullCoalescingOperands(ctx, expression)
+	return ok
+}
+
+// ternaryType returns the type of the value of a ternary, from the variable
+// it initializes or is assigned to, or else from its branches
+func ternaryType(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Type, bool) {
+	parent := expression.Parent()
+	for nodeKind(parent) == "parenthesized_expression" {
+		parent = parent.Parent()
+	}
+	switch nodeKind(parent) {
+	case "variable_declarator":
+		if ty, ok := TryParseType(ctx, parent.Parent().ChildByFieldName("type")); ok {
+			return ty, true
+		}
 	case "assignment_expression":
-		return convertAssignmentExpression(ctx, expression)
+		if ty, ok := staticType(ctx, parent.ChildByFieldName("left")); ok {
+			return ty, true
+		}
+	}
+	for _, branch := range []*tree_sitter.Node{expression.ChildByFieldName("consequence"), expression.ChildByFieldName("alternative")} {
+		if ty, ok := valueType(ctx, branch); ok {
+			return ty, true
+		}
+	}
+	return "", false
+}
+
+// valueType returns the Go type of a branch of a ternary if it can be told
+// without type checking
+func valueType(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Type, bool) {
+	node = unwrapParentheses(node)
+	switch nodeKind(node) {
+	case "unary_expression":
+		if ctx.nodeText(node.ChildByFieldName("operator")) == "!" {
+			return gosrc.TypeBool, true
+		}
+		return valueType(ctx, node.ChildByFieldName("operand"))
+	case "binary_expression":
+		switch ctx.nodeText(node.ChildByFieldName("operator")) {
+		case "==", "!=", "<", "<=", ">", ">=", "&&", "||":
+			return gosrc.TypeBool, true
+		}
+		if isStringValue(ctx, node) {
+			return gosrc.TypeString, true
+		}
+		leftTy, leftOk := valueType(ctx, node.ChildByFieldName("left"))
+		rightTy, rightOk := valueType(ctx, node.ChildByFieldName("right"))
+		if promoted, ok := promotedType(leftTy, rightTy); ok {
+			return promoted, true
+		}
+		if leftOk {
+			return leftTy, true
+		}
+		return rightTy, rightOk
 	case "ternary_expression":
-		// TODO: do better
-		return &gosrc.GoExpression{
-			Source: expression.Utf8Text(ctx.JavaSource),
-		}, nil
-	case "array_creation_expression":
-		return convertArrayCreationExpression(ctx, expression)
+		if ty, ok := valueType(ctx, node.ChildByFieldName("consequence")); ok {
+			return ty, true
+		}
+		return valueType(ctx, node.ChildByFieldName("alternative"))
+	case "cast_expression":
+		return TryParseType(ctx, node.ChildByFieldName("type"))
 	case "instanceof_expression":
-		return convertInstanceofExpression(ctx, expression)
-	case "update_expression":
-		return &gosrc.GoExpression{
-			Source: expression.Utf8Text(ctx.JavaSource),
-		}, nil
-	case "switch_expression":
-		switchStatement := convertSwitchStatement(ctx, expression)
-		return &switchStatement, nil
+		return gosrc.TypeBool, true
+	case "method_invocation":
+		if ty, ok := invokedReturnType(ctx, node); ok {
+			return ty, true
+		}
+		if ty, ok := listMethodType(ctx, node); ok {
+			return ty, true
+		}
+		if ty, ok := enumStaticCallType(ctx, node); ok {
+			return ty, true
+		}
+		if ty, ok := asListType(ctx, node); ok {
+			return ty, true
+		}
+	case "array_access":
+		if ty, ok := valueType(ctx, node.ChildByFieldName("array")); ok && IsArrayOrSliceType(ty) {
+			return gosrc.Type(strings.TrimPrefix(string(ty), "[]")), true
+		}
+	}
+	if ty := numericLiteralType(ctx, node); ty != "" {
+		return ty, true
+	}
+	if ty := literalType(node); ty != "" {
+		return ty, true
+	}
+	if isStringOperand(ctx, node) {
+		return gosrc.TypeString, true
+	}
+	return staticType(ctx, node)
+}
+
+// unwrapParentheses returns the expression inside any parentheses around node
+func unwrapParentheses(node *tree_sitter.Node) *tree_sitter.Node {
+	for nodeKind(node) == "parenthesized_expression" {
+		node = node.NamedChild(0)
+	}
+	return node
+}
+
+// nullCoalescingOperands matches x != null ? x : fallback and its negation
+// x == null ? fallback : x, returning the nodes of x and fallback
+func nullCoalescingOperands(ctx *MigrationContext, expression *tree_sitter.Node) (*tree_sitter.Node, *tree_sitter.Node, bool) {
+	condition := expression.ChildByFieldName("condition")
+	for nodeKind(condition) == "parenthesized_expression" {
+		condition = condition.NamedChild(0)
+	}
+	if nodeKind(condition) != "binary_expression" {
+		return nil, nil, false
+	}
+	checked := condition.ChildByFieldName("left")
+	if nodeKind(checked) == "null_literal" {
+		checked = condition.ChildByFieldName("right")
+	} else if nodeKind(condition.ChildByFieldName("right")) != "null_literal" {
+		return nil, nil, false
+	}
+	consequence := expression.ChildByFieldName("consequence")
+	alternative := expression.ChildByFieldName("alternative")
+	switch ctx.nodeText(condition.ChildByFieldName("operator")) {
+	case "!=":
+		return consequence, alternative, ctx.nodeText(consequence) == ctx.nodeText(checked)
+	case "==":
+		return alternative, consequence, ctx.nodeText(alternative) == ctx.nodeText(checked)
+	default:
+		return nil, nil, false
+	}
+}
+
+// convertNullCoalescing converts a null coalescing ternary into a temporary
+// that is replaced by fallback if value is nil
+func convertNullCoalescing(ctx *MigrationContext, valueNode, fallbackNode *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	value, initStmts := convertExpression(ctx, valueNode)
+	fallback, fallbackInit := convertExpression(ctx, fallbackNode)
+	base := "valueOrDefault"
+	if nodeKind(valueNode) == "identifier" {
+		base = ctx.nodeText(valueNode) + "OrDefault"
+	}
+	ref := gosrc.VarRef{Ref: ctx.tempName(base)}
+	initStmts = append(initStmts, &gosrc.VarDeclaration{Name: ref.Ref, Value: value})
+	initStmts = append(initStmts, &gosrc.IfStatement{
+		Condition: ctx.arena.BinaryExpression(gosrc.BinaryExpression{Left: &ref, Operator: "==", Right: &gosrc.NIL}),
+		// The fallback is only evaluated if it is needed
+		Body: append(fallbackInit, &gosrc.AssignStatement{Ref: ref, Value: fallback}),
+	})
+	return ctx.arena.VarRef(ref), initStmts
+}
+
+// convertVerbatimExpression copies the Java text of expression to the output
+// convertUpdateExpression keeps an increment or decrement as written, except
+// that a bare field it updates is reached through this and an array element
+// indexed by the ordinal of an enum value by the value itself
+func convertUpdateExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	operand := expression.NamedChild(0)
+	var ref string
+	ok := false
+	switch nodeKind(operand) {
 	case "identifier":
-		return convertIdentifier(ctx, expression)
+		ref, ok = implicitFieldReference(ctx, operand, ctx.nodeText(operand))
 	case "array_access":
-		return &gosrc.GoExpression{
-			Source: expression.Utf8Text(ctx.JavaSource),
-		}, nil
-	case "object_creation_expression":
-		return convertObjectCreationExpression(ctx, expression)
-	case "field_access":
-		return convertFieldAccess(ctx, expression)
-	case "method_invocation":
-		return convertMethodInvocation(ctx, expression)
-	case "return":
-		var initStmts []gosrc.Statement
-		var value gosrc.Expression
-		if expression.ChildCount() == 1 {
-			value, initStmts = convertExpression(ctx, expression.Child(0))
-		}
-		return &gosrc.ReturnExpression{
-			Value: value,
-		}, initStmts
-	case "parenthesized_expression":
-		return convertExpression(ctx, expression.Child(1))
-	case "binary_expression":
-		return convertBinaryExpression(ctx, expression)
-	case "character_literal":
-		return &gosrc.CharLiteral{
-			Value: expression.Utf8Text(ctx.JavaSource),
-		}, nil
-	case "string_literal":
-		return &gosrc.GoExpression{
-			Source: expression.Utf8Text(ctx.JavaSource),
-		}, nil
-	case "null_literal":
-		return &gosrc.NIL, nil
-	case "true":
-		return &gosrc.BooleanLiteral{
-			Value: true,
-		}, nil
-	case "false":
-		return &gosrc.BooleanLiteral{
-			Value: false,
-		}, nil
-	case "decimal_integer_literal":
-		text := expression.Utf8Text(ctx.JavaSource)
-		// Check if literal has L or l suffix (Java long literal)
-		isLong := false
-		if len(text) > 0 && (text[len(text)-1] == 'L' || text[len(text)-1] == 'l') {
-			isLong = true
-			text = text[:len(text)-1] // Strip the suffix
+		if _, _, ok = ordinalReceiver(ctx, operand.ChildByFieldName("index")); ok {
+			converted, initStmts := convertArrayAccess(ctx, operand)
+			Assert("array index expression is expected to be simple", len(initStmts) == 0)
+			ref = converted.ToSource()
 		}
+	}
+	if !ok {
+		return convertVerbatimExpression(ctx, expression)
+	}
+	source := ctx.nodeText(expression)
+	start := operand.StartByte() - expression.StartByte()
+	end := operand.EndByte() - expression.StartByte()
+	return ctx.arena.GoExpression(gosrc.GoExpression{
+		Source: source[:start] + ref + source[end:],
+	}), nil
+}
+
+func convertVerbatimExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	return ctx.arena.GoExpression(gosrc.GoExpression{
+		Source: ctx.nodeText(expression),
+	}), nil
+}
+
+func convertThis(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	return ctx.arena.GoExpression(gosrc.GoExpression{Source: "this"}), nil
+}
+
+// convertSwitchExpression converts a switch expression. In a return
+// statement the switch itself is returned, to be flattened into a switch whose
+// arms return; elsewhere the arms assign a temporary holding the value.
+func convertSwitchExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	if ctx.InReturn {
+		switchStatement := convertSwitchStatement(ctx, expression, "")
+		return &switchStatement, nil
+	}
+	ty, ok := switchExpressionType(ctx, expression)
+	if !ok {
+		FatalError(ctx, expression, "unable to infer the type of switch_expression", "switch_expression")
+	}
+	result := ctx.tempName("switchResult")
+	switchStatement := convertSwitchStatement(ctx, expression, result)
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: result}), []gosrc.Statement{
+		&gosrc.VarDeclaration{Name: result, Ty: ty},
+		&switchStatement,
+	}
+}
 
-		n, err := strconv.ParseInt(text, 10, 64)
-		if err != nil {
-			FatalError(ctx, expression, fmt.Sprintf("failed to parse integer: %v", err), "integer_literal")
+// switchValueFunction converts a switch expression of type ty that has no
+// statements to go before it, such as a field initializer, to a function
+// literal returning its value that is called right away
+func switchValueFunction(ctx *MigrationContext, expression *tree_sitter.Node, ty gosrc.Type) gosrc.Expression {
+	inReturn := ctx.InReturn
+	ctx.InReturn = true
+	switchStatement := convertSwitchStatement(ctx, expression, "")
+	ctx.InReturn = inReturn
+	return ctx.arena.GoExpression(gosrc.GoExpression{
+		Source: fmt.Sprintf("func() %s {\n%s\n}()", ty, switchStatement.ToSource()),
+	})
+}
+
+// switchExpressionType returns the type of the value of a switch expression,
+// from the variable it is assigned to or the parameter it is passed to, or
+// else from the values it yields
+func switchExpressionType(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Type, bool) {
+	switch parent := expression.Parent(); nodeKind(parent) {
+	case "assignment_expression":
+		return staticType(ctx, parent.ChildByFieldName("left"))
+	case "argument_list":
+		if ty, ok := argumentParameterType(ctx, parent, expression); ok {
+			return ty, true
 		}
+	}
+	var ty gosrc.Type
+	var walk func(node *tree_sitter.Node)
+	walk = func(node *tree_sitter.Node) {
+		IterateChildren(node, func(child *tree_sitter.Node) {
+			switch nodeKind(child) {
+			case "switch_expression":
+				// Nested switches yield their own values
+			case "yield_statement":
+				ty = cmp.Or(ty, yieldedType(ctx, child.NamedChild(0)))
+			case "switch_rule":
+				if body := child.NamedChild(child.NamedChildCount() - 1); nodeKind(body) == "expression_statement" {
+					ty = cmp.Or(ty, yieldedType(ctx, body.NamedChild(0)))
+				}
+				walk(child)
+			default:
+				walk(child)
+			}
+		})
+	}
+	walk(expression.ChildByFieldName("body"))
+	return ty, ty != ""
+}
+
+// yieldedType returns the Go type of a value yielded by a switch expression,
+// or "" if it can't be told without type checking
+func yieldedType(ctx *MigrationContext, node *tree_sitter.Node) gosrc.Type {
+	ty, _ := valueType(ctx, node)
+	return ty
+}
 
-		if isLong {
-			return &gosrc.Int64Literal{
-				Value: n,
-			}, nil
+// argumentParameterType returns the Go type of the parameter of the method
+// called with the arguments argsNode that argument is passed to, if the call
+// can only be of one method
+func argumentParameterType(ctx *MigrationContext, argsNode, argument *tree_sitter.Node) (gosrc.Type, bool) {
+	invocation := argsNode.Parent()
+	if nodeKind(invocation) != "method_invocation" {
+		return "", false
+	}
+	arguments := argumentNodes(argsNode)
+	index := slices.IndexFunc(arguments, func(node *tree_sitter.Node) bool { return node.Id() == argument.Id() })
+	var candidates []FunctionData
+	for _, method := range trackedMethods(ctx, ctx.nodeText(invocation.ChildByFieldName("name"))) {
+		if len(method.ArgumentTypes) == len(arguments) {
+			candidates = append(candidates, method)
 		}
-		return &gosrc.IntLiteral{
-			Value: int(n),
-		}, nil
+	}
+	if len(candidates) != 1 || index < 0 {
+		return "", false
+	}
+	return candidates[0].ArgumentTypes[index], true
+}
+
+// invokedReturnType returns the Go return type of the method of the
+// enclosing class called by invocation, if there is only one it can be once
+// overloads are resolved
+func invokedReturnType(ctx *MigrationContext, invocation *tree_sitter.Node) (gosrc.Type, bool) {
+	method := invokedMethodDeclaration(ctx, invocation)
+	if method == nil {
+		return "", false
+	}
+	returnTy := getMethodMetadata(ctx, method).valueTy
+	if returnTy == nil {
+		return "", false
+	}
+	return *returnTy, true
+}
+
+// invokedMethodDeclaration returns the declaration of the method of the
+// enclosing class an unqualified call, or a call on this, invokes, or nil if
+// it is not known
+func invokedMethodDeclaration(ctx *MigrationContext, invocation *tree_sitter.Node) *tree_sitter.Node {
+	if object := invocation.ChildByFieldName("object"); object != nil && nodeKind(object) != "this" {
+		return nil
+	}
+	classNode := enclosingTypeDeclaration(invocation)
+	if classNode == nil {
+		return nil
+	}
+	name := ctx.nodeText(invocation.ChildByFieldName("name"))
+	argCount := len(argumentNodes(invocation.ChildByFieldName("arguments")))
+	var methods []*tree_sitter.Node
+	IterateChildren(classNode.ChildByFieldName("body"), func(member *tree_sitter.Node) {
+		if nodeKind(member) == "method_declaration" && ctx.nodeText(member.ChildByFieldName("name")) == name &&
+			len(argumentNodes(member.ChildByFieldName("parameters"))) == argCount {
+			methods = append(methods, member)
+		}
+	})
+	if len(methods) > 1 {
+		// Overloads taking as many arguments are told apart by their types
+		resolved, found, multipleMatches := invokedMethodName(ctx, name, invocation.ChildByFieldName("arguments"))
+		if !found || multipleMatches {
+			return nil
+		}
+		methods = slices.DeleteFunc(methods, func(method *tree_sitter.Node) bool {
+			return getMethodMetadata(ctx, method).name != resolved
+		})
+	}
+	if len(methods) != 1 {
+		return nil
+	}
+	return methods[0]
+}
+
+// literalType returns the Go type of a literal, or "" if node is not one
+func literalType(node *tree_sitter.Node) gosrc.Type {
+	switch nodeKind(node) {
+	case "string_literal":
+		return gosrc.TypeString
+	case "decimal_integer_literal", "hex_integer_literal", "character_literal":
+		return gosrc.TypeInt
 	case "decimal_floating_point_literal":
-		// Parse floating point literal
-		return &gosrc.GoExpression{
-			Source: expression.Utf8Text(ctx.JavaSource),
+		return gosrc.TypeFloat64
+	case "true", "false":
+		return gosrc.TypeBool
+	default:
+		return ""
+	}
+}
+
+func convertReturnExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	var initStmts []gosrc.Statement
+	var value gosrc.Expression
+	if expression.ChildCount() == 1 {
+		value, initStmts = convertExpression(ctx, expression.Child(0))
+	}
+	return &gosrc.ReturnExpression{
+		Value: value,
+	}, initStmts
+}
+
+func convertParenthesizedExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	return convertExpression(ctx, expression.Child(1))
+}
+
+func convertNullLiteral(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	return &gosrc.NIL, nil
+}
+
+func convertBooleanLiteral(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	return &gosrc.BooleanLiteral{
+		Value: nodeKind(expression) == "true",
+	}, nil
+}
+
+// convertIntegerLiteral converts any of the Java integer literal kinds. Java
+// long literals (L or l suffix) become int64. Hex and binary literals keep
+// their spelling, octal literals get Go's 0o prefix, and decimal literals are
+// printed in plain decimal.
+func convertIntegerLiteral(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	text := ctx.nodeText(expression)
+	isLong := false
+	if len(text) > 0 && (text[len(text)-1] == 'L' || text[len(text)-1] == 'l') {
+		isLong = true
+		text = text[:len(text)-1] // Strip the suffix
+	}
+	digits, base, goPrefix := splitIntegerLiteral(nodeKind(expression), text)
+
+	bits, err := strconv.ParseUint(strings.ReplaceAll(digits, "_", ""), base, 64)
+	if err != nil {
+		FatalError(ctx, expression, fmt.Sprintf("failed to parse integer: %v", err), "integer_literal")
+	}
+	value := integerLiteralValue(ctx, expression, bits, base, isLong)
+
+	// A non decimal literal denotes the two's complement bits of the value,
+	// so only keep the spelling when Go reads the same value from it
+	literal := ""
+	if base != 10 && value >= 0 {
+		literal = goPrefix + digits
+	}
+	if isLong {
+		return &gosrc.Int64Literal{
+			Value:   value,
+			Literal: literal,
 		}, nil
-	case "hex_integer_literal":
-		text := expression.Utf8Text(ctx.JavaSource)
-		// Check if literal has L or l suffix (Java long literal)
-		isLong := false
-		if len(text) > 0 && (text[len(text)-1] == 'L' || text[len(text)-1] == 'l') {
-			isLong = true
-			text = text[:len(text)-1] // Strip the suffix
-		}
+	}
+	return &gosrc.IntLiteral{
+		Value:   int(value),
+		Literal: literal,
+	}, nil
+}
 
-		n, err := strconv.ParseInt(text, 0, 64)
-		if err != nil {
-			FatalError(ctx, expression, fmt.Sprintf("failed to parse hex/octal integer: %v", err), "hex_integer_literal")
-		}
+// splitIntegerLiteral splits a Java integer literal without its long suffix
+// into its digits, their base, and the Go prefix for that base
+func splitIntegerLiteral(kind, text string) (digits string, base int, goPrefix string) {
+	switch kind {
+	case "hex_integer_literal":
+		return text[2:], 16, "0x"
+	case "binary_integer_literal":
+		return text[2:], 2, "0b"
+	case "octal_integer_literal":
+		return text[1:], 8, "0o"
+	default:
+		return text, 10, ""
+	}
+}
 
-		if isLong {
-			return &gosrc.Int64Literal{
-				Value: n,
-			}, nil
+// integerLiteralValue interprets the parsed bits of an integer literal the
+// way Java does: hex, octal, and binary int literals may set the sign bit
+// (0xFFFFFFFF is -1), decimal literals may not.
+func integerLiteralValue(ctx *MigrationContext, expression *tree_sitter.Node, bits uint64, base int, isLong bool) int64 {
+	switch {
+	case base == 10:
+		if bits > math.MaxInt64 {
+			FatalError(ctx, expression, "integer literal out of range", "integer_literal")
 		}
-		return &gosrc.IntLiteral{
-			Value: int(n),
-		}, nil
-	case "unary_expression":
-		return convertUnaryExpression(ctx, expression)
-	case "cast_expression":
-		return convertCastExpression(ctx, expression)
-	case "method_reference":
-		return convertMethodReference(ctx, expression)
+		return int64(bits)
+	case isLong:
+		return int64(bits)
 	default:
-		fmt.Println(expression.Utf8Text(ctx.JavaSource))
-		expression.Parent()
-		FatalError(ctx, expression, "unhandled expression kind: "+expression.Kind(), "expression")
+		if bits > math.MaxUint32 {
+			FatalError(ctx, expression, "integer literal out of range for int", "integer_literal")
+		}
+		return int64(int32(uint32(bits)))
 	}
-	panic("unreachable")
 }
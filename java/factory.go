@@ -0,0 +1,117 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// factoryStructName returns the Go name of the struct that the static method
+// methodNode creates if it is a static factory, such as Point.of(x, y): a
+// static method of a class returning an instance of that class
+func factoryStructName(ctx *MigrationContext, methodNode *tree_sitter.Node, returnType gosrc.Type) (string, bool) {
+	classNode := enclosingTypeDeclaration(methodNode)
+	switch nodeKind(classNode) {
+	case "class_declaration", "record_declaration":
+	default:
+		return "", false
+	}
+	if ctx.AbstractClasses[ctx.nodeText(classNode.ChildByFieldName("name"))] {
+		return "", false
+	}
+	structName := goTypeName(ctx, classNode)
+	// Generic classes are returned instantiated, by pointer
+	returned, _, _ := strings.Cut(string(structType(returnType)), "[")
+	return structName, returned == structName
+}
+
+// factoryName returns the Go name of the static factory javaName of the
+// struct structName. Like constructors, it is named after the struct, and it
+// keeps the name of the factory so Point.of and Point.from stay apart:
+// Point.of becomes NewPointOf.
+func factoryName(structName, javaName string, isPublic bool) string {
+	return gosrc.ToIdentifier("new", isPublic) + gosrc.CapitalizeFirstLetter(structName) + gosrc.CapitalizeFirstLetter(javaName)
+}
+
+// addFactoryToCtx registers a static factory with the constructors of
+// structName and returns its Go name. Overloads of a factory are named like
+// overloaded methods.
+func addFactoryToCtx(ctx *MigrationContext, fn FunctionData, structName string) string {
+	ty := gosrc.Type(structName)
+	current := ctx.Constructors[ty]
+	overloaded := false
+	for _, each := range current {
+		if each.Factory != fn.Factory {
+			continue
+		}
+		if each.sameArgs(fn) {
+			return each.Name
+		}
+		overloaded = true
+	}
+	if overloaded {
+		fn.Name = overloadedName(fn.Name, fn.ArgumentTypes)
+	}
+	ctx.Constructors[ty] = append(current, fn)
+	return fn.Name
+}
+
+// withoutFactories returns the constructors among the registered
+// constructors of a struct, leaving out its static factories
+func withoutFactories(constructors []FunctionData) []FunctionData {
+	var result []FunctionData
+	for _, constructor := range constructors {
+		if constructor.Factory == "" {
+			result = append(result, constructor)
+		}
+	}
+	return result
+}
+
+// convertFactoryCall converts a call of the static factory name of the class
+// objectText, or of the enclosing class for a bare call, to a call of the
+// function it was migrated to. It returns false if the call is not one of a
+// registered factory.
+func convertFactoryCall(ctx *MigrationContext, expression *tree_sitter.Node, name, objectText string) (gosrc.Expression, []gosrc.Statement, bool) {
+	structName := objectText
+	switch {
+	case objectText == "":
+		classNode := enclosingTypeDeclaration(expression)
+		if classNode == nil {
+			return nil, nil, false
+		}
+		structName = goTypeName(ctx, classNode)
+	default:
+		if goName, ok := ctx.resolveTypeReference(expression, objectText); ok {
+			structName = goName
+		}
+	}
+	constructors := ctx.Constructors[gosrc.Type(structName)]
+	var factories []FunctionData
+	for _, constructor := range constructors {
+		if constructor.Factory == name {
+			factories = append(factories, constructor)
+		}
+	}
+	if len(factories) == 0 {
+		return nil, nil, false
+	}
+	argsNode := expression.ChildByFieldName("arguments")
+	fnName, found, multipleMatches := resolveOverload(ctx, factories, argsNode)
+	if !found {
+		return nil, nil, false
+	}
+	args, initStmts := convertArguments(ctx, argsNode)
+	if !multipleMatches {
+		paramTypes := argumentTypes(factories, fnName)
+		args = passArrayArguments(ctx, argsNode, coerceArguments(ctx, argsNode, args, paramTypes), paramTypes)
+	}
+	if multipleMatches {
+		comment := fmt.Sprintf("FIXME: more than one possible factory for %s.%s with %d arguments", structName, name, len(args))
+		initStmts = append(initStmts, &gosrc.CommentStmt{Comments: []string{comment}})
+	}
+	return &gosrc.CallExpression{Function: fnName, Args: args}, initStmts, true
+}
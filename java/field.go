@@ -1,6 +1,9 @@
 package java
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/heshanpadmasiri/javaGo/gosrc"
 
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
@@ -9,7 +12,7 @@ import (
 func convertParameters(ctx *MigrationContext, paramsNode *tree_sitter.Node) []gosrc.Param {
 	var params []gosrc.Param
 	IterateChildren(paramsNode, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "formal_parameters":
 			params = append(params, convertFormalParameters(ctx, child)...)
 		default:
@@ -22,16 +25,13 @@ func convertParameters(ctx *MigrationContext, paramsNode *tree_sitter.Node) []go
 func convertFormalParameters(ctx *MigrationContext, paramsNode *tree_sitter.Node) []gosrc.Param {
 	var params []gosrc.Param
 	IterateChildren(paramsNode, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "formal_parameter":
 			typeNode := child.ChildByFieldName("type")
 			if typeNode == nil {
 				FatalError(ctx, child, "formal_parameter missing type field", "formal_parameter")
 			}
-			nameNode := child.ChildByFieldName("name")
-			if nameNode == nil {
-				FatalError(ctx, child, "formal_parameter missing name field", "formal_parameter")
-			}
+			name := identifierText(ctx, child, child.ChildByFieldName("name"), "formal_parameter")
 			ty, ok := TryParseType(ctx, typeNode)
 			if !ok {
 				FatalError(ctx, typeNode, "unable to parse type in formal_parameter", "formal_parameter")
@@ -41,20 +41,19 @@ func convertFormalParameters(ctx *MigrationContext, paramsNode *tree_sitter.Node
 				ty = gosrc.Type("*" + ty)
 			}
 			params = append(params, gosrc.Param{
-				Name: nameNode.Utf8Text(ctx.JavaSource),
+				Name: name,
 				Ty:   ty,
 			})
 		case "spread_parameter":
 			var ty gosrc.Type
 			var name string
 			IterateChildren(child, func(spreadChild *tree_sitter.Node) {
-				switch spreadChild.Kind() {
+				switch nodeKind(spreadChild) {
 				case "variable_declarator":
-					nameNode := spreadChild.ChildByFieldName("name")
-					if nameNode == nil {
-						FatalError(ctx, spreadChild, "spread child missing name field", "spread_parameter")
-					}
-					name = nameNode.Utf8Text(ctx.JavaSource)
+					name = identifierText(ctx, spreadChild, spreadChild.ChildByFieldName("name"), "spread_parameter")
+				// Go has no final parameters, and parameter annotations
+				// such as @Nullable don't change the migrated type
+				case "modifiers":
 				case "...":
 					return
 				default:
@@ -82,21 +81,24 @@ func convertFormalParameters(ctx *MigrationContext, paramsNode *tree_sitter.Node
 	return params
 }
 
-func convertFieldDeclaration(ctx *MigrationContext, fieldNode *tree_sitter.Node) (gosrc.StructField, gosrc.Expression, modifiers) {
-	var mods modifiers
+// convertFieldDeclaration converts a field declaration to a struct field and
+// its initializer. Initializers that need statements to compute, such as
+// ternaries, have no place for them next to the field, so they are returned
+// as initStmts assigning the field instead of initExpr, to be run by the
+// constructor, or the init function for static fields.
+func convertFieldDeclaration(ctx *MigrationContext, fieldNode *tree_sitter.Node) (field gosrc.StructField, initExpr gosrc.Expression, initStmts []gosrc.Statement, mods modifiers) {
 	var ty gosrc.Type
 	var name string
 	var comments []string
-	var initExpr gosrc.Expression
 	IterateChildren(fieldNode, func(child *tree_sitter.Node) {
 		t, ok := TryParseType(ctx, child)
 		if ok {
 			ty = t
 			return
 		}
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "modifiers":
-			mods = ParseModifiers(child.Utf8Text(ctx.JavaSource))
+			mods = ParseModifiers(ctx.nodeText(child))
 		case "variable_declarator":
 			result := convertVariableDecl(ctx, child)
 			name = result.name
@@ -104,20 +106,40 @@ func convertFieldDeclaration(ctx *MigrationContext, fieldNode *tree_sitter.Node)
 
 			// Extract comments from init statements (e.g., FIXME for ambiguous constructors)
 			// These will be added as field-level comments
+			var liftedStmts []gosrc.Statement
 			for _, stmt := range result.initStmts {
 				if commentStmt, ok := stmt.(*gosrc.CommentStmt); ok {
 					comments = append(comments, commentStmt.Comments...)
+				} else {
+					liftedStmts = append(liftedStmts, stmt)
 				}
 			}
 
 			// Handle shorthand array initializer: { 1, 2, 3 }
 			// Check if the value node was array_initializer
 			valueNode := child.ChildByFieldName("value")
-			if valueNode != nil && valueNode.Kind() == "array_initializer" {
+			switch {
+			case valueNode == nil:
+			case nodeKind(valueNode) == "array_initializer":
 				// convertVariableDecl couldn't handle this (no type info)
 				// Parse it here with type context
-				elements := convertArrayInitializer(ctx, valueNode)
-				initExpr = &gosrc.ArrayLiteral{ElementType: ty, Elements: elements}
+				initExpr = convertArrayInitializer(ctx, valueNode, ty)
+			case nodeKind(valueNode) == "switch_expression":
+				// Initializers have no statements to assign a temporary in
+				initExpr = switchValueFunction(ctx, valueNode, ty)
+			case isAssigningTernary(ctx, valueNode):
+				// The branches of the ternary assign the field itself
+				target := fieldTarget(name, mods)
+				initStmts = liftedFieldInit(target, nil, convertTernaryAssignment(ctx, unwrapParentheses(valueNode), target))
+			case mods&STATIC != 0:
+				// Module level vars take their type from their value
+				initExpr = coerceDeclaredNumeric(ctx, valueNode, initExpr, ty)
+			default:
+				initExpr = coerceNumeric(ctx, valueNode, initExpr, ty)
+			}
+			if len(liftedStmts) > 0 {
+				initStmts = liftedFieldInit(fieldTarget(name, mods), initExpr, liftedStmts)
+				initExpr = nil
 			}
 		// ignored
 		case ";":
@@ -132,7 +154,52 @@ func convertFieldDeclaration(ctx *MigrationContext, fieldNode *tree_sitter.Node)
 		Ty:       ty,
 		Public:   mods&PUBLIC != 0,
 		Comments: comments,
-	}, initExpr, mods
+	}, initExpr, initStmts, mods
+}
+
+// isAssigningTernary reports whether valueNode is a ternary, possibly in
+// parentheses, converted to an if assigning the variable it initializes
+func isAssigningTernary(ctx *MigrationContext, valueNode *tree_sitter.Node) bool {
+	innerNode := unwrapParentheses(valueNode)
+	return nodeKind(innerNode) == "ternary_expression" && !isNullCoalescing(ctx, innerNode)
+}
+
+// fieldTarget returns the Go reference the constructor, or the init function
+// for static fields, assigns the initializer of the field name to. Static
+// fields are module variables named after the field.
+func fieldTarget(name string, mods modifiers) string {
+	if mods&STATIC != 0 {
+		return name
+	}
+	return gosrc.SelfRef + "." + gosrc.ToIdentifier(name, mods&PUBLIC != 0)
+}
+
+// liftedFieldInit returns the statements assigning target the value of an
+// initializer, computed by initStmts, or just initStmts if value is nil.
+// Temporaries are only unique within the member they were converted in, so
+// statements declaring them get a block of their own.
+func liftedFieldInit(target string, value gosrc.Expression, initStmts []gosrc.Statement) []gosrc.Statement {
+	stmts := slices.Clone(initStmts)
+	if value != nil {
+		stmts = append(stmts, &gosrc.AssignStatement{Ref: gosrc.VarRef{Ref: target}, Value: value})
+	}
+	if !slices.ContainsFunc(stmts, declaresVariable) {
+		return stmts
+	}
+	var block strings.Builder
+	block.WriteString("{\n")
+	for _, stmt := range stmts {
+		block.WriteString(stmt.ToSource())
+		block.WriteString("\n")
+	}
+	block.WriteString("}")
+	return []gosrc.Statement{&gosrc.GoStatement{Source: block.String()}}
+}
+
+// declaresVariable reports whether stmt declares a variable
+func declaresVariable(stmt gosrc.Statement) bool {
+	_, ok := stmt.(*gosrc.VarDeclaration)
+	return ok
 }
 
 type variableDeclResult struct {
@@ -142,17 +209,12 @@ type variableDeclResult struct {
 }
 
 func convertVariableDecl(ctx *MigrationContext, declNode *tree_sitter.Node) variableDeclResult {
-	var name string
-	nameNode := declNode.ChildByFieldName("name")
-	if nameNode != nil {
-		name = nameNode.Utf8Text(ctx.JavaSource)
-	} else {
-		FatalError(ctx, declNode, "variable_declarator missing name field", "variable_declarator")
-	}
+	name := identifierText(ctx, declNode, declNode.ChildByFieldName("name"), "variable_declarator")
 	valueNode := declNode.ChildByFieldName("value")
 	if valueNode != nil {
-		// Skip array_initializer - parent will handle with type context
-		if valueNode.Kind() == "array_initializer" {
+		// Skip array_initializer, switch_expression and ternaries - parent
+		// will handle with type context
+		if kind := nodeKind(valueNode); kind == "array_initializer" || kind == "switch_expression" || isAssigningTernary(ctx, valueNode) {
 			return variableDeclResult{
 				name:  name,
 				value: nil, // Signal to parent to handle
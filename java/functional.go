@@ -0,0 +1,93 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// functionalInterface describes a JDK functional interface that is migrated
+// to a Go function type
+type functionalInterface struct {
+	method     string     // The single abstract method, called by calling the function
+	params     []int      // Type arguments the function takes, by index
+	result     int        // Type argument the function returns, or -1
+	resultType gosrc.Type // Fixed result type, if it is not a type argument
+}
+
+// functionalInterfaces maps the JDK functional interfaces to the Go function
+// types they are migrated to
+var functionalInterfaces = map[string]functionalInterface{
+	"Runnable":       {method: "run", result: -1},
+	"Supplier":       {method: "get", result: 0},
+	"Consumer":       {method: "accept", params: []int{0}, result: -1},
+	"BiConsumer":     {method: "accept", params: []int{0, 1}, result: -1},
+	"Function":       {method: "apply", params: []int{0}, result: 1},
+	"BiFunction":     {method: "apply", params: []int{0, 1}, result: 2},
+	"UnaryOperator":  {method: "apply", params: []int{0}, result: 0},
+	"BinaryOperator": {method: "apply", params: []int{0, 0}, result: 0},
+	"Predicate":      {method: "test", params: []int{0}, result: -1, resultType: gosrc.TypeBool},
+	"BiPredicate":    {method: "test", params: []int{0, 1}, result: -1, resultType: gosrc.TypeBool},
+	"Comparator":     {method: "compare", params: []int{0, 0}, result: -1, resultType: gosrc.TypeInt},
+}
+
+// functionalType returns the Go function type of the functional interface
+// javaName instantiated with typeArgs. Missing type arguments, as in raw
+// types, are any.
+func functionalType(javaName string, typeArgs []gosrc.Type) (gosrc.Type, bool) {
+	iface, ok := functionalInterfaces[javaName]
+	if !ok {
+		return "", false
+	}
+	typeArg := func(index int) string {
+		if index < len(typeArgs) {
+			return string(typeArgs[index])
+		}
+		return "any"
+	}
+	var params []string
+	for _, index := range iface.params {
+		params = append(params, typeArg(index))
+	}
+	ty := "func(" + strings.Join(params, ", ") + ")"
+	switch {
+	case iface.result >= 0:
+		ty += " " + typeArg(iface.result)
+	case iface.resultType != "":
+		ty += " " + string(iface.resultType)
+	}
+	return gosrc.Type(ty), true
+}
+
+// isFunctionalInterface reports whether javaName is migrated to a function
+// type, which it is unless the configuration maps it to something else
+func (ctx *MigrationContext) isFunctionalInterface(javaName string) bool {
+	_, mapped := ctx.TypeMappings[javaName]
+	_, ok := functionalInterfaces[javaName]
+	return ok && !mapped
+}
+
+// isFunctionCall reports whether the method invocation name on objectNode
+// calls a function value migrated from a functional interface, as in
+// task.run()
+func isFunctionCall(ctx *MigrationContext, objectNode *tree_sitter.Node, name string) bool {
+	ty, ok := staticType(ctx, objectNode)
+	if !ok || !strings.HasPrefix(string(ty), "func(") {
+		return false
+	}
+	for _, iface := range functionalInterfaces {
+		if iface.method == name {
+			return true
+		}
+	}
+	return false
+}
+
+// convertFunctionCall converts a call of the functional method of a function
+// value objectText to a call of the function itself
+func convertFunctionCall(ctx *MigrationContext, expression *tree_sitter.Node, objectText string) (gosrc.Expression, []gosrc.Statement) {
+	args, initStmts := convertArguments(ctx, expression.ChildByFieldName("arguments"))
+	return &gosrc.CallExpression{Function: objectText, Args: args}, initStmts
+}
@@ -0,0 +1,99 @@
+//go:build ignore
+
+// gen_node_kinds generates node_kinds_gen.go from the node-types.json shipped
+// with the tree-sitter Java grammar. Run it with `go generate ./java`.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const grammarModule = "github.com/tree-sitter/tree-sitter-java"
+
+type nodeType struct {
+	Type     string `json:"type"`
+	Named    bool   `json:"named"`
+	Subtypes []struct {
+		Type string `json:"type"`
+	} `json:"subtypes"`
+}
+
+func main() {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", grammarModule).Output()
+	if err != nil {
+		fatal("locating grammar module", err)
+	}
+	grammarDir := strings.TrimSpace(string(out))
+	data, err := os.ReadFile(filepath.Join(grammarDir, "src", "node-types.json"))
+	if err != nil {
+		fatal("reading node-types.json", err)
+	}
+	var nodeTypes []nodeType
+	if err := json.Unmarshal(data, &nodeTypes); err != nil {
+		fatal("parsing node-types.json", err)
+	}
+	supertypes := make(map[string][]string)
+	for _, nt := range nodeTypes {
+		for _, sub := range nt.Subtypes {
+			supertypes[nt.Type] = append(supertypes[nt.Type], sub.Type)
+		}
+	}
+
+	sb := bytes.Buffer{}
+	sb.WriteString("// Code generated by gen_node_kinds.go; DO NOT EDIT.\n\n")
+	sb.WriteString("package java\n\n")
+	writeKinds(&sb, "GrammarStatementKinds", "statement", leafKinds(supertypes, "statement"))
+	writeKinds(&sb, "GrammarExpressionKinds", "expression", leafKinds(supertypes, "expression"))
+	formatted, err := format.Source(sb.Bytes())
+	if err != nil {
+		fatal("formatting generated source", err)
+	}
+	if err := os.WriteFile("node_kinds_gen.go", formatted, 0o644); err != nil {
+		fatal("writing node_kinds_gen.go", err)
+	}
+}
+
+// leafKinds expands a supertype into the concrete node kinds it covers
+func leafKinds(supertypes map[string][]string, supertype string) []string {
+	seen := make(map[string]bool)
+	var expand func(kind string)
+	expand = func(kind string) {
+		subtypes, isSupertype := supertypes[kind]
+		if !isSupertype {
+			seen[kind] = true
+			return
+		}
+		for _, sub := range subtypes {
+			expand(sub)
+		}
+	}
+	expand(supertype)
+	var kinds []string
+	for kind := range seen {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+func writeKinds(sb *bytes.Buffer, name, supertype string, kinds []string) {
+	fmt.Fprintf(sb, "// %s lists every concrete node kind of the grammar's %s supertype\n", name, supertype)
+	fmt.Fprintf(sb, "var %s = []string{\n", name)
+	for _, kind := range kinds {
+		fmt.Fprintf(sb, "%q,\n", kind)
+	}
+	sb.WriteString("}\n\n")
+}
+
+func fatal(msg string, err error) {
+	fmt.Fprintf(os.Stderr, "Fatal: %s: %v\n", msg, err)
+	os.Exit(1)
+}
@@ -0,0 +1,164 @@
+package java
+
+import (
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// isHelperMethod reports whether methodNode is a private instance method
+// migrated to a package function, as ctx.PrivateHelperFunctions asks for
+func isHelperMethod(ctx *MigrationContext, methodNode *tree_sitter.Node) bool {
+	classBody := methodNode.Parent()
+	return ctx.helperMethods(classBody)[ctx.nodeText(methodNode.ChildByFieldName("name"))]
+}
+
+// isHelperCall reports whether the unqualified call or call on this
+// expression calls a package function: a helper, or any function called by
+// a helper, which only calls helpers and static methods
+func isHelperCall(ctx *MigrationContext, expression *tree_sitter.Node, name string) bool {
+	for parent := expression.Parent(); parent != nil; parent = parent.Parent() {
+		switch nodeKind(parent) {
+		case "method_declaration":
+			if isHelperMethod(ctx, parent) {
+				return true
+			}
+		case "class_body":
+			return ctx.helperMethods(parent)[name]
+		}
+	}
+	return false
+}
+
+// helperMethods returns the Java names of the private instance methods of
+// classBody that use neither the fields nor the methods of the instance, and
+// can be package functions. They are found once per class.
+func (ctx *MigrationContext) helperMethods(classBody *tree_sitter.Node) map[string]bool {
+	if !ctx.PrivateHelperFunctions || nodeKind(classBody) != "class_body" {
+		return nil
+	}
+	key := classBody.StartByte()
+	if helpers, ok := ctx.helpers[key]; ok {
+		return helpers
+	}
+	if ctx.helpers == nil {
+		ctx.helpers = make(map[uint]map[string]bool)
+	}
+	helpers := findHelperMethods(ctx, classBody)
+	ctx.helpers[key] = helpers
+	return helpers
+}
+
+// findHelperMethods computes helperMethods. Helpers may call each other, so
+// candidates calling a method that is not one are dropped until none are.
+func findHelperMethods(ctx *MigrationContext, classBody *tree_sitter.Node) map[string]bool {
+	classNode := classBody.Parent()
+	if nodeKind(classNode) != "class_declaration" || classNode.ChildByFieldName("type_parameters") != nil {
+		// Type parameters of the class would have to become those of the
+		// function
+		return nil
+	}
+	if _, isInner := ctx.InnerClasses[goTypeName(ctx, classNode)]; isInner {
+		return nil
+	}
+	fields := make(map[string]bool)
+	staticMethods := make(map[string]bool)
+	innerClasses := make(map[string]bool)
+	candidates := make(map[string]bool)
+	rejected := make(map[string]bool)
+	IterateChildren(classBody, func(member *tree_sitter.Node) {
+		mods := declarationModifiers(ctx, member)
+		switch nodeKind(member) {
+		case "field_declaration":
+			if mods&STATIC == 0 {
+				IterateChildren(member, func(child *tree_sitter.Node) {
+					if nodeKind(child) == "variable_declarator" {
+						fields[ctx.nodeText(child.ChildByFieldName("name"))] = true
+					}
+				})
+			}
+		case "class_declaration":
+			if mods&STATIC == 0 {
+				innerClasses[ctx.nodeText(member.ChildByFieldName("name"))] = true
+			}
+		case "method_declaration":
+			name := ctx.nodeText(member.ChildByFieldName("name"))
+			switch {
+			case mods&STATIC != 0:
+				staticMethods[name] = true
+			case mods&PRIVATE != 0 && mods&ABSTRACT == 0 && member.ChildByFieldName("body") != nil:
+				candidates[name] = true
+			default:
+				rejected[name] = true
+			}
+		}
+	})
+	for name := range rejected {
+		// Overloads that are not candidates keep all of them methods
+		delete(candidates, name)
+	}
+	rejectQualifiedCalls(ctx, classBody, candidates)
+
+	for changed := true; changed; {
+		changed = false
+		IterateChildren(classBody, func(member *tree_sitter.Node) {
+			if nodeKind(member) != "method_declaration" || !candidates[ctx.nodeText(member.ChildByFieldName("name"))] {
+				return
+			}
+			if usesInstance(ctx, member.ChildByFieldName("body"), fields, innerClasses, func(callee string) bool {
+				return staticMethods[callee] || candidates[callee]
+			}) {
+				delete(candidates, ctx.nodeText(member.ChildByFieldName("name")))
+				changed = true
+			}
+		})
+	}
+	return candidates
+}
+
+// rejectQualifiedCalls drops the candidates called on other objects than
+// this, or referred to by method references, anywhere in classBody
+func rejectQualifiedCalls(ctx *MigrationContext, classBody *tree_sitter.Node, candidates map[string]bool) {
+	var visit func(node *tree_sitter.Node)
+	visit = func(node *tree_sitter.Node) {
+		switch nodeKind(node) {
+		case "method_invocation":
+			object := node.ChildByFieldName("object")
+			if object != nil && nodeKind(object) != "this" {
+				delete(candidates, ctx.nodeText(node.ChildByFieldName("name")))
+			}
+		case "method_reference":
+			delete(candidates, ctx.nodeText(node.NamedChild(node.NamedChildCount()-1)))
+		}
+		IterateChildren(node, visit)
+	}
+	visit(classBody)
+}
+
+// usesInstance reports whether body refers to this, super, a field of the
+// instance, an inner class, or calls a method for which callable is false
+func usesInstance(ctx *MigrationContext, body *tree_sitter.Node, fields, innerClasses map[string]bool, callable func(string) bool) bool {
+	uses := false
+	var visit func(node *tree_sitter.Node)
+	visit = func(node *tree_sitter.Node) {
+		switch nodeKind(node) {
+		case "this", "super":
+			uses = true
+		case "identifier":
+			uses = uses || (fields[ctx.nodeText(node)] && isNameReference(node))
+		case "type_identifier":
+			uses = uses || innerClasses[ctx.nodeText(node)]
+		case "method_invocation":
+			object := node.ChildByFieldName("object")
+			if object == nil || nodeKind(object) == "this" {
+				uses = uses || !callable(ctx.nodeText(node.ChildByFieldName("name")))
+			}
+			// A call on this is fine once the callee is a function
+			if object != nil && nodeKind(object) == "this" {
+				visit(node.ChildByFieldName("arguments"))
+				return
+			}
+		}
+		IterateChildren(node, visit)
+	}
+	visit(body)
+	return uses
+}
@@ -0,0 +1,218 @@
+package java
+
+import (
+	"maps"
+	"strings"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_java "github.com/tree-sitter/tree-sitter-java/bindings/go"
+)
+
+// IncrementalMigrator migrates successive versions of the same Java file. It
+// keeps the previous tree so an edited source is reparsed incrementally, and
+// keeps the parsed method and constructor signatures of the previous run so
+// only edited declarations are analyzed again.
+type IncrementalMigrator struct {
+	parser       *tree_sitter.Parser
+	tree         *tree_sitter.Tree
+	source       []byte
+	fileName     string
+	strictness   Strictness
+	typeMappings map[string]string
+	options      Options
+	cache        *analysisCache
+}
+
+// NewIncrementalMigrator creates a migrator for the file named fileName,
+// migrating it with opts. Call Close when done to release the parser and the
+// last tree.
+func NewIncrementalMigrator(fileName string, strictness Strictness, typeMappings map[string]string, opts Options) *IncrementalMigrator {
+	parser := tree_sitter.NewParser()
+	parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_java.Language()))
+	return &IncrementalMigrator{
+		parser:       parser,
+		fileName:     fileName,
+		strictness:   strictness,
+		typeMappings: typeMappings,
+		options:      opts,
+		cache:        newAnalysisCache(),
+	}
+}
+
+// Migrate migrates source, which is usually an edited version of the source
+// passed to the previous call, and returns the resulting context
+func (m *IncrementalMigrator) Migrate(source []byte) *MigrationContext {
+	oldTree := m.tree
+	if oldTree != nil {
+		edit := sourceEdit(m.source, source)
+		oldTree.Edit(&edit)
+	}
+	m.tree = m.parser.Parse(source, oldTree)
+	if oldTree != nil {
+		oldTree.Close()
+	}
+	m.source = source
+
+	ctx := NewMigrationContext(source, m.fileName, m.strictness, m.typeMappings)
+	ApplyOptions(ctx, m.options)
+	ctx.analysisCache = m.cache
+	MigrateTree(ctx, m.tree)
+	return ctx
+}
+
+// LastAnalysis reports how many signatures the last Migrate call reused from
+// the previous run and how many it had to parse
+func (m *IncrementalMigrator) LastAnalysis() (reused, parsed int) {
+	return m.cache.reused, m.cache.parsed
+}
+
+// Close releases the parser and the last tree
+func (m *IncrementalMigrator) Close() {
+	if m.tree != nil {
+		m.tree.Close()
+	}
+	m.parser.Close()
+}
+
+// sourceEdit describes the change from oldSource to newSource as a single
+// edit spanning everything between their common prefix and common suffix
+func sourceEdit(oldSource, newSource []byte) tree_sitter.InputEdit {
+	start := 0
+	for start < len(oldSource) && start < len(newSource) && oldSource[start] == newSource[start] {
+		start++
+	}
+	oldEnd, newEnd := len(oldSource), len(newSource)
+	for oldEnd > start && newEnd > start && oldSource[oldEnd-1] == newSource[newEnd-1] {
+		oldEnd--
+		newEnd--
+	}
+	return tree_sitter.InputEdit{
+		StartByte:      uint(start),
+		OldEndByte:     uint(oldEnd),
+		NewEndByte:     uint(newEnd),
+		StartPosition:  pointAt(oldSource, start),
+		OldEndPosition: pointAt(oldSource, oldEnd),
+		NewEndPosition: pointAt(newSource, newEnd),
+	}
+}
+
+// pointAt returns the row and byte column of offset in source
+func pointAt(source []byte, offset int) tree_sitter.Point {
+	point := tree_sitter.Point{}
+	for _, b := range source[:offset] {
+		switch b {
+		case '\n':
+			point.Row++
+			point.Column = 0
+		default:
+			point.Column++
+		}
+	}
+	return point
+}
+
+// analysisCache holds parsed signatures across incremental runs. Node IDs
+// change whenever a parent is rebuilt, even if the reparse reused the
+// declaration itself, so entries are keyed by the declaration's text; a
+// method signature only depends on that text. Constructors and static
+// factory methods are named after the enclosing types, so their text is
+// qualified by the class name. Entries not looked up during a run are dropped when it
+// finishes, so signatures of edited or deleted declarations don't accumulate.
+type analysisCache struct {
+	methods          map[string]methodMetadata
+	constructors     map[string]constructorMetadata
+	nextMethods      map[string]methodMetadata
+	nextConstructors map[string]constructorMetadata
+	classes          map[string]bool // Classes of the previous run
+	reused           int
+	parsed           int
+}
+
+func newAnalysisCache() *analysisCache {
+	return &analysisCache{
+		methods:          make(map[string]methodMetadata),
+		constructors:     make(map[string]constructorMetadata),
+		nextMethods:      make(map[string]methodMetadata),
+		nextConstructors: make(map[string]constructorMetadata),
+	}
+}
+
+// methodSignature returns the signature of methodNode, reusing the cached one
+// if the declaration is unchanged since the previous run
+func (cache *analysisCache) methodSignature(ctx *MigrationContext, methodNode *tree_sitter.Node) methodMetadata {
+	if cache == nil {
+		return parseMethodSignature(ctx, methodNode)
+	}
+	text := qualifiedDeclarationText(ctx, methodNode)
+	metadata, ok := cache.methods[text]
+	switch {
+	case ok:
+		cache.reused++
+	default:
+		metadata = parseMethodSignature(ctx, methodNode)
+		cache.parsed++
+		// Clone so the cache doesn't pin every previous version of the source
+		text = strings.Clone(text)
+	}
+	cache.nextMethods[text] = metadata
+	return metadata
+}
+
+// constructorSignature is the constructor counterpart of methodSignature
+func (cache *analysisCache) constructorSignature(ctx *MigrationContext, constructorNode *tree_sitter.Node) constructorMetadata {
+	if cache == nil {
+		return parseConstructorSignature(ctx, constructorNode)
+	}
+	text := qualifiedDeclarationText(ctx, constructorNode)
+	metadata, ok := cache.constructors[text]
+	switch {
+	case ok:
+		cache.reused++
+	default:
+		metadata = parseConstructorSignature(ctx, constructorNode)
+		cache.parsed++
+		text = strings.Clone(text)
+	}
+	cache.nextConstructors[text] = metadata
+	return metadata
+}
+
+// qualifiedDeclarationText returns the text of a member declaration
+// qualified by the name of the type declaring it
+func qualifiedDeclarationText(ctx *MigrationContext, declaration *tree_sitter.Node) string {
+	text := ctx.nodeText(declaration)
+	if classNode := enclosingTypeDeclaration(declaration); classNode != nil {
+		text = qualifiedTypeName(ctx, classNode) + ":" + text
+	}
+	return text
+}
+
+// keepClasses drops the cached signatures if classes, the concrete classes of
+// the current run, differ from those of the previous one
+func (cache *analysisCache) keepClasses(classes map[string]bool) {
+	if cache == nil {
+		return
+	}
+	if !maps.Equal(cache.classes, classes) {
+		cache.methods = make(map[string]methodMetadata)
+		cache.constructors = make(map[string]constructorMetadata)
+	}
+	cache.classes = maps.Clone(classes)
+}
+
+// start resets the counters before an analysis run
+func (cache *analysisCache) start() {
+	if cache == nil {
+		return
+	}
+	cache.reused, cache.parsed = 0, 0
+}
+
+// finish keeps only the entries used by the run that just completed
+func (cache *analysisCache) finish() {
+	if cache == nil {
+		return
+	}
+	cache.methods, cache.nextMethods = cache.nextMethods, make(map[string]methodMetadata)
+	cache.constructors, cache.nextConstructors = cache.nextConstructors, make(map[string]constructorMetadata)
+}
@@ -0,0 +1,224 @@
+package java
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// indexLoop is a for loop counting an index from 0 up to the length of a
+// local array, for (int i = 0; i < items.length; i++)
+type indexLoop struct {
+	index     string // Java name of the index variable
+	array     string // Java name of the array
+	parameter bool   // Whether the array is a parameter, which is passed as a pointer to the slice
+	body      *tree_sitter.Node
+}
+
+// convertIndexRangeStatement converts a for loop counting an index over a
+// local array to a range loop over the array. Reads of items[i] become the
+// range value when the loop neither writes the elements nor uses the array
+// otherwise, and the index is dropped if nothing else uses it.
+func convertIndexRangeStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) ([]gosrc.Statement, bool) {
+	loop, ok := matchIndexLoop(ctx, stmtNode)
+	if !ok {
+		return nil, false
+	}
+	uses, ok := indexLoopUses(ctx, loop, loop.body)
+	if !ok {
+		return nil, false
+	}
+
+	collection := loop.array
+	if loop.parameter {
+		collection = "*" + collection
+	}
+	rangeLoop := &gosrc.RangeForStatement{
+		IndexVar:       loop.index,
+		CollectionExpr: ctx.arena.VarRef(gosrc.VarRef{Ref: collection}),
+	}
+	if uses.elementReads && !uses.otherArrayUses {
+		rangeLoop.ValueVar = rangeElementName(ctx, loop)
+		outer := ctx.rangeElements
+		ctx.rangeElements = maps.Clone(outer)
+		if ctx.rangeElements == nil {
+			ctx.rangeElements = make(map[string]string)
+		}
+		ctx.rangeElements[loop.array+"["+loop.index+"]"] = rangeLoop.ValueVar
+		defer func() { ctx.rangeElements = outer }()
+	}
+	rangeLoop.Body = convertStatementBlock(ctx, loop.body)
+
+	// Whether the variables are still used is told from the converted body,
+	// since some expressions are copied from the Java source as they are
+	if !gosrc.UsesIdentifier(rangeLoop.Body, loop.index) {
+		rangeLoop.IndexVar = "_"
+	}
+	if rangeLoop.ValueVar != "" && !gosrc.UsesIdentifier(rangeLoop.Body, rangeLoop.ValueVar) {
+		rangeLoop.ValueVar = ""
+	}
+	return []gosrc.Statement{rangeLoop}, true
+}
+
+// matchIndexLoop reports whether stmtNode is an index loop over a local
+// array, and returns it if it is
+func matchIndexLoop(ctx *MigrationContext, stmtNode *tree_sitter.Node) (indexLoop, bool) {
+	initNode := stmtNode.ChildByFieldName("init")
+	if nodeKind(initNode) != "local_variable_declaration" || ctx.nodeText(initNode.ChildByFieldName("type")) != "int" {
+		return indexLoop{}, false
+	}
+	declarator := initNode.ChildByFieldName("declarator")
+	if declarator.NextNamedSibling() != nil || ctx.nodeText(declarator.ChildByFieldName("value")) != "0" {
+		// More than one variable, or a start other than 0
+		return indexLoop{}, false
+	}
+	index := ctx.nodeText(declarator.ChildByFieldName("name"))
+
+	condition := stmtNode.ChildByFieldName("condition")
+	if nodeKind(condition) != "binary_expression" || ctx.nodeText(condition.ChildByFieldName("operator")) != "<" ||
+		ctx.nodeText(condition.ChildByFieldName("left")) != index {
+		return indexLoop{}, false
+	}
+	bound := condition.ChildByFieldName("right")
+	if nodeKind(bound) != "field_access" || ctx.nodeText(bound.ChildByFieldName("field")) != "length" {
+		return indexLoop{}, false
+	}
+	arrayNode := bound.ChildByFieldName("object")
+	if nodeKind(arrayNode) != "identifier" {
+		return indexLoop{}, false
+	}
+	array := ctx.nodeText(arrayNode)
+	typeNode := declaredType(ctx, arrayNode, array)
+	if typeNode == nil || nodeKind(typeNode.Parent()) == "field_declaration" {
+		// Any call may replace an array held by a field, which Java would
+		// notice when checking the condition
+		return indexLoop{}, false
+	}
+
+	cursor := stmtNode.Walk()
+	defer cursor.Close()
+	if updates := stmtNode.ChildrenByFieldName("update", cursor); len(updates) != 1 {
+		return indexLoop{}, false
+	}
+	switch strings.Join(strings.Fields(ctx.nodeText(stmtNode.ChildByFieldName("update"))), "") {
+	case index + "++", "++" + index, index + "+=1":
+	default:
+		return indexLoop{}, false
+	}
+	return indexLoop{
+		index:     index,
+		array:     array,
+		parameter: nodeKind(typeNode.Parent()) == "formal_parameter",
+		body:      stmtNode.ChildByFieldName("body"),
+	}, true
+}
+
+// indexLoopUsage tells how the body of an index loop uses the array
+type indexLoopUsage struct {
+	elementReads   bool // items[i] is read
+	otherArrayUses bool // The array is used other than by reading items[i], which may change its elements
+}
+
+// indexLoopUses returns how node, part of the body of loop, uses the array.
+// It reports false if node assigns the index or the array, which a range
+// loop can't follow.
+func indexLoopUses(ctx *MigrationContext, loop indexLoop, node *tree_sitter.Node) (indexLoopUsage, bool) {
+	var uses indexLoopUsage
+	switch nodeKind(node) {
+	case "assignment_expression":
+		if target := ctx.nodeText(node.ChildByFieldName("left")); target == loop.index || target == loop.array {
+			return uses, false
+		}
+	case "update_expression":
+		if operand := strings.Trim(ctx.nodeText(node), "+- \t"); operand == loop.index {
+			return uses, false
+		}
+	case "array_access":
+		if isRangeElementRead(ctx, loop, node) {
+			uses.elementReads = true
+			// The index is read as well, which is fine
+			return uses, true
+		}
+	case "identifier":
+		if ctx.nodeText(node) == loop.array {
+			uses.otherArrayUses = true
+		}
+	}
+	ok := true
+	IterateChildrenWhile(node, func(child *tree_sitter.Node) bool {
+		childUses, childOk := indexLoopUses(ctx, loop, child)
+		uses.elementReads = uses.elementReads || childUses.elementReads
+		uses.otherArrayUses = uses.otherArrayUses || childUses.otherArrayUses
+		ok = childOk
+		return ok
+	})
+	return uses, ok
+}
+
+// isRangeElementRead reports whether node, an array_access, reads the
+// element of the array at the index of loop
+func isRangeElementRead(ctx *MigrationContext, loop indexLoop, node *tree_sitter.Node) bool {
+	if ctx.nodeText(node.ChildByFieldName("array")) != loop.array || ctx.nodeText(node.ChildByFieldName("index")) != loop.index {
+		return false
+	}
+	parent := node.Parent()
+	switch nodeKind(parent) {
+	case "assignment_expression":
+		return parent.ChildByFieldName("left").Id() != node.Id()
+	case "update_expression":
+		return false
+	case "array_access", "field_access", "method_invocation":
+		// The element may be changed through them, which the range value
+		// would not see
+		return false
+	default:
+		return true
+	}
+}
+
+// rangeElementName returns a name for the range value of loop, derived from
+// the name of the array, that doesn't clash with the names used in its body
+func rangeElementName(ctx *MigrationContext, loop indexLoop) string {
+	base := loop.array + "Elem"
+	if singular, plural := strings.CutSuffix(loop.array, "s"); plural && singular != "" {
+		base = singular
+	}
+	name := base
+	for usesIdentifier(ctx.nodeText(loop.body), name) || name == loop.index {
+		name = ctx.tempName(base)
+	}
+	return name
+}
+
+// usesIdentifier reports whether source contains the identifier name
+func usesIdentifier(source, name string) bool {
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`).MatchString(source)
+}
+
+// convertArrayAccess replaces reads of the element a range loop iterates
+// over with its range value, and indexes arrays indexed by the ordinal of an
+// enum value by the value itself
+func convertArrayAccess(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	if receiver, _, ok := ordinalReceiver(ctx, expression.ChildByFieldName("index")); ok {
+		return convertOrdinalIndex(ctx, expression, receiver)
+	}
+	key := ctx.nodeText(expression.ChildByFieldName("array")) + "[" + ctx.nodeText(expression.ChildByFieldName("index")) + "]"
+	if name, ok := ctx.rangeElements[key]; ok {
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: name}), nil
+	}
+	// Arrays returned by calls, as in Color.values()[0], are converted like
+	// any other call
+	if array := expression.ChildByFieldName("array"); containsInvocation(array) {
+		arrayExpr, initStmts := convertExpression(ctx, array)
+		index, indexInit := convertExpression(ctx, expression.ChildByFieldName("index"))
+		return ctx.arena.GoExpression(gosrc.GoExpression{
+			Source: fmt.Sprintf("%s[%s]", arrayExpr.ToSource(), index.ToSource()),
+		}), append(initStmts, indexInit...)
+	}
+	return convertVerbatimExpression(ctx, expression)
+}
@@ -0,0 +1,188 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// outerFieldName is the field of a migrated inner class that points to the
+// instance of the enclosing class it belongs to, which Java keeps implicitly
+const outerFieldName = "outer"
+
+// collectInnerClass records typeNode in ctx.InnerClasses if it is an inner
+// class
+func collectInnerClass(ctx *MigrationContext, typeNode *tree_sitter.Node) {
+	if outer, ok := innerClassOuter(ctx, typeNode); ok {
+		ctx.InnerClasses[goTypeName(ctx, typeNode)] = goTypeName(ctx, outer)
+	}
+}
+
+// innerClassOuter returns the class whose instances the inner class
+// classNode belongs to. Inner classes are the non-static member classes of
+// classes and records; classes nested in interfaces are implicitly static,
+// and classes nested in abstract classes are left alone since their outer
+// instance would be an interface.
+func innerClassOuter(ctx *MigrationContext, classNode *tree_sitter.Node) (*tree_sitter.Node, bool) {
+	if nodeKind(classNode) != "class_declaration" || nodeKind(classNode.Parent()) != "class_body" {
+		return nil, false
+	}
+	outer := classNode.Parent().Parent()
+	switch nodeKind(outer) {
+	case "class_declaration", "record_declaration":
+	default:
+		return nil, false
+	}
+	if ctx.AbstractClasses[ctx.nodeText(outer.ChildByFieldName("name"))] || ctx.AbstractClasses[ctx.nodeText(classNode.ChildByFieldName("name"))] {
+		return nil, false
+	}
+	return outer, declarationModifiers(ctx, classNode)&STATIC == 0
+}
+
+// declarationModifiers returns the modifiers of a declaration node
+func declarationModifiers(ctx *MigrationContext, node *tree_sitter.Node) modifiers {
+	var mods modifiers
+	IterateChildren(node, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "modifiers" {
+			mods = ParseModifiers(ctx.nodeText(child))
+		}
+	})
+	return mods
+}
+
+// addOuterParam makes the constructor of an inner class take the instance of
+// outerType it belongs to as its first parameter
+func addOuterParam(constructor *gosrc.Function, outerType string) {
+	constructor.Params = append([]gosrc.Param{{Name: outerFieldName, Ty: gosrc.Type("*" + outerType)}}, constructor.Params...)
+	if _, delegates := constructor.Body[0].(*gosrc.VarDeclaration); delegates {
+		// The constructor delegated to by this(...) already set it
+		return
+	}
+	// The first statement declares the struct being constructed
+	setOuter := &gosrc.AssignStatement{
+		Ref:   gosrc.VarRef{Ref: gosrc.SelfRef + "." + outerFieldName},
+		Value: &gosrc.VarRef{Ref: outerFieldName},
+	}
+	constructor.Body = append(constructor.Body[:1], append([]gosrc.Statement{setOuter}, constructor.Body[1:]...)...)
+}
+
+// enclosingInstance returns the Go expression of the instance of the
+// innermost class enclosing node that matches, reached from this through the
+// outer fields of the inner classes in between. It returns false if there is
+// no such instance, as in a static method.
+func enclosingInstance(ctx *MigrationContext, node *tree_sitter.Node, matches func(scope *tree_sitter.Node) bool) (string, bool) {
+	if inStaticMember(ctx, node) {
+		return "", false
+	}
+	ref := gosrc.SelfRef
+	for scope := enclosingTypeDeclaration(node); scope != nil; scope = enclosingTypeDeclaration(scope) {
+		if matches(scope) {
+			return ref, true
+		}
+		if _, ok := ctx.InnerClasses[goTypeName(ctx, scope)]; !ok {
+			return "", false
+		}
+		ref += "." + outerFieldName
+	}
+	return "", false
+}
+
+// inStaticMember reports whether node is in a static method, initializer or
+// field of the class enclosing it
+func inStaticMember(ctx *MigrationContext, node *tree_sitter.Node) bool {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch nodeKind(parent) {
+		case "method_declaration", "field_declaration":
+			return declarationModifiers(ctx, parent)&STATIC != 0
+		case "static_initializer":
+			return true
+		case "class_body":
+			return false
+		}
+	}
+	return false
+}
+
+// innerClassOuterType returns the Go name of the class whose instances the
+// inner class ty belongs to
+func (ctx *MigrationContext) innerClassOuterType(ty gosrc.Type) (string, bool) {
+	outerType, ok := ctx.InnerClasses[string(ty)]
+	return outerType, ok
+}
+
+// outerInstanceArgument returns the instance of outerType that a new instance
+// of an inner class created by expression belongs to: the object it is
+// created on, as in outer.new Inner(), or else the innermost enclosing
+// instance of outerType
+func outerInstanceArgument(ctx *MigrationContext, expression *tree_sitter.Node, outerType string) (gosrc.Expression, []gosrc.Statement) {
+	// The object is the expression before the new keyword
+	var outerNode *tree_sitter.Node
+	IterateChildrenWhile(expression, func(child *tree_sitter.Node) bool {
+		if nodeKind(child) == "new" {
+			return false
+		}
+		if child.IsNamed() {
+			outerNode = child
+		}
+		return true
+	})
+	if outerNode != nil && nodeKind(outerNode) != "this" {
+		// Instances of classes are held by pointers
+		return convertExpression(ctx, outerNode)
+	}
+	ref, ok := enclosingInstance(ctx, expression, func(scope *tree_sitter.Node) bool {
+		return goTypeName(ctx, scope) == outerType
+	})
+	if !ok {
+		FatalError(ctx, expression, fmt.Sprintf("no enclosing instance of %s to create the inner class with", outerType), "object_creation_expression")
+	}
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: ref}), nil
+}
+
+// isQualifiedThis reports whether node is a qualified this, as in Outer.this
+func isQualifiedThis(node *tree_sitter.Node) bool {
+	return nodeKind(node) == "field_access" && nodeKind(node.ChildByFieldName("field")) == "this"
+}
+
+// convertQualifiedThis returns the Go expression of Outer.this, the instance
+// of the enclosing class Outer, along with the Go name of Outer
+func convertQualifiedThis(ctx *MigrationContext, node *tree_sitter.Node) (string, string) {
+	javaName := ctx.nodeText(node.ChildByFieldName("object"))
+	var goName string
+	ref, ok := enclosingInstance(ctx, node, func(scope *tree_sitter.Node) bool {
+		goName = goTypeName(ctx, scope)
+		return ctx.nodeText(scope.ChildByFieldName("name")) == javaName
+	})
+	if !ok {
+		FatalError(ctx, node, fmt.Sprintf("no enclosing instance of %s", javaName), "field_access")
+	}
+	return ref, goName
+}
+
+// outerMethodReceiver returns the enclosing instance a bare call of the method
+// name in an inner class is made on, if the inner class doesn't declare the
+// method itself
+func outerMethodReceiver(ctx *MigrationContext, node *tree_sitter.Node, name string) (string, bool) {
+	if len(ctx.InnerClasses) == 0 {
+		return "", false
+	}
+	ref, ok := enclosingInstance(ctx, node, func(scope *tree_sitter.Node) bool {
+		return declaresMethod(ctx, scope, name)
+	})
+	return ref, ok && strings.Contains(ref, "."+outerFieldName)
+}
+
+// declaresMethod reports whether the body of typeNode declares an instance
+// method called name
+func declaresMethod(ctx *MigrationContext, typeNode *tree_sitter.Node, name string) bool {
+	found := false
+	IterateChildren(typeNode.ChildByFieldName("body"), func(member *tree_sitter.Node) {
+		if nodeKind(member) == "method_declaration" && ctx.nodeText(member.ChildByFieldName("name")) == name && declarationModifiers(ctx, member)&STATIC == 0 {
+			found = true
+		}
+	})
+	return found
+}
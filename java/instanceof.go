@@ -0,0 +1,129 @@
+package java
+
+import (
+	"fmt"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// convertInstanceofExpression converts an instanceof test to a type assertion
+// whose boolean result is declared before the expression, along with the
+// pattern variable, if any. Tests an enclosing if statement asserts in its
+// init statement are the result it declares.
+func convertInstanceofExpression(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	if result, ok := ctx.instanceofResults[expression.StartByte()]; ok {
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: result}), nil
+	}
+	result := ctx.tempName("is" + gosrc.CapitalizeFirstLetter(ctx.nodeText(baseTypeNode(expression.ChildByFieldName("right")))))
+	assertion, initStmts := instanceofAssertion(ctx, expression, result)
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: result}), append(initStmts, assertion)
+}
+
+// instanceofAssertion returns the statement asserting the type an instanceof
+// expression tests, declaring its pattern variable and result, the variable
+// holding whether the assertion holds. The statements converting the tested
+// value are returned too.
+func instanceofAssertion(ctx *MigrationContext, expression *tree_sitter.Node, result string) (gosrc.Statement, []gosrc.Statement) {
+	if pattern := expression.ChildByFieldName("pattern"); pattern != nil {
+		FatalError(ctx, pattern, "record patterns are not supported", "instanceof_expression")
+	}
+	valueNode := expression.ChildByFieldName("left")
+	value, initStmts := convertExpression(ctx, valueNode)
+	typeNode := expression.ChildByFieldName("right")
+	ty, ok := TryParseType(ctx, typeNode)
+	if !ok {
+		FatalError(ctx, typeNode, "unable to parse type in instanceof_expression", "instanceof_expression")
+	}
+	// Only interface values can be asserted, so other values are converted
+	// to one
+	source := value.ToSource()
+	if valueTy, ok := valueType(ctx, valueNode); !ok || (valueTy != "interface{}" && valueTy != "any") {
+		source = "any(" + source + ")"
+	}
+	binding := "_"
+	if name := expression.ChildByFieldName("name"); name != nil {
+		binding = ctx.nodeText(name)
+	}
+	return &gosrc.GoStatement{Source: fmt.Sprintf("%s, %s := %s.(%s)", binding, result, source, ty)}, initStmts
+}
+
+// leadingInstanceof returns the instanceof expression evaluated first by the
+// condition of an if statement, looking through &&, || and !, and whether it
+// is negated. Its assertion can be the init statement of the if, whose
+// variables are in scope in the whole condition and in every branch.
+func leadingInstanceof(ctx *MigrationContext, condition *tree_sitter.Node) (*tree_sitter.Node, bool) {
+	negated := false
+	node := unwrapParentheses(condition)
+	for {
+		switch nodeKind(node) {
+		case "instanceof_expression":
+			return node, negated
+		case "binary_expression":
+			if operator := ctx.nodeText(node.ChildByFieldName("operator")); operator != "&&" && operator != "||" {
+				return nil, false
+			}
+			node = unwrapParentheses(node.ChildByFieldName("left"))
+		case "unary_expression":
+			if ctx.nodeText(node.ChildByFieldName("operator")) != "!" {
+				return nil, false
+			}
+			negated = !negated
+			node = unwrapParentheses(node.ChildByFieldName("operand"))
+		default:
+			return nil, false
+		}
+	}
+}
+
+// instanceofInit returns the init statement of the if statement stmtNode
+// asserting the instanceof test its condition starts with, and the
+// statements converting the tested value, or nil if it has none. A pattern
+// variable a negated test binds is in scope after the if in Java, so if it is
+// referred to there the assertion is left to be declared before the if,
+// unless the if is an else if.
+func instanceofInit(ctx *MigrationContext, stmtNode *tree_sitter.Node, inner bool) (gosrc.Statement, []gosrc.Statement) {
+	test, negated := leadingInstanceof(ctx, stmtNode.ChildByFieldName("condition"))
+	if test == nil {
+		return nil, nil
+	}
+	if name := test.ChildByFieldName("name"); negated && name != nil && !inner {
+		for next := stmtNode.NextNamedSibling(); next != nil; next = next.NextNamedSibling() {
+			if referencesName(ctx, next, ctx.nodeText(name)) {
+				return nil, nil
+			}
+		}
+	}
+	result := ctx.tempName("ok")
+	assertion, initStmts := instanceofAssertion(ctx, test, result)
+	if ctx.instanceofResults == nil {
+		ctx.instanceofResults = make(map[uint]string)
+	}
+	ctx.instanceofResults[test.StartByte()] = result
+	return assertion, initStmts
+}
+
+// patternType returns the type node of the pattern variable called name an
+// instanceof expression in node binds, or nil if there is none
+func patternType(ctx *MigrationContext, node *tree_sitter.Node, name string) *tree_sitter.Node {
+	if node == nil {
+		return nil
+	}
+	switch nodeKind(node) {
+	case "instanceof_expression":
+		if binding := node.ChildByFieldName("name"); binding != nil && ctx.nodeText(binding) == name {
+			return node.ChildByFieldName("right")
+		}
+	case "lambda_expression", "class_body":
+		// Their tests bind variables of their own
+		return nil
+	}
+	var typeNode *tree_sitter.Node
+	IterateChildren(node, func(child *tree_sitter.Node) {
+		if typeNode == nil {
+			typeNode = patternType(ctx, child, name)
+		}
+	})
+	return typeNode
+}
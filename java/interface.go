@@ -2,6 +2,7 @@ package java
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/heshanpadmasiri/javaGo/gosrc"
 
@@ -9,22 +10,25 @@ import (
 )
 
 func migrateInterfaceDeclaration(ctx *MigrationContext, interfaceNode *tree_sitter.Node) {
-	var interfaceName string
+	interfaceName := identifierText(ctx, interfaceNode, interfaceNode.ChildByFieldName("name"), "interface_declaration")
 	var superInterfaces []gosrc.Type
 	var regularMethods []gosrc.InterfaceMethod
 	var defaultMethods []gosrc.Function
 	var staticMethods []gosrc.Function
+	var typeParams []gosrc.TypeParam
 
 	IterateChildren(interfaceNode, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "modifiers":
 			// Interfaces are always public, so we don't need to parse modifiers
 		case "identifier":
-			interfaceName = child.Utf8Text(ctx.JavaSource)
+			// The name is read before iterating
+		case "type_parameters":
+			typeParams = parseTypeParameters(ctx, child)
 		case "extends_interfaces":
 			// Parse extends clause - iterate through children to find type_list
 			IterateChildren(child, func(extendsChild *tree_sitter.Node) {
-				if extendsChild.Kind() == "type_list" {
+				if nodeKind(extendsChild) == "type_list" {
 					// Iterate through the type_list to get individual types
 					IterateChildren(extendsChild, func(typeChild *tree_sitter.Node) {
 						ty, ok := TryParseType(ctx, typeChild)
@@ -38,14 +42,14 @@ func migrateInterfaceDeclaration(ctx *MigrationContext, interfaceNode *tree_sitt
 			// Parse methods in interface body
 			IterateChildren(child, func(bodyChild *tree_sitter.Node) {
 				// Skip ignored tokens
-				switch bodyChild.Kind() {
+				switch nodeKind(bodyChild) {
 				case "{", "}", ";", "line_comment", "block_comment":
 					return
 				}
 
 				// Wrap member migration in error recovery
-				failed := tryMigrateMember(ctx, fmt.Sprintf("interface %s.%s", interfaceName, bodyChild.Kind()), bodyChild, func() {
-					switch bodyChild.Kind() {
+				failed := tryMigrateMember(ctx, fmt.Sprintf("interface %s.%s", interfaceName, nodeKind(bodyChild)), bodyChild, func() {
+					switch nodeKind(bodyChild) {
 					case "class_declaration":
 						migrateClassDeclaration(ctx, bodyChild)
 					case "record_declaration":
@@ -58,11 +62,11 @@ func migrateInterfaceDeclaration(ctx *MigrationContext, interfaceNode *tree_sitt
 
 						if isDefault {
 							// Default method - convert to standalone function with 'this' parameter
-							function := convertMethodDeclarationToFunction(ctx, bodyChild, true, interfaceName)
+							function := convertMethodDeclarationToFunction(ctx, bodyChild, true, interfaceName, typeParams)
 							defaultMethods = append(defaultMethods, function)
 						} else if isStatic {
 							// Static method - convert to package-level function
-							function := convertMethodDeclarationToFunction(ctx, bodyChild, false, "")
+							function := convertMethodDeclarationToFunction(ctx, bodyChild, false, "", nil)
 							staticMethods = append(staticMethods, function)
 						} else {
 							// Regular method - add to interface
@@ -89,12 +93,14 @@ func migrateInterfaceDeclaration(ctx *MigrationContext, interfaceNode *tree_sitt
 	})
 
 	// Generate Go interface with regular methods
+	importConstraints(ctx, typeParams)
 	goInterface := gosrc.Interface{
-		Name:     gosrc.CapitalizeFirstLetter(interfaceName),
-		Embeds:   superInterfaces,
-		Methods:  regularMethods,
-		Public:   true, // Java interfaces are always public
-		Comments: []string{},
+		Name:       gosrc.CapitalizeFirstLetter(interfaceName),
+		TypeParams: typeParams,
+		Embeds:     superInterfaces,
+		Methods:    regularMethods,
+		Public:     true, // Java interfaces are always public
+		Comments:   []string{},
 	}
 	ctx.Source.Interfaces = append(ctx.Source.Interfaces, goInterface)
 
@@ -112,16 +118,20 @@ func migrateInterfaceDeclaration(ctx *MigrationContext, interfaceNode *tree_sitt
 func extractInterfaceMethodSignature(ctx *MigrationContext, methodNode *tree_sitter.Node) gosrc.InterfaceMethod {
 	// Use cached metadata
 	metadata := getMethodMetadata(ctx, methodNode)
+	params, returnType := eraseSignatureTypeParams(metadata.params, metadata.returnTy, metadata.typeParams)
 
 	return gosrc.InterfaceMethod{
 		Name:       gosrc.CapitalizeFirstLetter(metadata.name),
-		Params:     metadata.params,
-		ReturnType: metadata.returnTy,
+		Params:     params,
+		ReturnType: returnType,
 		Public:     true, // All interface methods are public
 	}
 }
 
-func convertMethodDeclarationToFunction(ctx *MigrationContext, methodNode *tree_sitter.Node, isDefault bool, interfaceName string) gosrc.Function {
+// convertMethodDeclarationToFunction converts a default or static method of an
+// interface to a function. Default methods take the receiver as their first
+// parameter, and the type parameters of the interface as their own.
+func convertMethodDeclarationToFunction(ctx *MigrationContext, methodNode *tree_sitter.Node, isDefault bool, interfaceName string, interfaceTypeParams []gosrc.TypeParam) gosrc.Function {
 	// Use cached metadata for signature
 	metadata := getMethodMetadata(ctx, methodNode)
 	name := metadata.name
@@ -131,42 +141,52 @@ func convertMethodDeclarationToFunction(ctx *MigrationContext, methodNode *tree_
 	// Parse body using ChildByFieldName
 	var body []gosrc.Statement
 	blockNode := methodNode.ChildByFieldName("body")
-	if blockNode != nil {
-		if isDefault {
-			// Set context for default method conversion
-			oldInDefaultMethod := ctx.InDefaultMethod
-			oldDefaultMethodSelf := ctx.DefaultMethodSelf
-			ctx.InDefaultMethod = true
-			ctx.DefaultMethodSelf = "this"
-
-			// Convert block with empty field map (interfaces have no fields)
-			rawBody := convertStatementBlock(ctx, blockNode)
-			for _, stmt := range rawBody {
-				body = append(body, convertStatementForDefaultMethod(ctx, stmt, interfaceName, make(map[string]bool)))
-			}
-
-			// Restore context
-			ctx.InDefaultMethod = oldInDefaultMethod
-			ctx.DefaultMethodSelf = oldDefaultMethodSelf
-		} else {
-			body = convertStatementBlock(ctx, blockNode)
-		}
+	switch {
+	case blockNode == nil:
+	case skipsMethodBody(ctx, methodNode):
+		body = stubBody()
+	case isManualMethod(ctx, methodNode):
+		body = manualBody(ctx, methodNode)
+	case isDefault:
+		// Set context for default method conversion
+		oldInDefaultMethod := ctx.InDefaultMethod
+		oldDefaultMethodSelf := ctx.DefaultMethodSelf
+		ctx.InDefaultMethod = true
+		ctx.DefaultMethodSelf = "this"
+
+		// Convert block with empty field map (interfaces have no fields)
+		body = defaultMethodRewriter(ctx, nil).Statements(convertStatementBlock(ctx, blockNode))
+
+		// Restore context
+		ctx.InDefaultMethod = oldInDefaultMethod
+		ctx.DefaultMethodSelf = oldDefaultMethodSelf
+	default:
+		body = convertStatementBlock(ctx, blockNode)
 	}
 
-	// If default method, prepend 'this' parameter
+	// If default method, dispatch to overrides and prepend 'this' parameter
 	if isDefault {
+		if blockNode != nil {
+			body = append([]gosrc.Statement{overrideDispatch(gosrc.CapitalizeFirstLetter(name), params, returnType)}, body...)
+		}
 		thisParam := gosrc.Param{
 			Name: "this",
-			Ty:   gosrc.Type(gosrc.CapitalizeFirstLetter(interfaceName)),
+			Ty:   gosrc.Instantiate(gosrc.CapitalizeFirstLetter(interfaceName), interfaceTypeParams),
 		}
 		params = append([]gosrc.Param{thisParam}, params...)
 	}
+	typeParams := metadata.typeParams
+	if isDefault {
+		typeParams = append(slices.Clip(interfaceTypeParams), typeParams...)
+	}
+	importConstraints(ctx, typeParams)
 
 	// Add migration comment
 	migrationComment := getMigrationComment(ctx, methodNode)
 
 	return gosrc.Function{
 		Name:       gosrc.CapitalizeFirstLetter(name),
+		TypeParams: typeParams,
 		Params:     params,
 		ReturnType: returnType,
 		Body:       body,
@@ -0,0 +1,262 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// needsIteratorSupport reports whether the Go source migrated from tree uses
+// the Iterator and Iterable interfaces declared by IteratorSupport. Files
+// declaring or mapping types of those names themselves use theirs.
+func needsIteratorSupport(ctx *MigrationContext, tree *tree_sitter.Tree) bool {
+	for _, name := range []string{"Iterator", "Iterable"} {
+		if _, mapped := ctx.TypeMappings[name]; mapped || declaresType(ctx, tree.RootNode(), name) {
+			return false
+		}
+	}
+	return treeRefersTo(ctx, tree, "Iterator", "Iterable", "iterator")
+}
+
+// declaresType reports whether a class, interface or enum called name is
+// declared anywhere in node
+func declaresType(ctx *MigrationContext, node *tree_sitter.Node, name string) bool {
+	found := false
+	IterateChildren(node, func(child *tree_sitter.Node) {
+		switch nodeKind(child) {
+		case "class_declaration", "interface_declaration", "enum_declaration", "record_declaration":
+			found = found || ctx.nodeText(child.ChildByFieldName("name")) == name || declaresType(ctx, child.ChildByFieldName("body"), name)
+		case "class_body", "interface_body", "enum_body", "enum_body_declarations":
+			found = found || declaresType(ctx, child, name)
+		}
+	})
+	return found
+}
+
+// IteratorSupport returns the declarations of the generic Iterator and
+// Iterable interfaces java.util.Iterator and java.lang.Iterable migrate to,
+// and of IteratorOf, which iterator() of a list or set migrates to
+func IteratorSupport() gosrc.GoSource {
+	typeParam := []gosrc.TypeParam{{Name: "T", Constraint: "any"}}
+	boolType, elementType, iteratorType := gosrc.TypeBool, gosrc.Type("T"), gosrc.Type("Iterator[T]")
+	self := gosrc.Param{Name: "it", Ty: "*sliceIterator[T]"}
+	return gosrc.GoSource{
+		Interfaces: []gosrc.Interface{
+			{
+				Name:       "Iterator",
+				TypeParams: typeParam,
+				Methods: []gosrc.InterfaceMethod{
+					{Name: "HasNext", ReturnType: &boolType, Public: true},
+					{Name: "Next", ReturnType: &elementType, Public: true},
+				},
+				Public:   true,
+				Comments: []string{"Iterator iterates over a sequence of values, migrated from java.util.Iterator"},
+			},
+			{
+				Name:       "Iterable",
+				TypeParams: typeParam,
+				Methods:    []gosrc.InterfaceMethod{{Name: "Iterator", ReturnType: &iteratorType, Public: true}},
+				Public:     true,
+				Comments:   []string{"Iterable is a sequence of values that can be iterated over, migrated from java.lang.Iterable"},
+			},
+		},
+		Structs: []gosrc.Struct{{
+			Name:       "sliceIterator",
+			TypeParams: typeParam,
+			Fields: []gosrc.StructField{
+				{Name: "items", Ty: "[]T"},
+				{Name: "next", Ty: gosrc.TypeInt},
+			},
+		}},
+		Functions: []gosrc.Function{
+			supportFunction("IteratorOf", typeParam, []gosrc.Param{{Name: "items", Ty: "[]T"}}, iteratorType,
+				"return &sliceIterator[T]{items: items}"),
+		},
+		Methods: []gosrc.Method{
+			{Function: supportFunction("HasNext", nil, nil, boolType, "return it.next < len(it.items)"), Receiver: self},
+			{Function: supportFunction("Next", nil, nil, elementType, "item := it.items[it.next]", "it.next++", "return item"), Receiver: self},
+		},
+	}
+}
+
+// isIteratorExpression reports whether expression evaluates to an Iterator
+func isIteratorExpression(ctx *MigrationContext, expression *tree_sitter.Node) bool {
+	ty, ok := valueType(ctx, expression)
+	return ok && strings.HasPrefix(string(ty), "Iterator[")
+}
+
+// convertIteratorMethod converts a call of a java.util.Iterator method on
+// receiver. Removing elements while iterating has no equivalent on the
+// slices and maps collections migrate to.
+func convertIteratorMethod(ctx *MigrationContext, expression *tree_sitter.Node, name, receiver string) (gosrc.Expression, bool) {
+	if expression.ChildByFieldName("arguments").NamedChildCount() != 0 {
+		return nil, false
+	}
+	switch name {
+	case "hasNext", "next":
+		return &gosrc.CallExpression{Function: receiver + "." + gosrc.CapitalizeFirstLetter(name)}, true
+	case "remove":
+		FatalError(ctx, expression, "Iterator.remove has no Go equivalent, remove the element from the collection instead", "method_invocation")
+	}
+	return nil, false
+}
+
+// convertCollectionIterator converts collection.iterator() on a list or set
+// to an Iterator over its elements, or returns false if collection is
+// neither
+func convertCollectionIterator(ctx *MigrationContext, expression *tree_sitter.Node, receiver string) (gosrc.Expression, bool) {
+	collection := expression.ChildByFieldName("object")
+	if expression.ChildByFieldName("arguments").NamedChildCount() != 0 || collection == nil {
+		return nil, false
+	}
+	ty, _ := valueType(ctx, collection)
+	switch {
+	case strings.HasPrefix(string(ty), "[]"):
+		items := listSource(ctx, collection, &gosrc.VarRef{Ref: receiver})
+		return &gosrc.CallExpression{Function: "IteratorOf", Args: []gosrc.Expression{&gosrc.VarRef{Ref: items}}}, true
+	case strings.HasPrefix(string(ty), "map[") && strings.HasSuffix(string(ty), "]bool"):
+		// The elements of a set are the keys of its map
+		ctx.Source.AddImport("maps")
+		ctx.Source.AddImport("slices")
+		return &gosrc.CallExpression{Function: "IteratorOf", Args: []gosrc.Expression{
+			&gosrc.VarRef{Ref: fmt.Sprintf("slices.Collect(maps.Keys(%s))", receiver)},
+		}}, true
+	default:
+		return nil, false
+	}
+}
+
+// convertIterableRangeStatement converts a for-each loop over an Iterable
+// that is not a collection, such as an instance of a class implementing
+// Iterable, to a loop calling the methods of its iterator
+func convertIterableRangeStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) ([]gosrc.Statement, bool) {
+	valueNode := stmtNode.ChildByFieldName("value")
+	ty, ok := valueType(ctx, valueNode)
+	if !ok || !ctx.isSubtype(ty, "Iterable") {
+		return nil, false
+	}
+	iterable, stmts := convertExpression(ctx, valueNode)
+	iterator := ctx.tempName("iterator")
+	next := &gosrc.VarDeclaration{
+		Name:  ctx.nodeText(stmtNode.ChildByFieldName("name")),
+		Value: &gosrc.CallExpression{Function: iterator + ".Next"},
+	}
+	body := append([]gosrc.Statement{next}, convertStatementBlock(ctx, stmtNode.ChildByFieldName("body"))...)
+	return append(stmts, &gosrc.GoStatement{Source: fmt.Sprintf("for %s := %s.Iterator(); %s.HasNext(); {\n%s}", iterator, iterable.ToSource(), iterator, statementsSource(body))}), true
+}
+
+// iteratorLoop is a loop that takes the elements of a list or set from an
+// iterator one at a time, as in
+//
+//	Iterator<T> it = items.iterator();
+//	while (it.hasNext()) {
+//	    T item = it.next();
+//	    ...
+//	}
+//
+// and uses the iterator for nothing else, so it can be a range loop
+type iteratorLoop struct {
+	collection *tree_sitter.Node // The list or set iterated over
+	element    *tree_sitter.Node // The declaration of the element taken by next()
+	body       *tree_sitter.Node // The body of the loop, starting with element
+}
+
+// matchIteratorLoop matches the declaration of the iterator decl, and the
+// loop taking elements from it with a condition and body
+func matchIteratorLoop(ctx *MigrationContext, decl, condition, body *tree_sitter.Node) (iteratorLoop, bool) {
+	if nodeKind(decl) != "local_variable_declaration" || !strings.HasPrefix(ctx.nodeText(decl.ChildByFieldName("type")), "Iterator") || nodeKind(body) != "block" {
+		return iteratorLoop{}, false
+	}
+	declarator := decl.ChildByFieldName("declarator")
+	iterator := ctx.nodeText(declarator.ChildByFieldName("name"))
+	value := declarator.ChildByFieldName("value")
+	if nodeKind(value) != "method_invocation" || ctx.nodeText(value.ChildByFieldName("name")) != "iterator" || value.ChildByFieldName("object") == nil {
+		return iteratorLoop{}, false
+	}
+	if ctx.nodeText(unwrapParentheses(condition)) != iterator+".hasNext()" {
+		return iteratorLoop{}, false
+	}
+	loop := iteratorLoop{collection: value.ChildByFieldName("object"), body: body}
+	if ty, _ := valueType(ctx, loop.collection); !strings.HasPrefix(string(ty), "[]") && !strings.HasPrefix(string(ty), "map[") {
+		return iteratorLoop{}, false
+	}
+	for i := uint(0); i < body.NamedChildCount(); i++ {
+		if kind := nodeKind(body.NamedChild(i)); kind != "line_comment" && kind != "block_comment" {
+			loop.element = body.NamedChild(i)
+			break
+		}
+	}
+	if nodeKind(loop.element) != "local_variable_declaration" {
+		return iteratorLoop{}, false
+	}
+	elementDeclarator := loop.element.ChildByFieldName("declarator")
+	if ctx.nodeText(elementDeclarator.ChildByFieldName("value")) != iterator+".next()" || elementDeclarator.NextNamedSibling() != nil {
+		return iteratorLoop{}, false
+	}
+	for statement := loop.element.NextNamedSibling(); statement != nil; statement = statement.NextNamedSibling() {
+		if referencesName(ctx, statement, iterator) {
+			return iteratorLoop{}, false
+		}
+	}
+	return loop, true
+}
+
+// convertIteratorLoop converts loop to a range loop over its collection
+func convertIteratorLoop(ctx *MigrationContext, loop iteratorLoop) []gosrc.Statement {
+	collection, stmts := convertExpression(ctx, loop.collection)
+	element := ctx.nodeText(loop.element.ChildByFieldName("declarator").ChildByFieldName("name"))
+	rangeLoop := &gosrc.RangeForStatement{ValueVar: element, CollectionExpr: collection}
+	if ty, _ := valueType(ctx, loop.collection); strings.HasPrefix(string(ty), "map[") {
+		// The elements of a set are the keys of its map
+		rangeLoop.IndexVar, rangeLoop.ValueVar = element, ""
+	} else {
+		rangeLoop.CollectionExpr = ctx.arena.GoExpression(gosrc.GoExpression{Source: listSource(ctx, loop.collection, collection)})
+	}
+	for statement := loop.element.NextNamedSibling(); statement != nil; statement = statement.NextNamedSibling() {
+		switch nodeKind(statement) {
+		case "line_comment", "block_comment":
+		default:
+			rangeLoop.Body = append(rangeLoop.Body, convertStatement(ctx, statement)...)
+		}
+	}
+	return append(stmts, rangeLoop)
+}
+
+// convertIteratorWhile converts the declaration of an iterator decl and the
+// while loop following it to a range loop if they form an iteratorLoop and
+// the iterator is not used after the loop. It returns the while loop, which
+// is converted with decl.
+func convertIteratorWhile(ctx *MigrationContext, decl *tree_sitter.Node) ([]gosrc.Statement, *tree_sitter.Node, bool) {
+	whileNode := decl.NextNamedSibling()
+	if nodeKind(decl) != "local_variable_declaration" || whileNode == nil || nodeKind(whileNode) != "while_statement" {
+		return nil, nil, false
+	}
+	loop, ok := matchIteratorLoop(ctx, decl, whileNode.ChildByFieldName("condition"), whileNode.ChildByFieldName("body"))
+	if !ok {
+		return nil, nil, false
+	}
+	iterator := ctx.nodeText(decl.ChildByFieldName("declarator").ChildByFieldName("name"))
+	for statement := whileNode.NextNamedSibling(); statement != nil; statement = statement.NextNamedSibling() {
+		if referencesName(ctx, statement, iterator) {
+			return nil, nil, false
+		}
+	}
+	return convertIteratorLoop(ctx, loop), whileNode, true
+}
+
+// convertIteratorForStatement converts a for loop declaring an iterator in
+// its init and taking elements from it in its body to a range loop if they
+// form an iteratorLoop
+func convertIteratorForStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) ([]gosrc.Statement, bool) {
+	if stmtNode.ChildByFieldName("update") != nil {
+		return nil, false
+	}
+	loop, ok := matchIteratorLoop(ctx, stmtNode.ChildByFieldName("init"), stmtNode.ChildByFieldName("condition"), stmtNode.ChildByFieldName("body"))
+	if !ok {
+		return nil, false
+	}
+	return convertIteratorLoop(ctx, loop), true
+}
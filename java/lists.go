@@ -0,0 +1,260 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// isListExpression reports whether the type of expression is a migrated
+// java.util.List or other collection migrated to a slice
+func isListExpression(ctx *MigrationContext, expression *tree_sitter.Node) bool {
+	if expression == nil {
+		return false
+	}
+	ty, ok := valueType(ctx, expression)
+	return ok && strings.HasPrefix(string(ty), "[]")
+}
+
+// sliceOperands returns the Go source assigning the slice list migrates to,
+// and the source indexing it. List parameters are pointers to slices, so
+// they are assigned through and indexed in parentheses.
+func sliceOperands(ctx *MigrationContext, list *tree_sitter.Node, receiver string) (target, slice string) {
+	target = listSource(ctx, list, &gosrc.VarRef{Ref: receiver})
+	if strings.HasPrefix(target, "*") {
+		return target, "(" + target + ")"
+	}
+	return target, target
+}
+
+// convertSliceMethod converts a call of a method of a collection migrated to
+// a slice, trying the stack, queue and deque methods before the List ones.
+// If used is false the result of the call is unused.
+func convertSliceMethod(ctx *MigrationContext, expression *tree_sitter.Node, name, receiver string, used bool) (gosrc.Expression, []gosrc.Statement, bool) {
+	if converted, initStmts, ok := convertDequeMethod(ctx, expression, name, receiver, used); ok {
+		return converted, initStmts, true
+	}
+	return convertListMethod(ctx, expression, name, receiver, used)
+}
+
+// convertListMethod converts a call of a java.util.List method on receiver,
+// the Go source of a slice. If used is false the result of the call is
+// unused, and only the statements changing the slice are returned. It
+// returns false for methods without a translation.
+func convertListMethod(ctx *MigrationContext, expression *tree_sitter.Node, name, receiver string, used bool) (gosrc.Expression, []gosrc.Statement, bool) {
+	listNode := expression.ChildByFieldName("object")
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	args, initStmts := convertArguments(ctx, expression.ChildByFieldName("arguments"))
+	target, slice := sliceOperands(ctx, listNode, receiver)
+	sliceRef := gosrc.VarRef{Ref: target}
+	switch {
+	case name == "add" && (len(args) == 1 || len(args) == 2):
+		value := &gosrc.VarRef{Ref: fmt.Sprintf("append(%s, %s)", target, args[0].ToSource())}
+		if len(args) == 2 {
+			ctx.Source.AddImport("slices")
+			value = &gosrc.VarRef{Ref: fmt.Sprintf("slices.Insert(%s, %s, %s)", target, args[0].ToSource(), args[1].ToSource())}
+		}
+		// Adding to a list always changes it
+		return &gosrc.BooleanLiteral{Value: true}, append(initStmts, &gosrc.AssignStatement{Ref: sliceRef, Value: value}), true
+	case name == "get" && len(args) == 1:
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: listIndex(slice, args[0])}), initStmts, true
+	case name == "set" && len(args) == 2:
+		assign := &gosrc.AssignStatement{Ref: gosrc.VarRef{Ref: listIndex(slice, args[0])}, Value: args[1]}
+		if !used {
+			return nil, append(initStmts, assign), true
+		}
+		// The previous element is the result of the call
+		previous := ctx.tempName("previous")
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: previous}), append(initStmts,
+			&gosrc.VarDeclaration{Name: previous, Value: &gosrc.VarRef{Ref: listIndex(slice, args[0])}},
+			assign,
+		), true
+	case name == "remove" && len(args) == 1 && isIndexArgument(ctx, listNode, argNodes[0]):
+		ctx.Source.AddImport("slices")
+		index := args[0].ToSource()
+		remove := &gosrc.AssignStatement{Ref: sliceRef, Value: &gosrc.VarRef{Ref: fmt.Sprintf("slices.Delete(%s, %s, %s+1)", target, index, index)}}
+		if !used {
+			return nil, append(initStmts, remove), true
+		}
+		removed := ctx.tempName("removed")
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: removed}), append(initStmts,
+			&gosrc.VarDeclaration{Name: removed, Value: &gosrc.VarRef{Ref: listIndex(slice, args[0])}},
+			remove,
+		), true
+	case name == "remove" && len(args) == 1:
+		// Removes the first element equal to the argument, if there is one
+		ctx.Source.AddImport("slices")
+		index := ctx.tempName("index")
+		remove := &gosrc.GoStatement{Source: fmt.Sprintf("%s := slices.Index(%s, %s)", index, target, args[0].ToSource())}
+		deletion := &gosrc.AssignStatement{Ref: sliceRef, Value: &gosrc.VarRef{Ref: fmt.Sprintf("slices.Delete(%s, %s, %s+1)", target, index, index)}}
+		if !used {
+			return nil, append(initStmts, remove, &gosrc.IfStatement{
+				Condition: &gosrc.VarRef{Ref: index + " >= 0"},
+				Body:      []gosrc.Statement{deletion},
+			}), true
+		}
+		removed := ctx.tempName("removed")
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: removed}), append(initStmts, remove,
+			&gosrc.VarDeclaration{Name: removed, Value: &gosrc.VarRef{Ref: index + " >= 0"}},
+			&gosrc.IfStatement{Condition: &gosrc.VarRef{Ref: removed}, Body: []gosrc.Statement{deletion}},
+		), true
+	case name == "contains" && len(args) == 1:
+		ctx.Source.AddImport("slices")
+		return &gosrc.CallExpression{Function: "slices.Contains", Args: []gosrc.Expression{&sliceRef, args[0]}}, initStmts, true
+	case name == "indexOf" && len(args) == 1:
+		ctx.Source.AddImport("slices")
+		return &gosrc.CallExpression{Function: "slices.Index", Args: []gosrc.Expression{&sliceRef, args[0]}}, initStmts, true
+	case name == "size" && len(args) == 0:
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("len(%s)", target)}), initStmts, true
+	case name == "isEmpty" && len(args) == 0:
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("(len(%s) == 0)", target)}), initStmts, true
+	case name == "clear" && len(args) == 0 && !used:
+		// Keeps the backing array, as Java keeps the capacity of the list
+		return nil, append(initStmts, &gosrc.AssignStatement{Ref: sliceRef, Value: &gosrc.VarRef{Ref: slice + "[:0]"}}), true
+	case name == "addAll" && len(args) == 1:
+		elements, ok := collectionElements(ctx, argNodes[0], args[0])
+		if !ok {
+			return nil, nil, false
+		}
+		initStmts = append(initStmts, &gosrc.AssignStatement{Ref: sliceRef, Value: &gosrc.VarRef{Ref: fmt.Sprintf("append(%s, %s...)", target, elements)}})
+		// Whether the list changed is whether there was anything to add
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("(len(%s) > 0)", elements)}), initStmts, true
+	case name == "subList" && len(args) == 2:
+		// Like the view Java returns, the subslice shares the elements
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: fmt.Sprintf("%s[%s:%s]", slice, args[0].ToSource(), args[1].ToSource())}), initStmts, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// listMethodType returns the Go type of the value of a call of a List
+// method returning elements or a part of the list
+func listMethodType(ctx *MigrationContext, invocation *tree_sitter.Node) (gosrc.Type, bool) {
+	listNode := invocation.ChildByFieldName("object")
+	if !isListExpression(ctx, listNode) {
+		return "", false
+	}
+	listType, _ := valueType(ctx, listNode)
+	argNodes := argumentNodes(invocation.ChildByFieldName("arguments"))
+	switch name := ctx.nodeText(invocation.ChildByFieldName("name")); {
+	case (name == "get" && len(argNodes) == 1) || (name == "set" && len(argNodes) == 2):
+		return gosrc.Type(strings.TrimPrefix(string(listType), "[]")), true
+	case name == "remove" && len(argNodes) == 1 && isIndexArgument(ctx, listNode, argNodes[0]):
+		return gosrc.Type(strings.TrimPrefix(string(listType), "[]")), true
+	case name == "subList" && len(argNodes) == 2:
+		return listType, true
+	default:
+		return "", false
+	}
+}
+
+// asListType returns the type of the slice Arrays.asList(...) is migrated
+// to: the array it is passed, or a slice of its elements, which are typed
+// interface{} unless the first of them has a known type
+func asListType(ctx *MigrationContext, invocation *tree_sitter.Node) (gosrc.Type, bool) {
+	if !isAsList(ctx, invocation) {
+		return "", false
+	}
+	if array, ok := asListArray(ctx, invocation); ok {
+		return valueType(ctx, array)
+	}
+	if argNodes := argumentNodes(invocation.ChildByFieldName("arguments")); len(argNodes) > 0 {
+		if ty, ok := valueType(ctx, argNodes[0]); ok {
+			return gosrc.Type("[]" + ty), true
+		}
+	}
+	return "[]interface{}", true
+}
+
+// isAsList reports whether invocation calls Arrays.asList
+func isAsList(ctx *MigrationContext, invocation *tree_sitter.Node) bool {
+	object := invocation.ChildByFieldName("object")
+	return object != nil && ctx.nodeText(object) == "Arrays" && ctx.nodeText(invocation.ChildByFieldName("name")) == "asList"
+}
+
+// asListArray returns the array node Arrays.asList(array) is passed as its
+// only argument, whose elements it lists
+func asListArray(ctx *MigrationContext, invocation *tree_sitter.Node) (*tree_sitter.Node, bool) {
+	argNodes := argumentNodes(invocation.ChildByFieldName("arguments"))
+	if len(argNodes) != 1 || arrayArgumentKind(ctx, argNodes[0]) == notArray {
+		return nil, false
+	}
+	return argNodes[0], true
+}
+
+// convertAsList converts Arrays.asList to the array it is passed, or to a
+// slice literal of the elements it is passed
+func convertAsList(ctx *MigrationContext, invocation *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	if array, ok := asListArray(ctx, invocation); ok {
+		converted, initStmts := convertExpression(ctx, array)
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: listSource(ctx, array, converted)}), initStmts
+	}
+	args, initStmts := convertArguments(ctx, invocation.ChildByFieldName("arguments"))
+	ty, _ := asListType(ctx, invocation)
+	return &gosrc.ArrayLiteral{ElementType: gosrc.Type(strings.TrimPrefix(string(ty), "[]")), Elements: args}, initStmts
+}
+
+// listIndex returns the Go source of indexing the slice by index
+func listIndex(slice string, index gosrc.Expression) string {
+	return slice + "[" + index.ToSource() + "]"
+}
+
+// isIndexArgument reports whether the argument argNode of remove on list
+// selects remove(int index) rather than remove(Object o). Like Java, an int
+// argument is an index even for lists of integers.
+func isIndexArgument(ctx *MigrationContext, list, argNode *tree_sitter.Node) bool {
+	argNode = unwrapParentheses(argNode)
+	switch nodeKind(argNode) {
+	case "cast_expression":
+		// remove((Integer) i) removes the element i
+		return false
+	case "method_invocation":
+		if ctx.nodeText(argNode.ChildByFieldName("name")) == "valueOf" {
+			return false
+		}
+	}
+	if ty, ok := valueType(ctx, argNode); ok {
+		return ty == gosrc.TypeInt
+	}
+	// Elements of other types can't be mistaken for an index
+	listType, _ := valueType(ctx, list)
+	return listType == "[]"+gosrc.TypeInt
+}
+
+// collectionElements returns the Go source of the elements of the collection
+// argNode converted to arg, as a slice, or false if it isn't a list or set
+func collectionElements(ctx *MigrationContext, argNode *tree_sitter.Node, arg gosrc.Expression) (string, bool) {
+	ty, ok := valueType(ctx, argNode)
+	switch {
+	case !ok:
+		return "", false
+	case strings.HasPrefix(string(ty), "[]"):
+		return listSource(ctx, argNode, arg), true
+	case strings.HasPrefix(string(ty), "map[") && strings.HasSuffix(string(ty), "]bool"):
+		// The elements of a set are the keys of its map
+		ctx.Source.AddImport("maps")
+		ctx.Source.AddImport("slices")
+		return mapView("Keys", arg.ToSource()).ToSource(), true
+	default:
+		return "", false
+	}
+}
+
+// convertListStatement converts calls of methods of collections migrated to
+// slices whose result is unused, such as list.remove(0); or stack.pop(); to
+// the statements changing the slice alone
+func convertListStatement(ctx *MigrationContext, invocation *tree_sitter.Node) ([]gosrc.Statement, bool) {
+	objectNode := invocation.ChildByFieldName("object")
+	if !isListExpression(ctx, objectNode) {
+		return nil, false
+	}
+	receiver, receiverStmts := convertReceiver(ctx, objectNode)
+	_, stmts, ok := convertSliceMethod(ctx, invocation, ctx.nodeText(invocation.ChildByFieldName("name")), receiver, false)
+	if !ok {
+		return nil, false
+	}
+	return append(receiverStmts, stmts...), true
+}
@@ -0,0 +1,156 @@
+package java
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// errUnpairedSurrogate reports a \u escape of a UTF-16 surrogate that is not
+// part of a pair. Java strings can hold one, Go strings can't.
+var errUnpairedSurrogate = errors.New("unpaired UTF-16 surrogate")
+
+func convertStringLiteral(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	text := ctx.nodeText(expression)
+	if strings.HasPrefix(text, `"""`) {
+		// TODO: text blocks
+		return convertVerbatimExpression(ctx, expression)
+	}
+	body, err := translateEscapes(text[1:len(text)-1], '"')
+	if err != nil {
+		FatalError(ctx, expression, fmt.Sprintf("failed to translate string literal: %v", err), "string_literal")
+	}
+	return ctx.arena.GoExpression(gosrc.GoExpression{
+		Source: `"` + body + `"`,
+	}), nil
+}
+
+func convertCharacterLiteral(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	text := ctx.nodeText(expression)
+	body, err := translateEscapes(text[1:len(text)-1], '\'')
+	switch {
+	case errors.Is(err, errUnpairedSurrogate):
+		// A Java char is a UTF-16 code unit; a rune can hold the surrogate's
+		// value but there is no literal for it
+		return &gosrc.CharLiteral{
+			Value: "rune(0x" + strings.TrimPrefix(text[1:len(text)-1], `\u`) + ")",
+		}, nil
+	case err != nil:
+		FatalError(ctx, expression, fmt.Sprintf("failed to translate character literal: %v", err), "character_literal")
+	}
+	return &gosrc.CharLiteral{
+		Value: "'" + body + "'",
+	}, nil
+}
+
+// translateEscapes rewrites the escape sequences in the body of a Java string
+// or char literal delimited by quote so it is a valid Go literal body with the
+// same value. Escapes valid in both languages are kept as written.
+func translateEscapes(body string, quote byte) (string, error) {
+	if strings.IndexByte(body, '\\') < 0 {
+		return body, nil
+	}
+	sb := strings.Builder{}
+	sb.Grow(len(body))
+	for i := 0; i < len(body); i++ {
+		if body[i] != '\\' || i+1 == len(body) {
+			sb.WriteByte(body[i])
+			continue
+		}
+		i++
+		switch c := body[i]; {
+		case strings.IndexByte(`btnfr\`, c) >= 0:
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c == '"' || c == '\'':
+			// Go only accepts an escaped quote inside its own kind of literal
+			if c == quote {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(c)
+		case c == 's':
+			sb.WriteByte(' ')
+		case c >= '0' && c <= '7':
+			end := octalEscapeEnd(body, i)
+			value, _ := strconv.ParseUint(body[i:end], 8, 16)
+			writeOctalEscape(&sb, rune(value))
+			i = end - 1
+		case c == 'u':
+			end, err := writeUnicodeEscape(&sb, body, i-1)
+			if err != nil {
+				return "", err
+			}
+			i = end - 1
+		default:
+			return "", fmt.Errorf("invalid escape sequence \\%c", c)
+		}
+	}
+	return sb.String(), nil
+}
+
+// octalEscapeEnd returns the end of the octal escape whose first digit is at
+// start. Java octal escapes are up to three digits, but only up to \377.
+func octalEscapeEnd(body string, start int) int {
+	maxDigits := 2
+	if body[start] <= '3' {
+		maxDigits = 3
+	}
+	end := start
+	for end < len(body) && end-start < maxDigits && body[end] >= '0' && body[end] <= '7' {
+		end++
+	}
+	return end
+}
+
+// writeOctalEscape writes the Go escape for the char value of a Java octal
+// escape. Go octal escapes denote bytes, so values past ASCII must be written
+// as the code point instead.
+func writeOctalEscape(sb *strings.Builder, value rune) {
+	switch {
+	case value < 0x80:
+		fmt.Fprintf(sb, `\%03o`, value)
+	default:
+		fmt.Fprintf(sb, `\u%04x`, value)
+	}
+}
+
+// writeUnicodeEscape writes the Go escape for the \u escape starting at start
+// and returns the end of the Java escapes it consumed. A surrogate pair
+// written as two \u escapes becomes a single \U escape.
+func writeUnicodeEscape(sb *strings.Builder, body string, start int) (int, error) {
+	first, end, err := parseUnicodeEscape(body, start)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case !utf16.IsSurrogate(first):
+		sb.WriteString(body[start:end])
+		return end, nil
+	case first >= 0xDC00:
+		return 0, errUnpairedSurrogate
+	}
+	second, pairEnd, err := parseUnicodeEscape(body, end)
+	if err != nil || second < 0xDC00 || second > 0xDFFF {
+		return 0, errUnpairedSurrogate
+	}
+	fmt.Fprintf(sb, `\U%08x`, utf16.DecodeRune(first, second))
+	return pairEnd, nil
+}
+
+// parseUnicodeEscape parses the \uXXXX escape starting at start
+func parseUnicodeEscape(body string, start int) (rune, int, error) {
+	end := start + len(`\uXXXX`)
+	if end > len(body) || !strings.HasPrefix(body[start:], `\u`) {
+		return 0, 0, fmt.Errorf("invalid unicode escape at %q", body[start:])
+	}
+	value, err := strconv.ParseUint(body[start+2:end], 16, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid unicode escape %q", body[start:end])
+	}
+	return rune(value), end, nil
+}
@@ -0,0 +1,149 @@
+package java
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_java "github.com/tree-sitter/tree-sitter-java/bindings/go"
+)
+
+// keyedCollections are the Java collections that migrate to Go maps, keyed by
+// their first type argument
+var keyedCollections = map[string]bool{
+	"Map":     true,
+	"HashMap": true,
+	"EnumMap": true,
+	"Set":     true,
+	"HashSet": true,
+	"EnumSet": true,
+}
+
+// checkMapKeys reports maps and sets keyed by a migrated struct that Go can't
+// compare. Java keys compare with equals and hashCode, but Go map keys
+// compare with ==, which doesn't compile for structs holding slices, maps or
+// functions.
+func checkMapKeys(ctx *MigrationContext, root *tree_sitter.Node) {
+	if !hasIncomparableStruct(ctx) {
+		return
+	}
+	language := tree_sitter.NewLanguage(tree_sitter_java.Language())
+	query, err := tree_sitter.NewQuery(language, "(generic_type (type_identifier) @collection (type_arguments . (_) @key))")
+	if err != nil {
+		// This is a programming error - the query syntax is invalid
+		panic(fmt.Sprintf("Invalid tree-sitter query: %v", err))
+	}
+	defer query.Close()
+
+	cursor := tree_sitter.NewQueryCursor()
+	defer cursor.Close()
+
+	matches := cursor.Matches(query, root, ctx.JavaSource)
+	for match := matches.Next(); match != nil; match = matches.Next() {
+		collection, key := match.Captures[0].Node, match.Captures[1].Node
+		if !keyedCollections[ctx.nodeText(&collection)] {
+			continue
+		}
+		keyType, ok := TryParseType(ctx, &key)
+		if !ok {
+			continue
+		}
+		// Pointers to classes compare by identity, which loses equals and
+		// hashCode just the same, so the struct itself is checked
+		keyType = structType(keyType)
+		if field, ok := incomparableField(ctx, keyType, nil); ok {
+			msg := fmt.Sprintf("%s can't be a Go map key since its field %s of type %s is not comparable; "+
+				"key the map by a comparable value instead, such as a string built from the fields used by equals and hashCode",
+				keyType, field.Name, field.Type)
+			reportDiagnostic(ctx, &key, ErrIncomparableKey, msg)
+		}
+	}
+}
+
+// hasIncomparableStruct reports whether any migrated struct is not
+// comparable, in which case map keys have to be checked
+func hasIncomparableStruct(ctx *MigrationContext) bool {
+	for _, fields := range ctx.Fields {
+		for _, field := range fields {
+			if !comparableType(field.Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// incomparableField returns a field of the migrated struct ty that makes it
+// incomparable, following fields of struct types. seen guards against
+// recursive structs.
+func incomparableField(ctx *MigrationContext, ty gosrc.Type, seen map[gosrc.Type]bool) (FieldSymbol, bool) {
+	fields, ok := ctx.structFields(ty)
+	if !ok || seen[ty] {
+		return FieldSymbol{}, false
+	}
+	if seen == nil {
+		seen = make(map[gosrc.Type]bool)
+	}
+	seen[ty] = true
+	for _, field := range fields {
+		if !comparableType(field.Type) {
+			return field, true
+		}
+		if field.Type != structType(field.Type) {
+			// Pointers are comparable
+			continue
+		}
+		if _, ok := incomparableField(ctx, field.Type, seen); ok {
+			return field, true
+		}
+	}
+	return FieldSymbol{}, false
+}
+
+// comparableType reports whether values of ty can be compared with ==, as
+// far as can be told from the type itself
+func comparableType(ty gosrc.Type) bool {
+	source := string(ty)
+	return !strings.HasPrefix(source, "[]") && !strings.HasPrefix(source, "map[") && !strings.HasPrefix(source, "func(")
+}
+
+// reportDiagnostic records an error that doesn't stop the migration of the
+// enclosing declaration, or exits if its category is strict
+func reportDiagnostic(ctx *MigrationContext, node *tree_sitter.Node, code ErrorCode, msg string) {
+	if ctx.strict(node, code) {
+		fmt.Fprintf(os.Stderr, "Fatal: %s\n", msg)
+		os.Exit(1)
+	}
+	line, column := nodeLineColumn(node)
+	location := diagnosticLocation(ctx, node)
+	ctx.Errors = append(ctx.Errors, MigrationError{
+		Code:       code,
+		Category:   errorCategory(ctx, node, code),
+		Location:   location,
+		Line:       line,
+		Column:     column,
+		JavaSource: ctx.nodeText(node),
+		SExpr:      node.ToSexp(),
+		Message:    msg,
+		NodeKind:   nodeKind(node),
+	})
+	fmt.Fprintf(ctx.Log, "Warning: %s: %s\n", location, msg)
+}
+
+// diagnosticLocation describes the member enclosing node the way failed
+// member migrations are located, such as "class Foo.method_declaration"
+func diagnosticLocation(ctx *MigrationContext, node *tree_sitter.Node) string {
+	member := node
+	for member.Parent() != nil && nodeKind(member.Parent()) != "class_body" && nodeKind(member.Parent()) != "program" {
+		member = member.Parent()
+	}
+	typeNode := enclosingTypeDeclaration(node)
+	if typeNode == nil {
+		return nodeKind(member)
+	}
+	kind := strings.TrimSuffix(nodeKind(typeNode), "_declaration")
+	return fmt.Sprintf("%s %s.%s", kind, goTypeName(ctx, typeNode), nodeKind(member))
+}
@@ -0,0 +1,391 @@
+package java
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// mapEntry holds the range variables standing in for a Map.Entry in a loop
+// over entrySet()
+type mapEntry struct {
+	key   string
+	value string
+}
+
+// isMapExpression reports whether the static type of expression is a
+// migrated java.util.Map
+func isMapExpression(ctx *MigrationContext, expression *tree_sitter.Node) bool {
+	if expression == nil {
+		return false
+	}
+	ty, ok := staticType(ctx, expression)
+	return ok && strings.HasPrefix(string(ty), "map[")
+}
+
+// convertMapStatement converts calls of Map methods whose result is unused
+// and that have a Go statement counterpart, such as m.put(k, v) to
+// m[k] = v
+func convertMapStatement(ctx *MigrationContext, invocation *tree_sitter.Node) ([]gosrc.Statement, bool) {
+	objectNode := invocation.ChildByFieldName("object")
+	if !isMapExpression(ctx, objectNode) {
+		return nil, false
+	}
+	receiver, initStmts := convertReceiver(ctx, objectNode)
+	name := ctx.nodeText(invocation.ChildByFieldName("name"))
+	if isSetExpression(ctx, objectNode) {
+		if _, stmts, ok := convertSetMethod(ctx, invocation, name, receiver, false); ok {
+			return append(initStmts, stmts...), true
+		}
+	}
+	args := convertArgumentList(ctx, invocation.ChildByFieldName("arguments"))
+	switch {
+	case name == "put" && len(args) == 2:
+		return append(initStmts, &gosrc.AssignStatement{
+			Ref:   gosrc.VarRef{Ref: mapIndex(receiver, args[0])},
+			Value: args[1],
+		}), true
+	case name == "add" && len(args) == 1:
+		// Sets migrate to map[E]bool
+		return append(initStmts, &gosrc.AssignStatement{
+			Ref:   gosrc.VarRef{Ref: mapIndex(receiver, args[0])},
+			Value: &gosrc.BooleanLiteral{Value: true},
+		}), true
+	case name == "remove" && len(args) == 1:
+		return append(initStmts, &gosrc.CallStatement{Exp: &gosrc.CallExpression{
+			Function: "delete",
+			Args:     []gosrc.Expression{&gosrc.VarRef{Ref: receiver}, args[0]},
+		}}), true
+	default:
+		return nil, false
+	}
+}
+
+// convertMapMethod converts a call of a java.util.Map method on receiver,
+// the Go source of a map. It returns false for methods without a
+// translation.
+func convertMapMethod(ctx *MigrationContext, expression *tree_sitter.Node, name, receiver string) (gosrc.Expression, []gosrc.Statement, bool) {
+	args := convertArgumentList(ctx, expression.ChildByFieldName("arguments"))
+	mapRef := &gosrc.VarRef{Ref: receiver}
+	switch {
+	case name == "get" && len(args) == 1:
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: mapIndex(receiver, args[0])}), nil, true
+	case name == "containsKey" && len(args) == 1:
+		found := ctx.tempName("hasKey")
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: found}), []gosrc.Statement{
+			&gosrc.GoStatement{Source: fmt.Sprintf("_, %s := %s", found, mapIndex(receiver, args[0]))},
+		}, true
+	case name == "contains" && len(args) == 1:
+		// Sets migrate to map[E]bool, whose missing elements are false
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: mapIndex(receiver, args[0])}), nil, true
+	case name == "add" && len(args) == 1:
+		// Whether the element was added is whether it was missing
+		added := ctx.tempName("added")
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: added}), []gosrc.Statement{
+			&gosrc.VarDeclaration{Name: added, Value: ctx.arena.GoExpression(gosrc.GoExpression{Source: "!" + mapIndex(receiver, args[0])})},
+			&gosrc.AssignStatement{Ref: gosrc.VarRef{Ref: mapIndex(receiver, args[0])}, Value: &gosrc.BooleanLiteral{Value: true}},
+		}, true
+	case name == "containsValue" && len(args) == 1:
+		ctx.Source.AddImport("maps")
+		ctx.Source.AddImport("slices")
+		return &gosrc.CallExpression{
+			Function: "slices.Contains",
+			Args:     []gosrc.Expression{mapView("Values", receiver), args[0]},
+		}, nil, true
+	case name == "getOrDefault" && len(args) == 2:
+		value := ctx.tempName("valueOrDefault")
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: value}), []gosrc.Statement{
+			&gosrc.VarDeclaration{Name: value, Value: args[1]},
+			&gosrc.GoStatement{Source: fmt.Sprintf("if v, ok := %s; ok {\n%s = v\n}", mapIndex(receiver, args[0]), value)},
+		}, true
+	case (name == "put" && len(args) == 2) || (name == "remove" && len(args) == 1):
+		// The previous value is the result of the call
+		previous := ctx.tempName("previous")
+		initStmts := []gosrc.Statement{&gosrc.VarDeclaration{Name: previous, Value: &gosrc.VarRef{Ref: mapIndex(receiver, args[0])}}}
+		if name == "put" {
+			initStmts = append(initStmts, &gosrc.AssignStatement{Ref: gosrc.VarRef{Ref: mapIndex(receiver, args[0])}, Value: args[1]})
+		} else {
+			initStmts = append(initStmts, &gosrc.CallStatement{Exp: &gosrc.CallExpression{Function: "delete", Args: []gosrc.Expression{mapRef, args[0]}}})
+		}
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: previous}), initStmts, true
+	case name == "putAll" && len(args) == 1:
+		ctx.Source.AddImport("maps")
+		return &gosrc.CallExpression{Function: "maps.Copy", Args: []gosrc.Expression{mapRef, args[0]}}, nil, true
+	case name == "clear" && len(args) == 0:
+		return &gosrc.CallExpression{Function: "clear", Args: []gosrc.Expression{mapRef}}, nil, true
+	case name == "isEmpty" && len(args) == 0:
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("(len(%s) == 0)", receiver)}), nil, true
+	case name == "keySet" && len(args) == 0:
+		ctx.Source.AddImport("maps")
+		ctx.Source.AddImport("slices")
+		return mapView("Keys", receiver), nil, true
+	case name == "values" && len(args) == 0:
+		ctx.Source.AddImport("maps")
+		ctx.Source.AddImport("slices")
+		return mapView("Values", receiver), nil, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// mapGet returns the map and the key of expression if it is a call of get on
+// a migrated java.util.Map
+func mapGet(ctx *MigrationContext, expression *tree_sitter.Node) (mapNode, keyNode *tree_sitter.Node, ok bool) {
+	expression = unwrapParentheses(expression)
+	if nodeKind(expression) != "method_invocation" || ctx.nodeText(expression.ChildByFieldName("name")) != "get" {
+		return nil, nil, false
+	}
+	mapNode = expression.ChildByFieldName("object")
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	if len(argNodes) != 1 || !isMapExpression(ctx, mapNode) {
+		return nil, nil, false
+	}
+	return mapNode, argNodes[0], true
+}
+
+// nullComparisonOperand returns the operand compared to null by expression,
+// a binary expression, and whether the comparison is ==
+func nullComparisonOperand(ctx *MigrationContext, expression *tree_sitter.Node) (*tree_sitter.Node, bool, bool) {
+	operator := ctx.nodeText(expression.ChildByFieldName("operator"))
+	leftNode, rightNode := expression.ChildByFieldName("left"), expression.ChildByFieldName("right")
+	switch {
+	case operator != "==" && operator != "!=":
+		return nil, false, false
+	case nodeKind(leftNode) == "null_literal":
+		return rightNode, operator == "==", true
+	case nodeKind(rightNode) == "null_literal":
+		return leftNode, operator == "==", true
+	default:
+		return nil, false, false
+	}
+}
+
+// nullMapComparison converts m.get(k) == null and m.get(k) != null to a
+// comma-ok lookup of k, as indexing a map yields the zero value of the
+// values for missing keys rather than nil. Null checks of a local holding
+// the result of m.get(k) test the ok variable of its declaration.
+func nullMapComparison(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement, bool) {
+	valueNode, isNull, ok := nullComparisonOperand(ctx, expression)
+	if !ok {
+		return nil, nil, false
+	}
+	var found string
+	var initStmts []gosrc.Statement
+	if declarator := mapLookupLocal(ctx, valueNode); declarator != nil {
+		found = ctx.mapLookups[declarator.StartByte()]
+	} else {
+		mapNode, keyNode, ok := mapGet(ctx, valueNode)
+		if !ok {
+			return nil, nil, false
+		}
+		var lookup string
+		lookup, initStmts = convertMapLookup(ctx, mapNode, keyNode)
+		found = ctx.tempName("hasKey")
+		initStmts = append(initStmts, &gosrc.GoStatement{Source: fmt.Sprintf("_, %s := %s", found, lookup)})
+	}
+	if isNull {
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: "!" + found}), initStmts, true
+	}
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: found}), initStmts, true
+}
+
+// convertMapLookupDeclaration converts the declaration of a local
+// initialized with m.get(k), as in Integer v = m.get(k), to a comma-ok lookup
+// if the local is checked for null, since the zero value a missing key
+// yields can't be told apart from a value
+func convertMapLookupDeclaration(ctx *MigrationContext, declarator *tree_sitter.Node) ([]gosrc.Statement, bool) {
+	mapNode, keyNode, ok := mapGet(ctx, declarator.ChildByFieldName("value"))
+	if !ok || !isNullCheckedLocal(ctx, declarator) {
+		return nil, false
+	}
+	name := ctx.nodeText(declarator.ChildByFieldName("name"))
+	lookup, initStmts := convertMapLookup(ctx, mapNode, keyNode)
+	found := ctx.tempName("has" + gosrc.CapitalizeFirstLetter(name))
+	if ctx.mapLookups == nil {
+		ctx.mapLookups = make(map[uint]string)
+	}
+	ctx.mapLookups[declarator.StartByte()] = found
+	return append(initStmts, &gosrc.GoStatement{Source: fmt.Sprintf("%s, %s := %s", name, found, lookup)}), true
+}
+
+// isNullCheckedLocal reports whether the local declared by declarator is
+// compared to null in its scope before it is assigned again
+func isNullCheckedLocal(ctx *MigrationContext, declarator *tree_sitter.Node) bool {
+	name := ctx.nodeText(declarator.ChildByFieldName("name"))
+	checked := false
+	var visit func(node *tree_sitter.Node)
+	visit = func(node *tree_sitter.Node) {
+		if checked {
+			return
+		}
+		if nodeKind(node) == "binary_expression" {
+			if operand, _, ok := nullComparisonOperand(ctx, node); ok && nodeKind(operand) == "identifier" && ctx.nodeText(operand) == name {
+				local := localDeclarator(ctx, operand, name)
+				checked = local != nil && local.StartByte() == declarator.StartByte() && !reassignedBefore(ctx, declarator, operand, name)
+			}
+		}
+		IterateChildren(node, visit)
+	}
+	for next := declarator.Parent().NextNamedSibling(); next != nil; next = next.NextNamedSibling() {
+		visit(next)
+	}
+	return checked
+}
+
+// mapLookupLocal returns the declarator of the local node refers to if its
+// declaration was converted to a comma-ok lookup and node reads the value
+// it was initialized with
+func mapLookupLocal(ctx *MigrationContext, node *tree_sitter.Node) *tree_sitter.Node {
+	if nodeKind(node) != "identifier" || len(ctx.mapLookups) == 0 {
+		return nil
+	}
+	name := ctx.nodeText(node)
+	declarator := localDeclarator(ctx, node, name)
+	if declarator == nil || reassignedBefore(ctx, declarator, node, name) {
+		return nil
+	}
+	if _, ok := ctx.mapLookups[declarator.StartByte()]; !ok {
+		return nil
+	}
+	return declarator
+}
+
+// localDeclarator returns the variable_declarator of the local called name
+// that is in scope at node, or nil if name is not a local of the enclosing
+// member
+func localDeclarator(ctx *MigrationContext, node *tree_sitter.Node, name string) *tree_sitter.Node {
+	for scope := node.Parent(); scope != nil; scope = scope.Parent() {
+		switch nodeKind(scope) {
+		case "method_declaration", "constructor_declaration", "lambda_expression", "class_body":
+			return nil
+		}
+		var declarator *tree_sitter.Node
+		IterateChildren(scope, func(statement *tree_sitter.Node) {
+			if nodeKind(statement) != "local_variable_declaration" || statement.StartByte() >= node.StartByte() {
+				return
+			}
+			IterateChildren(statement, func(child *tree_sitter.Node) {
+				if nodeKind(child) == "variable_declarator" && ctx.nodeText(child.ChildByFieldName("name")) == name {
+					declarator = child
+				}
+			})
+		})
+		if declarator != nil {
+			return declarator
+		}
+	}
+	return nil
+}
+
+// reassignedBefore reports whether the local called name declared by
+// declarator is assigned between its declaration and node
+func reassignedBefore(ctx *MigrationContext, declarator, node *tree_sitter.Node, name string) bool {
+	assigned := false
+	var visit func(child *tree_sitter.Node)
+	visit = func(child *tree_sitter.Node) {
+		if assigned || child.StartByte() >= node.StartByte() {
+			return
+		}
+		if nodeKind(child) == "assignment_expression" && ctx.nodeText(child.ChildByFieldName("left")) == name && child.StartByte() > declarator.EndByte() {
+			assigned = true
+			return
+		}
+		IterateChildren(child, visit)
+	}
+	for next := declarator.Parent().NextNamedSibling(); next != nil; next = next.NextNamedSibling() {
+		visit(next)
+	}
+	return assigned
+}
+
+// convertMapLookup converts the call of get on a map mapGet matched to the Go
+// source of indexing the map, to be used in a comma-ok lookup
+func convertMapLookup(ctx *MigrationContext, mapNode, keyNode *tree_sitter.Node) (string, []gosrc.Statement) {
+	receiver, initStmts := convertReceiver(ctx, mapNode)
+	key, keyStmts := convertExpression(ctx, keyNode)
+	return mapIndex(receiver, key), append(initStmts, keyStmts...)
+}
+
+// mapIndex returns the Go source of indexing the map receiver by key
+func mapIndex(receiver string, key gosrc.Expression) string {
+	return receiver + "[" + key.ToSource() + "]"
+}
+
+// mapView collects the keys or values of a map into a slice, like the
+// keySet() and values() views of a Java map
+func mapView(view, receiver string) gosrc.Expression {
+	return &gosrc.CallExpression{
+		Function: "slices.Collect",
+		Args: []gosrc.Expression{&gosrc.CallExpression{
+			Function: "maps." + view,
+			Args:     []gosrc.Expression{&gosrc.VarRef{Ref: receiver}},
+		}},
+	}
+}
+
+// convertMapRangeStatement converts a for-each loop over the keySet(),
+// values() or entrySet() of a map to a range loop over the map itself.
+// Calls of getKey() and getValue() on the entry become the range variables.
+func convertMapRangeStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) ([]gosrc.Statement, bool) {
+	valueNode := stmtNode.ChildByFieldName("value")
+	if nodeKind(valueNode) != "method_invocation" || valueNode.ChildByFieldName("arguments").NamedChildCount() != 0 {
+		return nil, false
+	}
+	objectNode := valueNode.ChildByFieldName("object")
+	if !isMapExpression(ctx, objectNode) {
+		return nil, false
+	}
+	varName := ctx.nodeText(stmtNode.ChildByFieldName("name"))
+	bodyNode := stmtNode.ChildByFieldName("body")
+	loop := &gosrc.RangeForStatement{}
+	switch ctx.nodeText(valueNode.ChildByFieldName("name")) {
+	case "keySet":
+		loop.IndexVar = varName
+	case "values":
+		loop.ValueVar = varName
+	case "entrySet":
+		entry := mapEntry{key: "_", value: "_"}
+		body := ctx.nodeText(bodyNode)
+		if strings.Contains(body, varName+".getKey()") {
+			entry.key = varName + "Key"
+		}
+		if strings.Contains(body, varName+".getValue()") {
+			entry.value = varName + "Value"
+		}
+		loop.IndexVar, loop.ValueVar = entry.key, entry.value
+		outer := ctx.mapEntries
+		ctx.mapEntries = maps.Clone(outer)
+		if ctx.mapEntries == nil {
+			ctx.mapEntries = make(map[string]mapEntry)
+		}
+		ctx.mapEntries[varName] = entry
+		defer func() { ctx.mapEntries = outer }()
+	default:
+		return nil, false
+	}
+	receiver, initStmts := convertReceiver(ctx, objectNode)
+	loop.CollectionExpr = ctx.arena.VarRef(gosrc.VarRef{Ref: receiver})
+	loop.Body = convertStatementBlock(ctx, bodyNode)
+	return append(initStmts, loop), true
+}
+
+// convertMapEntryMethod converts getKey() and getValue() calls on the entry
+// variable of a loop over entrySet()
+func convertMapEntryMethod(ctx *MigrationContext, name, receiver string) (gosrc.Expression, bool) {
+	entry, ok := ctx.mapEntries[receiver]
+	if !ok {
+		return nil, false
+	}
+	switch name {
+	case "getKey":
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: entry.key}), true
+	case "getValue":
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: entry.value}), true
+	default:
+		return nil, false
+	}
+}
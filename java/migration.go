@@ -2,8 +2,13 @@ package java
 
 import (
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"path/filepath"
+	"runtime"
 	"slices"
+	"sync"
 
 	"github.com/heshanpadmasiri/javaGo/gosrc"
 
@@ -15,77 +20,411 @@ import (
 type MigrationContext struct {
 	Source                   gosrc.GoSource
 	JavaSource               []byte
+	javaText                 string // JavaSource as a string, so node text can be sliced without copying
 	SourceFilePath           string // Path to the source Java file
-	InReturn                 bool
+	InReturn                 bool   // Converting a switch expression that is the value of a return statement
 	AbstractClasses          map[string]bool
+	Classes                  map[string]bool // Go names of the concrete classes, whose instances are referred to by pointers
 	InDefaultMethod          bool
 	DefaultMethodSelf        string
-	EnumConstants            map[string]string // Maps enum constant name to prefixed name (e.g., "ACTIVE" -> "Status_ACTIVE")
+	EnumConstants            map[string]string                 // Maps enum constant name to prefixed name (e.g., "ACTIVE" -> "Status_ACTIVE")
+	EnumOrdinals             map[string][]string               // Maps Go names of enums migrated to integers to their prefixed constants in declaration order
+	EnumToString             map[string]bool                   // Go names of the enums migrated to integers that declare toString, whose constants are named by Name instead of String
+	TypeNames                map[string]string                 // Maps qualified Java names of the declared types to their Go names (e.g., "Outer.Inner" -> "OuterInner", "Span" -> "span")
+	InnerClasses             map[string]string                 // Maps Go names of inner classes to the Go name of the class their instances belong to
+	Fields                   map[string]map[string]FieldSymbol // Maps Go struct names to their instance fields by Java name
+	DefaultMethods           map[string]map[string]string      // Maps Go names of interfaces to the functions their default methods are migrated to by Java name
+	Supertypes               map[string][]gosrc.Type           // Maps Go type names to the interfaces they implement or extend and the class they extend
 	Constructors             map[gosrc.Type][]FunctionData
-	Methods                  map[string][]FunctionData       // Maps method name to method signatures
-	MethodMetadataCache      map[uintptr]methodMetadata      // Cache of parsed method signatures by node ID
-	ConstructorMetadataCache map[uintptr]constructorMetadata // Cache of parsed constructor signatures by node ID
-	StrictMode               bool                            // If true, treat migration errors as fatal
-	Errors                   []MigrationError                // Collected migration errors
+	Methods                  map[string][]FunctionData    // Maps method name to method signatures
+	MethodMetadataCache      map[uint]methodMetadata      // Cache of parsed method signatures by node start byte
+	ConstructorMetadataCache map[uint]constructorMetadata // Cache of parsed constructor signatures by node start byte
+	Strictness               Strictness                   // Categories whose migration errors are fatal
+	Errors                   []MigrationError             // Collected migration errors
 	TypeMappings             map[string]string
-	// TODO: have seperate channels for std out and std error
+	PackageMappings          map[string]string          // Maps Java package prefixes to the import paths of the Go packages they are migrated to
+	StubOnly                 bool                       // If true, method bodies are replaced by stubs
+	OnlyMethods              map[string]bool            // If set, only methods with these Java names get their bodies converted
+	ManualMethods            map[string]bool            // Java names, optionally qualified by the class name, of methods whose body is kept as Java comments to port by hand
+	DeepCopy                 bool                       // If true, generated Clone and Copy methods also copy slice and map fields
+	FlatNestedClasses        bool                       // If true, hoisted static nested classes keep their own name instead of being prefixed with the enclosing type's name
+	ClassLiterals            string                     // How Foo.class is migrated, one of the ClassLiterals constants; ClassLiteralsReflect if empty
+	Annotations              string                     // How @interface declarations are migrated, one of the Annotations constants; AnnotationsStruct if empty
+	Optionals                string                     // How java.util.Optional is migrated, one of the Optionals constants; OptionalsPointer if empty
+	SharedSupport            bool                       // If true, the Support declarations the migrated code uses are left to the caller to declare once per package
+	ProjectPackages          map[string]bool            // Java packages declared by the files of the project being migrated, whose imports are reported unless they are mapped
+	SystemProperties         map[string]string          // Maps system property keys to the Go expressions System.getProperty is migrated to, on top of the well-known ones
+	ExceptionPolicies        map[string]string          // Maps exception names to how throwing them is migrated, one of the Exceptions constants or a Go statement
+	PrivateHelperFunctions   bool                       // If true, private instance methods that don't use the instance become package functions
+	arena                    *gosrc.Arena               // Allocates hot gosrc node types in chunks
+	analysisCache            *analysisCache             // Signatures kept between incremental runs, nil otherwise
+	yieldReturns             bool                       // Yield statements return the value of the enclosing switch expression
+	yieldTarget              string                     // Variable yield statements assign the value of the enclosing switch expression to
+	ordinalLabels            []string                   // Constants of the enum the enclosing switch on ordinal() switches on instead, by ordinal
+	tempNames                map[string]int             // Temporary variable names handed out in the current member
+	variableTypes            typeEnvironment            // Go types of the variables the current member refers to
+	instanceofResults        map[uint]string            // Variables the init statements of enclosing if statements assert instanceof tests into, by the start byte of the test
+	mapLookups               map[uint]string            // Variables holding whether the Map.get a local is initialized with found its key, by the start byte of the declarator of the local
+	importedTypes            map[string]string          // Maps simple names of the types imported from mapped packages to their Go import paths
+	importedMethods          map[string]string          // Maps names of the static methods imported from mapped packages to their Go import paths
+	mapEntries               map[string]mapEntry        // Range variables of the Map.Entry loop variables in scope, replaced rather than mutated
+	rangeElements            map[string]string          // Range values replacing items[i] in index loops converted to range loops, by the Java source of items[i]
+	helpers                  map[uint]map[string]bool   // Java names of the methods migrated to package functions, by the start byte of their class body
+	staticFields             map[string]map[string]bool // Java names of the static fields of the classes declared in the file, by class name
+	Log                      io.Writer                  // Receives warnings and recovered errors, must be safe for concurrent use
 }
 
 // MigrationError represents an error that occurred during migration
 type MigrationError struct {
-	Location   string // e.g., "class Foo.method bar"
-	JavaSource string // The Java code that failed
-	SExpr      string // The S-expression
-	Message    string // Error message
-	NodeKind   string // Type of node (for debugging)
+	Code       ErrorCode // Kind of the error
+	Category   Category  // Kind of construct the error is reported for
+	Location   string    // e.g., "class Foo.method bar"
+	Line       int       // 1-based line of the offending node in the Java source
+	Column     int       // 1-based column of the offending node in the Java source
+	JavaSource string    // The Java code that failed
+	SExpr      string    // The S-expression
+	Message    string    // Error message
+	NodeKind   string    // Type of node (for debugging)
 }
 
+// ErrorCode classifies migration errors so tools can act on them without
+// parsing messages
+type ErrorCode string
+
+const (
+	// ErrUnhandledNode is reported when the converter meets a node kind it has no rule for
+	ErrUnhandledNode ErrorCode = "JG001"
+	// ErrConversionFailed is reported when a known node kind can't be converted
+	ErrConversionFailed ErrorCode = "JG002"
+	// ErrInternal is reported for unexpected panics inside the converter
+	ErrInternal ErrorCode = "JG003"
+	// ErrMissingIdentifier is reported when a declaration has no name, which
+	// happens when the Java source is partially broken
+	ErrMissingIdentifier ErrorCode = "JG004"
+	// ErrIncomparableKey is reported when a map or set is keyed by a struct
+	// that Go can't compare
+	ErrIncomparableKey ErrorCode = "JG005"
+	// ErrClassLiteral is reported when a class literal is dropped
+	ErrClassLiteral ErrorCode = "JG006"
+	// ErrReflection is reported for reflective calls without a Go equivalent
+	ErrReflection ErrorCode = "JG007"
+	// ErrSystemProperty is reported when a System.getProperty lookup has no
+	// Go equivalent
+	ErrSystemProperty ErrorCode = "JG008"
+	// ErrIntegerDivision is reported for integer divisions whose truncated
+	// result is converted to a floating point number
+	ErrIntegerDivision ErrorCode = "JG009"
+	// ErrAnnotation is reported when an annotation type declaration is skipped
+	ErrAnnotation ErrorCode = "JG010"
+	// ErrEnumOrdinal is reported when code compares or computes with the
+	// ordinals of enum constants, relying on their declaration order
+	ErrEnumOrdinal ErrorCode = "JG011"
+	// ErrSyntax is reported for the ERROR and MISSING nodes of a malformed
+	// Java source; the declarations containing them are skipped
+	ErrSyntax ErrorCode = "JG012"
+	// ErrStreamPipeline is reported for java.util.stream pipelines that can't
+	// be lowered into a loop
+	ErrStreamPipeline ErrorCode = "JG013"
+	// ErrNullableValue is reported for Optional.ofNullable of a value that
+	// can't be nil in Go, whose optional is never empty
+	ErrNullableValue ErrorCode = "JG014"
+	// ErrUnmappedPackage is reported for imports from another package of a
+	// migrated project whose Go import path is unknown, so references to it
+	// can't be qualified
+	ErrUnmappedPackage ErrorCode = "JG015"
+)
+
 type FunctionData struct {
 	Name          string
 	ArgumentTypes []gosrc.Type
+	Factory       string     `json:",omitempty"` // Java name of a static factory method registered with the constructors
+	Throws        bool       `json:",omitempty"` // Whether the function returns an error for the checked exceptions it throws
+	ResultType    gosrc.Type `json:",omitempty"` // Type of the value a function that throws returns besides the error, if any
+	Static        bool       `json:",omitempty"` // Whether the function is migrated from a static method to a package function
 }
 
 func (this FunctionData) sameArgs(other FunctionData) bool {
 	return slices.Equal(this.ArgumentTypes, other.ArgumentTypes)
 }
 
-// TODO: make it possibl to map the std out and std error from outside so we can control this for things like tests
-// NewMigrationContext creates and initializes a new MigrationContext
-func NewMigrationContext(javaSource []byte, sourceFilePath string, strictMode bool, typeMappings map[string]string) *MigrationContext {
+// NewMigrationContext creates and initializes a new MigrationContext. Errors
+// of the categories strictness selects exit the process, use StrictAll to
+// make all of them fatal. Warnings are logged to os.Stderr unless Log is
+// changed.
+func NewMigrationContext(javaSource []byte, sourceFilePath string, strictness Strictness, typeMappings map[string]string) *MigrationContext {
 	if typeMappings == nil {
 		typeMappings = make(map[string]string)
 	}
 	return &MigrationContext{
 		JavaSource:               javaSource,
+		javaText:                 string(javaSource),
 		SourceFilePath:           sourceFilePath,
 		AbstractClasses:          make(map[string]bool),
+		Classes:                  make(map[string]bool),
 		EnumConstants:            make(map[string]string),
+		EnumOrdinals:             make(map[string][]string),
+		EnumToString:             make(map[string]bool),
+		TypeNames:                make(map[string]string),
+		InnerClasses:             make(map[string]string),
+		Fields:                   make(map[string]map[string]FieldSymbol),
+		DefaultMethods:           make(map[string]map[string]string),
+		Supertypes:               make(map[string][]gosrc.Type),
 		Constructors:             make(map[gosrc.Type][]FunctionData),
 		Methods:                  make(map[string][]FunctionData),
-		MethodMetadataCache:      make(map[uintptr]methodMetadata),
-		ConstructorMetadataCache: make(map[uintptr]constructorMetadata),
-		StrictMode:               strictMode,
+		MethodMetadataCache:      make(map[uint]methodMetadata),
+		ConstructorMetadataCache: make(map[uint]constructorMetadata),
+		Strictness:               strictness,
 		Errors:                   []MigrationError{},
 		TypeMappings:             typeMappings,
+		arena:                    gosrc.NewArena(),
+		Log:                      os.Stderr,
 	}
 }
 
+// Options are the configurable options of a migration, set on the context
+// of every file migrated with them by ApplyOptions
+type Options struct {
+	PackageMappings        map[string]string
+	StubOnly               bool
+	OnlyMethods            map[string]bool
+	ManualMethods          map[string]bool
+	DeepCopy               bool
+	FlatNestedClasses      bool
+	ClassLiterals          string
+	Annotations            string
+	Optionals              string
+	SystemProperties       map[string]string
+	ExceptionPolicies      map[string]string
+	PrivateHelperFunctions bool
+}
+
+// ApplyOptions sets the options of ctx to opts. Every entry point creating a
+// context goes through it, so they all honor the same options.
+func ApplyOptions(ctx *MigrationContext, opts Options) {
+	ctx.PackageMappings = opts.PackageMappings
+	ctx.StubOnly = opts.StubOnly
+	ctx.OnlyMethods = opts.OnlyMethods
+	ctx.ManualMethods = opts.ManualMethods
+	ctx.DeepCopy = opts.DeepCopy
+	ctx.FlatNestedClasses = opts.FlatNestedClasses
+	ctx.ClassLiterals = opts.ClassLiterals
+	ctx.Annotations = opts.Annotations
+	ctx.Optionals = opts.Optionals
+	ctx.SystemProperties = opts.SystemProperties
+	ctx.ExceptionPolicies = opts.ExceptionPolicies
+	ctx.PrivateHelperFunctions = opts.PrivateHelperFunctions
+}
+
 // MigrateTree migrates a Java tree-sitter tree to Go source
 func MigrateTree(ctx *MigrationContext, tree *tree_sitter.Tree) {
 	// Analyze tree first to collect method metadata
-	analyzeNode(ctx, tree)
+	AnalyzeTree(ctx, tree)
 
 	// Then perform migration
+	ConvertTree(ctx, tree)
+}
+
+// AnalyzeTree runs only the analysis phase, collecting method and constructor
+// metadata into ctx. ConvertTree expects this to have been called first.
+func AnalyzeTree(ctx *MigrationContext, tree *tree_sitter.Tree) {
+	analyzeNode(ctx, tree)
+}
+
+// ConvertTree runs only the conversion phase over an already analyzed tree.
+// When a file has several top-level declarations they are converted
+// concurrently (bounded by GOMAXPROCS) and merged back in declaration order,
+// so the output is the same as a serial conversion.
+func ConvertTree(ctx *MigrationContext, tree *tree_sitter.Tree) {
+	root := tree.RootNode()
+	collectImports(ctx, root)
+	checkSyntax(ctx, root)
+	checkMapKeys(ctx, root)
+	checkProjectImports(ctx, root)
+	// Strict categories exit on the first error, so keep it serial to make "first" well defined
+	if ctx.Strictness.Any() || nodeKind(root) != "program" || countTypeDeclarations(root) < 2 {
+		migrateNode(ctx, root)
+	} else {
+		convertChildrenConcurrently(ctx, tree)
+	}
+	for _, support := range ownedSupport(ctx, tree) {
+		ctx.Source.Append(support.Source())
+	}
+}
+
+// ConvertTreeStreaming converts an already analyzed tree one top-level node
+// at a time, handing the output of each node to emit and dropping it
+// afterwards, so the generated code held in memory is bounded by the largest
+// declaration rather than the whole file. ctx.Source is left empty. It stops
+// at the first error returned by emit.
+func ConvertTreeStreaming(ctx *MigrationContext, tree *tree_sitter.Tree, emit func(gosrc.GoSource) error) error {
 	root := tree.RootNode()
-	migrateNode(ctx, root)
+	collectImports(ctx, root)
+	checkSyntax(ctx, root)
+	checkMapKeys(ctx, root)
+	checkProjectImports(ctx, root)
+	if nodeKind(root) != "program" {
+		migrateNode(ctx, root)
+		return flushSource(ctx, emit)
+	}
+	var err error
+	IterateChildrenWhile(root, func(child *tree_sitter.Node) bool {
+		migrateNode(ctx, child)
+		err = flushSource(ctx, emit)
+		return err == nil
+	})
+	for _, support := range ownedSupport(ctx, tree) {
+		if err != nil {
+			break
+		}
+		err = emit(support.Source())
+	}
+	return err
+}
+
+// flushSource passes everything converted so far to emit and clears it
+func flushSource(ctx *MigrationContext, emit func(gosrc.GoSource) error) error {
+	source := ctx.Source
+	ctx.Source = gosrc.GoSource{}
+	return emit(source)
+}
+
+// conversionFragment is the output of converting a single top-level node
+type conversionFragment struct {
+	source gosrc.GoSource
+	errors []MigrationError
+	panic  any
+}
+
+func convertChildrenConcurrently(ctx *MigrationContext, tree *tree_sitter.Tree) {
+	childCount := tree.RootNode().ChildCount()
+	fragments := make([]conversionFragment, childCount)
+	limit := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i := range childCount {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-limit }()
+			fragments[i] = convertTopLevelChild(ctx, tree, i)
+		}()
+	}
+	wg.Wait()
+
+	for _, fragment := range fragments {
+		ctx.Source.Append(fragment.source)
+		ctx.Errors = append(ctx.Errors, fragment.errors...)
+		if fragment.panic != nil {
+			// Surface the panic on the caller's goroutine, as a serial run would
+			panic(fragment.panic)
+		}
+	}
+}
+
+// convertTopLevelChild converts the index-th child of the root on its own copy
+// of the tree and a forked context
+func convertTopLevelChild(ctx *MigrationContext, tree *tree_sitter.Tree, index uint) (fragment conversionFragment) {
+	// Trees are not safe for concurrent use; a clone has the same nodes at the
+	// same byte offsets, so the metadata caches stay valid
+	clone := tree.Clone()
+	defer clone.Close()
+	childCtx := ctx.fork()
+	defer func() {
+		fragment.source = childCtx.Source
+		fragment.errors = childCtx.Errors
+		fragment.panic = recover()
+	}()
+	migrateNode(childCtx, clone.RootNode().Child(index))
+	return fragment
+}
+
+// fork returns a context that shares the read-only analysis results with ctx
+// but collects its own output and errors
+func (ctx *MigrationContext) fork() *MigrationContext {
+	child := *ctx
+	child.Source = gosrc.GoSource{}
+	child.Errors = []MigrationError{}
+	// Arenas are not safe for concurrent use
+	child.arena = gosrc.NewArena()
+	child.tempNames = nil
+	child.variableTypes = nil
+	child.instanceofResults = nil
+	child.mapLookups = nil
+	child.AbstractClasses = maps.Clone(ctx.AbstractClasses)
+	child.EnumConstants = maps.Clone(ctx.EnumConstants)
+	return &child
+}
+
+func countTypeDeclarations(root *tree_sitter.Node) int {
+	count := 0
+	IterateChildren(root, func(child *tree_sitter.Node) {
+		switch nodeKind(child) {
+		case "class_declaration", "record_declaration", "interface_declaration", "enum_declaration":
+			count++
+		}
+	})
+	return count
 }
 
 // analyzeNode performs pre-migration analysis to collect method signatures
+// and the cross-class state needed before classes can be converted independently
 func analyzeNode(ctx *MigrationContext, tree *tree_sitter.Tree) {
+	ctx.analysisCache.start()
+	defer ctx.analysisCache.finish()
+	collectImports(ctx, tree.RootNode())
+	// Signatures refer to nested types by their hoisted names, so types are
+	// analyzed first
+	analyzeTypeDeclarations(ctx, tree)
 	analyzeMethodDeclartions(ctx, tree)
 	analyzeConstructorDeclarations(ctx, tree)
 }
 
+// analyzeTypeDeclarations records abstract classes, enum constants, nested
+// types and fields so that every class can refer to them regardless of
+// declaration order
+func analyzeTypeDeclarations(ctx *MigrationContext, tree *tree_sitter.Tree) {
+	language := tree_sitter.NewLanguage(tree_sitter_java.Language())
+	query, err := tree_sitter.NewQuery(language, "[(class_declaration) (enum_declaration) (interface_declaration) (record_declaration)] @type")
+	if err != nil {
+		// This is a programming error - the query syntax is invalid
+		panic(fmt.Sprintf("Invalid tree-sitter query: %v", err))
+	}
+	defer query.Close()
+
+	cursor := tree_sitter.NewQueryCursor()
+	defer cursor.Close()
+
+	var typeNodes []tree_sitter.Node
+	matches := cursor.Matches(query, tree.RootNode(), ctx.JavaSource)
+	for match := matches.Next(); match != nil; match = matches.Next() {
+		for _, capture := range match.Captures {
+			typeNodes = append(typeNodes, capture.Node)
+			switch nodeKind(&capture.Node) {
+			case "class_declaration":
+				collectAbstractClass(ctx, &capture.Node)
+			case "enum_declaration":
+				collectEnumConstants(ctx, &capture.Node)
+			}
+		}
+	}
+	// Go names of classes depend on whether they extend an abstract class, so
+	// nested types are collected once all abstract classes are known, and
+	// fields once field types can refer to nested types
+	for i := range typeNodes {
+		collectTypeName(ctx, &typeNodes[i])
+		collectInnerClass(ctx, &typeNodes[i])
+		collectClass(ctx, &typeNodes[i])
+		collectSupertypes(ctx, &typeNodes[i])
+	}
+	// Signatures refer to classes by pointers, so the cached ones are stale
+	// once the set of classes changes
+	ctx.analysisCache.keepClasses(ctx.Classes)
+	for i := range typeNodes {
+		collectFields(ctx, &typeNodes[i])
+		collectDefaultConstructor(ctx, &typeNodes[i])
+	}
+}
+
 func analyzeMethodDeclartions(ctx *MigrationContext, tree *tree_sitter.Tree) {
 	// Create query to find all method declarations
 	language := tree_sitter.NewLanguage(tree_sitter_java.Language())
@@ -110,25 +449,12 @@ func analyzeMethodDeclartions(ctx *MigrationContext, tree *tree_sitter.Tree) {
 
 			// Parse method signature with error recovery
 			func() {
-				defer func() {
-					if r := recover(); r != nil {
-						// In strict mode, let panic propagate
-						if ctx.StrictMode {
-							panic(r)
-						}
-						// In non-strict mode, skip this method and continue
-						// We don't add it to the context, but log the error
-						if panicErr, ok := r.(MigrationPanic); ok {
-							fmt.Fprintf(os.Stderr, "Warning: Failed to analyze method signature: %s\n", panicErr.Message)
-						} else {
-							fmt.Fprintf(os.Stderr, "Warning: Failed to analyze method signature: %v\n", r)
-						}
-					}
-				}()
-
-				methodMetadata := parseMethodSignature(ctx, methodNode)
+				defer recoverSignature(ctx, "method")
+
+				methodMetadata := ctx.analysisCache.methodSignature(ctx, methodNode)
 				funcData := methodMetadata.toFunctionData()
-				addMethodToCtx(ctx, funcData, methodMetadata, methodNode.Id())
+				addMethodToCtx(ctx, funcData, methodMetadata, methodNode.StartByte())
+				collectDefaultMethod(ctx, methodNode)
 			}()
 		}
 	}
@@ -156,21 +482,48 @@ func analyzeConstructorDeclarations(ctx *MigrationContext, tree *tree_sitter.Tre
 		for _, capture := range match.Captures {
 			constructorNode := &capture.Node
 
-			// Parse constructor signature
-			constructorMetadata := parseConstructorSignature(ctx, constructorNode)
-			funcData := constructorMetadata.toFunctionData()
+			// Parse constructor signature with error recovery
+			func() {
+				defer recoverSignature(ctx, "constructor")
 
-			addConstructorToCtx(ctx, funcData, constructorMetadata, constructorNode.Id())
+				constructorMetadata := ctx.analysisCache.constructorSignature(ctx, constructorNode)
+				funcData := constructorMetadata.toFunctionData()
+				addConstructorToCtx(ctx, funcData, constructorMetadata, constructorNode.StartByte())
+			}()
 		}
 	}
 }
 
-func addMethodToCtx(ctx *MigrationContext, fn FunctionData, metadata methodMetadata, nodeID uintptr) {
+// recoverSignature must be deferred around the analysis of a single
+// signature. Unless the error is fatal, a failing declaration is skipped with
+// a warning; converting it later reports the error again.
+func recoverSignature(ctx *MigrationContext, kind string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	// Unexpected panics propagate if expressions are strict
+	if ctx.propagates(r) {
+		panic(r)
+	}
+	if panicErr, ok := r.(MigrationPanic); ok {
+		fmt.Fprintf(ctx.Log, "Warning: Failed to analyze %s signature: %s\n", kind, panicErr.Message)
+	} else {
+		fmt.Fprintf(ctx.Log, "Warning: Failed to analyze %s signature: %v\n", kind, r)
+	}
+}
+
+func addMethodToCtx(ctx *MigrationContext, fn FunctionData, metadata methodMetadata, nodeKey uint) {
+	if metadata.factoryOf != "" {
+		metadata.name = addFactoryToCtx(ctx, fn, metadata.factoryOf)
+		ctx.MethodMetadataCache[nodeKey] = metadata
+		return
+	}
 	name, shouldChangeName := addMethodToCtxInner(ctx, fn)
 	if shouldChangeName {
 		metadata.name = name
 	}
-	ctx.MethodMetadataCache[nodeID] = metadata
+	ctx.MethodMetadataCache[nodeKey] = metadata
 }
 
 func addMethodToCtxInner(ctx *MigrationContext, fn FunctionData) (string, bool) {
@@ -193,26 +546,26 @@ func addMethodToCtxInner(ctx *MigrationContext, fn FunctionData) (string, bool)
 	return overloadedName, true
 }
 
-func addConstructorToCtx(ctx *MigrationContext, fn FunctionData, metadata constructorMetadata, nodeID uintptr) {
+func addConstructorToCtx(ctx *MigrationContext, fn FunctionData, metadata constructorMetadata, nodeKey uint) {
 	ty := gosrc.Type(metadata.structName)
 	currentConstructors := ctx.Constructors[ty]
 	if len(currentConstructors) == 0 {
 		ctx.Constructors[ty] = append(currentConstructors, fn)
-		ctx.ConstructorMetadataCache[nodeID] = metadata
+		ctx.ConstructorMetadataCache[nodeKey] = metadata
 		return
 	}
 	// Check if we already have a matching constructor
 	for _, each := range currentConstructors {
-		if each.sameArgs(fn) {
+		if each.Factory == "" && each.sameArgs(fn) {
 			// No need to add we already have a matching constructor
-			ctx.ConstructorMetadataCache[nodeID] = metadata
+			ctx.ConstructorMetadataCache[nodeKey] = metadata
 			return
 		}
 	}
 	// Constructor names already include parameter types (e.g., "newTypeFromString"),
 	// so they should be unique. Just add it with the original name.
 	ctx.Constructors[ty] = append(currentConstructors, fn)
-	ctx.ConstructorMetadataCache[nodeID] = metadata
+	ctx.ConstructorMetadataCache[nodeKey] = metadata
 }
 
 // getMigrationComment creates a comment indicating the source location in the Java file
@@ -226,7 +579,12 @@ func getMigrationComment(ctx *MigrationContext, node *tree_sitter.Node) string {
 
 // migrateNode dispatches node migration based on node kind
 func migrateNode(ctx *MigrationContext, node *tree_sitter.Node) {
-	switch node.Kind() {
+	if nodeKind(node) != "program" && brokenDeclaration(node) {
+		failed := syntaxFailure(ctx, diagnosticLocation(ctx, node), node)
+		ctx.Source.FailedMigrations = append(ctx.Source.FailedMigrations, *failed)
+		return
+	}
+	switch nodeKind(node) {
 	case "program":
 		IterateChildren(node, func(child *tree_sitter.Node) {
 			migrateNode(ctx, child)
@@ -239,10 +597,15 @@ func migrateNode(ctx *MigrationContext, node *tree_sitter.Node) {
 		migrateInterfaceDeclaration(ctx, node)
 	case "enum_declaration":
 		migrateEnumDeclaration(ctx, node)
+	case "annotation_type_declaration":
+		migrateAnnotationTypeDeclaration(ctx, node)
 	// Ignored
 	case "block_comment":
 	case "line_comment":
 	case "package_declaration":
+		if filepath.Base(ctx.SourceFilePath) == packageInfoFile {
+			ctx.Source.PackageDoc = packageDoc(ctx, node)
+		}
 	case "import_declaration":
 	default:
 		UnhandledChild(ctx, node, "<root>")
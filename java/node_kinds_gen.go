@@ -0,0 +1,70 @@
+// Code generated by gen_node_kinds.go; DO NOT EDIT.
+
+package java
+
+// GrammarStatementKinds lists every concrete node kind of the grammar's statement supertype
+var GrammarStatementKinds = []string{
+	";",
+	"annotation_type_declaration",
+	"assert_statement",
+	"block",
+	"break_statement",
+	"class_declaration",
+	"continue_statement",
+	"do_statement",
+	"enhanced_for_statement",
+	"enum_declaration",
+	"expression_statement",
+	"for_statement",
+	"if_statement",
+	"import_declaration",
+	"interface_declaration",
+	"labeled_statement",
+	"local_variable_declaration",
+	"module_declaration",
+	"package_declaration",
+	"record_declaration",
+	"return_statement",
+	"switch_expression",
+	"synchronized_statement",
+	"throw_statement",
+	"try_statement",
+	"try_with_resources_statement",
+	"while_statement",
+	"yield_statement",
+}
+
+// GrammarExpressionKinds lists every concrete node kind of the grammar's expression supertype
+var GrammarExpressionKinds = []string{
+	"array_access",
+	"array_creation_expression",
+	"assignment_expression",
+	"binary_expression",
+	"binary_integer_literal",
+	"cast_expression",
+	"character_literal",
+	"class_literal",
+	"decimal_floating_point_literal",
+	"decimal_integer_literal",
+	"false",
+	"field_access",
+	"hex_floating_point_literal",
+	"hex_integer_literal",
+	"identifier",
+	"instanceof_expression",
+	"lambda_expression",
+	"method_invocation",
+	"method_reference",
+	"null_literal",
+	"object_creation_expression",
+	"octal_integer_literal",
+	"parenthesized_expression",
+	"string_literal",
+	"switch_expression",
+	"template_expression",
+	"ternary_expression",
+	"this",
+	"true",
+	"unary_expression",
+	"update_expression",
+}
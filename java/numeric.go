@@ -0,0 +1,198 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// typeInt64 is the Go type of Java longs
+const typeInt64 gosrc.Type = "int64"
+
+// numericRanks orders the Go types of the Java numeric primitives the way
+// Java widens them: int to long to double
+var numericRanks = map[gosrc.Type]int{
+	gosrc.TypeInt:     1,
+	typeInt64:         2,
+	gosrc.TypeFloat64: 3,
+}
+
+// promotedType returns the type binary numeric promotion gives an operation
+// on values of types a and b, the wider of the two
+func promotedType(a, b gosrc.Type) (gosrc.Type, bool) {
+	rankA, okA := numericRanks[a]
+	rankB, okB := numericRanks[b]
+	switch {
+	case !okA || !okB:
+		return "", false
+	case rankA >= rankB:
+		return a, true
+	default:
+		return b, true
+	}
+}
+
+// numericLiteralType returns the Go type of a numeric literal, or "" if node
+// is not one. Literals with a long suffix are int64.
+func numericLiteralType(ctx *MigrationContext, node *tree_sitter.Node) gosrc.Type {
+	switch nodeKind(node) {
+	case "decimal_integer_literal", "hex_integer_literal", "octal_integer_literal", "binary_integer_literal":
+		if strings.HasSuffix(strings.ToLower(ctx.nodeText(node)), "l") {
+			return typeInt64
+		}
+		return gosrc.TypeInt
+	case "decimal_floating_point_literal":
+		return gosrc.TypeFloat64
+	default:
+		return ""
+	}
+}
+
+// isNumericLiteral reports whether node is a numeric literal, possibly
+// negated, which Go treats as an untyped constant
+func isNumericLiteral(ctx *MigrationContext, node *tree_sitter.Node) bool {
+	node = unwrapParentheses(node)
+	if nodeKind(node) == "unary_expression" {
+		switch ctx.nodeText(node.ChildByFieldName("operator")) {
+		case "-", "+":
+			return isNumericLiteral(ctx, node.ChildByFieldName("operand"))
+		}
+	}
+	return numericLiteralType(ctx, node) != ""
+}
+
+// untypedLiteral drops the int64 conversion of a converted long literal, so
+// it takes the type of wherever it is used like any other Go constant
+func untypedLiteral(value gosrc.Expression) gosrc.Expression {
+	switch value := value.(type) {
+	case *gosrc.Int64Literal:
+		return &gosrc.IntLiteral{Value: int(value.Value), Literal: value.Literal}
+	case *gosrc.UnaryExpression:
+		return &gosrc.UnaryExpression{Operator: value.Operator, Operand: untypedLiteral(value.Operand)}
+	default:
+		return value
+	}
+}
+
+// coerceNumeric converts value, converted from node, to the numeric type
+// target where Java converts it implicitly, as when an int is assigned to a
+// double. Go converts constants on its own, so literals are left untyped.
+func coerceNumeric(ctx *MigrationContext, node *tree_sitter.Node, value gosrc.Expression, target gosrc.Type) gosrc.Expression {
+	if _, ok := numericRanks[target]; !ok {
+		return value
+	}
+	if isNumericLiteral(ctx, node) {
+		return untypedLiteral(value)
+	}
+	source, ok := valueType(ctx, node)
+	if _, numeric := numericRanks[source]; !ok || !numeric || source == target {
+		return value
+	}
+	if target == gosrc.TypeFloat64 {
+		checkIntegerDivision(ctx, node)
+	}
+	return &gosrc.CastExpression{Ty: target, Value: value}
+}
+
+// checkIntegerDivision reports node if it is an integer division whose
+// result is converted to a floating point number. Java and Go both truncate
+// the quotient first, which is rarely what the conversion was meant for.
+func checkIntegerDivision(ctx *MigrationContext, node *tree_sitter.Node) {
+	node = unwrapParentheses(node)
+	if nodeKind(node) != "binary_expression" {
+		return
+	}
+	switch ctx.nodeText(node.ChildByFieldName("operator")) {
+	case "/":
+		msg := fmt.Sprintf("integer division %s is truncated before it is converted to float64; convert an operand first to divide exactly", ctx.nodeText(node))
+		reportDiagnostic(ctx, node, ErrIntegerDivision, msg)
+	}
+}
+
+// arithmeticExpression returns left operator right computed in ty. Go has no
+// remainder operator for floating point numbers, so % becomes math.Mod,
+// which truncates the quotient like Java does.
+func arithmeticExpression(ctx *MigrationContext, left gosrc.Expression, operator string, right gosrc.Expression, ty gosrc.Type) gosrc.Expression {
+	if operator == "%" && ty == gosrc.TypeFloat64 {
+		ctx.Source.AddImport("math")
+		return &gosrc.CallExpression{Function: "math.Mod", Args: []gosrc.Expression{left, right}}
+	}
+	return ctx.arena.BinaryExpression(gosrc.BinaryExpression{
+		Left:     left,
+		Operator: operator,
+		Right:    right,
+	})
+}
+
+// coerceDeclaredNumeric is coerceNumeric for the value of a declaration whose
+// Go type is inferred from its value, where literals need a conversion too
+func coerceDeclaredNumeric(ctx *MigrationContext, node *tree_sitter.Node, value gosrc.Expression, target gosrc.Type) gosrc.Expression {
+	if _, ok := numericRanks[target]; !ok || !isNumericLiteral(ctx, node) {
+		return coerceNumeric(ctx, node, value, target)
+	}
+	if literalTy, _ := valueType(ctx, node); literalTy == target {
+		return value
+	}
+	return &gosrc.CastExpression{Ty: target, Value: untypedLiteral(value)}
+}
+
+// coerceBinaryOperands converts the operands of an arithmetic, bitwise or
+// comparison operator to the type binary numeric promotion gives them
+func coerceBinaryOperands(ctx *MigrationContext, expression *tree_sitter.Node, left, right gosrc.Expression) (gosrc.Expression, gosrc.Expression) {
+	leftNode, rightNode := expression.ChildByFieldName("left"), expression.ChildByFieldName("right")
+	leftTy, leftOk := valueType(ctx, leftNode)
+	rightTy, rightOk := valueType(ctx, rightNode)
+	if !leftOk || !rightOk {
+		return left, right
+	}
+	promoted, ok := promotedType(leftTy, rightTy)
+	if !ok {
+		return left, right
+	}
+	return coerceNumeric(ctx, leftNode, left, promoted), coerceNumeric(ctx, rightNode, right, promoted)
+}
+
+// coerceArguments converts the arguments of a call to the numeric types of
+// the parameters of the function it resolved to
+func coerceArguments(ctx *MigrationContext, argsNode *tree_sitter.Node, args []gosrc.Expression, paramTypes []gosrc.Type) []gosrc.Expression {
+	argNodes := argumentNodes(argsNode)
+	if len(argNodes) != len(args) || len(paramTypes) != len(args) {
+		return args
+	}
+	for i := range args {
+		args[i] = coerceNumeric(ctx, argNodes[i], args[i], paramTypes[i])
+	}
+	return args
+}
+
+// argumentTypes returns the parameter types of the function called name
+// among functions
+func argumentTypes(functions []FunctionData, name string) []gosrc.Type {
+	for _, function := range functions {
+		if function.Name == name {
+			return function.ArgumentTypes
+		}
+	}
+	return nil
+}
+
+// enclosingReturnType returns the Go return type of the method whose body
+// node is in
+func enclosingReturnType(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Type, bool) {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch nodeKind(parent) {
+		case "method_declaration":
+			returnTy := getMethodMetadata(ctx, parent).valueTy
+			if returnTy == nil {
+				return "", false
+			}
+			return *returnTy, true
+		case "lambda_expression", "class_body":
+			return "", false
+		}
+	}
+	return "", false
+}
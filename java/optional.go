@@ -0,0 +1,347 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Ways of migrating java.util.Optional, selected by MigrationContext.Optionals
+const (
+	// OptionalsPointer migrates Optional<T> to *T, with nil for an empty
+	// optional. Optionals of classes are the pointer to the class. It is the
+	// default.
+	OptionalsPointer = "pointer"
+	// OptionalsGeneric migrates Optional<T> to the generic Optional[T]
+	// declared by OptionalSupport
+	OptionalsGeneric = "generic"
+)
+
+// optionalTypeName is the name of the generic type Optional<T> migrates to
+// under OptionalsGeneric
+const optionalTypeName = "Optional"
+
+// optionalType returns the Go type Optional<elementType> migrates to
+func (ctx *MigrationContext) optionalType(elementType gosrc.Type) gosrc.Type {
+	switch {
+	case ctx.Optionals == OptionalsGeneric:
+		return gosrc.Type(optionalTypeName + "[" + string(elementType) + "]")
+	case ctx.isClassPointer(elementType):
+		return elementType
+	default:
+		return "*" + elementType
+	}
+}
+
+// isClassPointer reports whether ty is a pointer to a migrated class, which
+// stands for an optional of itself under OptionalsPointer
+func (ctx *MigrationContext) isClassPointer(ty gosrc.Type) bool {
+	if !isPointerType(ty) {
+		return false
+	}
+	name, _, _ := strings.Cut(strings.TrimPrefix(string(ty), "*"), "[")
+	return ctx.Classes[name]
+}
+
+// isPointerType reports whether ty is a pointer
+func isPointerType(ty gosrc.Type) bool {
+	return strings.HasPrefix(string(ty), "*")
+}
+
+// optionalElementType returns the element type of the Java type typeNode if
+// it is an Optional
+func optionalElementType(ctx *MigrationContext, typeNode *tree_sitter.Node) (gosrc.Type, bool) {
+	if typeNode == nil || nodeKind(typeNode) != "generic_type" || ctx.nodeText(typeNode.NamedChild(0)) != "Optional" {
+		return "", false
+	}
+	var typeArgs []gosrc.Type
+	IterateChildren(typeNode, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "type_arguments" {
+			typeArgs = parseTypeArguments(ctx, child)
+		}
+	})
+	if len(typeArgs) != 1 {
+		return "", false
+	}
+	return typeArgs[0], true
+}
+
+// optionalOf returns the element type of the optional expression evaluates
+// to, found from the Java type of the variable, field or method it refers to
+func optionalOf(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Type, bool) {
+	var typeNode *tree_sitter.Node
+	switch nodeKind(expression) {
+	case "identifier":
+		typeNode = declaredType(ctx, expression, ctx.nodeText(expression))
+	case "field_access":
+		if nodeKind(expression.ChildByFieldName("object")) == "this" {
+			typeNode = declaredType(ctx, expression, ctx.nodeText(expression.ChildByFieldName("field")))
+		}
+	case "method_invocation":
+		if method := invokedMethodDeclaration(ctx, expression); method != nil {
+			typeNode = method.ChildByFieldName("type")
+		}
+	case "parenthesized_expression":
+		return optionalOf(ctx, expression.NamedChild(0))
+	}
+	return optionalElementType(ctx, typeNode)
+}
+
+// isOptionalExpression reports whether expression evaluates to an optional
+func isOptionalExpression(ctx *MigrationContext, expression *tree_sitter.Node) bool {
+	_, ok := optionalOf(ctx, expression)
+	return ok
+}
+
+// expectedOptional returns the element type of the optional expression is
+// expected to be
+func expectedOptional(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Type, bool) {
+	return optionalElementType(ctx, expectedJavaType(ctx, expression))
+}
+
+// convertOptionalFactory converts the static factories of
+// java.util.Optional. It returns false for the factories without a
+// translation.
+func convertOptionalFactory(ctx *MigrationContext, expression *tree_sitter.Node, name string) (gosrc.Expression, []gosrc.Statement, bool) {
+	argsNode := expression.ChildByFieldName("arguments")
+	argNodes := argumentNodes(argsNode)
+	elementType, known := expectedOptional(ctx, expression)
+	switch {
+	case name == "empty" && len(argNodes) == 0,
+		name == "ofNullable" && len(argNodes) == 1 && nodeKind(unwrapParentheses(argNodes[0])) == "null_literal":
+		if ctx.Optionals != OptionalsGeneric {
+			return &gosrc.NIL, nil, true
+		}
+		if !known {
+			FatalError(ctx, expression, "unable to infer the element type of Optional."+name+"()", "method_invocation")
+		}
+		return &gosrc.CallExpression{Function: "OptionalEmpty", TypeArgs: []gosrc.Type{elementType}}, nil, true
+	case (name == "of" || name == "ofNullable") && len(argNodes) == 1:
+		if !known {
+			elementType, _ = valueType(ctx, argNodes[0])
+		}
+		nullable := name == "ofNullable" && isPointerType(elementType)
+		if mapNode, keyNode, ok := mapGet(ctx, argNodes[0]); ok && name == "ofNullable" && !nullable {
+			// Missing keys are null in Java, while indexing yields the zero
+			// value, so the optional is only set if the key is there
+			return optionalLookup(ctx, mapNode, keyNode, elementType)
+		}
+		if name == "ofNullable" && !nullable && !ctx.isClassPointer(elementType) {
+			reportDiagnostic(ctx, expression, ErrNullableValue,
+				fmt.Sprintf("Optional.ofNullable(%s) is never empty, as the value can't be nil in Go", ctx.nodeText(argNodes[0])))
+		}
+		args, initStmts := convertArguments(ctx, argsNode)
+		switch {
+		case ctx.Optionals == OptionalsGeneric && nullable:
+			return &gosrc.CallExpression{Function: "OptionalOfNullable", Args: args}, initStmts, true
+		case ctx.Optionals == OptionalsGeneric:
+			return &gosrc.CallExpression{Function: "OptionalOf", Args: args}, initStmts, true
+		case ctx.isClassPointer(elementType):
+			// A nil pointer is an empty optional already
+			return args[0], initStmts, true
+		default:
+			// Take the address of a copy, as the optional holds the value
+			value := ctx.tempName("value")
+			initStmts = append(initStmts, &gosrc.VarDeclaration{Name: value, Value: args[0]})
+			return ctx.arena.GoExpression(gosrc.GoExpression{Source: "&" + value}), initStmts, true
+		}
+	default:
+		return nil, nil, false
+	}
+}
+
+// optionalLookup converts Optional.ofNullable(m.get(k)) to an optional set
+// only if m has the key k
+func optionalLookup(ctx *MigrationContext, mapNode, keyNode *tree_sitter.Node, elementType gosrc.Type) (gosrc.Expression, []gosrc.Statement, bool) {
+	if elementType == "" {
+		FatalError(ctx, mapNode.Parent(), "unable to infer the element type of Optional.ofNullable()", "method_invocation")
+	}
+	lookup, initStmts := convertMapLookup(ctx, mapNode, keyNode)
+	value, element, found := ctx.tempName("value"), ctx.tempName("element"), ctx.tempName("found")
+	empty, present := "var "+value+" "+string(ctx.optionalType(elementType)), value+" = &"+element
+	if ctx.Optionals == OptionalsGeneric {
+		empty = fmt.Sprintf("%s := OptionalEmpty[%s]()", value, elementType)
+		present = fmt.Sprintf("%s = OptionalOf(%s)", value, element)
+	}
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: value}), append(initStmts,
+		&gosrc.GoStatement{Source: empty},
+		&gosrc.GoStatement{Source: fmt.Sprintf("if %s, %s := %s; %s {\n%s\n}", element, found, lookup, found, present)},
+	), true
+}
+
+// convertOptionalMethod converts a call of a java.util.Optional method on
+// receiver, the Go source of an optional of elementType. It returns false
+// for methods without a translation.
+func convertOptionalMethod(ctx *MigrationContext, expression *tree_sitter.Node, name, receiver string, elementType gosrc.Type) (gosrc.Expression, []gosrc.Statement, bool) {
+	argsNode := expression.ChildByFieldName("arguments")
+	argCount := len(argumentNodes(argsNode))
+	switch {
+	case (name == "isPresent" || name == "isEmpty" || name == "get" || name == "orElseThrow") && argCount == 0:
+	case (name == "orElse" || name == "orElseGet" || name == "ifPresent" || name == "map") && argCount == 1:
+	default:
+		return nil, nil, false
+	}
+	args, initStmts := convertArguments(ctx, argsNode)
+	if ctx.Optionals == OptionalsGeneric {
+		converted := &gosrc.CallExpression{Function: receiver + "." + gosrc.CapitalizeFirstLetter(name), Args: args}
+		switch name {
+		case "orElseThrow":
+			converted.Function = receiver + ".Get"
+		case "map":
+			converted.Function = "OptionalMap"
+			converted.Args = append([]gosrc.Expression{&gosrc.VarRef{Ref: receiver}}, args...)
+		}
+		return converted, initStmts, true
+	}
+	if containsInvocation(expression.ChildByFieldName("object")) {
+		// The receiver is used more than once, so it is evaluated once first
+		optional := ctx.tempName("optional")
+		initStmts = append([]gosrc.Statement{&gosrc.VarDeclaration{Name: optional, Value: &gosrc.VarRef{Ref: receiver}}}, initStmts...)
+		receiver = optional
+	}
+	value := receiver
+	if !ctx.isClassPointer(elementType) {
+		value = "*" + receiver
+	}
+	present := fmt.Sprintf("%s != nil", receiver)
+	switch name {
+	case "isPresent":
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: "(" + present + ")"}), initStmts, true
+	case "isEmpty":
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("(%s == nil)", receiver)}), initStmts, true
+	case "get", "orElseThrow":
+		// Dereferencing nil panics like get() on an empty optional throws
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: value}), initStmts, true
+	case "orElse":
+		result := ctx.tempName("valueOrElse")
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: result}), append(initStmts,
+			&gosrc.VarDeclaration{Name: result, Value: args[0]},
+			&gosrc.GoStatement{Source: fmt.Sprintf("if %s {\n%s = %s\n}", present, result, value)},
+		), true
+	case "orElseGet":
+		result := ctx.tempName("valueOrElse")
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: result}), append(initStmts,
+			&gosrc.VarDeclaration{Name: result, Ty: elementType},
+			&gosrc.GoStatement{Source: fmt.Sprintf("if %s {\n%s = %s\n} else {\n%s = %s()\n}", present, result, value, result, args[0].ToSource())},
+		), true
+	case "ifPresent":
+		// ifPresent returns nothing, so the call is only a statement
+		return nil, append(initStmts,
+			&gosrc.GoStatement{Source: fmt.Sprintf("if %s {\n%s(%s)\n}", present, args[0].ToSource(), value)},
+		), true
+	default:
+		return convertOptionalMap(ctx, expression, value, present, args[0], initStmts)
+	}
+}
+
+// convertOptionalMap converts optional.map(mapper) under OptionalsPointer to
+// an optional holding the result of mapper if optional holds a value
+func convertOptionalMap(ctx *MigrationContext, expression *tree_sitter.Node, value, present string, mapper gosrc.Expression, initStmts []gosrc.Statement) (gosrc.Expression, []gosrc.Statement, bool) {
+	resultType, ok := expectedOptional(ctx, expression)
+	if !ok {
+		resultType, ok = mapperResultType(ctx, expression.ChildByFieldName("arguments").NamedChild(0))
+	}
+	if !ok {
+		FatalError(ctx, expression, "unable to infer the element type of the result of Optional.map", "method_invocation")
+	}
+	result := ctx.tempName("mapped")
+	call := fmt.Sprintf("%s(%s)", mapper.ToSource(), value)
+	mapped := fmt.Sprintf("%s = %s", result, call)
+	if !ctx.isClassPointer(resultType) {
+		mapped = fmt.Sprintf("%sValue := %s\n%s = &%sValue", result, call, result, result)
+	}
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: result}), append(initStmts,
+		&gosrc.VarDeclaration{Name: result, Ty: ctx.optionalType(resultType)},
+		&gosrc.GoStatement{Source: fmt.Sprintf("if %s {\n%s\n}", present, mapped)},
+	), true
+}
+
+// mapperResultType returns the result type of the function mapper, a
+// variable or field of a functional interface type
+func mapperResultType(ctx *MigrationContext, mapper *tree_sitter.Node) (gosrc.Type, bool) {
+	ty, ok := staticType(ctx, mapper)
+	if !ok || !strings.HasPrefix(string(ty), "func(") {
+		return "", false
+	}
+	// The result follows the parameters, which may be functions themselves
+	depth := 0
+	for i, r := range ty {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				result := strings.TrimSpace(string(ty[i+1:]))
+				return gosrc.Type(result), result != ""
+			}
+		}
+	}
+	return "", false
+}
+
+// needsOptionalSupport reports whether the Go source migrated from tree uses
+// the Optional type declared by OptionalSupport
+func needsOptionalSupport(ctx *MigrationContext, tree *tree_sitter.Tree) bool {
+	return ctx.Optionals == OptionalsGeneric && treeRefersTo(ctx, tree, "Optional")
+}
+
+// OptionalSupport returns the declarations of the generic Optional type
+// java.util.Optional migrates to under OptionalsGeneric, and of the
+// functions standing in for its static factories and map
+func OptionalSupport() gosrc.GoSource {
+	typeParam := []gosrc.TypeParam{{Name: "T", Constraint: "any"}}
+	optionalT := gosrc.Type("Optional[T]")
+	self := gosrc.Param{Name: "o", Ty: optionalT}
+	method := func(name string, params []gosrc.Param, returnType gosrc.Type, body ...string) gosrc.Method {
+		function := supportFunction(name, nil, params, returnType, body...)
+		return gosrc.Method{Function: function, Receiver: self}
+	}
+	return gosrc.GoSource{
+		Structs: []gosrc.Struct{{
+			Name:       optionalTypeName,
+			TypeParams: typeParam,
+			Fields: []gosrc.StructField{
+				{Name: "value", Ty: "T"},
+				{Name: "present", Ty: gosrc.TypeBool},
+			},
+			Public:   true,
+			Comments: []string{"Optional is a value that may be absent, migrated from java.util.Optional"},
+		}},
+		Functions: []gosrc.Function{
+			supportFunction("OptionalOf", typeParam, []gosrc.Param{{Name: "value", Ty: "T"}}, optionalT,
+				"return Optional[T]{value: value, present: true}"),
+			supportFunction("OptionalOfNullable", typeParam, []gosrc.Param{{Name: "value", Ty: "*T"}}, "Optional[*T]",
+				"return Optional[*T]{value: value, present: value != nil}"),
+			supportFunction("OptionalEmpty", typeParam, nil, optionalT,
+				"return Optional[T]{}"),
+			supportFunction("OptionalMap", []gosrc.TypeParam{{Name: "T", Constraint: "any"}, {Name: "R", Constraint: "any"}},
+				[]gosrc.Param{{Name: "o", Ty: optionalT}, {Name: "mapper", Ty: "func(T) R"}}, "Optional[R]",
+				"if !o.present {\nreturn Optional[R]{}\n}", "return OptionalOf(mapper(o.value))"),
+		},
+		Methods: []gosrc.Method{
+			method("IsPresent", nil, gosrc.TypeBool, "return o.present"),
+			method("IsEmpty", nil, gosrc.TypeBool, "return !o.present"),
+			method("Get", nil, "T", "if !o.present {\npanic(\"no value present\")\n}", "return o.value"),
+			method("OrElse", []gosrc.Param{{Name: "other", Ty: "T"}}, "T", "if !o.present {\nreturn other\n}", "return o.value"),
+			method("OrElseGet", []gosrc.Param{{Name: "other", Ty: "func() T"}}, "T", "if !o.present {\nreturn other()\n}", "return o.value"),
+			method("IfPresent", []gosrc.Param{{Name: "action", Ty: "func(T)"}}, "", "if o.present {\naction(o.value)\n}"),
+		},
+	}
+}
+
+// supportFunction returns an exported function of the Optional support whose
+// body is the Go statements body
+func supportFunction(name string, typeParams []gosrc.TypeParam, params []gosrc.Param, returnType gosrc.Type, body ...string) gosrc.Function {
+	function := gosrc.Function{Name: name, TypeParams: typeParams, Params: params, Public: true}
+	if returnType != "" {
+		function.ReturnType = &returnType
+	}
+	for _, statement := range body {
+		function.Body = append(function.Body, &gosrc.GoStatement{Source: statement})
+	}
+	return function
+}
@@ -0,0 +1,126 @@
+package java
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// ordinalMethodName is the name of the method generated for the ordinal()
+// of simple enums
+const ordinalMethodName = "Ordinal"
+
+// orderedOperators are the operators that give the ordinals of enum constants
+// a meaning beyond telling them apart
+var orderedOperators = map[string]bool{
+	"<": true, "<=": true, ">": true, ">=": true,
+	"+": true, "-": true, "*": true, "/": true, "%": true,
+}
+
+// enumOrdinals returns the prefixed constants, in declaration order, of the
+// enum migrated to the integer type ty
+func (ctx *MigrationContext) enumOrdinals(ty gosrc.Type) ([]string, bool) {
+	constants, ok := ctx.EnumOrdinals[string(ty)]
+	return constants, ok
+}
+
+// ordinalReceiver returns the enum value node calls ordinal() on, as in
+// color.ordinal() or Color.valueOf(name).ordinal(), and the constants of its enum, if the enum is migrated to
+// an integer type
+func ordinalReceiver(ctx *MigrationContext, node *tree_sitter.Node) (*tree_sitter.Node, []string, bool) {
+	node = unwrapParentheses(node)
+	if nodeKind(node) != "method_invocation" || ctx.nodeText(node.ChildByFieldName("name")) != "ordinal" {
+		return nil, nil, false
+	}
+	receiver := node.ChildByFieldName("object")
+	if receiver == nil || len(argumentNodes(node.ChildByFieldName("arguments"))) != 0 {
+		return nil, nil, false
+	}
+	ty, ok := valueType(ctx, receiver)
+	if !ok {
+		return nil, nil, false
+	}
+	constants, ok := ctx.enumOrdinals(ty)
+	return receiver, constants, ok
+}
+
+// convertOrdinalCall converts value.ordinal() on a value of a simple enum to
+// a call of its Ordinal method. Comparing or computing with ordinals relies
+// on the declaration order of the constants, which is reported.
+func convertOrdinalCall(ctx *MigrationContext, expression *tree_sitter.Node, objectText string) gosrc.Expression {
+	parent := expression.Parent()
+	for nodeKind(parent) == "parenthesized_expression" {
+		parent = parent.Parent()
+	}
+	if nodeKind(parent) == "binary_expression" && orderedOperators[ctx.nodeText(parent.ChildByFieldName("operator"))] && !reportedWithLeft(ctx, parent, expression) {
+		msg := fmt.Sprintf("%s relies on the declaration order of the enum constants; check it still holds if they are reordered", ctx.nodeText(parent))
+		reportDiagnostic(ctx, parent, ErrEnumOrdinal, msg)
+	}
+	return &gosrc.CallExpression{Function: objectText + "." + ordinalMethodName}
+}
+
+// reportedWithLeft reports whether the ordering assumption of binary, which
+// has expression as an operand, is reported with its left operand, as in
+// a.ordinal() < b.ordinal()
+func reportedWithLeft(ctx *MigrationContext, binary, expression *tree_sitter.Node) bool {
+	left := unwrapParentheses(binary.ChildByFieldName("left"))
+	_, _, leftIsOrdinal := ordinalReceiver(ctx, left)
+	return leftIsOrdinal && left.StartByte() != expression.StartByte()
+}
+
+// convertOrdinalIndex converts items[value.ordinal()], an access of an array
+// indexed by the ordinal of value, to items[value]. The constants of simple
+// enums are their ordinals, and Go indexes slices by any integer type.
+func convertOrdinalIndex(ctx *MigrationContext, expression, receiver *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	arrayNode := expression.ChildByFieldName("array")
+	array, initStmts := convertExpression(ctx, arrayNode)
+	index, indexInit := convertExpression(ctx, receiver)
+	source := array.ToSource()
+	if arrayArgumentKind(ctx, arrayNode) == slicePointer {
+		source = "(*" + source + ")"
+	}
+	return ctx.arena.GoExpression(gosrc.GoExpression{
+		Source: source + "[" + index.ToSource() + "]",
+	}), append(initStmts, indexInit...)
+}
+
+// ordinalSwitch returns the enum value a switch on value.ordinal() can
+// switch on directly, and the constants of its enum, if every case label is
+// the ordinal of one of them
+func ordinalSwitch(ctx *MigrationContext, switchNode *tree_sitter.Node) (*tree_sitter.Node, []string, bool) {
+	receiver, constants, ok := ordinalReceiver(ctx, switchNode.ChildByFieldName("condition"))
+	if !ok {
+		return nil, nil, false
+	}
+	var visit func(node *tree_sitter.Node)
+	visit = func(node *tree_sitter.Node) {
+		switch nodeKind(node) {
+		case "switch_label":
+			for i := uint(0); i < node.NamedChildCount(); i++ {
+				if _, isOrdinal := ordinalConstant(ctx, node.NamedChild(i), constants); !isOrdinal {
+					ok = false
+				}
+			}
+		case "switch_block_statement_group", "switch_rule":
+			IterateChildren(node, visit)
+		}
+	}
+	IterateChildren(switchNode.ChildByFieldName("body"), visit)
+	return receiver, constants, ok
+}
+
+// ordinalConstant returns the constant among constants whose ordinal the case
+// label labelNode is, if it is an integer literal in range
+func ordinalConstant(ctx *MigrationContext, labelNode *tree_sitter.Node, constants []string) (string, bool) {
+	if nodeKind(labelNode) != "decimal_integer_literal" {
+		return "", false
+	}
+	ordinal, err := strconv.Atoi(ctx.nodeText(labelNode))
+	if err != nil || ordinal >= len(constants) {
+		return "", false
+	}
+	return constants[ordinal], true
+}
@@ -0,0 +1,131 @@
+package java
+
+import (
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// invokedMethodName looks up the converted name of the method methodName
+// called with the arguments in argsNode, telling overloads apart by the
+// number of arguments and then by their types.
+// Returns: (convertedName, found, multipleMatches)
+func invokedMethodName(ctx *MigrationContext, methodName string, argsNode *tree_sitter.Node) (string, bool, bool) {
+	methods := trackedMethods(ctx, methodName)
+	switch len(methods) {
+	case 0:
+		// Method not tracked - use original name
+		return methodName, false, false
+	case 1:
+		// No overloading - return the single method name
+		return methods[0].Name, true, false
+	default:
+		return resolveOverload(ctx, methods, argsNode)
+	}
+}
+
+// resolveOverload picks the overload among methods the arguments in argsNode
+// fit. Overloads taking as many arguments are told apart by the types of the
+// arguments that can be told without type checking: the overload they match
+// exactly wins over the ones they can be converted to, and among those the
+// most specific one wins, as in Java.
+// Returns: (name, found, multipleMatches)
+func resolveOverload(ctx *MigrationContext, methods []FunctionData, argsNode *tree_sitter.Node) (string, bool, bool) {
+	argNodes := argumentNodes(argsNode)
+	name, found, multipleMatches := tryGuessOverloadedMethod(methods, len(argNodes))
+	if !multipleMatches {
+		return name, found, false
+	}
+	argTypes := make([]gosrc.Type, len(argNodes))
+	for i, argNode := range argNodes {
+		// Unknown types fit any parameter
+		argTypes[i], _ = valueType(ctx, argNode)
+	}
+	for _, exact := range []bool{true, false} {
+		var matches []FunctionData
+		for _, method := range methods {
+			if len(method.ArgumentTypes) == len(argTypes) && ctx.argumentsFit(method.ArgumentTypes, argTypes, exact) {
+				matches = append(matches, method)
+			}
+		}
+		if len(matches) == 1 {
+			return matches[0].Name, true, false
+		}
+		if specific, ok := ctx.mostSpecific(matches); ok && !exact {
+			return specific.Name, true, false
+		}
+	}
+	return name, true, true
+}
+
+// mostSpecific returns the only overload among methods whose parameters fit
+// the parameters of every other one, which Java calls when the arguments fit
+// several: bar(1) calls bar(long) rather than bar(double)
+func (ctx *MigrationContext) mostSpecific(methods []FunctionData) (FunctionData, bool) {
+	var found []FunctionData
+	for _, candidate := range methods {
+		specific := true
+		for _, other := range methods {
+			specific = specific && ctx.argumentsFit(other.ArgumentTypes, candidate.ArgumentTypes, false)
+		}
+		if specific {
+			found = append(found, candidate)
+		}
+	}
+	if len(found) != 1 {
+		return FunctionData{}, false
+	}
+	return found[0], true
+}
+
+// argumentsFit reports whether arguments of argTypes can be passed for
+// parameters of paramTypes, without any conversion if exact is set
+func (ctx *MigrationContext) argumentsFit(paramTypes, argTypes []gosrc.Type, exact bool) bool {
+	for i, argType := range argTypes {
+		if !ctx.argumentFits(paramTypes[i], argType, exact) {
+			return false
+		}
+	}
+	return true
+}
+
+// argumentFits reports whether an argument of type argType can be passed for
+// a parameter of type paramType: numbers widen, and instances of classes fit
+// the interfaces they implement and the classes they extend. Types the
+// analysis knows nothing about, such as those of libraries, fit anything.
+func (ctx *MigrationContext) argumentFits(paramType, argType gosrc.Type, exact bool) bool {
+	switch {
+	case argType == "", argType == paramType:
+		return true
+	case exact:
+		return false
+	case paramType == "any", paramType == "interface{}":
+		return true
+	}
+	if promoted, ok := promotedType(argType, paramType); ok {
+		return promoted == paramType
+	}
+	if isBasicType(argType) || isBasicType(paramType) {
+		return false
+	}
+	if !ctx.declaresType(argType) {
+		return true
+	}
+	return ctx.isSubtype(argType, paramType)
+}
+
+// isBasicType reports whether ty is the Go type of a Java primitive or of a
+// String
+func isBasicType(ty gosrc.Type) bool {
+	_, isNumeric := numericRanks[ty]
+	return isNumeric || ty == gosrc.TypeString || ty == gosrc.TypeBool
+}
+
+// declaresType reports whether the analysis knows the supertypes of the type
+// ty refers to
+func (ctx *MigrationContext) declaresType(ty gosrc.Type) bool {
+	name, _ := splitTypeArguments(structType(ty))
+	_, hasFields := ctx.Fields[name]
+	_, hasSupertypes := ctx.Supertypes[name]
+	return ctx.Classes[name] || hasFields || hasSupertypes
+}
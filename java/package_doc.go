@@ -0,0 +1,63 @@
+package java
+
+import (
+	"regexp"
+	"strings"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// packageInfoFile is the name of the file holding Java package documentation
+const packageInfoFile = "package-info.java"
+
+// inlineJavadocTag matches inline Javadoc tags such as {@code x} and {@link x}
+var inlineJavadocTag = regexp.MustCompile(`\{@\w+\s*([^}]*)\}`)
+
+// packageDoc returns the lines of the Javadoc comment preceding the package
+// declaration packageNode, ready to become the Go package comment. It returns
+// nil if the package is not documented.
+func packageDoc(ctx *MigrationContext, packageNode *tree_sitter.Node) []string {
+	comment := packageNode.PrevNamedSibling()
+	for nodeKind(comment) == "line_comment" {
+		comment = comment.PrevNamedSibling()
+	}
+	if nodeKind(comment) != "block_comment" || !strings.HasPrefix(ctx.nodeText(comment), "/**") {
+		return nil
+	}
+	return javadocLines(ctx.nodeText(comment))
+}
+
+// javadocLines strips the comment markers from a Javadoc comment and turns
+// inline tags and paragraph breaks into plain text
+func javadocLines(comment string) []string {
+	comment = strings.TrimSuffix(strings.TrimPrefix(comment, "/**"), "*/")
+	var lines []string
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(strings.TrimPrefix(line, "*"), " ")
+		line = inlineJavadocTag.ReplaceAllString(line, "$1")
+		if rest, isParagraph := strings.CutPrefix(line, "<p>"); isParagraph {
+			lines = appendBlankLine(lines)
+			line = strings.TrimSpace(rest)
+		}
+		switch line = strings.TrimRight(line, " "); line {
+		case "":
+			lines = appendBlankLine(lines)
+		default:
+			lines = append(lines, line)
+		}
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// appendBlankLine appends a paragraph break to lines unless they are empty or
+// already end with one
+func appendBlankLine(lines []string) []string {
+	if len(lines) == 0 || lines[len(lines)-1] == "" {
+		return lines
+	}
+	return append(lines, "")
+}
@@ -0,0 +1,163 @@
+package java
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// collectImports records the types and static methods the program root
+// imports from the Java packages ctx.PackageMappings maps to other Go
+// packages, by their simple names. Wildcard imports are left alone since the
+// names they import are not known.
+func collectImports(ctx *MigrationContext, root *tree_sitter.Node) {
+	ctx.importedTypes, ctx.importedMethods = nil, nil
+	if len(ctx.PackageMappings) == 0 {
+		return
+	}
+	ctx.importedTypes = make(map[string]string)
+	ctx.importedMethods = make(map[string]string)
+	ownPath, _ := ctx.goImportPath(packageDeclarationName(root, ctx.JavaSource))
+	IterateChildren(root, func(child *tree_sitter.Node) {
+		if nodeKind(child) != "import_declaration" {
+			return
+		}
+		name, isStatic, isWildcard := importDeclaration(ctx, child)
+		qualifier, member, ok := cutLastDot(name)
+		if !ok || isWildcard {
+			return
+		}
+		imported := ctx.importedTypes
+		if isStatic {
+			// Static methods are imported through their class
+			qualifier, _, _ = cutLastDot(qualifier)
+			imported = ctx.importedMethods
+		}
+		if importPath, ok := ctx.goImportPath(qualifier); ok && importPath != ownPath {
+			imported[member] = importPath
+		}
+	})
+}
+
+// importDeclaration returns the name an import declaration imports, and
+// whether it is a static import and a wildcard import
+func importDeclaration(ctx *MigrationContext, node *tree_sitter.Node) (name string, isStatic bool, isWildcard bool) {
+	IterateChildren(node, func(part *tree_sitter.Node) {
+		switch nodeKind(part) {
+		case "static":
+			isStatic = true
+		case "asterisk":
+			isWildcard = true
+		case "identifier", "scoped_identifier":
+			name = ctx.nodeText(part)
+		}
+	})
+	return name, isStatic, isWildcard
+}
+
+// checkProjectImports reports the imports of the program root from the other
+// packages of the project being migrated that ctx.PackageMappings doesn't map
+// to Go packages. References to what they import are left unqualified, as if
+// it was declared in the same Go package.
+func checkProjectImports(ctx *MigrationContext, root *tree_sitter.Node) {
+	if len(ctx.ProjectPackages) == 0 {
+		return
+	}
+	ownPackage := packageDeclarationName(root, ctx.JavaSource)
+	IterateChildren(root, func(child *tree_sitter.Node) {
+		if nodeKind(child) != "import_declaration" {
+			return
+		}
+		name, isStatic, isWildcard := importDeclaration(ctx, child)
+		javaPackage := name
+		if !isWildcard {
+			javaPackage, _, _ = cutLastDot(javaPackage)
+		}
+		if isStatic {
+			// Static members are imported through their class
+			javaPackage, _, _ = cutLastDot(javaPackage)
+		}
+		if _, mapped := ctx.goImportPath(javaPackage); mapped || javaPackage == ownPackage || !ctx.ProjectPackages[javaPackage] {
+			return
+		}
+		reportDiagnostic(ctx, child, ErrUnmappedPackage, fmt.Sprintf("%s is imported from package %s of the project, whose Go import path is unknown, so references to it are not qualified; migrate the project into a Go module or map the package in package_mappings", name, javaPackage))
+	})
+}
+
+// goImportPath returns the import path of the Go package the Java package
+// javaPackage is migrated to. The longest prefix of javaPackage mapped by
+// ctx.PackageMappings wins, and the packages nested in it map to the
+// directories nested in its Go package.
+func (ctx *MigrationContext) goImportPath(javaPackage string) (string, bool) {
+	var prefix string
+	for each := range ctx.PackageMappings {
+		if (javaPackage == each || strings.HasPrefix(javaPackage, each+".")) && len(each) > len(prefix) {
+			prefix = each
+		}
+	}
+	if prefix == "" {
+		return "", false
+	}
+	rest := strings.TrimPrefix(javaPackage[len(prefix):], ".")
+	return path.Join(ctx.PackageMappings[prefix], strings.ReplaceAll(rest, ".", "/")), true
+}
+
+// importedType returns the Go type the type javaName imported from a mapped
+// package refers to, qualified by the name of its Go package, and adds the
+// import of that package
+func (ctx *MigrationContext) importedType(javaName string) (string, bool) {
+	importPath, ok := ctx.importedTypes[javaName]
+	if !ok {
+		return "", false
+	}
+	ctx.Source.AddImport(importPath)
+	goType := goPackageName(importPath) + "." + javaName
+	if ctx.Classes[javaName] {
+		// Known to be a class from the symbols of the other files
+		goType = "*" + goType
+	}
+	return goType, true
+}
+
+// importedFunction returns the function a static method of the Go package
+// importPath is migrated to, and adds the import of that package
+func (ctx *MigrationContext) importedFunction(importPath, convertedName string) string {
+	ctx.Source.AddImport(importPath)
+	return goPackageName(importPath) + "." + gosrc.CapitalizeFirstLetter(convertedName)
+}
+
+// splitImportedType splits the Go type of a type imported from a mapped
+// package, such as geometry.Point, into the qualifier of its package and its
+// name. Other types have no qualifier.
+func (ctx *MigrationContext) splitImportedType(ty gosrc.Type) (string, gosrc.Type) {
+	pkg, name, ok := strings.Cut(string(ty), ".")
+	if importPath, imported := ctx.importedTypes[name]; ok && imported && goPackageName(importPath) == pkg {
+		return pkg + ".", gosrc.Type(name)
+	}
+	return "", ty
+}
+
+// goPackageName returns the name of the Go package at importPath, the last
+// element of the path in lower case like the packages of a migrated project
+func goPackageName(importPath string) string {
+	return strings.ToLower(path.Base(importPath))
+}
+
+// isQualifiedType reports whether the Go type name is qualified by the name
+// of the package declaring it, as geo.Point is
+func isQualifiedType(name string) bool {
+	return !strings.ContainsAny(name, "[]() ") && strings.Contains(name, ".")
+}
+
+// cutLastDot splits a qualified name around its last dot
+func cutLastDot(name string) (string, string, bool) {
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return "", name, false
+	}
+	return name[:i], name[i+1:], true
+}
@@ -0,0 +1,89 @@
+package java
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// isClassExpression reports whether node evaluates to a java.lang.Class,
+// which is migrated to a reflect.Type
+func isClassExpression(ctx *MigrationContext, node *tree_sitter.Node) bool {
+	switch nodeKind(node) {
+	case "class_literal":
+		return true
+	case "method_invocation":
+		return ctx.nodeText(node.ChildByFieldName("name")) == "getClass" && node.ChildByFieldName("arguments").NamedChildCount() == 0
+	case "parenthesized_expression":
+		return isClassExpression(ctx, node.NamedChild(0))
+	default:
+		return false
+	}
+}
+
+// convertGetClass converts x.getClass() to the reflect.Type of x. The
+// receiver of a method is a pointer, so this.getClass() is the type of the
+// struct it points to, like it is in Java.
+func convertGetClass(ctx *MigrationContext, receiver string) gosrc.Expression {
+	if receiver == "" || receiver == gosrc.SelfRef {
+		receiver = "*" + gosrc.SelfRef
+	}
+	ctx.Source.AddImport("reflect")
+	return &gosrc.CallExpression{Function: "reflect.TypeOf", Args: []gosrc.Expression{&gosrc.VarRef{Ref: receiver}}}
+}
+
+// convertClassMethod converts a call of a java.lang.Class method on receiver,
+// the Go source of a reflect.Type. Methods without a reflect equivalent are
+// reported and replaced by a call that panics.
+func convertClassMethod(ctx *MigrationContext, expression, classNode *tree_sitter.Node, name, receiver string) (gosrc.Expression, []gosrc.Statement) {
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	switch {
+	case name == "getSimpleName" && len(argNodes) == 0:
+		return &gosrc.CallExpression{Function: receiver + ".Name"}, nil
+	case (name == "getName" || name == "getTypeName" || name == "toString") && len(argNodes) == 0:
+		return &gosrc.CallExpression{Function: receiver + ".String"}, nil
+	case name == "equals" && len(argNodes) == 1:
+		other, initStmts := convertExpression(ctx, argNodes[0])
+		return ctx.arena.BinaryExpression(gosrc.BinaryExpression{Left: &gosrc.VarRef{Ref: receiver}, Operator: "==", Right: other}), initStmts
+	case name == "isInstance" && len(argNodes) == 1 && nodeKind(classNode) == "class_literal":
+		// A type assertion is the Go equivalent of an instanceof check
+		ty, ok := TryParseType(ctx, classNode.NamedChild(0))
+		if !ok {
+			FatalError(ctx, classNode, "unable to parse type in class_literal", "class_literal")
+		}
+		value, initStmts := convertExpression(ctx, argNodes[0])
+		result := ctx.tempName("isInstance")
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: result}), append(initStmts, &gosrc.GoStatement{
+			Source: fmt.Sprintf("_, %s := any(%s).(%s)", result, value.ToSource(), ty),
+		})
+	case name == "isInstance" && len(argNodes) == 1:
+		value, initStmts := convertExpression(ctx, argNodes[0])
+		ctx.Source.AddImport("reflect")
+		return &gosrc.CallExpression{
+			Function: fmt.Sprintf("reflect.TypeOf(%s).AssignableTo", value.ToSource()),
+			Args:     []gosrc.Expression{&gosrc.VarRef{Ref: receiver}},
+		}, initStmts
+	case name == "isAssignableFrom" && len(argNodes) == 1:
+		other, initStmts := convertExpression(ctx, argNodes[0])
+		return &gosrc.CallExpression{
+			Function: other.ToSource() + ".AssignableTo",
+			Args:     []gosrc.Expression{&gosrc.VarRef{Ref: receiver}},
+		}, initStmts
+	default:
+		return unsupportedReflection(ctx, expression), nil
+	}
+}
+
+// unsupportedReflection reports a reflective call that can't be migrated and
+// returns a shim call standing in for it, which panics when it is reached
+func unsupportedReflection(ctx *MigrationContext, expression *tree_sitter.Node) gosrc.Expression {
+	call := ctx.nodeText(expression)
+	reportDiagnostic(ctx, expression, ErrReflection,
+		fmt.Sprintf("%s uses reflection that has no Go equivalent; it panics when reached", call))
+	return ctx.arena.GoExpression(gosrc.GoExpression{
+		Source: fmt.Sprintf("func() any { panic(%s) }()", strconv.Quote("unsupported reflection: "+call)),
+	})
+}
@@ -0,0 +1,278 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// setElementType returns the element type of the map[E]bool a set migrates
+// to, or false if ty is not one
+func setElementType(ty gosrc.Type) (gosrc.Type, bool) {
+	key, value, ok := strings.Cut(strings.TrimPrefix(string(ty), "map["), "]")
+	if !ok || !strings.HasPrefix(string(ty), "map[") || value != string(gosrc.TypeBool) {
+		return "", false
+	}
+	return gosrc.Type(key), true
+}
+
+// isSetExpression reports whether the static type of expression is the
+// map[E]bool a migrated java.util.Set is
+func isSetExpression(ctx *MigrationContext, expression *tree_sitter.Node) bool {
+	if expression == nil {
+		return false
+	}
+	ty, ok := staticType(ctx, expression)
+	if !ok {
+		return false
+	}
+	_, ok = setElementType(ty)
+	return ok
+}
+
+// convertEnumSetMethod converts the static factories of java.util.EnumSet to
+// the map[E]bool enum sets migrate to. It returns false for the factories
+// without a translation.
+func convertEnumSetMethod(ctx *MigrationContext, expression *tree_sitter.Node, name string) (gosrc.Expression, []gosrc.Statement, bool) {
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	switch {
+	case name == "noneOf" && len(argNodes) == 1:
+		enumType, ok := classLiteralType(ctx, argNodes[0])
+		if !ok {
+			return nil, nil, false
+		}
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("make(map[%s]bool)", enumType)}), nil, true
+	case name == "allOf" && len(argNodes) == 1:
+		enumType, ok := classLiteralType(ctx, argNodes[0])
+		if !ok {
+			return nil, nil, false
+		}
+		// Only the constants of simple enums are known in order
+		enumTypeName, ok := ctx.simpleEnum(expression, string(enumType))
+		if !ok {
+			return nil, nil, false
+		}
+		return setLiteral(ctx, gosrc.Type(enumTypeName), ctx.EnumOrdinals[enumTypeName]), nil, true
+	case name == "of" && len(argNodes) > 0:
+		enumType, ok := enumConstantType(ctx, argNodes[0])
+		if !ok {
+			return nil, nil, false
+		}
+		args, initStmts := convertArguments(ctx, expression.ChildByFieldName("arguments"))
+		elements := make([]string, len(args))
+		for i, arg := range args {
+			elements[i] = arg.ToSource()
+		}
+		return setLiteral(ctx, enumType, elements), initStmts, true
+	case name == "copyOf" && len(argNodes) == 1 && isSetExpression(ctx, argNodes[0]):
+		args, initStmts := convertArguments(ctx, expression.ChildByFieldName("arguments"))
+		ctx.Source.AddImport("maps")
+		return &gosrc.CallExpression{Function: "maps.Clone", Args: args}, initStmts, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// setLiteral returns the map[E]bool literal of a set of elementType holding
+// elements, the Go source of each
+func setLiteral(ctx *MigrationContext, elementType gosrc.Type, elements []string) gosrc.Expression {
+	entries := make([]string, len(elements))
+	for i, element := range elements {
+		entries[i] = element + ": true"
+	}
+	return ctx.arena.GoExpression(gosrc.GoExpression{
+		Source: fmt.Sprintf("map[%s]bool{%s}", elementType, strings.Join(entries, ", ")),
+	})
+}
+
+// classLiteralType returns the Go type the class literal node, such as
+// Kind.class, names
+func classLiteralType(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Type, bool) {
+	if nodeKind(node) != "class_literal" {
+		return "", false
+	}
+	return TryParseType(ctx, node.NamedChild(0))
+}
+
+// enumConstantType returns the Go type of the enum whose constant node
+// refers to, as Kind.A or a bare A does, or else the static type of node
+func enumConstantType(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Type, bool) {
+	var name string
+	switch nodeKind(node) {
+	case "identifier":
+		name = ctx.nodeText(node)
+	case "field_access":
+		name = ctx.nodeText(node.ChildByFieldName("field"))
+	}
+	if prefixed, ok := ctx.EnumConstants[name]; ok {
+		return gosrc.Type(strings.TrimSuffix(prefixed, "_"+name)), true
+	}
+	return staticType(ctx, node)
+}
+
+// convertSetRangeStatement converts a for-each loop over a set to a range
+// loop over the keys of its map. Sets of simple enums are iterated in the
+// order of their constants, as EnumSet is.
+func convertSetRangeStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) ([]gosrc.Statement, bool) {
+	valueNode := stmtNode.ChildByFieldName("value")
+	if !isSetExpression(ctx, valueNode) {
+		return nil, false
+	}
+	ty, _ := staticType(ctx, valueNode)
+	elementType, _ := setElementType(ty)
+	varName := ctx.nodeText(stmtNode.ChildByFieldName("name"))
+	receiver, initStmts := convertReceiver(ctx, valueNode)
+	body := convertStatementBlock(ctx, stmtNode.ChildByFieldName("body"))
+	enumTypeName, isEnum := ctx.simpleEnum(stmtNode, string(elementType))
+	if !isEnum {
+		return append(initStmts, &gosrc.RangeForStatement{
+			IndexVar:       varName,
+			CollectionExpr: ctx.arena.VarRef(gosrc.VarRef{Ref: receiver}),
+			Body:           body,
+		}), true
+	}
+	skip := &gosrc.IfStatement{
+		Condition: ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("!%s[%s]", receiver, varName)}),
+		Body:      []gosrc.Statement{&gosrc.GoStatement{Source: "continue"}},
+	}
+	return append(initStmts, &gosrc.RangeForStatement{
+		ValueVar:       varName,
+		CollectionExpr: &gosrc.CallExpression{Function: enumValuesFunction(enumTypeName)},
+		Body:           append([]gosrc.Statement{skip}, body...),
+	}), true
+}
+
+// elementsRange returns the clause of a range loop binding element to each
+// element of the list or set collection, converted to arg, or false if
+// collection is neither
+func elementsRange(ctx *MigrationContext, collection *tree_sitter.Node, arg gosrc.Expression, element string) (string, bool) {
+	ty, ok := valueType(ctx, collection)
+	switch {
+	case !ok:
+		return "", false
+	case strings.HasPrefix(string(ty), "[]"):
+		return fmt.Sprintf("_, %s := range %s", element, listSource(ctx, collection, arg)), true
+	case isSetType(ty):
+		return fmt.Sprintf("%s := range %s", element, arg.ToSource()), true
+	default:
+		return "", false
+	}
+}
+
+// isSetType reports whether ty is the map[E]bool a set migrates to
+func isSetType(ty gosrc.Type) bool {
+	_, ok := setElementType(ty)
+	return ok
+}
+
+// convertSetMethod converts the bulk operations of java.util.Set on
+// receiver, taking the elements of another list or set, to loops over them.
+// If used is false the result of the call is unused. It returns false for
+// other methods.
+func convertSetMethod(ctx *MigrationContext, expression *tree_sitter.Node, name, receiver string, used bool) (gosrc.Expression, []gosrc.Statement, bool) {
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	if len(argNodes) != 1 {
+		return nil, nil, false
+	}
+	switch name {
+	case "addAll", "removeAll", "retainAll", "containsAll":
+	default:
+		return nil, nil, false
+	}
+	setType, _ := staticType(ctx, expression.ChildByFieldName("object"))
+	elementType, _ := setElementType(setType)
+	args, initStmts := convertArguments(ctx, expression.ChildByFieldName("arguments"))
+	element := ctx.tempName("element")
+	elements, ok := elementsRange(ctx, argNodes[0], args[0], element)
+	if !ok {
+		return nil, nil, false
+	}
+	var loop string
+	switch name {
+	case "addAll":
+		loop = fmt.Sprintf("for %s {\n%s[%s] = true\n}", elements, receiver, element)
+	case "removeAll":
+		loop = fmt.Sprintf("for %s {\ndelete(%s, %s)\n}", elements, receiver, element)
+	case "retainAll":
+		// Keeps the elements the other collection contains
+		ctx.Source.AddImport("maps")
+		contains := fmt.Sprintf("%s[%s]", args[0].ToSource(), element)
+		if argType, _ := valueType(ctx, argNodes[0]); !isSetType(argType) {
+			ctx.Source.AddImport("slices")
+			contains = fmt.Sprintf("slices.Contains(%s, %s)", listSource(ctx, argNodes[0], args[0]), element)
+		}
+		loop = fmt.Sprintf("maps.DeleteFunc(%s, func(%s %s, _ bool) bool {\nreturn !%s\n})", receiver, element, elementType, contains)
+	case "containsAll":
+		contains := ctx.tempName("containsAll")
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: contains}), append(initStmts,
+			&gosrc.VarDeclaration{Name: contains, Value: &gosrc.BooleanLiteral{Value: true}},
+			&gosrc.GoStatement{Source: fmt.Sprintf("for %s {\nif !%s[%s] {\n%s = false\nbreak\n}\n}", elements, receiver, element, contains)},
+		), true
+	}
+	if !used {
+		return nil, append(initStmts, &gosrc.GoStatement{Source: loop}), true
+	}
+	// Whether the set changed is whether its size did
+	size := ctx.tempName("size")
+	return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("(len(%s) != %s)", receiver, size)}), append(initStmts,
+		&gosrc.VarDeclaration{Name: size, Value: &gosrc.VarRef{Ref: fmt.Sprintf("len(%s)", receiver)}},
+		&gosrc.GoStatement{Source: loop},
+	), true
+}
+
+// convertSetCreation converts new HashSet<>() to an empty map[E]bool, and
+// new HashSet<>(collection) to a map[E]bool holding the elements of the list
+// or set collection
+func convertSetCreation(ctx *MigrationContext, expression *tree_sitter.Node, elementType string) (gosrc.Expression, []gosrc.Statement) {
+	empty := fmt.Sprintf("make(map[%s]bool)", elementType)
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	if len(argNodes) != 1 {
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: empty}), nil
+	}
+	args, initStmts := convertArguments(ctx, expression.ChildByFieldName("arguments"))
+	if ty, ok := valueType(ctx, argNodes[0]); ok && ty == gosrc.TypeInt {
+		// The initial capacity
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("make(map[%s]bool, %s)", elementType, args[0].ToSource())}), initStmts
+	}
+	if ty, ok := valueType(ctx, argNodes[0]); ok && isSetType(ty) {
+		ctx.Source.AddImport("maps")
+		return &gosrc.CallExpression{Function: "maps.Clone", Args: args}, initStmts
+	}
+	set, element := ctx.tempName("set"), ctx.tempName("element")
+	elements, ok := elementsRange(ctx, argNodes[0], args[0], element)
+	if !ok {
+		FatalError(ctx, argNodes[0], "unable to tell the elements of the collection a set is created from", "object_creation_expression")
+	}
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: set}), append(initStmts,
+		&gosrc.VarDeclaration{Name: set, Value: &gosrc.VarRef{Ref: empty}},
+		&gosrc.GoStatement{Source: fmt.Sprintf("for %s {\n%s[%s] = true\n}", elements, set, element)},
+	)
+}
+
+// convertSetFactory converts Set.of(a, b) to a map[E]bool literal, taking
+// the element type from the declaration it initializes or else from the
+// first element. It returns false if neither is known.
+func convertSetFactory(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement, bool) {
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	var elementType gosrc.Type
+	if expected := expectedJavaType(ctx, expression); expected != nil {
+		if ty, ok := TryParseType(ctx, expected); ok {
+			elementType, _ = setElementType(ty)
+		}
+	}
+	if elementType == "" && len(argNodes) > 0 {
+		elementType, _ = valueType(ctx, argNodes[0])
+	}
+	if elementType == "" {
+		return nil, nil, false
+	}
+	args, initStmts := convertArguments(ctx, expression.ChildByFieldName("arguments"))
+	elements := make([]string, len(args))
+	for i, arg := range args {
+		elements[i] = arg.ToSource()
+	}
+	return setLiteral(ctx, elementType, elements), initStmts, true
+}
@@ -2,6 +2,7 @@ package java
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/heshanpadmasiri/javaGo/gosrc"
 
@@ -9,84 +10,90 @@ import (
 )
 
 func convertStatementBlock(ctx *MigrationContext, blockNode *tree_sitter.Node) []gosrc.Statement {
+	if nodeKind(blockNode) != "block" {
+		// Bodies of if statements and loops can be a single statement
+		return convertStatement(ctx, blockNode)
+	}
 	var body []gosrc.Statement
+	// A loop converted together with the statement before it
+	var converted *tree_sitter.Node
 	IterateChildren(blockNode, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		// ignored
 		case "{":
 		case "}":
 		case "line_comment":
 		case "block_comment":
 		default:
+			if converted != nil && child.StartByte() == converted.StartByte() {
+				return
+			}
+			if stmts, loop, ok := convertIteratorWhile(ctx, child); ok {
+				body, converted = append(body, stmts...), loop
+				return
+			}
 			body = append(body, convertStatement(ctx, child)...)
 		}
 	})
 	return body
 }
 
-func convertSwitchStatement(ctx *MigrationContext, switchNode *tree_sitter.Node) gosrc.SwitchStatement {
-	condition, conditionInit := convertExpression(ctx, switchNode.ChildByFieldName("condition"))
+// convertSwitchStatement converts a switch statement or expression. If it is
+// the value of a return statement (ctx.InReturn), every value point of the
+// switch (a yield or the expression of a rule) becomes a return and a default
+// case panics if the switch has none, so the switch is a terminating statement.
+// Otherwise, if target is set, every value point assigns the variable target.
+func convertSwitchStatement(ctx *MigrationContext, switchNode *tree_sitter.Node, target string) gosrc.SwitchStatement {
+	returnsValue := nodeKind(switchNode) == "switch_expression" && ctx.InReturn
+	producesValue := returnsValue || target != ""
+	// Expressions nested in the arms are not returned themselves
+	inReturn, yieldReturns, yieldTarget := ctx.InReturn, ctx.yieldReturns, ctx.yieldTarget
+	ctx.InReturn, ctx.yieldReturns, ctx.yieldTarget = false, returnsValue, target
+	ordinalLabels := ctx.ordinalLabels
+	defer func() {
+		ctx.InReturn, ctx.yieldReturns, ctx.yieldTarget = inReturn, yieldReturns, yieldTarget
+		ctx.ordinalLabels = ordinalLabels
+	}()
+	conditionNode := switchNode.ChildByFieldName("condition")
+	ctx.ordinalLabels = nil
+	if receiver, constants, ok := ordinalSwitch(ctx, switchNode); ok {
+		// Switch on the enum value, with the constants as labels
+		conditionNode, ctx.ordinalLabels = receiver, constants
+	}
+	condition, conditionInit := convertExpression(ctx, conditionNode)
 	Assert("condition expression is expected to be simple", len(conditionInit) == 0)
 	bodyNode := switchNode.ChildByFieldName("body")
 	var cases []gosrc.SwitchCase
 	var defaultBody []gosrc.Statement
+	var groups []*tree_sitter.Node
+	hasDefault := false
 	IterateChildren(bodyNode, func(switchBlockStatementGroup *tree_sitter.Node) {
-		switch switchBlockStatementGroup.Kind() {
+		switch nodeKind(switchBlockStatementGroup) {
 		case "switch_block_statement_group":
-			var caseBody []gosrc.Statement
-			var caseCondition gosrc.Expression
-			var isDefault bool
-			IterateChildren(switchBlockStatementGroup, func(child *tree_sitter.Node) {
-				switch child.Kind() {
-				case "switch_label":
-					if child.Utf8Text(ctx.JavaSource) == "default" {
-						isDefault = true
-					} else {
-						caseCondition, conditionInit = convertExpression(ctx, child.Child(1))
-						Assert("condition expression is expected to be simple", len(conditionInit) == 0)
-					}
-				// ignored
-				case ":":
-				case "{":
-				case "}":
-				case "->":
-				case "line_comment":
-				case "block_comment":
-				default:
-					var stmts []gosrc.Statement
-					if child.Kind() == "block" {
-						stmts = convertStatementBlock(ctx, child)
-					} else {
-						stmts = convertStatement(ctx, child)
-					}
-					if isDefault {
-						defaultBody = append(defaultBody, stmts...)
-					} else {
-						caseBody = append(caseBody, stmts...)
-					}
-				}
-			})
+			groups = append(groups, switchBlockStatementGroup)
+		case "switch_rule":
+			labels, isDefault := convertSwitchLabel(ctx, switchBlockStatementGroup.Child(0))
+			condition := "default"
 			if !isDefault {
-				cases = append(cases, gosrc.SwitchCase{
-					Condition: caseCondition,
-					Body:      caseBody,
-				})
+				condition = strings.Join(labels, ", ")
 			}
-		case "switch_rule":
-			caseConditionNode := switchBlockStatementGroup.Child(0)
-			caseCondition := gosrc.GoExpression{Source: caseConditionNode.Utf8Text(ctx.JavaSource)}
 			bodyNode := switchBlockStatementGroup.Child(2)
-			for bodyNode.Kind() == "line_comment" || bodyNode.Kind() == ":" || bodyNode.Kind() == "->" {
+			for nodeKind(bodyNode) == "line_comment" || nodeKind(bodyNode) == ":" || nodeKind(bodyNode) == "->" {
 				bodyNode = bodyNode.NextSibling()
 			}
 			var caseBody []gosrc.Statement
-			if bodyNode.Kind() == "block" {
+			switch {
+			case nodeKind(bodyNode) == "block":
 				caseBody = convertStatementBlock(ctx, bodyNode)
-			} else {
+			case nodeKind(bodyNode) == "expression_statement" && producesValue:
+				value, valueInit := convertExpression(ctx, bodyNode.NamedChild(0))
+				caseBody = append(valueInit, yieldStatement(ctx, bodyNode.NamedChild(0), value))
+			default:
 				caseBody = convertStatement(ctx, bodyNode)
 			}
+			hasDefault = hasDefault || isDefault
 			cases = append(cases, gosrc.SwitchCase{
-				Condition: &caseCondition,
+				Condition: ctx.arena.GoExpression(gosrc.GoExpression{Source: condition}),
 				Body:      caseBody,
 			})
 			// ignored
@@ -98,7 +105,16 @@ func convertSwitchStatement(ctx *MigrationContext, switchNode *tree_sitter.Node)
 			UnhandledChild(ctx, switchBlockStatementGroup, "switch_block_statement_group")
 		}
 	})
-	// TODO: if in return properly detect value points and add returns
+	if len(groups) > 0 {
+		var groupsHaveDefault bool
+		cases, groupsHaveDefault = convertSwitchGroups(ctx, groups)
+		hasDefault = hasDefault || groupsHaveDefault
+	}
+	if producesValue && !hasDefault {
+		// Java rejects switch expressions that are not exhaustive, but Go
+		// doesn't know the cases are
+		defaultBody = []gosrc.Statement{&gosrc.GoStatement{Source: `panic("unreachable: unhandled switch case")`}}
+	}
 	return gosrc.SwitchStatement{
 		Condition:   condition,
 		Cases:       cases,
@@ -106,29 +122,141 @@ func convertSwitchStatement(ctx *MigrationContext, switchNode *tree_sitter.Node)
 	}
 }
 
-func convertThrowStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) []gosrc.Statement {
-	valueNode := stmtNode.Child(1)
-	exception := valueNode.ChildByFieldName("type").Utf8Text(ctx.JavaSource)
-	arguments := valueNode.ChildByFieldName("arguments").Utf8Text(ctx.JavaSource)
-	switch exception {
-	case "IllegalArgumentException":
-		return []gosrc.Statement{
-			&gosrc.GoStatement{
-				Source: fmt.Sprintf("panic(%s)", arguments),
-			},
+// convertSwitchGroups converts the statement groups of a switch with colon
+// labels, keeping the default case in its place. Groups without statements
+// share those of the next group, a trailing break is dropped since Go cases
+// never fall through, and groups that can complete normally fall through to
+// the next one explicitly.
+func convertSwitchGroups(ctx *MigrationContext, groups []*tree_sitter.Node) ([]gosrc.SwitchCase, bool) {
+	var cases []gosrc.SwitchCase
+	var labels []string
+	isDefault, hasDefault := false, false
+	for i, group := range groups {
+		var statements []*tree_sitter.Node
+		IterateChildren(group, func(child *tree_sitter.Node) {
+			switch nodeKind(child) {
+			case "switch_label":
+				groupLabels, groupDefault := convertSwitchLabel(ctx, child)
+				labels = append(labels, groupLabels...)
+				isDefault = isDefault || groupDefault
+			// ignored
+			case ":", "line_comment", "block_comment":
+			default:
+				statements = append(statements, child)
+			}
+		})
+		last := i == len(groups)-1
+		if len(statements) == 0 && !last {
+			continue
 		}
-	default:
-		return []gosrc.Statement{
-			&gosrc.GoStatement{
-				Source: stmtNode.Utf8Text(ctx.JavaSource),
-			},
+		// The default case also matches the labels it shares statements with
+		condition := "default"
+		if !isDefault {
+			condition = strings.Join(labels, ", ")
+		}
+		hasDefault = hasDefault || isDefault
+		cases = append(cases, gosrc.SwitchCase{
+			Condition: ctx.arena.GoExpression(gosrc.GoExpression{Source: condition}),
+			Body:      convertSwitchGroupBody(ctx, statements, last),
+		})
+		labels, isDefault = nil, false
+	}
+	return cases, hasDefault
+}
+
+// convertSwitchLabel converts the values of a case label, such as the enum
+// constants of case A, B, or reports that it is the default label
+func convertSwitchLabel(ctx *MigrationContext, labelNode *tree_sitter.Node) ([]string, bool) {
+	if ctx.nodeText(labelNode) == "default" {
+		return nil, true
+	}
+	var labels []string
+	for i := uint(0); i < labelNode.NamedChildCount(); i++ {
+		if constant, ok := ordinalConstant(ctx, labelNode.NamedChild(i), ctx.ordinalLabels); ok {
+			labels = append(labels, constant)
+			continue
 		}
+		label, labelInit := convertExpression(ctx, labelNode.NamedChild(i))
+		Assert("condition expression is expected to be simple", len(labelInit) == 0)
+		labels = append(labels, label.ToSource())
 	}
+	return labels, false
+}
+
+// convertSwitchGroupBody converts the statements of a switch group, adding a
+// fallthrough unless it is the last group or can't complete normally
+func convertSwitchGroupBody(ctx *MigrationContext, statements []*tree_sitter.Node, last bool) []gosrc.Statement {
+	var body []gosrc.Statement
+	for i, statement := range statements {
+		switch {
+		case i == len(statements)-1 && nodeKind(statement) == "break_statement" && statement.NamedChildCount() == 0:
+			// Go cases end with an implicit break
+		case nodeKind(statement) == "block":
+			body = append(body, convertStatementBlock(ctx, statement)...)
+		default:
+			body = append(body, convertStatement(ctx, statement)...)
+		}
+	}
+	if !last && !completesAbruptly(statements[len(statements)-1]) {
+		body = append(body, &gosrc.GoStatement{Source: "fallthrough"})
+	}
+	return body
+}
+
+// completesAbruptly reports whether control never flows past statement,
+// which always breaks, continues, returns, throws or yields
+func completesAbruptly(statement *tree_sitter.Node) bool {
+	switch nodeKind(statement) {
+	case "break_statement", "continue_statement", "return_statement", "throw_statement", "yield_statement":
+		return true
+	case "block":
+		for i := int(statement.NamedChildCount()) - 1; i >= 0; i-- {
+			child := statement.NamedChild(uint(i))
+			if kind := nodeKind(child); kind != "line_comment" && kind != "block_comment" {
+				return completesAbruptly(child)
+			}
+		}
+		return false
+	case "if_statement":
+		alternative := statement.ChildByFieldName("alternative")
+		return alternative != nil && completesAbruptly(statement.ChildByFieldName("consequence")) && completesAbruptly(alternative)
+	default:
+		return false
+	}
+}
+
+// yieldStatement returns the statement producing value, converted from
+// valueNode, as the value of the enclosing switch expression
+func yieldStatement(ctx *MigrationContext, valueNode *tree_sitter.Node, value gosrc.Expression) gosrc.Statement {
+	if ctx.yieldReturns {
+		return returnStatement(ctx, valueNode, value)
+	}
+	return &gosrc.AssignStatement{Ref: gosrc.VarRef{Ref: ctx.yieldTarget}, Value: value}
+}
+
+func convertThrowStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) []gosrc.Statement {
+	valueNode := stmtNode.Child(1)
+	err, initStmts := convertExpression(ctx, valueNode)
+	return append(initStmts, throwStatement(ctx, stmtNode, thrownType(ctx, valueNode), err))
 }
 
 func convertEnhancedForStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) []gosrc.Statement {
-	varName := stmtNode.ChildByFieldName("name").Utf8Text(ctx.JavaSource)
-	valueExpr, stmts := convertExpression(ctx, stmtNode.ChildByFieldName("value"))
+	if stmts, ok := convertMapRangeStatement(ctx, stmtNode); ok {
+		return stmts
+	}
+	if stmts, ok := convertSetRangeStatement(ctx, stmtNode); ok {
+		return stmts
+	}
+	if stmts, ok := convertIterableRangeStatement(ctx, stmtNode); ok {
+		return stmts
+	}
+	varName := ctx.nodeText(stmtNode.ChildByFieldName("name"))
+	valueNode := stmtNode.ChildByFieldName("value")
+	valueExpr, stmts := convertExpression(ctx, valueNode)
+	// List and array parameters are pointers to the slices ranged over
+	if arrayArgumentKind(ctx, valueNode) == slicePointer {
+		valueExpr = ctx.arena.VarRef(gosrc.VarRef{Ref: listSource(ctx, valueNode, valueExpr)})
+	}
 	bodyStmts := convertStatementBlock(ctx, stmtNode.ChildByFieldName("body"))
 	return append(stmts, &gosrc.RangeForStatement{
 		ValueVar:       varName,
@@ -138,39 +266,69 @@ func convertEnhancedForStatement(ctx *MigrationContext, stmtNode *tree_sitter.No
 }
 
 func convertJavaForStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) []gosrc.Statement {
+	if stmts, ok := convertIndexRangeStatement(ctx, stmtNode); ok {
+		return stmts
+	}
+	if stmts, ok := convertIteratorForStatement(ctx, stmtNode); ok {
+		return stmts
+	}
 	initNode := stmtNode.ChildByFieldName("init")
 	var initStmts []gosrc.Statement
 	if initNode != nil {
 		initStmts = convertStatement(ctx, initNode)
 	}
 	conditionNode := stmtNode.ChildByFieldName("condition")
-	conditionExp, s := convertExpression(ctx, conditionNode)
-	initStmts = append(initStmts, s...)
+	conditionExp, conditionInit := convertExpression(ctx, conditionNode)
 	updateNode := stmtNode.ChildByFieldName("update")
-	var updateExp gosrc.Expression
-	if updateNode != nil {
-		var updateStmts []gosrc.Statement
-		updateExp, updateStmts = convertExpression(ctx, updateNode)
+	var update gosrc.Statement
+	switch {
+	case updateNode == nil:
+	case nodeKind(updateNode) == "assignment_expression":
+		// The assignment itself is the post statement, not its value
+		_, updateStmts := convertAssignmentExpression(ctx, updateNode)
+		update = updateStmts[len(updateStmts)-1]
+		initStmts = append(initStmts, updateStmts[:len(updateStmts)-1]...)
+	default:
+		updateExp, updateStmts := convertExpression(ctx, updateNode)
+		update = updateExp
 		initStmts = append(initStmts, updateStmts...)
 	}
 	bodyNode := stmtNode.ChildByFieldName("body")
 	bodyStmts := convertStatementBlock(ctx, bodyNode)
+	conditionExp, bodyStmts = guardLoopCondition(conditionExp, conditionInit, bodyStmts)
 	return append(initStmts, &gosrc.ForStatement{
 		Condition: conditionExp,
-		Post:      updateExp,
+		Post:      update,
 		Body:      bodyStmts,
 	})
 }
 
+// guardLoopCondition handles loop conditions that need init statements, such
+// as the read loop idiom while ((line = read()) != null). The init statements
+// have to run before every check of the condition, so they move to the top of
+// the body followed by a break if the condition fails, and the loop itself
+// gets no condition.
+func guardLoopCondition(condition gosrc.Expression, conditionInit, body []gosrc.Statement) (gosrc.Expression, []gosrc.Statement) {
+	if len(conditionInit) == 0 {
+		return condition, body
+	}
+	guard := append(conditionInit, &gosrc.IfStatement{
+		Condition: &gosrc.UnaryExpression{Operator: "!", Operand: condition},
+		Body:      []gosrc.Statement{&gosrc.GoStatement{Source: "break"}},
+	})
+	return nil, append(guard, body...)
+}
+
 func convertWhileStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) []gosrc.Statement {
 	conditionNode := stmtNode.ChildByFieldName("condition")
-	conditionExp, initStmts := convertExpression(ctx, conditionNode)
+	conditionExp, conditionInit := convertExpression(ctx, conditionNode)
 	bodyNode := stmtNode.ChildByFieldName("body")
 	bodyStmts := convertStatementBlock(ctx, bodyNode)
-	return append(initStmts, &gosrc.ForStatement{
+	conditionExp, bodyStmts = guardLoopCondition(conditionExp, conditionInit, bodyStmts)
+	return []gosrc.Statement{&gosrc.ForStatement{
 		Condition: conditionExp,
 		Body:      bodyStmts,
-	})
+	}}
 }
 
 func convertLocalVariableDeclaration(ctx *MigrationContext, stmtNode *tree_sitter.Node) []gosrc.Statement {
@@ -180,7 +338,7 @@ func convertLocalVariableDeclaration(ctx *MigrationContext, stmtNode *tree_sitte
 		FatalError(ctx, typeNode, "unable to parse type in local_variable_declaration", "local_variable_declaration")
 	}
 	declNode := stmtNode.ChildByFieldName("declarator")
-	name := declNode.ChildByFieldName("name").Utf8Text(ctx.JavaSource)
+	name := ctx.nodeText(declNode.ChildByFieldName("name"))
 	valueNode := declNode.ChildByFieldName("value")
 	if valueNode == nil {
 		return []gosrc.Statement{
@@ -190,66 +348,146 @@ func convertLocalVariableDeclaration(ctx *MigrationContext, stmtNode *tree_sitte
 			},
 		}
 	}
+	if isConstantLocal(ctx, stmtNode, valueNode) {
+		value, _ := convertExpression(ctx, valueNode)
+		return []gosrc.Statement{&gosrc.ModuleConst{Name: name, Value: coerceDeclaredNumeric(ctx, valueNode, value, ty)}}
+	}
+	if nodeKind(valueNode) == "switch_expression" {
+		// The arms of the switch assign the variable itself
+		switchStatement := convertSwitchStatement(ctx, valueNode, name)
+		return []gosrc.Statement{&gosrc.VarDeclaration{Name: name, Ty: ty}, &switchStatement}
+	}
+	if innerNode := unwrapParentheses(valueNode); nodeKind(innerNode) == "ternary_expression" && !isNullCoalescing(ctx, innerNode) {
+		// The branches of the ternary assign the variable itself
+		return append([]gosrc.Statement{&gosrc.VarDeclaration{Name: name, Ty: ty}}, convertTernaryAssignment(ctx, innerNode, name)...)
+	}
+	if nodeKind(valueNode) == "array_initializer" {
+		// Shorthand array initializers take their type from the declaration
+		return []gosrc.Statement{&gosrc.VarDeclaration{
+			Name:  name,
+			Ty:    ty,
+			Value: convertArrayInitializer(ctx, valueNode, ty),
+		}}
+	}
+	if stmts, ok := convertMapLookupDeclaration(ctx, declNode); ok {
+		return stmts
+	}
 	valueExpr, initStmts := convertExpression(ctx, valueNode)
 	return append(initStmts, &gosrc.VarDeclaration{
 		Name:  name,
 		Ty:    ty,
-		Value: valueExpr,
+		Value: coerceDeclaredNumeric(ctx, valueNode, valueExpr, ty),
 	})
 }
 
 func convertReturnStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) []gosrc.Statement {
-	var initialStmts []gosrc.Statement
-	var value gosrc.Expression
-	ctx.InReturn = true
+	var valueNode *tree_sitter.Node
 	IterateChildren(stmtNode, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case ";":
 		case "return":
 		default:
-			value, initialStmts = convertExpression(ctx, child)
+			valueNode = child
 		}
 	})
-	ctx.InReturn = true
+	if valueNode == nil {
+		if _, throws := throwingMethod(ctx, stmtNode); throws {
+			return []gosrc.Statement{&gosrc.ReturnStatement{Value: &gosrc.VarRef{Ref: "nil"}}}
+		}
+		return []gosrc.Statement{&gosrc.ReturnStatement{}}
+	}
+	return convertReturnValue(ctx, valueNode)
+}
+
+// convertReturnValue converts the statements returning the value of
+// valueNode. A ternary becomes an if returning the consequence followed by
+// a return of the alternative, so return cond ? a : b needs no temporary.
+func convertReturnValue(ctx *MigrationContext, valueNode *tree_sitter.Node) []gosrc.Statement {
+	innerNode := valueNode
+	for nodeKind(innerNode) == "parenthesized_expression" {
+		innerNode = innerNode.NamedChild(0)
+	}
+	if nodeKind(innerNode) == "ternary_expression" {
+		condition, initialStmts := convertExpression(ctx, innerNode.ChildByFieldName("condition"))
+		initialStmts = append(initialStmts, &gosrc.IfStatement{
+			Condition: condition,
+			Body:      convertReturnValue(ctx, innerNode.ChildByFieldName("consequence")),
+		})
+		return append(initialStmts, convertReturnValue(ctx, innerNode.ChildByFieldName("alternative"))...)
+	}
+	// Only a switch that is the returned value itself has arms that return
+	ctx.InReturn = nodeKind(innerNode) == "switch_expression"
+	value, initialStmts := convertExpression(ctx, valueNode)
+	ctx.InReturn = false
 	// Check if value is a gosrc.SwitchStatement
 	if switchStmt, ok := value.(*gosrc.SwitchStatement); ok {
 		// If value is a gosrc.SwitchStatement, flatten to its switch form
 		// Not conventional return, treat as statement
 		return append(initialStmts, switchStmt)
 	}
-	return append(initialStmts, &gosrc.ReturnStatement{Value: value})
+	return append(initialStmts, returnStatement(ctx, valueNode, value))
+}
+
+// returnStatement returns the statement returning value, converted from
+// valueNode, from the enclosing method
+func returnStatement(ctx *MigrationContext, valueNode *tree_sitter.Node, value gosrc.Expression) gosrc.Statement {
+	if returnTy, ok := enclosingReturnType(ctx, valueNode); ok {
+		value = coerceNumeric(ctx, valueNode, value, returnTy)
+	}
+	if _, throws := throwingMethod(ctx, valueNode); throws {
+		// The error returned alongside the value
+		value = ctx.arena.GoExpression(gosrc.GoExpression{Source: value.ToSource() + ", nil"})
+	}
+	return &gosrc.ReturnStatement{Value: value}
 }
 
 func convertExpressionStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) []gosrc.Statement {
 	var body []gosrc.Statement
 	IterateChildren(stmtNode, func(child *tree_sitter.Node) {
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "assignment_expression":
 			_, stmts := convertAssignmentExpression(ctx, child)
 			body = append(body, stmts...)
 		case "method_invocation":
+			if stmts, ok := convertMapStatement(ctx, child); ok {
+				body = append(body, stmts...)
+				return
+			}
+			if stmts, ok := convertListStatement(ctx, child); ok {
+				body = append(body, stmts...)
+				return
+			}
+			if isStringBuilder(ctx, child) {
+				// The builder returned for chaining is unused
+				_, stmts := convertMethodInvocation(ctx, child)
+				body = append(body, stmts...)
+				return
+			}
 			expr, stmts := convertMethodInvocation(ctx, child)
 			body = append(body, stmts...)
-			body = append(body, &gosrc.CallStatement{Exp: expr})
+			if expr != nil {
+				// Calls of methods that throw are converted to statements only
+				body = append(body, &gosrc.CallStatement{Exp: expr})
+			}
 		// ignored
 		case ";":
 		default:
 			expr, initStmts := convertExpression(ctx, child)
 			body = append(body, initStmts...)
-			body = append(body, &gosrc.GoStatement{Source: expr.ToSource() + ";"})
+			body = append(body, &gosrc.GoStatement{Source: expr.ToSource()})
 		}
 	})
 	return body
 }
 
 func convertStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) []gosrc.Statement {
-	switch stmtNode.Kind() {
+	switch nodeKind(stmtNode) {
 	case "line_comment":
 		return nil
 	case "block_comment":
 		return nil
 	case "switch_expression":
-		switchStatement := convertSwitchStatement(ctx, stmtNode)
+		switchStatement := convertSwitchStatement(ctx, stmtNode, "")
 		return []gosrc.Statement{&switchStatement}
 	case "assert_statement":
 		conditionNode := stmtNode.Child(1)
@@ -264,12 +502,12 @@ func convertStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) []gosrc
 	case "return_statement":
 		return convertReturnStatement(ctx, stmtNode)
 	case "if_statement":
-		ifStatement := convertIfStatement(ctx, stmtNode, false)
-		return []gosrc.Statement{&ifStatement}
+		ifStatement, initStmts := convertIfStatement(ctx, stmtNode, false)
+		return append(initStmts, &ifStatement)
 	case "break_statement":
-		return []gosrc.Statement{&gosrc.GoStatement{Source: "break;"}}
+		return []gosrc.Statement{&gosrc.GoStatement{Source: "break"}}
 	case "continue_statement":
-		return []gosrc.Statement{&gosrc.GoStatement{Source: "continue;"}}
+		return []gosrc.Statement{&gosrc.GoStatement{Source: "continue"}}
 	case "local_variable_declaration":
 		return convertLocalVariableDeclaration(ctx, stmtNode)
 	case "while_statement":
@@ -284,14 +522,20 @@ func convertStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) []gosrc
 		return nil
 	case "yield_statement":
 		expr, init := convertExpression(ctx, stmtNode.Child(1))
-		init = append(init, &gosrc.GoStatement{Source: expr.ToSource() + ";"})
+		if ctx.yieldReturns || ctx.yieldTarget != "" {
+			return append(init, yieldStatement(ctx, stmtNode.Child(1), expr))
+		}
+		init = append(init, &gosrc.GoStatement{Source: expr.ToSource()})
 		return init
 	case "try_statement":
 		tryStatement := convertTryStatement(ctx, stmtNode)
 		return []gosrc.Statement{&tryStatement}
+	case "try_with_resources_statement":
+		tryStatement := convertTryWithResourcesStatement(ctx, stmtNode)
+		return []gosrc.Statement{&tryStatement}
 	default:
 		expr, init := convertExpression(ctx, stmtNode)
-		init = append(init, &gosrc.GoStatement{Source: expr.ToSource() + ";"})
+		init = append(init, &gosrc.GoStatement{Source: expr.ToSource()})
 		return init
 	}
 }
@@ -313,53 +557,21 @@ func convertTryStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) gosr
 		finallyBodyNode := finallyNode.ChildByFieldName("body")
 		if finallyBodyNode != nil {
 			finallyBody = convertStatementBlock(ctx, finallyBodyNode)
-		} else if finallyNode.Kind() == "block" {
+		} else if nodeKind(finallyNode) == "block" {
 			finallyBody = convertStatementBlock(ctx, finallyNode)
 		}
 	}
 
 	// Iterate through children to find catch clauses and finally
+	caughtErrors := false
 	IterateChildren(stmtNode, func(child *tree_sitter.Node) {
-		if child.Kind() == "catch_clause" {
-			var exceptionType string
-			var exceptionVar string
-			var catchBody []gosrc.Statement
-
-			// Find catch_formal_parameter
-			IterateChildren(child, func(catchChild *tree_sitter.Node) {
-				if catchChild.Kind() == "catch_formal_parameter" {
-					// Find catch_type
-					IterateChildren(catchChild, func(paramChild *tree_sitter.Node) {
-						if paramChild.Kind() == "catch_type" {
-							// Get the type identifier from catch_type
-							IterateChildren(paramChild, func(typeChild *tree_sitter.Node) {
-								if typeChild.Kind() == "type_identifier" || typeChild.Kind() == "scoped_type_identifier" {
-									exceptionType = typeChild.Utf8Text(ctx.JavaSource)
-								}
-							})
-						}
-					})
-					// Get name field
-					nameNode := catchChild.ChildByFieldName("name")
-					if nameNode != nil {
-						exceptionVar = nameNode.Utf8Text(ctx.JavaSource)
-					}
-				}
-			})
-			// Get catch body
-			catchBodyNode := child.ChildByFieldName("body")
-			if catchBodyNode != nil {
-				catchBody = convertStatementBlock(ctx, catchBodyNode)
-			}
-
-			if exceptionType != "" {
-				catchClauses = append(catchClauses, gosrc.CatchClause{
-					ExceptionType: exceptionType,
-					ExceptionVar:  exceptionVar,
-					Body:          catchBody,
-				})
+		if nodeKind(child) == "catch_clause" {
+			clauses := convertCatchClause(ctx, child, caughtErrors)
+			for _, clause := range clauses {
+				caughtErrors = caughtErrors || clause.ExceptionType == "error"
 			}
-		} else if child.Kind() == "finally_clause" {
+			catchClauses = append(catchClauses, clauses...)
+		} else if nodeKind(child) == "finally_clause" {
 			// Get finally body
 			finallyBodyNode := child.ChildByFieldName("body")
 			if finallyBodyNode != nil {
@@ -367,7 +579,7 @@ func convertTryStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) gosr
 			} else {
 				// Look for block as direct child
 				IterateChildren(child, func(fc *tree_sitter.Node) {
-					if fc.Kind() == "block" {
+					if nodeKind(fc) == "block" {
 						finallyBody = convertStatementBlock(ctx, fc)
 					}
 				})
@@ -382,22 +594,149 @@ func convertTryStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) gosr
 	}
 }
 
-func convertIfStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node, inner bool) gosrc.IfStatement {
+// convertCatchClause converts a catch clause to one clause per caught type.
+// Standard exceptions are all error values, so they are caught as error;
+// once caughtErrors, clauses catching errors can't be reached any more.
+func convertCatchClause(ctx *MigrationContext, clauseNode *tree_sitter.Node, caughtErrors bool) []gosrc.CatchClause {
+	var typeNodes []*tree_sitter.Node
+	var exceptionVar string
+	IterateChildren(clauseNode, func(child *tree_sitter.Node) {
+		if nodeKind(child) != "catch_formal_parameter" {
+			return
+		}
+		IterateChildren(child, func(paramChild *tree_sitter.Node) {
+			if nodeKind(paramChild) == "catch_type" {
+				// Multi-catch parameters list several types
+				IterateChildren(paramChild, func(typeChild *tree_sitter.Node) {
+					if nodeKind(typeChild) == "type_identifier" || nodeKind(typeChild) == "scoped_type_identifier" {
+						typeNodes = append(typeNodes, typeChild)
+					}
+				})
+			}
+		})
+		if nameNode := child.ChildByFieldName("name"); nameNode != nil {
+			exceptionVar = ctx.nodeText(nameNode)
+		}
+	})
+	bodyNode := clauseNode.ChildByFieldName("body")
+	var body []gosrc.Statement
+	if bodyNode != nil {
+		body = convertStatementBlock(ctx, bodyNode)
+		if !referencesName(ctx, bodyNode, exceptionVar) {
+			// Go rejects unused variables
+			exceptionVar = ""
+		}
+	}
+
+	var clauses []gosrc.CatchClause
+	seen := make(map[string]bool)
+	for _, typeNode := range typeNodes {
+		ty, ok := TryParseType(ctx, typeNode)
+		if !ok {
+			ty = gosrc.Type(ctx.nodeText(typeNode))
+		}
+		if seen[string(ty)] {
+			continue
+		}
+		seen[string(ty)] = true
+		clauseBody := body
+		if ty == "error" && caughtErrors {
+			clauseBody = append([]gosrc.Statement{&gosrc.CommentStmt{Comments: []string{fmt.Sprintf(
+				"FIXME: %s is an error like the exceptions caught before, so this clause is never reached", ctx.nodeText(typeNode))}}}, body...)
+		}
+		clauses = append(clauses, gosrc.CatchClause{
+			ExceptionType: string(ty),
+			ExceptionVar:  exceptionVar,
+			Body:          clauseBody,
+		})
+	}
+	return clauses
+}
+
+// referencesName reports whether name is referred to anywhere in node
+func referencesName(ctx *MigrationContext, node *tree_sitter.Node, name string) bool {
+	if name == "" {
+		return false
+	}
+	found := false
+	var visit func(child *tree_sitter.Node)
+	visit = func(child *tree_sitter.Node) {
+		if found {
+			return
+		}
+		if nodeKind(child) == "identifier" && ctx.nodeText(child) == name && isNameReference(child) {
+			found = true
+			return
+		}
+		IterateChildren(child, visit)
+	}
+	visit(node)
+	return found
+}
+
+// convertTryWithResourcesStatement converts a try-with-resources statement
+// to a try statement whose body first declares the resources and defers
+// closing them. Deferred calls run in reverse order when the body returns or
+// panics, so the resources are closed the way Java does, before the catch
+// clauses run.
+func convertTryWithResourcesStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node) gosrc.TryStatement {
+	var resources []gosrc.Statement
+	IterateChildren(stmtNode.ChildByFieldName("resources"), func(child *tree_sitter.Node) {
+		if nodeKind(child) == "resource" {
+			resources = append(resources, convertResource(ctx, child)...)
+		}
+	})
+	tryStatement := convertTryStatement(ctx, stmtNode)
+	tryStatement.TryBody = append(resources, tryStatement.TryBody...)
+	return tryStatement
+}
+
+// convertResource converts a resource of a try-with-resources statement to
+// its declaration, if it declares a variable, and the deferred call closing it
+func convertResource(ctx *MigrationContext, resourceNode *tree_sitter.Node) []gosrc.Statement {
+	nameNode := resourceNode.ChildByFieldName("name")
+	if nameNode == nil {
+		// try (existing) closes a variable or field declared before
+		resource, initStmts := convertExpression(ctx, resourceNode.NamedChild(0))
+		return append(initStmts, closeResource(resource.ToSource()))
+	}
+	name := ctx.nodeText(nameNode)
+	ty, _ := TryParseType(ctx, resourceNode.ChildByFieldName("type"))
+	value, initStmts := convertExpression(ctx, resourceNode.ChildByFieldName("value"))
+	initStmts = append(initStmts, &gosrc.VarDeclaration{Name: name, Ty: ty, Value: value})
+	return append(initStmts, closeResource(name))
+}
+
+// closeResource returns the statement deferring the call closing resource
+func closeResource(resource string) gosrc.Statement {
+	return &gosrc.GoStatement{Source: "defer " + resource + ".Close()"}
+}
+
+// convertIfStatement converts an if statement. The statements the condition
+// of the outermost if needs are returned to be placed before it, while those
+// of an else if can't be hoisted. An instanceof test the condition starts with
+// is asserted in the init statement of the if, scoping its pattern variable
+// to the if.
+func convertIfStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node, inner bool) (gosrc.IfStatement, []gosrc.Statement) {
 	conditionNode := stmtNode.ChildByFieldName("condition")
-	conditionExp, stmts := convertExpression(ctx, conditionNode)
-	Assert("condition expression is expected to be simple", len(stmts) == 0)
+	init, stmts := instanceofInit(ctx, stmtNode, inner)
+	conditionExp, conditionStmts := convertExpression(ctx, conditionNode)
+	stmts = append(stmts, conditionStmts...)
+	Assert("condition expression is expected to be simple", !inner || len(stmts) == 0)
 	bodyNode := stmtNode.ChildByFieldName("consequence")
 	bodyStmts := convertStatementBlock(ctx, bodyNode)
 	ifStatement := &gosrc.IfStatement{
+		Init:      init,
 		Condition: conditionExp,
 		Body:      bodyStmts,
 	}
 	cursor := stmtNode.Walk()
 	elseIf := stmtNode.ChildrenByFieldName("alternative", cursor)
 	for _, elseIfNode := range elseIf {
-		switch elseIfNode.Kind() {
+		switch nodeKind(&elseIfNode) {
 		case "if_statement":
-			ifStatement.ElseIf = append(ifStatement.ElseIf, convertIfStatement(ctx, &elseIfNode, true))
+			elseIf, _ := convertIfStatement(ctx, &elseIfNode, true)
+			ifStatement.ElseIf = append(ifStatement.ElseIf, elseIf)
 		case "block":
 			elseBodyStmts := convertStatementBlock(ctx, &elseIfNode)
 			ifStatement.ElseStmts = append(ifStatement.ElseStmts, elseBodyStmts...)
@@ -405,7 +744,7 @@ func convertIfStatement(ctx *MigrationContext, stmtNode *tree_sitter.Node, inner
 			UnhandledChild(ctx, &elseIfNode, "else_if_statement")
 		}
 	}
-	return *ifStatement
+	return *ifStatement, stmts
 }
 
 // Check for finally using field name
@@ -413,7 +752,7 @@ func convertExplicitConstructorInvocation(ctx *MigrationContext, invocationNode
 	parentCall := "this"
 	var argExp []gosrc.Expression
 	IterateChildren(invocationNode, func(args *tree_sitter.Node) {
-		switch args.Kind() {
+		switch nodeKind(args) {
 		case "this":
 			parentCall = "this"
 		case "super":
@@ -0,0 +1,88 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// staticCallFunction returns the function a call of the method name on
+// objectText is migrated to if it calls a static method, which are package
+// functions. Calls of classes imported from packages the package mappings
+// map, or that the type mappings map to a type of another Go package, call
+// the exported function of that package, and so do unqualified calls of
+// static methods imported from mapped packages.
+func staticCallFunction(ctx *MigrationContext, expression *tree_sitter.Node, name, convertedName, objectText string, found bool) (string, bool) {
+	switch objectText {
+	case "":
+		if importPath, ok := ctx.importedMethods[name]; ok {
+			return ctx.importedFunction(importPath, name), true
+		}
+		return convertedName, found && isStaticFunction(ctx, name, convertedName)
+	case "this":
+		return convertedName, found && isStaticFunction(ctx, name, convertedName)
+	}
+	if !isTypeQualifier(ctx, expression.ChildByFieldName("object")) {
+		return "", false
+	}
+	if importPath, ok := ctx.importedTypes[objectText]; ok {
+		return ctx.importedFunction(importPath, convertedName), true
+	}
+	if mapped, ok := ctx.TypeMappings[objectText]; ok {
+		pkg, ok := mappedPackage(mapped)
+		return pkg + "." + gosrc.CapitalizeFirstLetter(convertedName), ok
+	}
+	return convertedName, found && isStaticFunction(ctx, name, convertedName)
+}
+
+// isStaticFunction reports whether convertedName, the function a call of the
+// method name resolved to, is migrated from a static method
+func isStaticFunction(ctx *MigrationContext, name, convertedName string) bool {
+	for _, method := range trackedMethods(ctx, name) {
+		if method.Name == convertedName {
+			return method.Static
+		}
+	}
+	return false
+}
+
+// allStatic reports whether every method called name is migrated from a
+// static method, so calls of it are calls of package functions even when
+// the overload called can't be told
+func allStatic(ctx *MigrationContext, name string) bool {
+	methods := trackedMethods(ctx, name)
+	for _, method := range methods {
+		if !method.Static {
+			return false
+		}
+	}
+	return len(methods) > 0
+}
+
+// isTypeQualifier reports whether the object of a method call names a type
+// rather than a value: a simple name no variable in scope is declared with,
+// or a qualified name of a nested type
+func isTypeQualifier(ctx *MigrationContext, object *tree_sitter.Node) bool {
+	if object == nil {
+		return false
+	}
+	switch nodeKind(object) {
+	case "identifier":
+		return declaredType(ctx, object, ctx.nodeText(object)) == nil
+	case "field_access", "scoped_identifier":
+		_, ok := ctx.resolveTypeReference(object, ctx.nodeText(object))
+		return ok
+	default:
+		return false
+	}
+}
+
+// mappedPackage returns the package of the Go type a type mapping maps to,
+// such as geometry for *geometry.Point[int], if it is in another package
+func mappedPackage(mapped string) (string, bool) {
+	name, _, _ := strings.Cut(strings.TrimPrefix(mapped, "*"), "[")
+	pkg, _, ok := strings.Cut(name, ".")
+	return pkg, ok
+}
@@ -0,0 +1,222 @@
+package java
+
+import (
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// staticInit migrates the static fields and static initializers of a class.
+// Java runs them in source order when the class is initialized. Go
+// initializes package variables in dependency order, whatever file declares
+// them, before any init function runs. Static fields therefore stay variables
+// initialized in their declaration until a static initializer or a field
+// whose initializer refers back to itself, which Go rejects as an
+// initialization cycle, is reached. From then on they are declared without a
+// value and assigned in an init function, in source order, along with the
+// static initializers. Compile-time constants are the exception: they become
+// Go constants, grouped in a single const declaration per class, which don't
+// depend on any initialization order.
+type staticInit struct {
+	cyclic    map[string]bool // Java names of the static fields on reference cycles
+	constants map[string]bool // Java names of the static fields that are constants
+	deferred  bool            // Whether later static fields are assigned in init
+	consts    gosrc.ConstGroup
+	body      []gosrc.Statement
+	comments  []string
+}
+
+// newStaticInit prepares the migration of the static members of classBody
+func newStaticInit(ctx *MigrationContext, classBody *tree_sitter.Node) *staticInit {
+	return &staticInit{
+		cyclic:    cyclicStaticFields(ctx, classBody),
+		constants: constantFields(ctx, classBody),
+	}
+}
+
+// addField adds the module variable, or constant, for the static field
+// declared by fieldNode, migrated to field and initExpr, or initStmts for
+// initializers that need statements
+func (statics *staticInit) addField(ctx *MigrationContext, fieldNode *tree_sitter.Node, field gosrc.StructField, initExpr gosrc.Expression, initStmts []gosrc.Statement) {
+	if initStmts != nil {
+		// Later fields may depend on the value assigned in init
+		statics.deferred = true
+		ctx.Source.Vars = append(ctx.Source.Vars, gosrc.ModuleVar{
+			Name:     field.Name,
+			Ty:       field.Ty,
+			Comments: append(field.Comments, "Assigned in init since its initializer needs statements"),
+		})
+		statics.body = append(statics.body, initStmts...)
+		return
+	}
+	if initExpr != nil && statics.constants[staticFieldName(ctx, fieldNode)] {
+		statics.consts.Constants = append(statics.consts.Constants, gosrc.ModuleConst{
+			Name:     field.Name,
+			Value:    initExpr,
+			Comments: field.Comments,
+		})
+		return
+	}
+	statics.deferred = statics.deferred || (initExpr != nil && statics.cyclic[staticFieldName(ctx, fieldNode)])
+	if !statics.deferred || initExpr == nil {
+		ctx.Source.Vars = append(ctx.Source.Vars, gosrc.ModuleVar{
+			Name:     field.Name,
+			Ty:       field.Ty,
+			Value:    initExpr,
+			Comments: field.Comments,
+		})
+		return
+	}
+	ctx.Source.Vars = append(ctx.Source.Vars, gosrc.ModuleVar{
+		Name:     field.Name,
+		Ty:       field.Ty,
+		Comments: append(field.Comments, "Assigned in init to keep the initialization order of Java"),
+	})
+	statics.body = append(statics.body, &gosrc.AssignStatement{
+		Ref:   gosrc.VarRef{Ref: field.Name},
+		Value: initExpr,
+	})
+}
+
+// addInitializer adds the body of the static initializer initializerNode to
+// the init function
+func (statics *staticInit) addInitializer(ctx *MigrationContext, initializerNode *tree_sitter.Node) {
+	statics.deferred = true
+	statics.comments = append(statics.comments, getMigrationComment(ctx, initializerNode))
+	IterateChildren(initializerNode, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "block" {
+			statics.body = append(statics.body, convertStatementBlock(ctx, child)...)
+		}
+	})
+}
+
+// finish adds the constants of the class and the init function, if the class
+// needs them
+func (statics *staticInit) finish(ctx *MigrationContext) {
+	if len(statics.consts.Constants) > 0 {
+		ctx.Source.ConstGroups = append(ctx.Source.ConstGroups, statics.consts)
+	}
+	if len(statics.body) == 0 {
+		return
+	}
+	ctx.Source.Functions = append(ctx.Source.Functions, gosrc.Function{
+		Name:     "init",
+		Body:     statics.body,
+		Comments: statics.comments,
+	})
+}
+
+// staticFieldName returns the Java name of the field declared by fieldNode
+func staticFieldName(ctx *MigrationContext, fieldNode *tree_sitter.Node) string {
+	return ctx.nodeText(fieldNode.ChildByFieldName("declarator").ChildByFieldName("name"))
+}
+
+// cyclicStaticFields returns the Java names of the static fields of classBody
+// whose initializers refer back to themselves through other static fields of
+// the class. Java allows this with qualified names, reading the default value
+// of fields not initialized yet.
+func cyclicStaticFields(ctx *MigrationContext, classBody *tree_sitter.Node) map[string]bool {
+	var className string
+	if nameNode := classBody.Parent().ChildByFieldName("name"); nameNode != nil {
+		className = ctx.nodeText(nameNode)
+	}
+	initializers := make(map[string]*tree_sitter.Node)
+	IterateChildren(classBody, func(member *tree_sitter.Node) {
+		if nodeKind(member) != "field_declaration" || declarationModifiers(ctx, member)&STATIC == 0 {
+			return
+		}
+		if value := member.ChildByFieldName("declarator").ChildByFieldName("value"); value != nil {
+			initializers[staticFieldName(ctx, member)] = value
+		}
+	})
+	references := make(map[string][]string, len(initializers))
+	for name, value := range initializers {
+		references[name] = staticFieldReferences(ctx, value, className, initializers)
+	}
+
+	cyclic := make(map[string]bool)
+	for name := range initializers {
+		if reaches(references, name, name, make(map[string]bool)) {
+			cyclic[name] = true
+		}
+	}
+	return cyclic
+}
+
+// staticFieldReferences returns the names of the fields of initializers that
+// value refers to, on their own or qualified by className
+func staticFieldReferences(ctx *MigrationContext, value *tree_sitter.Node, className string, initializers map[string]*tree_sitter.Node) []string {
+	var names []string
+	var visit func(node *tree_sitter.Node)
+	visit = func(node *tree_sitter.Node) {
+		switch nodeKind(node) {
+		case "identifier":
+			if _, ok := initializers[ctx.nodeText(node)]; ok && isNameReference(node) {
+				names = append(names, ctx.nodeText(node))
+			}
+		case "field_access":
+			object := node.ChildByFieldName("object")
+			field := ctx.nodeText(node.ChildByFieldName("field"))
+			if _, ok := initializers[field]; ok && ctx.nodeText(object) == className {
+				names = append(names, field)
+			}
+		}
+		IterateChildren(node, visit)
+	}
+	visit(value)
+	return names
+}
+
+// reaches reports whether target can be reached from the fields from refers
+// to
+func reaches(references map[string][]string, from, target string, visited map[string]bool) bool {
+	for _, next := range references[from] {
+		if next == target {
+			return true
+		}
+		if !visited[next] {
+			visited[next] = true
+			if reaches(references, next, target, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isStaticField reports whether className, a class declared in the file node
+// is in, has a static field called name. Static fields are module variables
+// named after the field, so Units.LIMIT is LIMIT.
+func (ctx *MigrationContext) isStaticField(node *tree_sitter.Node, className, name string) bool {
+	if ctx.staticFields == nil {
+		root := node
+		for root.Parent() != nil {
+			root = root.Parent()
+		}
+		ctx.staticFields = make(map[string]map[string]bool)
+		collectStaticFields(ctx, root)
+	}
+	return ctx.staticFields[className][name]
+}
+
+// collectStaticFields records the static fields of the classes declared in
+// node in ctx.staticFields
+func collectStaticFields(ctx *MigrationContext, node *tree_sitter.Node) {
+	IterateChildren(node, func(child *tree_sitter.Node) {
+		switch nodeKind(child) {
+		case "class_declaration":
+			className := ctx.nodeText(child.ChildByFieldName("name"))
+			fields := make(map[string]bool)
+			IterateChildren(child.ChildByFieldName("body"), func(member *tree_sitter.Node) {
+				if nodeKind(member) == "field_declaration" && declarationModifiers(ctx, member)&STATIC != 0 {
+					fields[staticFieldName(ctx, member)] = true
+				}
+			})
+			ctx.staticFields[className] = fields
+			collectStaticFields(ctx, child.ChildByFieldName("body"))
+		case "program", "class_body", "interface_body", "enum_body", "enum_body_declarations",
+			"interface_declaration", "enum_declaration":
+			collectStaticFields(ctx, child)
+		}
+	})
+}
@@ -0,0 +1,540 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// streamPipeline is a java.util.stream pipeline, from the collection it
+// streams to its terminal operation
+type streamPipeline struct {
+	node   *tree_sitter.Node   // The whole pipeline, ending with the terminal operation
+	source *tree_sitter.Node   // The collection or array streamed
+	stages []*tree_sitter.Node // Invocations of the operations in order, the terminal one last
+}
+
+// intermediateStreamOps are the intermediate stream operations lowered into
+// a loop. Each takes a single function.
+var intermediateStreamOps = map[string]bool{
+	"filter":      true,
+	"map":         true,
+	"mapToInt":    true,
+	"mapToLong":   true,
+	"mapToDouble": true,
+	"mapToObj":    true,
+}
+
+// terminalStreamOps are the terminal stream operations lowered into a loop,
+// by the number of arguments they take
+var terminalStreamOps = map[string]int{
+	"collect":   1,
+	"toList":    0,
+	"count":     0,
+	"sum":       0,
+	"forEach":   1,
+	"anyMatch":  1,
+	"allMatch":  1,
+	"noneMatch": 1,
+}
+
+// sumTypes are the Go types of the sums of the numeric streams the mapToX
+// operations produce
+var sumTypes = map[string]gosrc.Type{
+	"mapToInt":    gosrc.TypeInt,
+	"mapToLong":   typeInt64,
+	"mapToDouble": gosrc.TypeFloat64,
+}
+
+// findStreamPipeline returns the stream pipeline expression ends if it
+// invokes a stream operation on a receiver chain starting with
+// collection.stream() or Arrays.stream(array). It returns false if
+// expression is a call on the result of a pipeline, which ends further down
+// the chain.
+func findStreamPipeline(ctx *MigrationContext, expression *tree_sitter.Node) (streamPipeline, bool) {
+	pipeline := streamPipeline{node: expression}
+	for node := expression; pipeline.source == nil; node = unwrapParentheses(node.ChildByFieldName("object")) {
+		if nodeKind(node) != "method_invocation" {
+			return streamPipeline{}, false
+		}
+		name := ctx.nodeText(node.ChildByFieldName("name"))
+		object := node.ChildByFieldName("object")
+		argNodes := argumentNodes(node.ChildByFieldName("arguments"))
+		switch {
+		case name == "stream" && object != nil && ctx.nodeText(object) == "Arrays" && len(argNodes) == 1:
+			pipeline.source = argNodes[0]
+		case name == "stream" && object != nil && len(argNodes) == 0:
+			pipeline.source = object
+		case object == nil:
+			return streamPipeline{}, false
+		default:
+			pipeline.stages = append([]*tree_sitter.Node{node}, pipeline.stages...)
+		}
+	}
+	for i, stage := range pipeline.stages {
+		if isTerminalStreamOp(ctx, stage) {
+			// Calls on the result of the terminal operation are not part of it
+			return pipeline, i == len(pipeline.stages)-1
+		}
+		if !isIntermediateStreamOp(ctx, stage) {
+			break
+		}
+	}
+	// Streams that are not consumed in place, or by unsupported operations,
+	// are lowered by convertStreamPipeline into a failure
+	return pipeline, true
+}
+
+// isIntermediateStreamOp reports whether stage is an intermediate operation
+// lowered into a loop
+func isIntermediateStreamOp(ctx *MigrationContext, stage *tree_sitter.Node) bool {
+	name := ctx.nodeText(stage.ChildByFieldName("name"))
+	return intermediateStreamOps[name] && len(argumentNodes(stage.ChildByFieldName("arguments"))) == 1
+}
+
+// isTerminalStreamOp reports whether stage is a terminal operation lowered
+// into a loop
+func isTerminalStreamOp(ctx *MigrationContext, stage *tree_sitter.Node) bool {
+	argCount, ok := terminalStreamOps[ctx.nodeText(stage.ChildByFieldName("name"))]
+	return ok && len(argumentNodes(stage.ChildByFieldName("arguments"))) == argCount
+}
+
+// unsupportedStream reports that pipeline can't be lowered. The member it is
+// in becomes a FailedMigration keeping the pipeline as a comment.
+func unsupportedStream(ctx *MigrationContext, pipeline streamPipeline, msg string) {
+	fatalError(ctx, pipeline.node, ErrStreamPipeline, msg, "method_invocation")
+}
+
+// convertStreamPipeline lowers pipeline into a range loop over its source
+// that applies the intermediate operations to each element in turn and
+// feeds the result to the terminal operation, which the loop builds the
+// value of. Lambdas passed to the operations are inlined with their
+// parameter bound to the element.
+func convertStreamPipeline(ctx *MigrationContext, pipeline streamPipeline) (gosrc.Expression, []gosrc.Statement) {
+	if len(pipeline.stages) == 0 || !isTerminalStreamOp(ctx, pipeline.stages[len(pipeline.stages)-1]) {
+		unsupportedStream(ctx, pipeline, "streams not consumed by a terminal operation in place have no Go equivalent")
+	}
+	for _, stage := range pipeline.stages[:len(pipeline.stages)-1] {
+		if !isIntermediateStreamOp(ctx, stage) {
+			unsupportedStream(ctx, pipeline, fmt.Sprintf("unsupported stream operation %s", ctx.nodeText(stage.ChildByFieldName("name"))))
+		}
+	}
+	source, initStmts := convertExpression(ctx, pipeline.source)
+	sourceType, _ := valueType(ctx, pipeline.source)
+	element := ctx.tempName("element")
+	if name, ok := lambdaParameter(ctx, pipeline.stages[0]); ok {
+		element = name
+	}
+	loop := &gosrc.RangeForStatement{CollectionExpr: source, ValueVar: element}
+	if strings.HasPrefix(string(sourceType), "map[") {
+		// Sets migrate to maps, whose keys are the elements
+		loop.IndexVar, loop.ValueVar = element, ""
+	} else {
+		loop.CollectionExpr = ctx.arena.GoExpression(gosrc.GoExpression{Source: listSource(ctx, pipeline.source, source)})
+	}
+	lowering := streamLowering{ctx: ctx, pipeline: pipeline, elementType: streamElementType(sourceType)}
+	result, resultInit := lowering.terminalResult()
+	loop.Body = lowering.lowerStages(0, element, map[string]bool{element: true})
+	initStmts = append(initStmts, resultInit...)
+	initStmts = append(initStmts, loop)
+	return result, initStmts
+}
+
+// streamElementType returns the type of the elements of the collection of
+// type sourceType, or "" if it is not known
+func streamElementType(sourceType gosrc.Type) gosrc.Type {
+	switch ty := string(sourceType); {
+	case strings.HasPrefix(ty, "[]"):
+		return gosrc.Type(strings.TrimPrefix(ty, "[]"))
+	case strings.HasPrefix(ty, "map[") && strings.HasSuffix(ty, "]bool"):
+		return gosrc.Type(strings.TrimSuffix(strings.TrimPrefix(ty, "map["), "]bool"))
+	default:
+		return ""
+	}
+}
+
+// streamLowering holds the state of lowering a stream pipeline into a loop
+type streamLowering struct {
+	ctx         *MigrationContext
+	pipeline    streamPipeline
+	elementType gosrc.Type // Type of the elements of the source, "" if unknown
+	result      string     // Name of the variable the terminal operation builds its value in
+	sumType     gosrc.Type // Type of the sum of a numeric stream, "" if it isn't one
+}
+
+// terminalResult returns the value of the terminal operation of the
+// pipeline, and the statements declaring it before the loop
+func (l *streamLowering) terminalResult() (gosrc.Expression, []gosrc.Statement) {
+	ctx := l.ctx
+	terminal := l.pipeline.stages[len(l.pipeline.stages)-1]
+	name := ctx.nodeText(terminal.ChildByFieldName("name"))
+	switch name {
+	case "forEach":
+		// forEach returns nothing, so the pipeline is only a statement
+		return nil, nil
+	case "count":
+		l.result = ctx.tempName("count")
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: l.result}), []gosrc.Statement{&gosrc.VarDeclaration{Name: l.result, Ty: typeInt64}}
+	case "sum":
+		for _, stage := range l.pipeline.stages {
+			if ty, ok := sumTypes[ctx.nodeText(stage.ChildByFieldName("name"))]; ok {
+				l.sumType = ty
+			}
+		}
+		if l.sumType == "" && !l.mapsElements() && (l.elementType == gosrc.TypeInt || l.elementType == typeInt64 || l.elementType == gosrc.TypeFloat64) {
+			// Streams of int[], long[] and double[] are numeric already
+			l.sumType = l.elementType
+		}
+		if l.sumType == "" {
+			unsupportedStream(ctx, l.pipeline, "unable to infer the type of the sum of the stream")
+		}
+		l.result = ctx.tempName("sum")
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: l.result}), []gosrc.Statement{&gosrc.VarDeclaration{Name: l.result, Ty: l.sumType}}
+	case "anyMatch", "allMatch", "noneMatch":
+		l.result = ctx.tempName(name[:len(name)-len("Match")] + "Matches")
+		initial := &gosrc.VarRef{Ref: "false"}
+		if name != "anyMatch" {
+			initial = &gosrc.VarRef{Ref: "true"}
+		}
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: l.result}), []gosrc.Statement{&gosrc.VarDeclaration{Name: l.result, Value: initial}}
+	}
+	// collect and toList build a collection of the elements
+	collector := "toList"
+	if name == "collect" {
+		collector = l.collectorName(terminal)
+	}
+	resultType := l.collectedType(collector)
+	l.result = ctx.tempName("collected")
+	if strings.HasPrefix(string(resultType), "map[") {
+		return ctx.arena.VarRef(gosrc.VarRef{Ref: l.result}), []gosrc.Statement{&gosrc.VarDeclaration{
+			Name:  l.result,
+			Value: ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("make(%s)", resultType)}),
+		}}
+	}
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: l.result}), []gosrc.Statement{&gosrc.VarDeclaration{Name: l.result, Ty: resultType}}
+}
+
+// collectorName returns the name of the java.util.stream.Collectors factory
+// collect is passed, one of toList or toSet
+func (l *streamLowering) collectorName(collect *tree_sitter.Node) string {
+	collector := unwrapParentheses(argumentNodes(collect.ChildByFieldName("arguments"))[0])
+	name := l.ctx.nodeText(collector.ChildByFieldName("name"))
+	if nodeKind(collector) == "method_invocation" && len(argumentNodes(collector.ChildByFieldName("arguments"))) == 0 {
+		switch name {
+		case "toList", "toUnmodifiableList":
+			return "toList"
+		case "toSet", "toUnmodifiableSet":
+			return "toSet"
+		}
+	}
+	unsupportedStream(l.ctx, l.pipeline, fmt.Sprintf("unsupported collector %s", l.ctx.nodeText(collector)))
+	return ""
+}
+
+// collectedType returns the Go type of the list or set collector collects
+// the elements of the pipeline into: the type the pipeline is expected to
+// have, or else a collection of the elements of the source if no operation
+// maps them
+func (l *streamLowering) collectedType(collector string) gosrc.Type {
+	collection := "[]"
+	if collector == "toSet" {
+		collection = "map["
+	}
+	if typeNode := expectedJavaType(l.ctx, l.pipeline.node); typeNode != nil {
+		if ty, ok := TryParseType(l.ctx, typeNode); ok && strings.HasPrefix(string(ty), collection) {
+			return ty
+		}
+	}
+	elementType := l.elementType
+	if l.mapsElements() {
+		elementType = ""
+	}
+	if elementType == "" {
+		unsupportedStream(l.ctx, l.pipeline, "unable to infer the element type of the result of the stream")
+	}
+	if collector == "toSet" {
+		return gosrc.Type("map[" + string(elementType) + "]bool")
+	}
+	return gosrc.Type(collection + string(elementType))
+}
+
+// mapsElements reports whether an operation of the pipeline maps the elements
+// of the source to other values
+func (l *streamLowering) mapsElements() bool {
+	for _, stage := range l.pipeline.stages {
+		if strings.HasPrefix(l.ctx.nodeText(stage.ChildByFieldName("name")), "map") {
+			return true
+		}
+	}
+	return false
+}
+
+// lowerStages returns the statements applying the stages of the pipeline
+// from the i-th on to value, the name of the element the stages before
+// produced. declared holds the names declared in the block the statements
+// go to.
+func (l *streamLowering) lowerStages(i int, value string, declared map[string]bool) []gosrc.Statement {
+	ctx := l.ctx
+	stage := l.pipeline.stages[i]
+	name := ctx.nodeText(stage.ChildByFieldName("name"))
+	if param, ok := lambdaParameter(ctx, stage); ok && param != value && declared[param] {
+		// The parameter is bound to an earlier element in this block already,
+		// so it is bound again in a nested one
+		nested := l.lowerStages(i, value, map[string]bool{})
+		return []gosrc.Statement{&gosrc.GoStatement{Source: "{\n" + statementsSource(nested) + "}"}}
+	}
+	if i == len(l.pipeline.stages)-1 {
+		return l.lowerTerminal(stage, name, value, declared)
+	}
+	applied, stmts := l.apply(stage, value, declared)
+	switch name {
+	case "filter":
+		// The remaining stages only see the elements that pass the filter
+		return append(stmts, &gosrc.IfStatement{
+			Condition: applied,
+			Body:      l.lowerStages(i+1, value, map[string]bool{}),
+		})
+	default:
+		mapped := ctx.tempName("mapped")
+		declared[mapped] = true
+		stmts = append(stmts, &gosrc.VarDeclaration{Name: mapped, Value: applied})
+		return append(stmts, l.lowerStages(i+1, mapped, declared)...)
+	}
+}
+
+// lowerTerminal returns the statements feeding value to the terminal
+// operation stage, called name, of the pipeline
+func (l *streamLowering) lowerTerminal(stage *tree_sitter.Node, name, value string, declared map[string]bool) []gosrc.Statement {
+	ctx := l.ctx
+	ref := &gosrc.VarRef{Ref: l.result}
+	switch name {
+	case "forEach":
+		return l.applyAction(stage, value, declared)
+	case "count":
+		return []gosrc.Statement{&gosrc.GoStatement{Source: l.result + "++"}}
+	case "sum":
+		return []gosrc.Statement{&gosrc.GoStatement{Source: fmt.Sprintf("%s += %s", l.result, value)}}
+	case "anyMatch", "allMatch", "noneMatch":
+		// The loop stops at the first element deciding the result
+		applied, stmts := l.apply(stage, value, declared)
+		condition, decided := applied, "true"
+		if name != "anyMatch" {
+			decided = "false"
+		}
+		if name == "allMatch" {
+			condition = &gosrc.UnaryExpression{Operator: "!", Operand: applied}
+		}
+		return append(stmts, &gosrc.IfStatement{
+			Condition: condition,
+			Body: []gosrc.Statement{
+				&gosrc.AssignStatement{Ref: *ref, Value: &gosrc.VarRef{Ref: decided}},
+				&gosrc.GoStatement{Source: "break"},
+			},
+		})
+	}
+	if strings.HasPrefix(string(l.collectedType(l.collector(stage))), "map[") {
+		return []gosrc.Statement{&gosrc.GoStatement{Source: fmt.Sprintf("%s[%s] = true", l.result, value)}}
+	}
+	return []gosrc.Statement{&gosrc.AssignStatement{
+		Ref:   *ref,
+		Value: ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("append(%s, %s)", l.result, value)}),
+	}}
+}
+
+// collector returns the collector of the terminal operation stage, toList
+// for toList itself
+func (l *streamLowering) collector(stage *tree_sitter.Node) string {
+	if l.ctx.nodeText(stage.ChildByFieldName("name")) == "collect" {
+		return l.collectorName(stage)
+	}
+	return "toList"
+}
+
+// apply returns the result of applying the function passed to stage to
+// value, and the statements computing it. Lambdas are inlined, with their
+// parameter bound to value, and function values are called.
+func (l *streamLowering) apply(stage *tree_sitter.Node, value string, declared map[string]bool) (gosrc.Expression, []gosrc.Statement) {
+	ctx := l.ctx
+	function := unwrapParentheses(argumentNodes(stage.ChildByFieldName("arguments"))[0])
+	if nodeKind(function) != "lambda_expression" {
+		if ty, ok := staticType(ctx, function); !ok || !strings.HasPrefix(string(ty), "func(") {
+			unsupportedStream(ctx, l.pipeline, fmt.Sprintf("unsupported function %s passed to %s", ctx.nodeText(function), ctx.nodeText(stage.ChildByFieldName("name"))))
+		}
+		converted, stmts := convertExpression(ctx, function)
+		return &gosrc.CallExpression{Function: converted.ToSource(), Args: []gosrc.Expression{&gosrc.VarRef{Ref: value}}}, stmts
+	}
+	body := function.ChildByFieldName("body")
+	if nodeKind(body) == "block" {
+		unsupportedStream(ctx, l.pipeline, fmt.Sprintf("lambdas with block bodies passed to %s are not supported", ctx.nodeText(stage.ChildByFieldName("name"))))
+	}
+	stmts := l.bindParameter(stage, value, declared)
+	converted, initStmts := convertExpression(ctx, body)
+	return converted, append(stmts, initStmts...)
+}
+
+// applyAction returns the statements applying the action passed to forEach
+// to value. Lambdas are inlined, with their parameter bound to value, and
+// function values are called.
+func (l *streamLowering) applyAction(stage *tree_sitter.Node, value string, declared map[string]bool) []gosrc.Statement {
+	ctx := l.ctx
+	function := unwrapParentheses(argumentNodes(stage.ChildByFieldName("arguments"))[0])
+	if nodeKind(function) != "lambda_expression" {
+		applied, stmts := l.apply(stage, value, declared)
+		return append(stmts, &gosrc.CallStatement{Exp: applied})
+	}
+	stmts := l.bindParameter(stage, value, declared)
+	body := function.ChildByFieldName("body")
+	switch nodeKind(body) {
+	case "block":
+		if containsReturn(body) {
+			unsupportedStream(ctx, l.pipeline, "return statements in lambdas passed to forEach are not supported")
+		}
+		return append(stmts, convertStatementBlock(ctx, body)...)
+	case "method_invocation":
+		applied, initStmts := convertMethodInvocation(ctx, body)
+		stmts = append(stmts, initStmts...)
+		if applied != nil {
+			stmts = append(stmts, &gosrc.CallStatement{Exp: applied})
+		}
+		return stmts
+	case "assignment_expression":
+		_, initStmts := convertAssignmentExpression(ctx, body)
+		return append(stmts, initStmts...)
+	default:
+		unsupportedStream(ctx, l.pipeline, fmt.Sprintf("unsupported action %s passed to forEach", ctx.nodeText(function)))
+		return nil
+	}
+}
+
+// bindParameter returns the statement binding the parameter of the lambda
+// passed to stage to value, if they are not the same name
+func (l *streamLowering) bindParameter(stage *tree_sitter.Node, value string, declared map[string]bool) []gosrc.Statement {
+	param, ok := lambdaParameter(l.ctx, stage)
+	if !ok {
+		unsupportedStream(l.ctx, l.pipeline, fmt.Sprintf("lambdas passed to %s must take a single parameter", l.ctx.nodeText(stage.ChildByFieldName("name"))))
+	}
+	if param == value {
+		return nil
+	}
+	declared[param] = true
+	return []gosrc.Statement{&gosrc.VarDeclaration{Name: param, Value: &gosrc.VarRef{Ref: value}}}
+}
+
+// lambdaParameter returns the name of the single parameter of the lambda
+// passed to stage, if stage is passed a lambda taking one
+func lambdaParameter(ctx *MigrationContext, stage *tree_sitter.Node) (string, bool) {
+	argNodes := argumentNodes(stage.ChildByFieldName("arguments"))
+	if len(argNodes) != 1 || nodeKind(unwrapParentheses(argNodes[0])) != "lambda_expression" {
+		return "", false
+	}
+	return singleLambdaParameter(ctx, unwrapParentheses(argNodes[0]))
+}
+
+// singleLambdaParameter returns the name of the parameter of lambda if it
+// takes a single one
+func singleLambdaParameter(ctx *MigrationContext, lambda *tree_sitter.Node) (string, bool) {
+	params := lambda.ChildByFieldName("parameters")
+	switch nodeKind(params) {
+	case "identifier":
+		return ctx.nodeText(params), true
+	case "inferred_parameters", "formal_parameters":
+		var names []string
+		IterateChildren(params, func(child *tree_sitter.Node) {
+			switch nodeKind(child) {
+			case "identifier":
+				names = append(names, ctx.nodeText(child))
+			case "formal_parameter":
+				names = append(names, ctx.nodeText(child.ChildByFieldName("name")))
+			}
+		})
+		if len(names) == 1 {
+			return names[0], true
+		}
+	}
+	return "", false
+}
+
+// streamLambdaParameterType returns the Java type node of the parameter name
+// of lambda if lambda is passed to an operation of a stream of a collection
+// or array whose element type is declared, and no operation before maps the
+// elements: the type argument of the collection or the element type of the
+// array
+func streamLambdaParameterType(ctx *MigrationContext, lambda *tree_sitter.Node, name string) *tree_sitter.Node {
+	if param, ok := singleLambdaParameter(ctx, lambda); !ok || param != name {
+		return nil
+	}
+	argsNode := lambda.Parent()
+	if nodeKind(argsNode) != "argument_list" || nodeKind(argsNode.Parent()) != "method_invocation" {
+		return nil
+	}
+	stage := argsNode.Parent()
+	if !isIntermediateStreamOp(ctx, stage) && !isTerminalStreamOp(ctx, stage) {
+		return nil
+	}
+	var source *tree_sitter.Node
+	for node := unwrapParentheses(stage.ChildByFieldName("object")); source == nil; node = unwrapParentheses(node.ChildByFieldName("object")) {
+		if nodeKind(node) != "method_invocation" {
+			return nil
+		}
+		object := node.ChildByFieldName("object")
+		argNodes := argumentNodes(node.ChildByFieldName("arguments"))
+		switch ctx.nodeText(node.ChildByFieldName("name")) {
+		case "filter":
+		case "stream":
+			if object == nil || len(argNodes) > 1 || (len(argNodes) == 1) != (ctx.nodeText(object) == "Arrays") {
+				return nil
+			}
+			source = object
+			if len(argNodes) == 1 {
+				source = argNodes[0]
+			}
+		default:
+			return nil
+		}
+	}
+	typeNode := assignedType(ctx, source)
+	switch nodeKind(typeNode) {
+	case "generic_type":
+		var element *tree_sitter.Node
+		IterateChildren(typeNode, func(child *tree_sitter.Node) {
+			if nodeKind(child) == "type_arguments" && child.NamedChildCount() == 1 {
+				element = child.NamedChild(0)
+			}
+		})
+		return element
+	case "array_type":
+		if ctx.nodeText(typeNode.ChildByFieldName("dimensions")) == "[]" {
+			return typeNode.ChildByFieldName("element")
+		}
+	}
+	return nil
+}
+
+// containsReturn reports whether node has a return statement in it outside
+// nested lambdas and classes
+func containsReturn(node *tree_sitter.Node) bool {
+	found := false
+	IterateChildren(node, func(child *tree_sitter.Node) {
+		switch nodeKind(child) {
+		case "return_statement":
+			found = true
+		case "lambda_expression", "class_body":
+		default:
+			found = found || containsReturn(child)
+		}
+	})
+	return found
+}
+
+// statementsSource returns the Go source of stmts, one per line
+func statementsSource(stmts []gosrc.Statement) string {
+	var sb strings.Builder
+	for _, stmt := range stmts {
+		sb.WriteString(stmt.ToSource())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,175 @@
+package java
+
+import (
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Category groups migration errors by the kind of construct they are
+// reported for, so how strictly they are treated can be chosen per category
+type Category string
+
+const (
+	// CategoryExpressions covers statements, expressions and declarations,
+	// as well as unexpected failures of the converter
+	CategoryExpressions Category = "expressions"
+	// CategoryTypes covers types that can't be parsed or migrated
+	CategoryTypes Category = "types"
+	// CategoryLibraryCalls covers calls of library methods and lookups
+	// without a Go equivalent
+	CategoryLibraryCalls Category = "library_calls"
+	// CategoryConcurrency covers synchronized blocks and the concurrency
+	// utilities of the JDK
+	CategoryConcurrency Category = "concurrency"
+)
+
+// Categories lists every category in the order they are documented
+var Categories = []Category{CategoryExpressions, CategoryTypes, CategoryLibraryCalls, CategoryConcurrency}
+
+// Strictness selects the categories whose migration errors are fatal. Errors
+// of the other categories are recorded and the migration continues, replacing
+// the failing member with a FIXME.
+type Strictness map[Category]bool
+
+// StrictAll returns a Strictness treating the errors of every category as
+// fatal, which is what -Werror selects
+func StrictAll() Strictness {
+	strictness := make(Strictness, len(Categories))
+	for _, category := range Categories {
+		strictness[category] = true
+	}
+	return strictness
+}
+
+// Any reports whether the errors of any category are fatal
+func (s Strictness) Any() bool {
+	for _, strict := range s {
+		if strict {
+			return true
+		}
+	}
+	return false
+}
+
+// codeCategories are the categories of the error codes only reported for
+// one kind of construct. The category of the others depends on the node
+// they are reported for.
+var codeCategories = map[ErrorCode]Category{
+	ErrInternal:          CategoryExpressions,
+	ErrMissingIdentifier: CategoryExpressions,
+	ErrIncomparableKey:   CategoryTypes,
+	ErrClassLiteral:      CategoryTypes,
+	ErrReflection:        CategoryLibraryCalls,
+	ErrSystemProperty:    CategoryLibraryCalls,
+	ErrIntegerDivision:   CategoryExpressions,
+	ErrAnnotation:        CategoryTypes,
+	ErrEnumOrdinal:       CategoryExpressions,
+	ErrSyntax:            CategoryExpressions,
+	ErrStreamPipeline:    CategoryLibraryCalls,
+	ErrNullableValue:     CategoryLibraryCalls,
+	ErrUnmappedPackage:   CategoryTypes,
+}
+
+// typeNodeKinds are the node kinds of types
+var typeNodeKinds = map[string]bool{
+	"annotated_type":         true,
+	"array_type":             true,
+	"boolean_type":           true,
+	"dimensions":             true,
+	"floating_point_type":    true,
+	"generic_type":           true,
+	"integral_type":          true,
+	"scoped_type_identifier": true,
+	"superclass":             true,
+	"super_interfaces":       true,
+	"type_arguments":         true,
+	"type_bound":             true,
+	"type_identifier":        true,
+	"type_parameter":         true,
+	"type_parameters":        true,
+	"void_type":              true,
+	"wildcard":               true,
+}
+
+// concurrencyTypes are the JDK types whose use is migrated, or fails to
+// migrate, as concurrency
+var concurrencyTypes = map[string]bool{
+	"AtomicBoolean":     true,
+	"AtomicInteger":     true,
+	"AtomicLong":        true,
+	"AtomicReference":   true,
+	"CompletableFuture": true,
+	"ConcurrentHashMap": true,
+	"CountDownLatch":    true,
+	"ExecutorService":   true,
+	"Executors":         true,
+	"Future":            true,
+	"ReentrantLock":     true,
+	"Semaphore":         true,
+	"Thread":            true,
+	"ThreadLocal":       true,
+}
+
+// errorCategory returns the category of an error with code reported for node
+func errorCategory(ctx *MigrationContext, node *tree_sitter.Node, code ErrorCode) Category {
+	if category, ok := codeCategories[code]; ok {
+		return category
+	}
+	return nodeCategory(ctx, node)
+}
+
+// nodeCategory returns the category of the construct node is part of
+func nodeCategory(ctx *MigrationContext, node *tree_sitter.Node) Category {
+	if usesConcurrency(ctx, node) {
+		return CategoryConcurrency
+	}
+	switch kind := nodeKind(node); {
+	case typeNodeKinds[kind]:
+		return CategoryTypes
+	case kind == "method_invocation", kind == "method_reference":
+		return CategoryLibraryCalls
+	default:
+		return CategoryExpressions
+	}
+}
+
+// usesConcurrency reports whether node is inside a synchronized block or
+// refers to one of the concurrencyTypes, as in new Thread(task) or
+// Executors.newFixedThreadPool(4)
+func usesConcurrency(ctx *MigrationContext, node *tree_sitter.Node) bool {
+	for ancestor := node; ancestor != nil; ancestor = ancestor.Parent() {
+		if nodeKind(ancestor) == "synchronized_statement" {
+			return true
+		}
+	}
+	var referenced *tree_sitter.Node
+	switch nodeKind(node) {
+	case "object_creation_expression":
+		referenced = node.ChildByFieldName("type")
+	case "method_invocation":
+		referenced = node.ChildByFieldName("object")
+	case "type_identifier", "generic_type":
+		referenced = node
+	}
+	if referenced == nil {
+		return false
+	}
+	if nodeKind(referenced) == "generic_type" {
+		referenced = referenced.NamedChild(0)
+	}
+	return concurrencyTypes[ctx.nodeText(referenced)]
+}
+
+// strict reports whether an error with code reported for node is fatal
+func (ctx *MigrationContext) strict(node *tree_sitter.Node, code ErrorCode) bool {
+	return ctx.Strictness[errorCategory(ctx, node, code)]
+}
+
+// propagates reports whether the recovered panic r must not be recovered
+// from. Migration errors of strict categories exit before they panic, so only
+// unexpected failures of the converter are left, which count as expressions.
+func (ctx *MigrationContext) propagates(r any) bool {
+	if _, ok := r.(MigrationPanic); ok {
+		return false
+	}
+	return ctx.Strictness[CategoryExpressions]
+}
@@ -0,0 +1,149 @@
+package java
+
+import (
+	"fmt"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// stringBuilderType is the Go type of a migrated StringBuilder. It is a
+// pointer since a strings.Builder must not be copied once written to, while
+// Java code freely passes builders around.
+const stringBuilderType gosrc.Type = "*strings.Builder"
+
+// builderMutators are the StringBuilder methods that modify the builder and
+// return it, so calls of them can be chained
+var builderMutators = map[string]bool{
+	"append":    true,
+	"insert":    true,
+	"reverse":   true,
+	"setLength": true,
+}
+
+// isStringBuilder reports whether node evaluates to a StringBuilder
+func isStringBuilder(ctx *MigrationContext, node *tree_sitter.Node) bool {
+	switch nodeKind(node) {
+	case "object_creation_expression":
+		switch ctx.nodeText(node.ChildByFieldName("type")) {
+		case "StringBuilder", "StringBuffer":
+			return true
+		}
+		return false
+	case "method_invocation":
+		object := node.ChildByFieldName("object")
+		return object != nil && builderMutators[ctx.nodeText(node.ChildByFieldName("name"))] && isStringBuilder(ctx, object)
+	case "parenthesized_expression":
+		return isStringBuilder(ctx, node.NamedChild(0))
+	}
+	ty, ok := staticType(ctx, node)
+	return ok && ty == stringBuilderType
+}
+
+// convertStringBuilderCreation converts new StringBuilder(...). A capacity
+// argument is dropped, while an initial string is written to a temporary
+// builder.
+func convertStringBuilderCreation(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	ctx.Source.AddImport("strings")
+	builder := ctx.arena.GoExpression(gosrc.GoExpression{Source: "&strings.Builder{}"})
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	if len(argNodes) == 0 || formatVerb(ctx, argNodes[0]) == "%d" {
+		return builder, nil
+	}
+	name := ctx.tempName("builder")
+	initStmts := []gosrc.Statement{&gosrc.VarDeclaration{Name: name, Value: builder}}
+	initStmts = append(initStmts, builderWrite(ctx, name, argNodes[0])...)
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: name}), initStmts
+}
+
+// convertStringBuilderMethod converts a call of a StringBuilder method on
+// receiver, the Go source of a *strings.Builder. Modifications become
+// statements, and calls returning the builder evaluate to the receiver so
+// chained calls apply to the same builder. It returns false for methods
+// without a translation.
+func convertStringBuilderMethod(ctx *MigrationContext, expression *tree_sitter.Node, name, receiver string) (gosrc.Expression, []gosrc.Statement, bool) {
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	self := ctx.arena.VarRef(gosrc.VarRef{Ref: receiver})
+	switch {
+	case name == "append" && len(argNodes) == 1:
+		return self, builderWrite(ctx, receiver, argNodes[0]), true
+	case name == "length" && len(argNodes) == 0:
+		return &gosrc.CallExpression{Function: receiver + ".Len"}, nil, true
+	case name == "isEmpty" && len(argNodes) == 0:
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("(%s.Len() == 0)", receiver)}), nil, true
+	case name == "charAt" && len(argNodes) == 1:
+		args := stringArguments(ctx, argNodes)
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("int(%s.String()[%s])", receiver, args[0].ToSource())}), nil, true
+	case name == "setLength" && len(argNodes) == 1 && ctx.nodeText(argNodes[0]) == "0":
+		return self, []gosrc.Statement{builderCall(receiver, "Reset")}, true
+	case name == "setLength" && len(argNodes) == 1:
+		args := stringArguments(ctx, argNodes)
+		truncated := ctx.tempName("truncated")
+		return self, []gosrc.Statement{
+			&gosrc.VarDeclaration{Name: truncated, Value: ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("%s.String()[:%s]", receiver, args[0].ToSource())})},
+			builderCall(receiver, "Reset"),
+			builderCall(receiver, "WriteString", &gosrc.VarRef{Ref: truncated}),
+		}, true
+	case name == "insert" && len(argNodes) == 2:
+		// strings.Builder can only append, so the content is rebuilt
+		args := stringArguments(ctx, argNodes)
+		value := args[1]
+		if !isStringValue(ctx, argNodes[1]) {
+			value = stringConversion(ctx, value)
+		}
+		current := ctx.tempName("current")
+		index := args[0].ToSource()
+		return self, []gosrc.Statement{
+			&gosrc.VarDeclaration{Name: current, Value: &gosrc.CallExpression{Function: receiver + ".String"}},
+			builderCall(receiver, "Reset"),
+			builderCall(receiver, "WriteString", ctx.arena.GoExpression(gosrc.GoExpression{
+				Source: fmt.Sprintf("%s[:%s] + %s + %s[%s:]", current, index, value.ToSource(), current, index),
+			})),
+		}, true
+	case name == "reverse" && len(argNodes) == 0:
+		ctx.Source.AddImport("slices")
+		reversed := ctx.tempName("reversed")
+		return self, []gosrc.Statement{
+			&gosrc.VarDeclaration{Name: reversed, Value: ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("[]rune(%s.String())", receiver)})},
+			&gosrc.CallStatement{Exp: &gosrc.CallExpression{Function: "slices.Reverse", Args: []gosrc.Expression{&gosrc.VarRef{Ref: reversed}}}},
+			builderCall(receiver, "Reset"),
+			builderCall(receiver, "WriteString", ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("string(%s)", reversed)})),
+		}, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// builderWrite returns the statements appending the value of valueNode to
+// the builder receiver, picking the Write method for its type
+func builderWrite(ctx *MigrationContext, receiver string, valueNode *tree_sitter.Node) []gosrc.Statement {
+	value, initStmts := convertExpression(ctx, valueNode)
+	switch verb := formatVerb(ctx, valueNode); {
+	case isStringValue(ctx, valueNode):
+		return append(initStmts, builderCall(receiver, "WriteString", value))
+	case nodeKind(valueNode) == "character_literal":
+		return append(initStmts, builderCall(receiver, "WriteRune", value))
+	case verb == "%c":
+		// Java chars migrate to int
+		return append(initStmts, builderCall(receiver, "WriteRune", &gosrc.CallExpression{Function: "rune", Args: []gosrc.Expression{value}}))
+	default:
+		ctx.Source.AddImport("fmt")
+		return append(initStmts, &gosrc.CallStatement{Exp: &gosrc.CallExpression{
+			Function: "fmt.Fprint",
+			Args:     []gosrc.Expression{&gosrc.VarRef{Ref: receiver}, value},
+		}})
+	}
+}
+
+// isStringValue reports whether node is a string, including concatenations
+// that produce one
+func isStringValue(ctx *MigrationContext, node *tree_sitter.Node) bool {
+	_, isString := stringConcatOperands(ctx, node)
+	return isString
+}
+
+// builderCall returns the statement calling method on the builder receiver
+func builderCall(receiver, method string, args ...gosrc.Expression) gosrc.Statement {
+	return &gosrc.CallStatement{Exp: &gosrc.CallExpression{Function: receiver + "." + method, Args: args}}
+}
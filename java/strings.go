@@ -0,0 +1,231 @@
+package java
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// stringFunctions maps java.lang.String methods to the functions of the Go
+// strings package taking the receiver as their first argument
+var stringFunctions = map[string]struct {
+	function string
+	args     int
+}{
+	"contains":         {"strings.Contains", 1},
+	"startsWith":       {"strings.HasPrefix", 1},
+	"endsWith":         {"strings.HasSuffix", 1},
+	"indexOf":          {"strings.Index", 1},
+	"lastIndexOf":      {"strings.LastIndex", 1},
+	"toLowerCase":      {"strings.ToLower", 0},
+	"toUpperCase":      {"strings.ToUpper", 0},
+	"trim":             {"strings.TrimSpace", 0},
+	"strip":            {"strings.TrimSpace", 0},
+	"replace":          {"strings.ReplaceAll", 2},
+	"repeat":           {"strings.Repeat", 1},
+	"equalsIgnoreCase": {"strings.EqualFold", 1},
+	"compareTo":        {"strings.Compare", 1},
+}
+
+// regexpMeta matches the characters that make a String.split argument a
+// regular expression rather than a plain separator
+var regexpMeta = regexp.MustCompile(`[\\.\[\]{}()*+?^$|]`)
+
+// convertStringMethod converts a call of a java.lang.String method on
+// receiver, the Go source of a string. It returns false for methods without
+// a translation.
+func convertStringMethod(ctx *MigrationContext, expression *tree_sitter.Node, name, receiver string) (gosrc.Expression, []gosrc.Statement, bool) {
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	args := stringArguments(ctx, argNodes)
+	self := &gosrc.VarRef{Ref: receiver}
+	if function, ok := stringFunctions[name]; ok && len(args) == function.args {
+		ctx.Source.AddImport("strings")
+		return &gosrc.CallExpression{Function: function.function, Args: append([]gosrc.Expression{self}, args...)}, nil, true
+	}
+	switch {
+	case name == "length" && len(args) == 0:
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("len(%s)", receiver)}), nil, true
+	case name == "isEmpty" && len(args) == 0:
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("(len(%s) == 0)", receiver)}), nil, true
+	case name == "charAt" && len(args) == 1:
+		// Java chars migrate to int
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("int(%s[%s])", receiver, args[0].ToSource())}), nil, true
+	case name == "substring" && len(args) == 1:
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("%s[%s:]", receiver, args[0].ToSource())}), nil, true
+	case name == "substring" && len(args) == 2:
+		return ctx.arena.GoExpression(gosrc.GoExpression{Source: fmt.Sprintf("%s[%s:%s]", receiver, args[0].ToSource(), args[1].ToSource())}), nil, true
+	case name == "concat" && len(args) == 1:
+		return ctx.arena.BinaryExpression(gosrc.BinaryExpression{Left: self, Operator: "+", Right: args[0]}), nil, true
+	case name == "split" && len(args) == 1:
+		return convertSplit(ctx, argNodes[0], self, args[0]), nil, true
+	case name == "intern" && len(args) == 0:
+		// Go strings are values, there is no pool to intern them in
+		return self, nil, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// convertStringStaticMethod converts calls of the static methods of
+// java.lang.String other than valueOf
+func convertStringStaticMethod(ctx *MigrationContext, expression *tree_sitter.Node, name string) (gosrc.Expression, []gosrc.Statement, bool) {
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	args := stringArguments(ctx, argNodes)
+	switch {
+	case name == "format" && len(args) > 0:
+		// %n is the only Java format verb fmt doesn't know
+		if nodeKind(argNodes[0]) == "string_literal" {
+			args[0] = ctx.arena.GoExpression(gosrc.GoExpression{Source: strings.ReplaceAll(args[0].ToSource(), "%n", `\n`)})
+		}
+		ctx.Source.AddImport("fmt")
+		return &gosrc.CallExpression{Function: "fmt.Sprintf", Args: args}, nil, true
+	case name == "join" && len(args) == 2 && !isStringOperand(ctx, argNodes[1]):
+		ctx.Source.AddImport("strings")
+		return &gosrc.CallExpression{Function: "strings.Join", Args: []gosrc.Expression{args[1], args[0]}}, nil, true
+	case name == "join" && len(args) >= 2:
+		ctx.Source.AddImport("strings")
+		elements := &gosrc.ArrayLiteral{ElementType: gosrc.TypeString, Elements: args[1:]}
+		return &gosrc.CallExpression{Function: "strings.Join", Args: []gosrc.Expression{elements, args[0]}}, nil, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// convertSplit converts s.split(separator). Separators without regular
+// expression syntax split with strings.Split, others with a compiled
+// regexp. Unlike Java, both keep trailing empty strings.
+func convertSplit(ctx *MigrationContext, separatorNode *tree_sitter.Node, self, separator gosrc.Expression) gosrc.Expression {
+	if nodeKind(separatorNode) == "string_literal" && !regexpMeta.MatchString(ctx.nodeText(separatorNode)) {
+		ctx.Source.AddImport("strings")
+		return &gosrc.CallExpression{Function: "strings.Split", Args: []gosrc.Expression{self, separator}}
+	}
+	ctx.Source.AddImport("regexp")
+	return &gosrc.CallExpression{
+		Function: fmt.Sprintf("regexp.MustCompile(%s).Split", separator.ToSource()),
+		Args:     []gosrc.Expression{self, ctx.arena.GoExpression(gosrc.GoExpression{Source: "-1"})},
+	}
+}
+
+// argumentNodes returns the argument expressions of an argument_list
+func argumentNodes(argList *tree_sitter.Node) []*tree_sitter.Node {
+	var nodes []*tree_sitter.Node
+	IterateChildren(argList, func(child *tree_sitter.Node) {
+		switch nodeKind(child) {
+		case "(", ")", ",", "line_comment", "block_comment":
+		default:
+			nodes = append(nodes, child)
+		}
+	})
+	return nodes
+}
+
+// stringArguments converts the arguments of a String method. Char literals
+// become string literals since the strings functions take strings where
+// String methods are overloaded for chars.
+func stringArguments(ctx *MigrationContext, argNodes []*tree_sitter.Node) []gosrc.Expression {
+	args := make([]gosrc.Expression, len(argNodes))
+	for i, argNode := range argNodes {
+		if nodeKind(argNode) == "character_literal" {
+			if literal, ok := charAsStringLiteral(ctx, argNode); ok {
+				args[i] = literal
+				continue
+			}
+		}
+		arg, initStmts := convertExpression(ctx, argNode)
+		if len(initStmts) > 0 {
+			FatalError(ctx, argNode, "unexpected statements in argument list expression", "argument_list")
+		}
+		args[i] = arg
+	}
+	return args
+}
+
+// charAsStringLiteral converts a char literal to the string literal holding
+// that char
+func charAsStringLiteral(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Expression, bool) {
+	text := ctx.nodeText(node)
+	body, err := translateEscapes(text[1:len(text)-1], '"')
+	if err != nil {
+		return nil, false
+	}
+	switch body {
+	case `"`:
+		body = `\"`
+	case `'`, `\'`:
+		body = `'`
+	}
+	return ctx.arena.GoExpression(gosrc.GoExpression{Source: `"` + body + `"`}), true
+}
+
+// nullStringComparison converts the comparison expression of a string and
+// null with == or !=. Go strings can't be nil, the closest is the empty
+// string they are initialized to.
+func nullStringComparison(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Expression, []gosrc.Statement, bool) {
+	operator := ctx.nodeText(expression.ChildByFieldName("operator"))
+	leftNode, rightNode := expression.ChildByFieldName("left"), expression.ChildByFieldName("right")
+	valueNode := leftNode
+	switch {
+	case operator != "==" && operator != "!=":
+		return nil, nil, false
+	case nodeKind(leftNode) == "null_literal":
+		valueNode = rightNode
+	case nodeKind(rightNode) != "null_literal":
+		return nil, nil, false
+	}
+	if !isStringOperand(ctx, valueNode) {
+		return nil, nil, false
+	}
+	value, initStmts := convertExpression(ctx, valueNode)
+	comment := fmt.Sprintf("NOTE: %s compared with null in Java, Go strings can't be nil", ctx.nodeText(expression))
+	return ctx.arena.BinaryExpression(gosrc.BinaryExpression{
+		Left:     value,
+		Operator: operator,
+		Right:    ctx.arena.GoExpression(gosrc.GoExpression{Source: `""`}),
+	}), append(initStmts, &gosrc.CommentStmt{Comments: []string{comment}}), true
+}
+
+// stringIdentityNote returns a note for the comparison expression of two
+// strings with == or != if neither is a constant. Java interns constants, so
+// comparing one is usually meant to compare contents, as Go does; comparing
+// two other strings compared their identity.
+func stringIdentityNote(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Statement, bool) {
+	leftNode, rightNode := expression.ChildByFieldName("left"), expression.ChildByFieldName("right")
+	switch {
+	case !isStringOperand(ctx, leftNode) || !isStringOperand(ctx, rightNode):
+		return nil, false
+	case isStringConstant(ctx, leftNode) || isStringConstant(ctx, rightNode):
+		return nil, false
+	}
+	comment := fmt.Sprintf("NOTE: %s compared the identity of the strings in Java, Go compares their contents", ctx.nodeText(expression))
+	return &gosrc.CommentStmt{Comments: []string{comment}}, true
+}
+
+// isStringConstant reports whether node is a string that Java interns: a
+// literal, an interned string or a static final field
+func isStringConstant(ctx *MigrationContext, node *tree_sitter.Node) bool {
+	node = unwrapParentheses(node)
+	switch nodeKind(node) {
+	case "string_literal":
+		return true
+	case "method_invocation":
+		return ctx.nodeText(node.ChildByFieldName("name")) == "intern"
+	case "identifier":
+		typeNode := declaredType(ctx, node, ctx.nodeText(node))
+		if typeNode == nil || nodeKind(typeNode.Parent()) != "field_declaration" {
+			return false
+		}
+		var mods modifiers
+		IterateChildren(typeNode.Parent(), func(child *tree_sitter.Node) {
+			if nodeKind(child) == "modifiers" {
+				mods = ParseModifiers(ctx.nodeText(child))
+			}
+		})
+		return mods&STATIC != 0 && mods&FINAL != 0
+	default:
+		return false
+	}
+}
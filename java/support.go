@@ -0,0 +1,65 @@
+package java
+
+import (
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Support is a set of Go declarations standing in for a Java library type,
+// such as the generic Optional. Migrated code of several files of a package
+// may use it, so it is declared once per package.
+type Support struct {
+	Name   string // Short name of the support, such as "optional"
+	Source func() gosrc.GoSource
+	// needed reports whether the Go source migrated from tree uses the
+	// declarations
+	needed func(ctx *MigrationContext, tree *tree_sitter.Tree) bool
+}
+
+// supports are all the supports, in the order they are declared in
+var supports = []Support{
+	{Name: "optional", Source: OptionalSupport, needed: needsOptionalSupport},
+	{Name: "iterator", Source: IteratorSupport, needed: needsIteratorSupport},
+}
+
+// NeededSupport returns the supports the Go source migrated from tree uses
+func NeededSupport(ctx *MigrationContext, tree *tree_sitter.Tree) []Support {
+	var needed []Support
+	for _, support := range supports {
+		if support.needed(ctx, tree) {
+			needed = append(needed, support)
+		}
+	}
+	return needed
+}
+
+// ownedSupport returns the supports the Go source migrated from tree uses
+// and declares itself, which is none of them if ctx.SharedSupport is set
+func ownedSupport(ctx *MigrationContext, tree *tree_sitter.Tree) []Support {
+	if ctx.SharedSupport {
+		return nil
+	}
+	return NeededSupport(ctx, tree)
+}
+
+// treeRefersTo reports whether tree refers to any of names, as a type or
+// otherwise
+func treeRefersTo(ctx *MigrationContext, tree *tree_sitter.Tree, names ...string) bool {
+	uses := false
+	var visit func(node *tree_sitter.Node)
+	visit = func(node *tree_sitter.Node) {
+		if uses {
+			return
+		}
+		if nodeKind(node) == "type_identifier" || nodeKind(node) == "identifier" {
+			for _, name := range names {
+				uses = uses || ctx.nodeText(node) == name
+			}
+			return
+		}
+		IterateChildren(node, visit)
+	}
+	visit(tree.RootNode())
+	return uses
+}
@@ -0,0 +1,106 @@
+package java
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+)
+
+// SymbolTable collects the analysis results of several files, so that the
+// conversion of each file can resolve calls, constructors and fields of
+// classes, interfaces and enums declared in the others
+type SymbolTable struct {
+	Methods         map[string][]FunctionData
+	Constructors    map[gosrc.Type][]FunctionData
+	AbstractClasses map[string]bool
+	Classes         map[string]bool
+	EnumConstants   map[string]string
+	EnumOrdinals    map[string][]string
+	EnumToString    map[string]bool
+	TypeNames       map[string]string
+	InnerClasses    map[string]string
+	Fields          map[string]map[string]FieldSymbol
+	DefaultMethods  map[string]map[string]string
+	Supertypes      map[string][]gosrc.Type
+}
+
+// NewSymbolTable creates an empty SymbolTable
+func NewSymbolTable() *SymbolTable {
+	return &SymbolTable{
+		Methods:         make(map[string][]FunctionData),
+		Constructors:    make(map[gosrc.Type][]FunctionData),
+		AbstractClasses: make(map[string]bool),
+		Classes:         make(map[string]bool),
+		EnumConstants:   make(map[string]string),
+		EnumOrdinals:    make(map[string][]string),
+		EnumToString:    make(map[string]bool),
+		TypeNames:       make(map[string]string),
+		InnerClasses:    make(map[string]string),
+		Fields:          make(map[string]map[string]FieldSymbol),
+		DefaultMethods:  make(map[string]map[string]string),
+		Supertypes:      make(map[string][]gosrc.Type),
+	}
+}
+
+// Add records the declarations of an analyzed file. Methods with the same
+// Java name in different files are kept as overloads, since calls are
+// resolved by name and argument count only.
+func (table *SymbolTable) Add(ctx *MigrationContext) {
+	for name, methods := range ctx.Methods {
+		table.Methods[name] = appendFunctions(table.Methods[name], methods)
+	}
+	for ty, constructors := range ctx.Constructors {
+		table.Constructors[ty] = appendFunctions(table.Constructors[ty], constructors)
+	}
+	maps.Copy(table.AbstractClasses, ctx.AbstractClasses)
+	maps.Copy(table.Classes, ctx.Classes)
+	maps.Copy(table.EnumConstants, ctx.EnumConstants)
+	maps.Copy(table.EnumOrdinals, ctx.EnumOrdinals)
+	maps.Copy(table.EnumToString, ctx.EnumToString)
+	maps.Copy(table.TypeNames, ctx.TypeNames)
+	maps.Copy(table.InnerClasses, ctx.InnerClasses)
+	maps.Copy(table.Fields, ctx.Fields)
+	maps.Copy(table.DefaultMethods, ctx.DefaultMethods)
+	maps.Copy(table.Supertypes, ctx.Supertypes)
+}
+
+// appendFunctions appends the functions in more that are not in functions yet
+func appendFunctions(functions, more []FunctionData) []FunctionData {
+	functions = slices.Clip(functions)
+	for _, function := range more {
+		if !slices.ContainsFunc(functions, func(other FunctionData) bool {
+			return other.Name == function.Name && other.sameArgs(function)
+		}) {
+			functions = append(functions, function)
+		}
+	}
+	return functions
+}
+
+// ImportSymbols makes the declarations of table visible to the conversion of
+// ctx. It must be called after the analysis of ctx and before its conversion.
+// Declarations of the file itself take precedence over those of other files.
+func (ctx *MigrationContext) ImportSymbols(table *SymbolTable) {
+	importMissing(ctx.Methods, table.Methods)
+	importMissing(ctx.Constructors, table.Constructors)
+	importMissing(ctx.AbstractClasses, table.AbstractClasses)
+	importMissing(ctx.Classes, table.Classes)
+	importMissing(ctx.EnumConstants, table.EnumConstants)
+	importMissing(ctx.EnumOrdinals, table.EnumOrdinals)
+	importMissing(ctx.EnumToString, table.EnumToString)
+	importMissing(ctx.TypeNames, table.TypeNames)
+	importMissing(ctx.InnerClasses, table.InnerClasses)
+	importMissing(ctx.Fields, table.Fields)
+	importMissing(ctx.DefaultMethods, table.DefaultMethods)
+	importMissing(ctx.Supertypes, table.Supertypes)
+}
+
+// importMissing copies the entries of src whose keys are not in dst
+func importMissing[K comparable, V any](dst, src map[K]V) {
+	for key, value := range src {
+		if _, ok := dst[key]; !ok {
+			dst[key] = value
+		}
+	}
+}
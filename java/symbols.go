@@ -0,0 +1,385 @@
+package java
+
+import (
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// FieldSymbol is the Go name and type of an instance field of a migrated
+// struct
+type FieldSymbol struct {
+	Name string     `json:"name"`
+	Type gosrc.Type `json:"type"`
+}
+
+// collectFields records the instance fields of typeNode in ctx.Fields under
+// the Go name of the struct it migrates to. Abstract classes are skipped
+// since their fields are only reachable through getters.
+func collectFields(ctx *MigrationContext, typeNode *tree_sitter.Node) {
+	if nodeKind(typeNode) == "class_declaration" && ctx.AbstractClasses[ctx.nodeText(typeNode.ChildByFieldName("name"))] {
+		return
+	}
+	fields := make(map[string]FieldSymbol)
+	if parameters := typeNode.ChildByFieldName("parameters"); parameters != nil {
+		// Record components are always exported
+		IterateChildren(parameters, func(component *tree_sitter.Node) {
+			if nodeKind(component) == "formal_parameter" {
+				name := ctx.nodeText(component.ChildByFieldName("name"))
+				fields[name] = FieldSymbol{
+					Name: gosrc.CapitalizeFirstLetter(name),
+					Type: fieldType(ctx, component.ChildByFieldName("type")),
+				}
+			}
+		})
+	}
+	if body := typeNode.ChildByFieldName("body"); body != nil {
+		IterateChildren(body, func(member *tree_sitter.Node) {
+			if nodeKind(member) != "field_declaration" {
+				return
+			}
+			var mods modifiers
+			IterateChildren(member, func(child *tree_sitter.Node) {
+				switch nodeKind(child) {
+				case "modifiers":
+					mods = ParseModifiers(ctx.nodeText(child))
+				case "variable_declarator":
+					// Static fields become module level vars
+					if mods&STATIC == 0 {
+						name := ctx.nodeText(child.ChildByFieldName("name"))
+						fields[name] = FieldSymbol{
+							Name: gosrc.ToIdentifier(name, mods.isPublic()),
+							Type: fieldType(ctx, member.ChildByFieldName("type")),
+						}
+					}
+				}
+			})
+		})
+	}
+	if len(fields) > 0 {
+		ctx.Fields[goTypeName(ctx, typeNode)] = fields
+	}
+}
+
+// fieldType returns the Go type of a field declared with typeNode, or an
+// empty type if it can't be parsed
+func fieldType(ctx *MigrationContext, typeNode *tree_sitter.Node) gosrc.Type {
+	if typeNode == nil {
+		return ""
+	}
+	ty, _ := TryParseType(ctx, typeNode)
+	return ty
+}
+
+// fieldSymbol returns the field javaName of the struct ty
+func (ctx *MigrationContext) fieldSymbol(ty gosrc.Type, javaName string) (FieldSymbol, bool) {
+	fields, ok := ctx.structFields(ty)
+	if !ok {
+		return FieldSymbol{}, false
+	}
+	field, ok := fields[javaName]
+	return field, ok
+}
+
+// structFields returns the fields of the struct ty, or of the struct ty points
+// to, by Java name
+func (ctx *MigrationContext) structFields(ty gosrc.Type) (map[string]FieldSymbol, bool) {
+	// Structs of mapped packages are known by their own name
+	_, ty = ctx.splitImportedType(structType(ty))
+	fields, ok := ctx.Fields[string(ty)]
+	return fields, ok
+}
+
+// expectedJavaType returns the Java type node of the type expression is
+// expected to have: the type of the variable it initializes or is assigned
+// to, or of the method it is returned from. It returns nil if it is not
+// known.
+func expectedJavaType(ctx *MigrationContext, expression *tree_sitter.Node) *tree_sitter.Node {
+	parent := expression.Parent()
+	if parent == nil {
+		return nil
+	}
+	switch nodeKind(parent) {
+	case "variable_declarator":
+		return parent.Parent().ChildByFieldName("type")
+	case "assignment_expression":
+		return assignedType(ctx, parent.ChildByFieldName("left"))
+	case "return_statement":
+		for scope := parent.Parent(); scope != nil; scope = scope.Parent() {
+			switch nodeKind(scope) {
+			case "method_declaration":
+				return scope.ChildByFieldName("type")
+			case "lambda_expression", "class_body":
+				return nil
+			}
+		}
+	case "parenthesized_expression":
+		return expectedJavaType(ctx, parent)
+	}
+	return nil
+}
+
+// assignedType returns the Java type node of the variable or field left
+// refers to
+func assignedType(ctx *MigrationContext, left *tree_sitter.Node) *tree_sitter.Node {
+	switch nodeKind(left) {
+	case "identifier":
+		return declaredType(ctx, left, ctx.nodeText(left))
+	case "field_access":
+		if nodeKind(left.ChildByFieldName("object")) == "this" {
+			return declaredType(ctx, left, ctx.nodeText(left.ChildByFieldName("field")))
+		}
+	}
+	return nil
+}
+
+// declaredType returns the type node of the parameter, local variable or
+// field named name that is in scope at node, or nil if there is none. Scopes
+// are searched innermost first, so shadowing declarations win.
+func declaredType(ctx *MigrationContext, node *tree_sitter.Node, name string) *tree_sitter.Node {
+	for scope := node.Parent(); scope != nil; scope = scope.Parent() {
+		var typeNode *tree_sitter.Node
+		switch nodeKind(scope) {
+		case "method_declaration", "constructor_declaration":
+			typeNode = parameterType(ctx, scope.ChildByFieldName("parameters"), name)
+		case "lambda_expression":
+			typeNode = parameterType(ctx, scope.ChildByFieldName("parameters"), name)
+			if typeNode == nil {
+				typeNode = streamLambdaParameterType(ctx, scope, name)
+			}
+		case "catch_clause":
+			IterateChildren(scope, func(child *tree_sitter.Node) {
+				if nodeKind(child) == "catch_formal_parameter" && ctx.nodeText(child.ChildByFieldName("name")) == name {
+					IterateChildren(child, func(part *tree_sitter.Node) {
+						if nodeKind(part) == "catch_type" {
+							typeNode = part
+						}
+					})
+				}
+			})
+		case "enhanced_for_statement":
+			if ctx.nodeText(scope.ChildByFieldName("name")) == name {
+				typeNode = scope.ChildByFieldName("type")
+			}
+		case "for_statement":
+			if init := scope.ChildByFieldName("init"); init != nil && nodeKind(init) == "local_variable_declaration" {
+				typeNode = declarationType(ctx, init, name)
+			}
+		case "if_statement", "while_statement", "ternary_expression":
+			typeNode = patternType(ctx, scope.ChildByFieldName("condition"), name)
+		case "binary_expression":
+			typeNode = patternType(ctx, scope.ChildByFieldName("left"), name)
+		case "block", "constructor_body", "switch_block_statement_group":
+			typeNode = localVariableType(ctx, scope, node, name)
+		case "class_body":
+			IterateChildren(scope, func(member *tree_sitter.Node) {
+				if typeNode == nil && nodeKind(member) == "field_declaration" {
+					typeNode = declarationType(ctx, member, name)
+				}
+			})
+			// Anonymous classes see the locals of the code creating them,
+			// names declared in other enclosing classes are not followed
+			if typeNode != nil || nodeKind(scope.Parent()) != "object_creation_expression" {
+				return typeNode
+			}
+		}
+		if typeNode != nil {
+			return typeNode
+		}
+	}
+	return nil
+}
+
+// parameterType returns the type node of the parameter called name in
+// parameters, which may be nil
+func parameterType(ctx *MigrationContext, parameters *tree_sitter.Node, name string) *tree_sitter.Node {
+	if parameters == nil {
+		return nil
+	}
+	var typeNode *tree_sitter.Node
+	IterateChildren(parameters, func(parameter *tree_sitter.Node) {
+		if nodeKind(parameter) == "formal_parameter" && ctx.nodeText(parameter.ChildByFieldName("name")) == name {
+			typeNode = parameter.ChildByFieldName("type")
+		}
+	})
+	return typeNode
+}
+
+// localVariableType returns the type node of the local variable called name
+// declared in block before node, including the pattern variables of the
+// conditions of if statements, which can be in scope after them
+func localVariableType(ctx *MigrationContext, block, node *tree_sitter.Node, name string) *tree_sitter.Node {
+	var typeNode *tree_sitter.Node
+	IterateChildren(block, func(statement *tree_sitter.Node) {
+		if statement.StartByte() >= node.StartByte() {
+			return
+		}
+		switch nodeKind(statement) {
+		case "local_variable_declaration":
+			if declared := declarationType(ctx, statement, name); declared != nil {
+				typeNode = declared
+			}
+		case "if_statement":
+			if declared := patternType(ctx, statement.ChildByFieldName("condition"), name); declared != nil {
+				typeNode = declared
+			}
+		}
+	})
+	return typeNode
+}
+
+// declarationType returns the type node of a local_variable_declaration or
+// field_declaration if it declares a variable called name
+func declarationType(ctx *MigrationContext, declaration *tree_sitter.Node, name string) *tree_sitter.Node {
+	var typeNode *tree_sitter.Node
+	IterateChildren(declaration, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "variable_declarator" && ctx.nodeText(child.ChildByFieldName("name")) == name {
+			typeNode = declaration.ChildByFieldName("type")
+		}
+	})
+	return typeNode
+}
+
+// staticType returns the Go type of expression if it is this, a variable, or
+// a chain of field accesses starting at one, whose type is known
+func staticType(ctx *MigrationContext, expression *tree_sitter.Node) (gosrc.Type, bool) {
+	switch nodeKind(expression) {
+	case "identifier":
+		return ctx.variableType(expression, ctx.nodeText(expression))
+	case "this":
+		typeNode := enclosingTypeDeclaration(expression)
+		if typeNode == nil {
+			return "", false
+		}
+		return gosrc.Type(goTypeName(ctx, typeNode)), true
+	case "field_access":
+		objectType, ok := staticType(ctx, expression.ChildByFieldName("object"))
+		if !ok {
+			return "", false
+		}
+		field, ok := ctx.fieldSymbol(objectType, ctx.nodeText(expression.ChildByFieldName("field")))
+		return field.Type, ok && field.Type != ""
+	default:
+		return "", false
+	}
+}
+
+// fieldOfStaticType returns the field javaName of object if the static type
+// of object is a migrated struct
+func fieldOfStaticType(ctx *MigrationContext, object *tree_sitter.Node, javaName string) (FieldSymbol, bool) {
+	if len(ctx.Fields) == 0 {
+		return FieldSymbol{}, false
+	}
+	ty, ok := staticType(ctx, object)
+	if !ok {
+		return FieldSymbol{}, false
+	}
+	return ctx.fieldSymbol(ty, javaName)
+}
+
+// enclosingField returns the field javaName of the struct migrated from the
+// class enclosing node
+func enclosingField(ctx *MigrationContext, node *tree_sitter.Node, javaName string) (FieldSymbol, bool) {
+	typeNode := enclosingTypeDeclaration(node)
+	if typeNode == nil || len(ctx.Fields) == 0 {
+		return FieldSymbol{}, false
+	}
+	field, ok := ctx.Fields[goTypeName(ctx, typeNode)][javaName]
+	return field, ok
+}
+
+// implicitFieldReference returns the Go expression of the instance field a
+// bare name refers to, as in return count, unless a parameter, local or
+// static field in scope at node shadows it. Fields of the enclosing instance
+// of an inner class are reached through its outer field, and so are those of
+// the instance an anonymous class is created in.
+func implicitFieldReference(ctx *MigrationContext, node *tree_sitter.Node, name string) (string, bool) {
+	if shadowsField(ctx, node, name) {
+		return "", false
+	}
+	if creation := enclosingAnonymousClass(ctx, node); creation != nil {
+		return anonymousFieldReference(ctx, node, creation, name)
+	}
+	var field FieldSymbol
+	ref, ok := enclosingInstance(ctx, node, func(scope *tree_sitter.Node) bool {
+		var found bool
+		field, found = ctx.Fields[goTypeName(ctx, scope)][name]
+		if !found && declaresAbstractClassField(ctx, scope, name) {
+			// Default methods turn this.name into a call of its getter
+			field, found = FieldSymbol{Name: name}, true
+		}
+		return found
+	})
+	if !ok {
+		return "", false
+	}
+	return ref + "." + field.Name, true
+}
+
+// declaresAbstractClassField reports whether typeNode is an abstract class
+// declaring an instance field called name
+func declaresAbstractClassField(ctx *MigrationContext, typeNode *tree_sitter.Node, name string) bool {
+	if nodeKind(typeNode) != "class_declaration" || !ctx.AbstractClasses[ctx.nodeText(typeNode.ChildByFieldName("name"))] {
+		return false
+	}
+	found := false
+	IterateChildren(typeNode.ChildByFieldName("body"), func(member *tree_sitter.Node) {
+		if nodeKind(member) == "field_declaration" && declarationModifiers(ctx, member)&STATIC == 0 {
+			found = found || declarationType(ctx, member, name) != nil
+		}
+	})
+	return found
+}
+
+// shadowsField reports whether the innermost declaration of name in scope at
+// node is something other than an instance field
+func shadowsField(ctx *MigrationContext, node *tree_sitter.Node, name string) bool {
+	typeNode := declaredType(ctx, node, name)
+	switch {
+	case typeNode != nil && nodeKind(typeNode.Parent()) != "field_declaration":
+		return true
+	case isSpreadParameter(ctx, node, name), isInferredLambdaParameter(ctx, node, name), inCompactConstructor(node):
+		// Compact constructors see the record components as parameters
+		return true
+	case typeNode != nil:
+		return declarationModifiers(ctx, typeNode.Parent())&STATIC != 0
+	default:
+		return false
+	}
+}
+
+// inCompactConstructor reports whether node is inside the compact constructor
+// of a record
+func inCompactConstructor(node *tree_sitter.Node) bool {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch nodeKind(parent) {
+		case "compact_constructor_declaration":
+			return true
+		case "class_body":
+			return false
+		}
+	}
+	return false
+}
+
+// isInferredLambdaParameter reports whether name is a parameter declared
+// without a type by a lambda enclosing node, as in x -> x + 1
+func isInferredLambdaParameter(ctx *MigrationContext, node *tree_sitter.Node, name string) bool {
+	for scope := node.Parent(); scope != nil && nodeKind(scope) != "class_body"; scope = scope.Parent() {
+		if nodeKind(scope) != "lambda_expression" {
+			continue
+		}
+		parameters := scope.ChildByFieldName("parameters")
+		if nodeKind(parameters) == "identifier" && ctx.nodeText(parameters) == name {
+			return true
+		}
+		found := false
+		IterateChildren(parameters, func(parameter *tree_sitter.Node) {
+			found = found || (nodeKind(parameter) == "identifier" && ctx.nodeText(parameter) == name)
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
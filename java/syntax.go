@@ -0,0 +1,87 @@
+package java
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// maxSyntaxErrorText bounds the Java source quoted by syntax error messages
+const maxSyntaxErrorText = 40
+
+// checkSyntax reports the ERROR and MISSING nodes tree-sitter recovered from
+// a malformed Java source with. Errors nested in an ERROR node are reported
+// with it, and missing names of declarations as ErrMissingIdentifier.
+func checkSyntax(ctx *MigrationContext, root *tree_sitter.Node) {
+	if !root.HasError() {
+		return
+	}
+	var visit func(node *tree_sitter.Node)
+	visit = func(node *tree_sitter.Node) {
+		switch {
+		case node.IsError():
+			reportDiagnostic(ctx, node, ErrSyntax, "syntax error: unexpected "+quoteSyntaxError(ctx.nodeText(node)))
+		case node.IsMissing() && isDeclarationName(node):
+			declaration := node.Parent()
+			reportDiagnostic(ctx, declaration, ErrMissingIdentifier, "missing name in "+nodeKind(declaration))
+		case node.IsMissing():
+			reportDiagnostic(ctx, node, ErrSyntax, "syntax error: missing "+nodeKind(node))
+		case node.HasError():
+			IterateChildren(node, visit)
+		}
+	}
+	visit(root)
+}
+
+// isDeclarationName reports whether node is the name of the declaration it
+// is part of
+func isDeclarationName(node *tree_sitter.Node) bool {
+	parent := node.Parent()
+	if parent == nil {
+		return false
+	}
+	name := parent.ChildByFieldName("name")
+	return name != nil && name.Id() == node.Id()
+}
+
+// quoteSyntaxError quotes the Java source of an ERROR node, shortened to its
+// first maxSyntaxErrorText bytes
+func quoteSyntaxError(text string) string {
+	if len(text) > maxSyntaxErrorText {
+		return fmt.Sprintf("%q...", text[:maxSyntaxErrorText])
+	}
+	return fmt.Sprintf("%q", text)
+}
+
+// brokenDeclaration reports whether node, a member or top-level declaration,
+// has a syntax error that keeps it from being migrated. Errors inside the
+// members of a type declaration only break those members, which are skipped
+// on their own.
+func brokenDeclaration(node *tree_sitter.Node) bool {
+	switch {
+	case !node.HasError():
+		return false
+	case node.IsError(), node.IsMissing(), !slices.Contains(typeDeclarationKinds, nodeKind(node)):
+		return true
+	}
+	body := node.ChildByFieldName("body")
+	broken := false
+	IterateChildren(node, func(child *tree_sitter.Node) {
+		broken = broken || (child.HasError() && (body == nil || child.Id() != body.Id()))
+	})
+	return broken
+}
+
+// syntaxFailure returns the placeholder of a declaration skipped for a
+// syntax error. The error itself was reported by checkSyntax.
+func syntaxFailure(ctx *MigrationContext, location string, node *tree_sitter.Node) *gosrc.FailedMigration {
+	return &gosrc.FailedMigration{
+		ErrorMessage: "skipped since the Java source has a syntax error",
+		JavaSource:   ctx.nodeText(node),
+		SExpr:        node.ToSexp(),
+		Location:     location,
+	}
+}
@@ -0,0 +1,106 @@
+package java
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// defaultSystemProperties maps the well-known system properties to the Go
+// expressions they are migrated to. ctx.SystemProperties can add to and
+// override these.
+var defaultSystemProperties = map[string]string{
+	"line.separator": `"\n"`,
+	"file.separator": "string(filepath.Separator)",
+	"path.separator": "string(filepath.ListSeparator)",
+	"os.name":        "runtime.GOOS",
+	"os.arch":        "runtime.GOARCH",
+	"java.io.tmpdir": "os.TempDir()",
+}
+
+// standardPackages maps the names of standard library packages configured Go
+// source may use to their import paths
+var standardPackages = map[string]string{
+	"errors":   "errors",
+	"filepath": "path/filepath",
+	"fmt":      "fmt",
+	"log":      "log",
+	"os":       "os",
+	"runtime":  "runtime",
+	"strconv":  "strconv",
+	"strings":  "strings",
+}
+
+var qualifiedIdentifier = regexp.MustCompile(`\b([a-z][a-z0-9]*)\.[A-Z]`)
+
+// systemProperty returns the Go expression the system property key is
+// migrated to, importing the standard packages it uses
+func (ctx *MigrationContext) systemProperty(key string) (gosrc.Expression, bool) {
+	source, ok := ctx.SystemProperties[key]
+	if !ok {
+		source, ok = defaultSystemProperties[key]
+	}
+	if !ok {
+		return nil, false
+	}
+	ctx.importStandardPackages(source)
+	return ctx.arena.GoExpression(gosrc.GoExpression{Source: source}), true
+}
+
+// importStandardPackages imports the standard packages the configured Go
+// source uses
+func (ctx *MigrationContext) importStandardPackages(source string) {
+	for _, match := range qualifiedIdentifier.FindAllStringSubmatch(source, -1) {
+		if path, ok := standardPackages[match[1]]; ok {
+			ctx.Source.AddImport(path)
+		}
+	}
+}
+
+// convertSystemMethod converts calls of the static methods of
+// java.lang.System that read the environment the program runs in
+func convertSystemMethod(ctx *MigrationContext, expression *tree_sitter.Node, name string) (gosrc.Expression, []gosrc.Statement, bool) {
+	argNodes := argumentNodes(expression.ChildByFieldName("arguments"))
+	switch {
+	case name == "getProperty" && (len(argNodes) == 1 || len(argNodes) == 2):
+		converted, initStmts := convertGetProperty(ctx, expression, argNodes)
+		return converted, initStmts, true
+	case name == "lineSeparator" && len(argNodes) == 0:
+		converted, _ := ctx.systemProperty("line.separator")
+		return converted, nil, true
+	case name == "getenv" && len(argNodes) == 1:
+		args, initStmts := convertArguments(ctx, expression.ChildByFieldName("arguments"))
+		ctx.Source.AddImport("os")
+		return &gosrc.CallExpression{Function: "os.Getenv", Args: args}, initStmts, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// convertGetProperty converts System.getProperty(key) and
+// System.getProperty(key, def) to the Go expression of the property. Keys
+// without one are reported and migrated to def, or to the empty string, as
+// if the property was not set.
+func convertGetProperty(ctx *MigrationContext, expression *tree_sitter.Node, argNodes []*tree_sitter.Node) (gosrc.Expression, []gosrc.Statement) {
+	keyNode := argNodes[0]
+	key, err := strconv.Unquote(ctx.nodeText(keyNode))
+	var msg string
+	switch {
+	case nodeKind(keyNode) != "string_literal" || err != nil:
+		msg = fmt.Sprintf("system property key %s is not a constant", ctx.nodeText(keyNode))
+	default:
+		if converted, ok := ctx.systemProperty(key); ok {
+			return converted, nil
+		}
+		msg = fmt.Sprintf("no Go equivalent of system property %q, set one in system_properties", key)
+	}
+	reportDiagnostic(ctx, expression, ErrSystemProperty, msg)
+	if len(argNodes) == 2 {
+		return convertExpression(ctx, argNodes[1])
+	}
+	return ctx.arena.GoExpression(gosrc.GoExpression{Source: `""`}), nil
+}
@@ -0,0 +1,143 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Config holds the options controlling a single in-memory migration
+type Config struct {
+	FileName               string // Name used in migration comments, defaults to "input.java"
+	PackageName            string // Go package name, defaults to gosrc.PackageName
+	LicenseHeader          string
+	Strictness             Strictness // Note: errors of strict categories exit the process
+	TypeMappings           map[string]string
+	PackageMappings        map[string]string
+	StubOnly               bool
+	OnlyMethods            map[string]bool
+	ManualMethods          map[string]bool
+	DeepCopy               bool
+	FlatNestedClasses      bool
+	ClassLiterals          string
+	Annotations            string
+	Optionals              string
+	SystemProperties       map[string]string
+	Exceptions             map[string]string
+	PrivateHelperFunctions bool
+}
+
+// options returns the migration options of cfg
+func (cfg Config) options() Options {
+	return Options{
+		PackageMappings:        cfg.PackageMappings,
+		StubOnly:               cfg.StubOnly,
+		OnlyMethods:            cfg.OnlyMethods,
+		ManualMethods:          cfg.ManualMethods,
+		DeepCopy:               cfg.DeepCopy,
+		FlatNestedClasses:      cfg.FlatNestedClasses,
+		ClassLiterals:          cfg.ClassLiterals,
+		Annotations:            cfg.Annotations,
+		Optionals:              cfg.Optionals,
+		SystemProperties:       cfg.SystemProperties,
+		ExceptionPolicies:      cfg.Exceptions,
+		PrivateHelperFunctions: cfg.PrivateHelperFunctions,
+	}
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.FileName == "" {
+		cfg.FileName = "input.java"
+	}
+	if cfg.PackageName == "" {
+		cfg.PackageName = gosrc.PackageName
+	}
+	return cfg
+}
+
+// Fixture bundles a parsed Java source with a migration context so tests of
+// custom passes don't have to repeat the parse/context boilerplate. Call
+// Close when done to release the tree-sitter tree.
+type Fixture struct {
+	Ctx    *MigrationContext
+	Tree   *tree_sitter.Tree
+	Config Config
+}
+
+// NewFixture parses src and creates a migration context for it without
+// running any migration phase
+func NewFixture(src string, cfg Config) *Fixture {
+	cfg = cfg.withDefaults()
+	javaSource := []byte(src)
+	ctx := NewMigrationContext(javaSource, cfg.FileName, cfg.Strictness, cfg.TypeMappings)
+	ApplyOptions(ctx, cfg.options())
+	return &Fixture{
+		Ctx:    ctx,
+		Tree:   ParseJava(javaSource),
+		Config: cfg,
+	}
+}
+
+// Migrate runs both migration phases and returns the generated Go source
+func (f *Fixture) Migrate() string {
+	MigrateTree(f.Ctx, f.Tree)
+	return f.Ctx.Source.ToSource(f.Config.LicenseHeader, f.Config.PackageName)
+}
+
+// Close releases the underlying tree-sitter tree
+func (f *Fixture) Close() {
+	f.Tree.Close()
+}
+
+// MigrateString migrates the Java source src and returns the generated Go
+// source along with any errors collected during migration
+func MigrateString(src string, cfg Config) (string, []MigrationError) {
+	fixture := NewFixture(src, cfg)
+	defer fixture.Close()
+	goSource := fixture.Migrate()
+	return goSource, fixture.Ctx.Errors
+}
+
+// JavaClass builds the source of a Java class named name with the given
+// member declarations, one per line
+func JavaClass(name string, members ...string) string {
+	return javaTypeDeclaration("class", name, members)
+}
+
+// JavaInterface builds the source of a Java interface named name with the
+// given member declarations, one per line
+func JavaInterface(name string, members ...string) string {
+	return javaTypeDeclaration("interface", name, members)
+}
+
+// JavaMethod builds the source of a Java method from its signature and body
+// statements, e.g. JavaMethod("int one()", "return 1;")
+func JavaMethod(signature string, statements ...string) string {
+	sb := strings.Builder{}
+	sb.WriteString(signature)
+	sb.WriteString(" {\n")
+	for _, stmt := range statements {
+		sb.WriteString("        ")
+		sb.WriteString(stmt)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("    }")
+	return sb.String()
+}
+
+func javaTypeDeclaration(keyword, name string, members []string) string {
+	sb := strings.Builder{}
+	sb.WriteString(keyword)
+	sb.WriteString(" ")
+	sb.WriteString(name)
+	sb.WriteString(" {\n")
+	for _, member := range members {
+		sb.WriteString("    ")
+		sb.WriteString(member)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
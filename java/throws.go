@@ -0,0 +1,177 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// How throw statements are migrated, selected per exception by
+// MigrationContext.ExceptionPolicies. Any other policy is a Go statement in
+// which $error stands for the exception.
+const (
+	// ExceptionsPanic panics with the exception
+	ExceptionsPanic = "panic"
+	// ExceptionsErrorReturn returns the exception as the error of the method
+	// throwing it, or panics where it can't be returned. It is the default.
+	ExceptionsErrorReturn = "error-return"
+)
+
+// errorPlaceholder stands for the exception in custom exception policies
+const errorPlaceholder = "$error"
+
+// errorVar is the name of the variables holding the errors returned by calls
+// of methods that throw
+const errorVar = "err"
+
+// throwingMethod returns the metadata of the method whose body node is in if
+// that method throws and errors at node can be returned from it. Try blocks
+// and lambdas are migrated to closures, so errors inside them panic instead.
+func throwingMethod(ctx *MigrationContext, node *tree_sitter.Node) (methodMetadata, bool) {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch nodeKind(parent) {
+		case "method_declaration":
+			metadata := getMethodMetadata(ctx, parent)
+			return metadata, metadata.throws
+		case "try_statement", "try_with_resources_statement", "lambda_expression", "class_body":
+			return methodMetadata{}, false
+		}
+	}
+	return methodMetadata{}, false
+}
+
+// errorReturn returns the statement returning err from the method of
+// metadata, along with the zero value of its result if it has one
+func errorReturn(ctx *MigrationContext, metadata methodMetadata, err gosrc.Expression) gosrc.Statement {
+	if metadata.valueTy == nil {
+		return &gosrc.ReturnStatement{Value: err}
+	}
+	return &gosrc.ReturnStatement{Value: ctx.arena.GoExpression(gosrc.GoExpression{
+		Source: zeroValue(ctx, *metadata.valueTy) + ", " + err.ToSource(),
+	})}
+}
+
+// zeroValue returns the Go source of the zero value of ty
+func zeroValue(ctx *MigrationContext, ty gosrc.Type) string {
+	source := string(ty)
+	_, isStruct := ctx.Fields[source]
+	_, hasConstructor := ctx.Constructors[ty]
+	switch {
+	case ty == gosrc.TypeInt || ty == typeInt64 || ty == gosrc.TypeFloat64:
+		return "0"
+	case ty == gosrc.TypeString:
+		return `""`
+	case ty == gosrc.TypeBool:
+		return "false"
+	case source == "error" || source == "any" || source == "interface{}":
+		return "nil"
+	case strings.HasPrefix(source, "*") || strings.HasPrefix(source, "[]") ||
+		strings.HasPrefix(source, "map[") || strings.HasPrefix(source, "func("):
+		return "nil"
+	case isStruct || hasConstructor:
+		return source + "{}"
+	default:
+		return "*new(" + source + ")"
+	}
+}
+
+// thrownType returns the Java name of the exception valueNode evaluates to,
+// without package and type arguments, or "" if it is not known
+func thrownType(ctx *MigrationContext, valueNode *tree_sitter.Node) string {
+	var typeNode *tree_sitter.Node
+	switch nodeKind(valueNode) {
+	case "object_creation_expression":
+		typeNode = valueNode.ChildByFieldName("type")
+	case "identifier":
+		typeNode = declaredType(ctx, valueNode, ctx.nodeText(valueNode))
+	}
+	if typeNode == nil {
+		return ""
+	}
+	if nodeKind(typeNode) == "generic_type" {
+		typeNode = typeNode.NamedChild(0)
+	}
+	name := ctx.nodeText(typeNode)
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// throwStatement returns the statement throwing err, an exception of type
+// exception, at node the way ctx.ExceptionPolicies asks for
+func throwStatement(ctx *MigrationContext, node *tree_sitter.Node, exception string, err gosrc.Expression) gosrc.Statement {
+	policy := ctx.ExceptionPolicies[exception]
+	switch policy {
+	case ExceptionsPanic:
+		return panicStatement(err)
+	case "", ExceptionsErrorReturn:
+		return propagateError(ctx, node, err)
+	default:
+		ctx.importStandardPackages(policy)
+		return &gosrc.GoStatement{Source: strings.ReplaceAll(policy, errorPlaceholder, err.ToSource())}
+	}
+}
+
+// panicStatement returns the statement panicking with err
+func panicStatement(err gosrc.Expression) gosrc.Statement {
+	return &gosrc.GoStatement{Source: "panic(" + err.ToSource() + ")"}
+}
+
+// propagateError returns the statement handling err where a call at node
+// returned it: it is returned from a method that throws, and panics
+// anywhere else like the exception would have unwound the stack
+func propagateError(ctx *MigrationContext, node *tree_sitter.Node, err gosrc.Expression) gosrc.Statement {
+	if metadata, ok := throwingMethod(ctx, node); ok {
+		return errorReturn(ctx, metadata, err)
+	}
+	return panicStatement(err)
+}
+
+// throwingFunction reports whether the method convertedName, called name in
+// Java, throws, and returns its signature
+func throwingFunction(ctx *MigrationContext, name, convertedName string) (FunctionData, bool) {
+	for _, function := range trackedMethods(ctx, name) {
+		if function.Name == convertedName {
+			return function, function.Throws
+		}
+	}
+	return FunctionData{}, false
+}
+
+// checkedCall converts the call of function, a method that throws, to
+// statements that check the error it returns and a reference to the value it
+// returns. Calls whose value is not used, or that return nothing but the
+// error, leave no value, so the returned expression is nil.
+func checkedCall(ctx *MigrationContext, expression *tree_sitter.Node, call *gosrc.CallExpression, function FunctionData) (gosrc.Expression, []gosrc.Statement) {
+	errRef := ctx.arena.GoExpression(gosrc.GoExpression{Source: errorVar})
+	check := &gosrc.IfStatement{
+		Condition: ctx.arena.GoExpression(gosrc.GoExpression{Source: errorVar + " != nil"}),
+		Body:      []gosrc.Statement{propagateError(ctx, expression, errRef)},
+	}
+	if function.ResultType == "" || nodeKind(expression.Parent()) == "expression_statement" {
+		results := errorVar
+		if function.ResultType != "" {
+			results = "_, " + errorVar
+		}
+		check.Condition = ctx.arena.GoExpression(gosrc.GoExpression{
+			Source: results + " := " + call.ToSource() + "; " + errorVar + " != nil",
+		})
+		return nil, []gosrc.Statement{check}
+	}
+	result := ctx.tempName(ctx.nodeText(expression.ChildByFieldName("name")) + "Result")
+	return ctx.arena.VarRef(gosrc.VarRef{Ref: result}), []gosrc.Statement{
+		&gosrc.GoStatement{Source: result + ", " + errorVar + " := " + call.ToSource()},
+		check,
+	}
+}
+
+// returnNilError ends the body of a void method that throws with a return of
+// a nil error, unless it already ends with a return
+func returnNilError(body []gosrc.Statement) []gosrc.Statement {
+	if len(body) > 0 {
+		if _, ok := body[len(body)-1].(*gosrc.ReturnStatement); ok {
+			return body
+		}
+	}
+	return append(body, &gosrc.ReturnStatement{Value: &gosrc.VarRef{Ref: "nil"}})
+}
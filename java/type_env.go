@@ -0,0 +1,85 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// typeEnvironment records the Go types of the parameters, local variables
+// and fields the member being migrated refers to, by their declaration.
+// Types that are not known are recorded as "".
+type typeEnvironment map[variableKey]gosrc.Type
+
+// variableKey identifies a declared variable by the type node declaring it,
+// and its name, since a declaration can declare several variables
+type variableKey struct {
+	declaration uint
+	name        string
+}
+
+// variableType returns the Go type of the variable called name in scope at
+// node, which is the type it is declared with, or for a local declared with
+// var, the type of its initializer
+func (ctx *MigrationContext) variableType(node *tree_sitter.Node, name string) (gosrc.Type, bool) {
+	typeNode := declaredType(ctx, node, name)
+	if typeNode == nil {
+		return "", false
+	}
+	key := variableKey{declaration: typeNode.StartByte(), name: name}
+	if ty, ok := ctx.variableTypes[key]; ok {
+		return ty, ty != ""
+	}
+	if ctx.variableTypes == nil {
+		ctx.variableTypes = make(typeEnvironment)
+	}
+	// Recorded before it is known, so a variable referred to in its own
+	// initializer is not looked up endlessly
+	ctx.variableTypes[key] = ""
+	ty, ok := TryParseType(ctx, typeNode)
+	if ctx.nodeText(typeNode) == "var" {
+		ty, ok = initializerType(ctx, typeNode.Parent(), name)
+	}
+	if ok {
+		ctx.variableTypes[key] = ty
+	}
+	return ty, ok
+}
+
+// initializerType returns the Go type of the value the variable called name
+// is initialized with in declaration
+func initializerType(ctx *MigrationContext, declaration *tree_sitter.Node, name string) (gosrc.Type, bool) {
+	var value *tree_sitter.Node
+	IterateChildren(declaration, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "variable_declarator" && ctx.nodeText(child.ChildByFieldName("name")) == name {
+			value = child.ChildByFieldName("value")
+		}
+	})
+	switch {
+	case value == nil:
+		return "", false
+	case nodeKind(value) == "object_creation_expression":
+		return TryParseType(ctx, value.ChildByFieldName("type"))
+	default:
+		return valueType(ctx, value)
+	}
+}
+
+// isCollectionType reports whether ty is a slice or map a collection, array
+// or map migrates to, or a pointer to a slice a List parameter migrates to
+func isCollectionType(ty gosrc.Type) bool {
+	return strings.HasPrefix(strings.TrimPrefix(string(ty), "*"), "[]") || strings.HasPrefix(string(ty), "map[")
+}
+
+// hasNonCollectionType reports whether the type of expression is known and
+// is not a collection, such as a class declaring methods called like the
+// methods of collections
+func hasNonCollectionType(ctx *MigrationContext, expression *tree_sitter.Node) bool {
+	if expression == nil {
+		return false
+	}
+	ty, ok := valueType(ctx, expression)
+	return ok && !isCollectionType(ty) && ty != stringBuilderType
+}
@@ -3,6 +3,8 @@ package java
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/heshanpadmasiri/javaGo/gosrc"
@@ -77,8 +79,8 @@ func ParseModifiers(source string) modifiers {
 func HasModifier(ctx *MigrationContext, methodNode *tree_sitter.Node, modifier string) bool {
 	hasModifier := false
 	IterateChildren(methodNode, func(child *tree_sitter.Node) {
-		if child.Kind() == "modifiers" {
-			modText := child.Utf8Text(ctx.JavaSource)
+		if nodeKind(child) == "modifiers" {
+			modText := ctx.nodeText(child)
 			if strings.Contains(modText, modifier) {
 				hasModifier = true
 			}
@@ -107,7 +109,7 @@ func parseTypeArguments(ctx *MigrationContext, typeArgsNode *tree_sitter.Node) [
 		var parsedType gosrc.Type
 		var ok bool
 
-		switch child.Kind() {
+		switch nodeKind(child) {
 		case "wildcard":
 			// Convert Java wildcards (?, ? extends T, ? super T) to Go 'any'
 			parsedType = gosrc.Type("any")
@@ -125,17 +127,289 @@ func parseTypeArguments(ctx *MigrationContext, typeArgsNode *tree_sitter.Node) [
 	return typeParams
 }
 
+// parseTypeParameters converts the type_parameters of a generic class,
+// interface or method to Go type parameters. Unbounded parameters are
+// constrained by any, Comparable bounds by cmp.Ordered, and other bounds by
+// the bounding type itself.
+func parseTypeParameters(ctx *MigrationContext, typeParamsNode *tree_sitter.Node) []gosrc.TypeParam {
+	var params []gosrc.TypeParam
+	IterateChildren(typeParamsNode, func(child *tree_sitter.Node) {
+		if nodeKind(child) != "type_parameter" {
+			return
+		}
+		param := gosrc.TypeParam{Constraint: "any"}
+		IterateChildren(child, func(part *tree_sitter.Node) {
+			switch nodeKind(part) {
+			case "type_identifier":
+				param.Name = ctx.nodeText(part)
+			case "type_bound":
+				param.Constraint = typeBoundConstraint(ctx, part)
+			}
+		})
+		params = append(params, param)
+	})
+	return params
+}
+
+// typeBoundConstraint converts the bound of a type parameter, such as
+// extends Shape & Comparable<Shape>, to a Go constraint
+func typeBoundConstraint(ctx *MigrationContext, boundNode *tree_sitter.Node) gosrc.Type {
+	var bounds []string
+	IterateChildren(boundNode, func(child *tree_sitter.Node) {
+		switch nodeKind(child) {
+		case "extends", "&":
+			return
+		}
+		if isComparableBound(ctx, child) {
+			bounds = append(bounds, "cmp.Ordered")
+			return
+		}
+		if ty, ok := TryParseType(ctx, child); ok {
+			bounds = append(bounds, string(ty))
+		}
+	})
+	switch len(bounds) {
+	case 0:
+		return "any"
+	case 1:
+		return gosrc.Type(bounds[0])
+	default:
+		return gosrc.Type("interface{ " + strings.Join(bounds, "; ") + " }")
+	}
+}
+
+// isComparableBound reports whether a type in the bound of a type parameter
+// is Comparable<T>, which is migrated to cmp.Ordered
+func isComparableBound(ctx *MigrationContext, typeNode *tree_sitter.Node) bool {
+	return nodeKind(typeNode) == "generic_type" && ctx.nodeText(typeNode.NamedChild(0)) == "Comparable"
+}
+
+// isOrderedTypeParameter reports whether the static type of expression is a
+// type parameter in scope at it bounded by Comparable, which is constrained by
+// cmp.Ordered. Type parameters of instance methods are erased to any, so they
+// are not.
+func isOrderedTypeParameter(ctx *MigrationContext, expression *tree_sitter.Node) bool {
+	ty, ok := staticType(ctx, expression)
+	if !ok {
+		return false
+	}
+	for node := expression.Parent(); node != nil; node = node.Parent() {
+		typeParams := node.ChildByFieldName("type_parameters")
+		if typeParams == nil {
+			continue
+		}
+		var param *tree_sitter.Node
+		IterateChildren(typeParams, func(child *tree_sitter.Node) {
+			if nodeKind(child) == "type_parameter" && ctx.nodeText(child.NamedChild(0)) == string(ty) {
+				param = child
+			}
+		})
+		if param == nil {
+			continue
+		}
+		if nodeKind(node) == "method_declaration" && declarationModifiers(ctx, node)&STATIC == 0 {
+			return false
+		}
+		ordered := false
+		IterateChildren(param, func(part *tree_sitter.Node) {
+			if nodeKind(part) == "type_bound" {
+				IterateChildren(part, func(bound *tree_sitter.Node) {
+					ordered = ordered || isComparableBound(ctx, bound)
+				})
+			}
+		})
+		return ordered
+	}
+	return false
+}
+
+// importConstraints adds the imports needed by the constraints of params
+func importConstraints(ctx *MigrationContext, params []gosrc.TypeParam) {
+	for _, param := range params {
+		if strings.Contains(string(param.Constraint), "cmp.") {
+			ctx.Source.AddImport("cmp")
+		}
+	}
+}
+
+// eraseTypeParams replaces the type parameters params in ty by their
+// constraints. Go methods can't have type parameters of their own, so this is
+// how generic instance methods are migrated.
+func eraseTypeParams(ty gosrc.Type, params []gosrc.TypeParam) gosrc.Type {
+	for _, param := range params {
+		erased := param.Constraint
+		if strings.Contains(string(erased), "cmp.") {
+			// Constraints that are not plain interfaces can't be used as types
+			erased = "any"
+		}
+		pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(param.Name) + `\b`)
+		ty = gosrc.Type(pattern.ReplaceAllLiteralString(string(ty), string(erased)))
+	}
+	return ty
+}
+
+// typeDeclarationKinds are the node kinds that declare a named type
+var typeDeclarationKinds = []string{"class_declaration", "enum_declaration", "interface_declaration", "record_declaration"}
+
+// collectTypeName records the Go name typeNode is declared with in
+// ctx.TypeNames under its qualified name, so references to it are resolved to
+// the hoisted name of a nested type, or the unexported name of a package
+// private one
+func collectTypeName(ctx *MigrationContext, typeNode *tree_sitter.Node) {
+	ctx.TypeNames[qualifiedTypeName(ctx, typeNode)] = goTypeName(ctx, typeNode)
+}
+
+// collectClass records the names concrete classes are referred to by in
+// ctx.Classes. Abstract classes become interfaces, which are referred to as
+// they are.
+func collectClass(ctx *MigrationContext, typeNode *tree_sitter.Node) {
+	if nodeKind(typeNode) != "class_declaration" {
+		return
+	}
+	name := ctx.nodeText(typeNode.ChildByFieldName("name"))
+	if ctx.AbstractClasses[name] {
+		return
+	}
+	ctx.Classes[goTypeName(ctx, typeNode)] = true
+}
+
+// collectDefaultConstructor registers the constructor Java gives a class
+// declaring none, so new Foo() finds it like any declared one. The enclosing
+// instance an inner class also takes is added where it is created.
+func collectDefaultConstructor(ctx *MigrationContext, typeNode *tree_sitter.Node) {
+	if nodeKind(typeNode) != "class_declaration" || ctx.AbstractClasses[ctx.nodeText(typeNode.ChildByFieldName("name"))] {
+		return
+	}
+	declared := false
+	IterateChildren(typeNode.ChildByFieldName("body"), func(member *tree_sitter.Node) {
+		if nodeKind(member) == "constructor_declaration" {
+			declared = true
+		}
+	})
+	if declared {
+		return
+	}
+	ty := gosrc.Type(goTypeName(ctx, typeNode))
+	isPublic := declarationModifiers(ctx, typeNode)&PUBLIC != 0
+	ctx.Constructors[ty] = append(ctx.Constructors[ty], FunctionData{Name: constructorName(ctx, isPublic, ty)})
+}
+
+// enclosingTypeDeclaration returns the innermost type declaration containing
+// node, or nil if node is not inside one
+func enclosingTypeDeclaration(node *tree_sitter.Node) *tree_sitter.Node {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		if slices.Contains(typeDeclarationKinds, nodeKind(parent)) {
+			return parent
+		}
+	}
+	return nil
+}
+
+// qualifiedTypeName returns the name of typeNode qualified by the names of
+// the types it is nested in, such as Outer.Inner
+func qualifiedTypeName(ctx *MigrationContext, typeNode *tree_sitter.Node) string {
+	name := ctx.nodeText(typeNode.ChildByFieldName("name"))
+	if outer := enclosingTypeDeclaration(typeNode); outer != nil {
+		return qualifiedTypeName(ctx, outer) + "." + name
+	}
+	return name
+}
+
+// goTypeName returns the name the Go type migrated from typeNode is declared
+// with. Nested types are hoisted to the top level and keep their own name,
+// except static nested classes, which are prefixed with the Go name of the
+// enclosing type unless ctx.FlatNestedClasses is set.
+func goTypeName(ctx *MigrationContext, typeNode *tree_sitter.Node) string {
+	name := ctx.nodeText(typeNode.ChildByFieldName("name"))
+	var modifiers modifiers
+	IterateChildren(typeNode, func(child *tree_sitter.Node) {
+		if nodeKind(child) == "modifiers" {
+			modifiers = ParseModifiers(ctx.nodeText(child))
+		}
+	})
+	switch nodeKind(typeNode) {
+	case "interface_declaration":
+		return gosrc.CapitalizeFirstLetter(name)
+	case "enum_declaration":
+		return gosrc.ToIdentifier(name, enumIsPublic(modifiers))
+	case "class_declaration":
+		if ctx.AbstractClasses[name] || extendsAbstractClass(ctx, typeNode) {
+			// Abstract classes become interfaces, and their subclasses are
+			// always exported
+			return gosrc.CapitalizeFirstLetter(name)
+		}
+		outer := enclosingTypeDeclaration(typeNode)
+		if outer != nil && modifiers&STATIC != 0 && !ctx.FlatNestedClasses {
+			name = goTypeName(ctx, outer) + gosrc.CapitalizeFirstLetter(name)
+		}
+		return gosrc.ToIdentifier(name, modifiers.isPublic())
+	default:
+		return gosrc.ToIdentifier(name, modifiers.isPublic())
+	}
+}
+
+// extendsAbstractClass reports whether the superclass of classNode is one of
+// ctx.AbstractClasses
+func extendsAbstractClass(ctx *MigrationContext, classNode *tree_sitter.Node) bool {
+	superclass := classNode.ChildByFieldName("superclass")
+	if superclass == nil || superclass.NamedChildCount() == 0 {
+		return false
+	}
+	return ctx.AbstractClasses[ctx.nodeText(superclass.NamedChild(0))]
+}
+
+// resolveTypeName returns the Go name of the declared type javaName refers
+// to, such as Outer.Inner
+func (ctx *MigrationContext) resolveTypeName(javaName string) (string, bool) {
+	goName, ok := ctx.TypeNames[javaName]
+	return goName, ok
+}
+
+// resolveTypeReference returns the Go name of the declared type javaName
+// refers to at node. Besides qualified names, this resolves simple names of
+// types nested in a type enclosing node, innermost first, the way Java scopes
+// them.
+func (ctx *MigrationContext) resolveTypeReference(node *tree_sitter.Node, javaName string) (string, bool) {
+	if len(ctx.TypeNames) == 0 {
+		return "", false
+	}
+	for scope := enclosingTypeDeclaration(node); scope != nil; scope = enclosingTypeDeclaration(scope) {
+		if goName, ok := ctx.resolveTypeName(qualifiedTypeName(ctx, scope) + "." + javaName); ok {
+			return goName, true
+		}
+	}
+	return ctx.resolveTypeName(javaName)
+}
+
+// referenceType returns the type values of the Go type goName are referred to
+// by. Methods of classes have pointer receivers and constructors return
+// pointers, so classes are referred to by pointers.
+func (ctx *MigrationContext) referenceType(goName string) gosrc.Type {
+	if ctx.Classes[goName] {
+		return gosrc.Type("*" + goName)
+	}
+	return gosrc.Type(goName)
+}
+
+// structType returns the struct type a class is referred to by ty through
+func structType(ty gosrc.Type) gosrc.Type {
+	return gosrc.Type(strings.TrimPrefix(string(ty), "*"))
+}
+
 // TryParseType attempts to parse a tree-sitter node into a Go type
 func TryParseType(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Type, bool) {
-	switch node.Kind() {
+	switch nodeKind(node) {
 	case "scoped_type_identifier":
+		if goName, ok := ctx.resolveTypeReference(node, ctx.nodeText(node)); ok {
+			return ctx.referenceType(goName), true
+		}
 		// For scoped types like Atom.Kind, we only use the second part (Kind)
 		// since Go doesn't have nested types
 		var typeName string
 		// The last type_identifier child is the actual type we want
 		IterateChildren(node, func(child *tree_sitter.Node) {
-			if child.Kind() == "type_identifier" {
-				typeName = child.Utf8Text(ctx.JavaSource)
+			if nodeKind(child) == "type_identifier" {
+				typeName = ctx.nodeText(child)
 			}
 		})
 		if typeName == "" {
@@ -155,10 +429,13 @@ func TryParseType(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Type, bo
 			return gosrc.Type(goType), true
 		}
 		goType = toGoType(ctx, typeName)
-		return gosrc.Type(goType), true
+		return ctx.referenceType(goType), true
 	case "type_identifier":
 		var goType string
-		typeName := node.Utf8Text(ctx.JavaSource)
+		typeName := ctx.nodeText(node)
+		if goName, ok := ctx.resolveTypeReference(node, typeName); ok {
+			return ctx.referenceType(goName), true
+		}
 		unwantedPrefixes := []string{"Abstract", "LexerTerminals", "ST"}
 		for _, prefix := range unwantedPrefixes {
 			if strings.HasPrefix(typeName, prefix) {
@@ -171,8 +448,11 @@ func TryParseType(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Type, bo
 			return gosrc.Type(goType), true
 		}
 		goType = toGoType(ctx, typeName)
-		return gosrc.Type(goType), true
+		return ctx.referenceType(goType), true
 	case "integral_type":
+		if ctx.nodeText(node) == "long" {
+			return typeInt64, true
+		}
 		return gosrc.TypeInt, true
 	case "boolean_type":
 		return gosrc.TypeBool, true
@@ -184,7 +464,9 @@ func TryParseType(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Type, bo
 		if !ok {
 			fatalTypeError(ctx, typeNode, errors.New("unable to parse element type in array_type"))
 		}
-		return gosrc.Type("[]" + ty), true
+		// int[][] has a single dimensions node spanning both brackets
+		dimensions := strings.Count(ctx.nodeText(node.ChildByFieldName("dimensions")), "[")
+		return gosrc.Type(strings.Repeat("[]", max(dimensions, 1)) + string(ty)), true
 	case "wildcard":
 		// Java wildcards (?, ? extends Foo, ? super Bar) -> Go 'any'
 		return gosrc.Type("any"), true
@@ -200,9 +482,9 @@ func TryParseType(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Type, bo
 		var typeArgsNode *tree_sitter.Node
 
 		IterateChildren(node, func(child *tree_sitter.Node) {
-			switch child.Kind() {
+			switch nodeKind(child) {
 			case "type_identifier":
-				typeName = child.Utf8Text(ctx.JavaSource)
+				typeName = ctx.nodeText(child)
 			case "type_arguments":
 				typeArgsNode = child
 			}
@@ -216,14 +498,14 @@ func TryParseType(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Type, bo
 
 		// Step 3: Special conversions for known collection types (backward compatibility)
 		switch typeName {
-		case "ArrayDeque", "Deque", "Collection", "ArrayList", "List":
+		case "ArrayDeque", "Deque", "Queue", "Stack", "LinkedList", "Collection", "ArrayList", "List":
 			Assert("List can have only one type param", len(typeParams) < 2)
 			if len(typeParams) == 0 {
 				return gosrc.Type("[]interface{}"), true
 			}
 			return gosrc.Type("[]" + typeParams[0]), true
 
-		case "HashMap", "Map":
+		case "HashMap", "Map", "EnumMap":
 			Assert("Map can have at most two type params", len(typeParams) < 3)
 			if len(typeParams) == 0 {
 				return gosrc.Type("map[interface{}]interface{}"), true
@@ -232,26 +514,49 @@ func TryParseType(ctx *MigrationContext, node *tree_sitter.Node) (gosrc.Type, bo
 				return gosrc.Type("map[" + typeParams[0] + "]interface{}"), true
 			}
 			return gosrc.Type("map[" + typeParams[0] + "]" + typeParams[1]), true
+
+		case "HashSet", "Set", "EnumSet":
+			Assert("Set can have only one type param", len(typeParams) < 2)
+			if len(typeParams) == 0 {
+				return gosrc.Type("map[interface{}]bool"), true
+			}
+			return gosrc.Type("map[" + typeParams[0] + "]bool"), true
+		}
+
+		if _, mapped := ctx.TypeMappings[typeName]; typeName == "Optional" && len(typeParams) == 1 && !mapped {
+			return ctx.optionalType(typeParams[0]), true
+		}
+
+		// Functional interfaces become function types
+		if ctx.isFunctionalInterface(typeName) {
+			return functionalType(typeName, typeParams)
 		}
 
-		// Step 4: Default case - apply type mapping and build generic syntax
+		// Step 4: Default case - resolve declared types, apply type mapping and
+		// build generic syntax
 		baseType := toGoType(ctx, typeName)
+		if goName, ok := ctx.resolveTypeReference(node, typeName); ok {
+			baseType = goName
+		}
 
 		if len(typeParams) == 0 {
 			// Raw generic without type parameters (e.g., Optional without <T>)
-			return gosrc.Type(baseType), true
+			return ctx.referenceType(baseType), true
 		}
 
-		// Build Go generic syntax: BaseType[T1,T2,...] (no spaces)
+		// Build Go generic syntax: BaseType[T1, T2, ...]
 		result := baseType + "["
 		for i, param := range typeParams {
 			if i > 0 {
-				result += ","
+				result += ", "
 			}
 			result += string(param)
 		}
 		result += "]"
 
+		if ctx.Classes[baseType] {
+			result = "*" + result
+		}
 		return gosrc.Type(result), true
 	}
 	return "", false
@@ -261,6 +566,9 @@ func toGoType(ctx *MigrationContext, javaTy string) (goType string) {
 	if configTy, ok := ctx.TypeMappings[javaTy]; ok {
 		return configTy
 	}
+	if importedTy, ok := ctx.importedType(javaTy); ok {
+		return importedTy
+	}
 	switch javaTy {
 	case "Object":
 		goType = "interface{}"
@@ -269,11 +577,20 @@ func toGoType(ctx *MigrationContext, javaTy string) (goType string) {
 	case "Integer":
 		goType = "int"
 	case "Long":
-		goType = "int64"
+		goType = string(typeInt64)
 	case "Boolean":
 		goType = "bool"
+	case "StringBuilder", "StringBuffer":
+		ctx.Source.AddImport("strings")
+		goType = string(stringBuilderType)
 	default:
 		goType = javaTy
+		if standardExceptions[javaTy] {
+			goType = "error"
+		}
+		if fnType, ok := functionalType(javaTy, nil); ok {
+			goType = string(fnType)
+		}
 	}
 	return goType
 }
@@ -3,7 +3,9 @@ package java
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/heshanpadmasiri/javaGo/gosrc"
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
@@ -19,6 +21,60 @@ func ParseJava(source []byte) *tree_sitter.Tree {
 	return tree
 }
 
+// PackageName returns the dotted name in the package declaration of tree, or
+// "" if the file is in the unnamed package
+func PackageName(tree *tree_sitter.Tree, source []byte) string {
+	return packageDeclarationName(tree.RootNode(), source)
+}
+
+// packageDeclarationName returns the dotted name in the package declaration
+// of the program node root
+func packageDeclarationName(root *tree_sitter.Node, source []byte) string {
+	var name string
+	IterateChildrenWhile(root, func(child *tree_sitter.Node) bool {
+		if nodeKind(child) != "package_declaration" {
+			return true
+		}
+		IterateChildren(child, func(part *tree_sitter.Node) {
+			switch nodeKind(part) {
+			case "identifier", "scoped_identifier":
+				name = part.Utf8Text(source)
+			}
+		})
+		return false
+	})
+	return name
+}
+
+// javaKindNames maps grammar symbol ids to node kind names. Node.Kind
+// allocates a fresh string on every call, which adds up since every
+// conversion step dispatches on the kind.
+var javaKindNames = sync.OnceValue(func() []string {
+	language := tree_sitter.NewLanguage(tree_sitter_java.Language())
+	names := make([]string, language.NodeKindCount())
+	for id := range names {
+		names[id] = language.NodeKindForId(uint16(id))
+	}
+	return names
+})
+
+// nodeKind returns node.Kind() without allocating for grammar symbols
+func nodeKind(node *tree_sitter.Node) string {
+	names := javaKindNames()
+	id := int(node.KindId())
+	if id < len(names) {
+		return names[id]
+	}
+	// Builtin symbols such as ERROR are not part of the symbol table
+	return node.Kind()
+}
+
+// nodeText returns the Java source text of node. Unlike Node.Utf8Text it
+// does not allocate a new string on every call.
+func (ctx *MigrationContext) nodeText(node *tree_sitter.Node) string {
+	return ctx.javaText[node.StartByte():node.EndByte()]
+}
+
 // TryGetChildByFieldName attempts to find a child node by field name
 func TryGetChildByFieldName(node *tree_sitter.Node, fieldName string) *tree_sitter.Node {
 	for i := uint(0); i < node.ChildCount(); i++ {
@@ -32,61 +88,76 @@ func TryGetChildByFieldName(node *tree_sitter.Node, fieldName string) *tree_sitt
 
 // MigrationPanic represents a panic during migration with structured error information
 type MigrationPanic struct {
+	Code       ErrorCode
+	Category   Category
 	Message    string
 	JavaSource string
 	SExpr      string
 	NodeKind   string
 	ParentName string
+	Line       int
+	Column     int
 }
 
-// UnhandledChild reports an unhandled child node and exits (in strict mode) or panics (in non-strict mode)
-func UnhandledChild(ctx *MigrationContext, node *tree_sitter.Node, parentName string) {
-	msg := fmt.Sprintf("unhandled %s child node kind: %s\nS-expression: %s\nSource: %s",
-		parentName,
-		node.Kind(),
-		node.ToSexp(),
-		node.Utf8Text(ctx.JavaSource))
-
-	if ctx.StrictMode {
-		fmt.Fprintf(os.Stderr, "Fatal: %s\n", msg)
-		os.Exit(1)
-	}
+// nodeLineColumn returns the 1-based line and column at which node starts
+func nodeLineColumn(node *tree_sitter.Node) (int, int) {
+	pos := node.StartPosition()
+	return int(pos.Row) + 1, int(pos.Column) + 1
+}
 
-	// In non-strict mode, panic with structured error info
-	panic(MigrationPanic{
-		Message:    msg,
-		JavaSource: node.Utf8Text(ctx.JavaSource),
-		SExpr:      node.ToSexp(),
-		NodeKind:   node.Kind(),
-		ParentName: parentName,
-	})
+// UnhandledChild reports an unhandled child node and exits (if its category is strict) or panics (otherwise)
+func UnhandledChild(ctx *MigrationContext, node *tree_sitter.Node, parentName string) {
+	// The source and S-expression of node are left to the FIXME comment
+	// and migration notes rendering the failure
+	msg := fmt.Sprintf("unhandled %s child node kind: %s", parentName, nodeKind(node))
+	fatalError(ctx, node, ErrUnhandledNode, msg, parentName)
 }
 
-// FatalError reports a fatal error and exits (in strict mode) or panics (in non-strict mode)
+// FatalError reports a fatal error and exits (if its category is strict) or panics (otherwise)
 // This is useful for errors during type parsing or other operations where graceful recovery is desired
 func FatalError(ctx *MigrationContext, node *tree_sitter.Node, msg string, parentName string) {
-	if ctx.StrictMode {
+	fatalError(ctx, node, ErrConversionFailed, msg, parentName)
+}
+
+// fatalError is FatalError with an explicit error code
+func fatalError(ctx *MigrationContext, node *tree_sitter.Node, code ErrorCode, msg string, parentName string) {
+	if ctx.strict(node, code) {
 		fmt.Fprintf(os.Stderr, "Fatal: %s: %s\n", node.ToSexp(), msg)
 		os.Exit(1)
 	}
 
-	// In non-strict mode, panic with structured error info
+	// Otherwise, panic with structured error info
+	line, column := nodeLineColumn(node)
 	panic(MigrationPanic{
+		Code:       code,
+		Category:   errorCategory(ctx, node, code),
 		Message:    msg,
-		JavaSource: node.Utf8Text(ctx.JavaSource),
+		JavaSource: ctx.nodeText(node),
 		SExpr:      node.ToSexp(),
-		NodeKind:   node.Kind(),
+		NodeKind:   nodeKind(node),
 		ParentName: parentName,
+		Line:       line,
+		Column:     column,
 	})
 }
 
-// Assert checks a condition and exits with an error message if false
+// identifierText returns the text of nameNode, the name of declNode.
+// Partially broken sources can leave the name missing or empty, which is
+// reported as an error instead of producing an invalid Go identifier.
+func identifierText(ctx *MigrationContext, declNode, nameNode *tree_sitter.Node, parentName string) string {
+	if nameNode == nil || nameNode.IsMissing() || nameNode.StartByte() == nameNode.EndByte() {
+		fatalError(ctx, declNode, ErrMissingIdentifier, "missing name in "+parentName, parentName)
+	}
+	return ctx.nodeText(nameNode)
+}
+
+// Assert checks a condition and panics with an error message if false. The
+// panic is recovered like any other failure of the member being migrated.
 func Assert(msg string, condition bool) {
 	if condition {
 		return
 	}
-	fmt.Fprintf(os.Stderr, "Assertion failed: %s\n", msg)
-	os.Exit(1)
+	panic("assertion failed: " + msg)
 }
 
 // IterateChildren iterates over all children of a node and calls fn for each
@@ -96,8 +167,8 @@ func IterateChildren(node *tree_sitter.Node, fn func(child *tree_sitter.Node)) {
 	}
 	cursor := node.Walk()
 	children := node.Children(cursor)
-	for _, child := range children {
-		fn(&child)
+	for i := range children {
+		fn(&children[i])
 	}
 }
 
@@ -108,8 +179,8 @@ func IterateChildrenWhile(node *tree_sitter.Node, fn func(child *tree_sitter.Nod
 	}
 	cursor := node.Walk()
 	children := node.Children(cursor)
-	for _, child := range children {
-		if !fn(&child) {
+	for i := range children {
+		if !fn(&children[i]) {
 			return
 		}
 	}
@@ -122,7 +193,7 @@ func constructorName(ctx *MigrationContext, isPublic bool, ty gosrc.Type, params
 	}
 	constructors, hasConstructors := ctx.Constructors[ty]
 	if hasConstructors {
-		name, hasMatching := findOverloadedMethod(constructors, paramTys)
+		name, hasMatching := findOverloadedMethod(withoutFactories(constructors), paramTys)
 		if hasMatching {
 			return name
 		}
@@ -171,6 +242,20 @@ func tryGuessOverloadedMethod(methods []FunctionData, nParams int) (string, bool
 			name = fn.Name
 		}
 	}
+	if name != defaultName {
+		return name, true, multipeMatch
+	}
+	// Java only picks a varargs method when no other one fits
+	for _, fn := range methods {
+		if !acceptsArgumentCount(fn.ArgumentTypes, nParams) {
+			continue
+		}
+		if name != defaultName {
+			multipeMatch = true
+		} else {
+			name = fn.Name
+		}
+	}
 	return name, name != defaultName, multipeMatch
 }
 
@@ -182,23 +267,81 @@ func overloadedName(baseName string, args []gosrc.Type) string {
 	nameBuilder.WriteString(baseName)
 	nameBuilder.WriteString("With")
 	for _, ty := range args {
-		nameBuilder.WriteString(gosrc.CapitalizeFirstLetter(ty.ToSource()))
+		nameBuilder.WriteString(typeNamePart(ty))
 	}
 	return nameBuilder.String()
 }
 
+// typeNamePart returns the part of an overloaded name that stands for a
+// parameter of type ty. Pointers to classes are named after the class, and
+// types of other packages after their own name.
+func typeNamePart(ty gosrc.Type) string {
+	name := strings.ReplaceAll(ty.ToSource(), "*", "")
+	switch {
+	case strings.HasPrefix(name, "..."):
+		return typeNamePart(gosrc.Type(strings.TrimPrefix(name, "..."))) + "Varargs"
+	case strings.HasPrefix(name, "[]"):
+		return typeNamePart(gosrc.Type(strings.TrimPrefix(name, "[]"))) + "Array"
+	case isQualifiedType(name):
+		_, unqualified, _ := cutLastDot(name)
+		return gosrc.CapitalizeFirstLetter(unqualified)
+	default:
+		return gosrc.CapitalizeFirstLetter(name)
+	}
+}
+
+// tempName returns a name for a temporary variable based on base, adding a
+// numeric suffix if base was already used in the current member
+func (ctx *MigrationContext) tempName(base string) string {
+	if ctx.tempNames == nil {
+		ctx.tempNames = make(map[string]int)
+	}
+	count := ctx.tempNames[base]
+	ctx.tempNames[base] = count + 1
+	if count == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s%d", base, count+1)
+}
+
+// declaredNames returns the names of the parameters and local variables
+// declared in node, counted as handed out temporary names
+func declaredNames(ctx *MigrationContext, node *tree_sitter.Node) map[string]int {
+	names := make(map[string]int)
+	var visit func(node *tree_sitter.Node)
+	visit = func(node *tree_sitter.Node) {
+		switch nodeKind(node) {
+		case "variable_declarator", "formal_parameter", "catch_formal_parameter", "enhanced_for_statement":
+			names[ctx.nodeText(node.ChildByFieldName("name"))] = 1
+		case "inferred_parameters":
+			IterateChildren(node, func(child *tree_sitter.Node) {
+				if nodeKind(child) == "identifier" {
+					names[ctx.nodeText(child)] = 1
+				}
+			})
+		case "lambda_expression":
+			if parameter := node.ChildByFieldName("parameters"); nodeKind(parameter) == "identifier" {
+				names[ctx.nodeText(parameter)] = 1
+			}
+		case "instanceof_expression":
+			if binding := node.ChildByFieldName("name"); binding != nil {
+				names[ctx.nodeText(binding)] = 1
+			}
+		}
+		IterateChildren(node, visit)
+	}
+	visit(node)
+	return names
+}
+
 // getConvertedMethodName looks up the converted method name for an invocation
 // Handles overloaded method resolution by argument count
 // Returns: (convertedName, found, multipleMatches)
 func getConvertedMethodName(ctx *MigrationContext, methodName string, argCount int) (string, bool, bool) {
-	methods, exists := ctx.Methods[methodName]
-	if !exists {
-		// Maybe it is public?
-		methods, exists = ctx.Methods[gosrc.ToIdentifier(methodName, true)]
-		if !exists {
-			// Method not tracked - use original name
-			return methodName, false, false
-		}
+	methods := trackedMethods(ctx, methodName)
+	if len(methods) == 0 {
+		// Method not tracked - use original name
+		return methodName, false, false
 	}
 
 	if len(methods) == 1 {
@@ -210,24 +353,48 @@ func getConvertedMethodName(ctx *MigrationContext, methodName string, argCount i
 	return tryGuessOverloadedMethod(methods, argCount)
 }
 
+// trackedMethods returns the signatures of the methods called methodName,
+// which are tracked under their Go name
+func trackedMethods(ctx *MigrationContext, methodName string) []FunctionData {
+	methods, exists := ctx.Methods[methodName]
+	if !exists {
+		// Maybe it is public?
+		methods = ctx.Methods[gosrc.ToIdentifier(methodName, true)]
+	}
+	return methods
+}
+
 // tryMigrateMember wraps a migration function with panic recovery
 // Returns a FailedMigration if the migration panics, nil otherwise
 func tryMigrateMember(ctx *MigrationContext, location string, node *tree_sitter.Node, fn func()) *gosrc.FailedMigration {
+	if brokenDeclaration(node) {
+		return syntaxFailure(ctx, location, node)
+	}
+	// Temporary names only have to be unique within a member, and must not
+	// be the names of its variables
+	ctx.tempNames = declaredNames(ctx, node)
+	ctx.variableTypes = nil
+	ctx.instanceofResults = nil
+	ctx.mapLookups = nil
 	defer func() {
 		if r := recover(); r != nil {
-			// Let strict mode panics propagate
-			if ctx.StrictMode {
+			// Let unexpected panics propagate if expressions are strict
+			if ctx.propagates(r) {
 				panic(r)
 			}
 			// Otherwise this is handled by handleMigrationPanic below
 		}
 	}()
 
+	// Imports added by a member that fails would be unused, so the ones
+	// before it are restored along with the FailedMigration
+	imports := slices.Clone(ctx.Source.Imports)
 	// Set up inner recovery to capture the panic and convert to FailedMigration
 	var failed *gosrc.FailedMigration
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
+				ctx.Source.Imports = imports
 				failed = handleMigrationPanic(ctx, location, node, r)
 			}
 		}()
@@ -245,7 +412,11 @@ func handleMigrationPanic(ctx *MigrationContext, location string, node *tree_sit
 	switch v := r.(type) {
 	case MigrationPanic:
 		err = MigrationError{
+			Code:       v.Code,
+			Category:   v.Category,
 			Location:   location,
+			Line:       v.Line,
+			Column:     v.Column,
 			JavaSource: v.JavaSource,
 			SExpr:      v.SExpr,
 			Message:    v.Message,
@@ -256,13 +427,19 @@ func handleMigrationPanic(ctx *MigrationContext, location string, node *tree_sit
 		javaSource := ""
 		sexpr := ""
 		nodeKind := ""
+		line, column := 0, 0
 		if node != nil {
-			javaSource = node.Utf8Text(ctx.JavaSource)
+			javaSource = ctx.nodeText(node)
 			sexpr = node.ToSexp()
 			nodeKind = node.Kind()
+			line, column = nodeLineColumn(node)
 		}
 		err = MigrationError{
+			Code:       ErrInternal,
+			Category:   codeCategories[ErrInternal],
 			Location:   location,
+			Line:       line,
+			Column:     column,
 			JavaSource: javaSource,
 			SExpr:      sexpr,
 			Message:    fmt.Sprintf("unexpected panic: %v", r),
@@ -272,9 +449,7 @@ func handleMigrationPanic(ctx *MigrationContext, location string, node *tree_sit
 
 	ctx.Errors = append(ctx.Errors, err)
 
-	// TODO: this should be controlled by the migration context using a channel
-	// Print to stderr immediately
-	fmt.Fprintf(os.Stderr, "Error migrating %s: %s\n", location, err.Message)
+	fmt.Fprintf(ctx.Log, "Error migrating %s: %s\n", location, err.Message)
 
 	// Return FailedMigration placeholder
 	return &gosrc.FailedMigration{
@@ -0,0 +1,148 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// arrayArgument tells how the Go value of an argument holds a Java array
+type arrayArgument int
+
+const (
+	notArray     arrayArgument = iota
+	sliceValue                 // A slice, such as a local array or a varargs parameter
+	slicePointer               // A pointer to a slice, as array parameters are passed
+)
+
+// isVariadic reports whether ty is the type of a varargs parameter, ...T
+func isVariadic(ty gosrc.Type) bool {
+	return strings.HasPrefix(string(ty), "...")
+}
+
+// acceptsArgumentCount reports whether a function with the parameter types
+// paramTypes can be called with argCount arguments. A varargs parameter
+// takes any number of them, including none.
+func acceptsArgumentCount(paramTypes []gosrc.Type, argCount int) bool {
+	if len(paramTypes) > 0 && isVariadic(paramTypes[len(paramTypes)-1]) {
+		return argCount >= len(paramTypes)-1
+	}
+	return argCount == len(paramTypes)
+}
+
+// passArrayArguments adapts the arrays among args, the converted arguments
+// argsNode holds, to the parameters paramTypes they are passed to. Java
+// passes an array to a varargs parameter as the whole of its values, which
+// Go spells as items..., and array parameters are pointers to slices, which
+// need the address of slices passed to them.
+func passArrayArguments(ctx *MigrationContext, argsNode *tree_sitter.Node, args []gosrc.Expression, paramTypes []gosrc.Type) []gosrc.Expression {
+	argNodes := argumentNodes(argsNode)
+	if len(argNodes) != len(args) || !acceptsArgumentCount(paramTypes, len(args)) {
+		return args
+	}
+	for i, paramTy := range paramTypes {
+		if i >= len(args) {
+			break
+		}
+		kind := arrayArgumentKind(ctx, argNodes[i])
+		switch {
+		case kind == notArray:
+		case isVariadic(paramTy) && len(args) == len(paramTypes):
+			args[i] = spreadArgument(ctx, args[i], kind)
+		case strings.HasPrefix(string(paramTy), "*[]") && kind == sliceValue && isAddressable(argNodes[i]):
+			args[i] = ctx.arena.GoExpression(gosrc.GoExpression{Source: "&" + args[i].ToSource()})
+		}
+	}
+	return args
+}
+
+// spreadArgument passes the values of the slice arg, held as kind, to a
+// varargs parameter
+func spreadArgument(ctx *MigrationContext, arg gosrc.Expression, kind arrayArgument) gosrc.Expression {
+	source := arg.ToSource() + "..."
+	if kind == slicePointer {
+		source = "*" + source
+	}
+	return ctx.arena.GoExpression(gosrc.GoExpression{Source: source})
+}
+
+// isAddressable reports whether the Go value of the argument argNode can
+// have its address taken
+func isAddressable(argNode *tree_sitter.Node) bool {
+	switch nodeKind(argNode) {
+	case "identifier", "field_access":
+		return true
+	case "array_creation_expression":
+		// Composite literals can, make(...) can't
+		return argNode.ChildByFieldName("value") != nil
+	default:
+		return false
+	}
+}
+
+// arrayArgumentKind returns how the Go value of the argument argNode holds a
+// Java array, if it does
+func arrayArgumentKind(ctx *MigrationContext, argNode *tree_sitter.Node) arrayArgument {
+	argNode = unwrapParentheses(argNode)
+	switch nodeKind(argNode) {
+	case "array_creation_expression", "array_initializer":
+		return sliceValue
+	case "identifier":
+		name := ctx.nodeText(argNode)
+		typeNode := declaredType(ctx, argNode, name)
+		if (typeNode == nil || nodeKind(typeNode.Parent()) == "field_declaration") && isSpreadParameter(ctx, argNode, name) {
+			return sliceValue
+		}
+		if typeNode == nil {
+			return notArray
+		}
+		if ty, ok := TryParseType(ctx, typeNode); !ok || !IsArrayOrSliceType(ty) {
+			return notArray
+		}
+		if nodeKind(typeNode.Parent()) == "formal_parameter" {
+			return slicePointer
+		}
+		return sliceValue
+	case "field_access":
+		if ty, ok := staticType(ctx, argNode); ok && IsArrayOrSliceType(ty) {
+			return sliceValue
+		}
+	}
+	return notArray
+}
+
+// isSpreadParameter reports whether name is the varargs parameter of the
+// method, constructor or lambda node is in
+func isSpreadParameter(ctx *MigrationContext, node *tree_sitter.Node, name string) bool {
+	for scope := node.Parent(); scope != nil; scope = scope.Parent() {
+		switch nodeKind(scope) {
+		case "method_declaration", "constructor_declaration", "lambda_expression":
+			if spreadParameterName(ctx, scope.ChildByFieldName("parameters")) == name {
+				return true
+			}
+			if nodeKind(scope) != "lambda_expression" {
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// spreadParameterName returns the name of the varargs parameter among
+// parameters, or an empty string if there is none
+func spreadParameterName(ctx *MigrationContext, parameters *tree_sitter.Node) string {
+	var name string
+	IterateChildren(parameters, func(parameter *tree_sitter.Node) {
+		if nodeKind(parameter) != "spread_parameter" {
+			return
+		}
+		IterateChildren(parameter, func(part *tree_sitter.Node) {
+			if nodeKind(part) == "variable_declarator" {
+				name = ctx.nodeText(part.ChildByFieldName("name"))
+			}
+		})
+	})
+	return name
+}
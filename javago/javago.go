@@ -0,0 +1,194 @@
+// Package javago migrates Java sources to Go. It is the library counterpart
+// of the javaGo command: it never exits the process or writes to stdout, so
+// other Go tools can embed the converter.
+package javago
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+// ErrDiagnostics is returned by Migrate in strict mode when the migration
+// reported any diagnostics
+var ErrDiagnostics = errors.New("javago: migration reported diagnostics")
+
+// Options controls a single migration. The zero value migrates with the
+// same defaults as the javaGo command without a config file.
+type Options struct {
+	FileName      string // Name used in migration comments, defaults to "input.java"
+	PackageName   string // Go package name, defaults to "converted"
+	LicenseHeader string // Prepended to the generated source
+	// TypeMappings maps Java type names to Go types, as the type_mappings
+	// table of Config.toml does
+	TypeMappings map[string]string
+	// PackageMappings maps Java package prefixes to Go import paths, as the
+	// package_mappings table of Config.toml does
+	PackageMappings map[string]string
+	// Strict makes Migrate fail with ErrDiagnostics if anything could not
+	// be migrated, instead of returning the partially migrated source
+	Strict bool
+	// StrictCategories makes Migrate fail with ErrDiagnostics like Strict,
+	// but only for diagnostics of these categories: "expressions", "types",
+	// "library_calls" or "concurrency"
+	StrictCategories  []string
+	StubOnly          bool            // Replace method bodies by stubs
+	OnlyMethods       map[string]bool // If set, only these Java methods get their bodies converted
+	ManualMethods     map[string]bool // Java methods, optionally Class.method, whose body is kept as comments
+	DeepCopy          bool            // Copy slice and map fields in generated Clone and Copy methods
+	FlatNestedClasses bool            // Keep the own name of hoisted static nested classes
+	ClassLiterals     string          // How Foo.class is migrated: "reflect" (default), "name" or "drop"
+	Annotations       string          // How @interface declarations are migrated: "struct" (default) or "skip"
+	Optionals         string          // How java.util.Optional is migrated: "pointer" (default) or "generic"
+	// SystemProperties maps system property keys to Go expressions, as the
+	// system_properties table of Config.toml does
+	SystemProperties map[string]string
+	// Exceptions maps exception names to how throwing them is migrated, as
+	// the exceptions table of Config.toml does
+	Exceptions map[string]string
+	// PrivateHelperFunctions migrates private instance methods that don't use
+	// the instance to package functions
+	PrivateHelperFunctions bool
+	// NoFormat returns the generated source as it is instead of formatting
+	// it the way gofmt does
+	NoFormat bool
+	// Log receives warnings as they happen. It must be safe for concurrent
+	// use. Warnings are discarded if it is nil.
+	Log io.Writer
+}
+
+// Diagnostic describes a part of the Java source that could not be migrated.
+// The generated source contains a FIXME comment in its place.
+type Diagnostic struct {
+	Code     string // Stable error code, such as JG001 for unhandled nodes
+	Category string // Kind of construct, such as "types" or "library_calls"
+	Message  string
+	Location string // Declaration being migrated, such as "class Foo.method_declaration"
+	Line     int    // 1-based, 0 if unknown
+	Column   int    // 1-based, 0 if unknown
+	NodeKind string // tree-sitter node kind of the offending node
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s", d.Line, d.Column, d.Code, d.Message)
+}
+
+// Migrate converts the Java source to Go and returns the generated Go source
+// along with diagnostics for everything that could not be migrated. The error
+// is non-nil if the converter failed outright, or, with opts.Strict, if there
+// are any diagnostics, or, with opts.StrictCategories, if there are any of
+// those categories.
+func Migrate(source []byte, opts Options) (goSource string, diagnostics []Diagnostic, err error) {
+	opts = opts.withDefaults()
+	ctx := java.NewMigrationContext(source, opts.FileName, nil, opts.TypeMappings)
+	java.ApplyOptions(ctx, java.Options{
+		PackageMappings:        opts.PackageMappings,
+		StubOnly:               opts.StubOnly,
+		OnlyMethods:            opts.OnlyMethods,
+		ManualMethods:          opts.ManualMethods,
+		DeepCopy:               opts.DeepCopy,
+		FlatNestedClasses:      opts.FlatNestedClasses,
+		ClassLiterals:          opts.ClassLiterals,
+		Annotations:            opts.Annotations,
+		Optionals:              opts.Optionals,
+		SystemProperties:       opts.SystemProperties,
+		ExceptionPolicies:      opts.Exceptions,
+		PrivateHelperFunctions: opts.PrivateHelperFunctions,
+	})
+	ctx.Log = opts.Log
+
+	defer func() {
+		// Failures outside a single declaration are not recovered by the
+		// converter itself
+		if r := recover(); r != nil {
+			goSource = ""
+			diagnostics = append(toDiagnostics(ctx.Errors), panicDiagnostic(r))
+			err = fmt.Errorf("javago: migrating %s failed: %s", opts.FileName, diagnostics[len(diagnostics)-1].Message)
+		}
+	}()
+
+	tree := java.ParseJava(source)
+	defer tree.Close()
+	java.MigrateTree(ctx, tree)
+
+	diagnostics = toDiagnostics(ctx.Errors)
+	if opts.failsOn(diagnostics) {
+		return "", diagnostics, ErrDiagnostics
+	}
+	var sb strings.Builder
+	if err := ctx.Source.WriteSource(&sb, opts.LicenseHeader, opts.PackageName); err != nil {
+		return "", diagnostics, err
+	}
+	if opts.NoFormat {
+		return sb.String(), diagnostics, nil
+	}
+	// Partially migrated sources that can't be formatted are still returned
+	formatted, _ := gosrc.Format([]byte(sb.String()))
+	return string(formatted), diagnostics, nil
+}
+
+func (opts Options) withDefaults() Options {
+	if opts.FileName == "" {
+		opts.FileName = "input.java"
+	}
+	if opts.PackageName == "" {
+		opts.PackageName = gosrc.PackageName
+	}
+	if opts.Log == nil {
+		opts.Log = io.Discard
+	}
+	return opts
+}
+
+// failsOn reports whether diagnostics make Migrate fail
+func (opts Options) failsOn(diagnostics []Diagnostic) bool {
+	if opts.Strict {
+		return len(diagnostics) > 0
+	}
+	return slices.ContainsFunc(diagnostics, func(d Diagnostic) bool {
+		return slices.Contains(opts.StrictCategories, d.Category)
+	})
+}
+
+func toDiagnostics(errs []java.MigrationError) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0, len(errs))
+	for _, err := range errs {
+		diagnostics = append(diagnostics, Diagnostic{
+			Code:     string(err.Code),
+			Category: string(err.Category),
+			Message:  err.Message,
+			Location: err.Location,
+			Line:     err.Line,
+			Column:   err.Column,
+			NodeKind: err.NodeKind,
+		})
+	}
+	return diagnostics
+}
+
+// panicDiagnostic describes a failure the converter did not recover from
+func panicDiagnostic(r any) Diagnostic {
+	switch v := r.(type) {
+	case java.MigrationPanic:
+		return Diagnostic{
+			Code:     string(v.Code),
+			Category: string(v.Category),
+			Message:  v.Message,
+			Location: v.ParentName,
+			Line:     v.Line,
+			Column:   v.Column,
+			NodeKind: v.NodeKind,
+		}
+	default:
+		return Diagnostic{
+			Code:     string(java.ErrInternal),
+			Category: string(java.CategoryExpressions),
+			Message:  fmt.Sprintf("unexpected panic: %v", r),
+		}
+	}
+}
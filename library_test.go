@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/javago"
+)
+
+func TestLibraryMigrate(t *testing.T) {
+	const pointJava = "public record Point(int x, int y) {}"
+	const brokenJava = "class Broken {\n    void () {}\n    int value;\n}"
+
+	tests := []struct {
+		name      string
+		source    string
+		opts      javago.Options
+		failed    bool
+		wantErr   error // Checked with errors.Is if set
+		wantCodes []string
+		contains  []string
+	}{
+		{
+			name:     "defaults",
+			source:   pointJava,
+			contains: []string{"package converted", "type Point struct"},
+		},
+		{
+			name:     "options",
+			source:   pointJava,
+			opts:     javago.Options{PackageName: "geometry", LicenseHeader: "// Licensed under MIT\n"},
+			contains: []string{"// Licensed under MIT", "package geometry"},
+		},
+		{
+			name:     "formatted_by_default",
+			source:   "class Counter {\n    int next(int n) { if (n > 0) { return n; } else { return 0; } }\n}",
+			contains: []string{"\tif n > 0 {", "} else {"},
+		},
+		{
+			name:     "no_format",
+			source:   pointJava,
+			opts:     javago.Options{NoFormat: true},
+			contains: []string{"type Point struct {\n    X int\n"},
+		},
+		{
+			name:     "static_calls_of_mapped_classes",
+			source:   "class Shape {\n    int area(int side) { return Geometry.square(side); }\n}",
+			opts:     javago.Options{TypeMappings: map[string]string{"Geometry": "*geometry.Shapes"}},
+			contains: []string{"return geometry.Square(side)"},
+		},
+		{
+			name:      "diagnostics_are_returned",
+			source:    brokenJava,
+			wantCodes: []string{"JG004"},
+			contains:  []string{"// FIXME: Failed to migrate", "value int"},
+		},
+		{
+			name:      "strict_fails_on_diagnostics",
+			source:    brokenJava,
+			opts:      javago.Options{Strict: true},
+			failed:    true,
+			wantErr:   javago.ErrDiagnostics,
+			wantCodes: []string{"JG004"},
+		},
+		{
+			name:      "strict_categories_fail_on_their_diagnostics",
+			source:    brokenJava,
+			opts:      javago.Options{StrictCategories: []string{"expressions"}},
+			failed:    true,
+			wantErr:   javago.ErrDiagnostics,
+			wantCodes: []string{"JG004"},
+		},
+		{
+			name:      "strict_categories_tolerate_other_diagnostics",
+			source:    brokenJava,
+			opts:      javago.Options{StrictCategories: []string{"types", "library_calls"}},
+			wantCodes: []string{"JG004"},
+			contains:  []string{"// FIXME: Failed to migrate", "value int"},
+		},
+		{
+			name:      "unrecovered_failures_are_errors",
+			source:    "class { }",
+			failed:    true,
+			wantCodes: []string{"JG012", "JG001"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diagnostics, err := javago.Migrate([]byte(tt.source), tt.opts)
+			if failed := err != nil; failed != tt.failed {
+				t.Fatalf("Expected failure %v, got error %v and output:\n%s", tt.failed, err, got)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			var codes []string
+			for _, diagnostic := range diagnostics {
+				codes = append(codes, diagnostic.Code)
+			}
+			if strings.Join(codes, ",") != strings.Join(tt.wantCodes, ",") {
+				t.Errorf("Expected diagnostic codes %v, got %v", tt.wantCodes, diagnostics)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestLibraryMigrateLog(t *testing.T) {
+	var log bytes.Buffer
+	_, _, err := javago.Migrate([]byte("class Broken {\n    void () {}\n}"), javago.Options{Log: &log})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(log.String(), "missing name in method_declaration") {
+		t.Errorf("Expected the warning to be logged, got: %s", log.String())
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+	"github.com/heshanpadmasiri/javaGo/java"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// convertLowMemory converts an analyzed tree one top-level declaration at a
+// time and writes the result to out. Declarations are spooled to a temporary
+// file as they are converted, since the imports they need are only known at
+// the end but have to be written first. Failed migrations are moved to notes
+// if it is not nil.
+func convertLowMemory(ctx *java.MigrationContext, tree *tree_sitter.Tree, out io.Writer, cfg config, notes *migrationNotes) error {
+	spool, err := os.CreateTemp("", "javago-*.go")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	spoolWriter := bufio.NewWriter(spool)
+	var imports []gosrc.Import
+	var packageDoc []string
+	err = java.ConvertTreeStreaming(ctx, tree, func(fragment gosrc.GoSource) error {
+		imports = gosrc.MergeImports(imports, fragment.Imports...)
+		if fragment.PackageDoc != nil {
+			packageDoc = fragment.PackageDoc
+		}
+		notes.take(&fragment)
+		return writeFormatted(spoolWriter, cfg, fragment.WriteDeclarations)
+	})
+	if err == nil {
+		err = spoolWriter.Flush()
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	outWriter := bufio.NewWriter(out)
+	err = writeFormatted(outWriter, cfg, func(w io.Writer) error {
+		return gosrc.WriteHeader(w, cfg.LicenseHeader, cfg.PackageName, packageDoc, imports)
+	})
+	if err != nil {
+		return err
+	}
+	if !cfg.noFormat {
+		// Formatting drops the blank line separating the header from the
+		// declarations
+		outWriter.WriteString("\n")
+	}
+	if _, err := io.Copy(outWriter, spool); err != nil {
+		return err
+	}
+	return outWriter.Flush()
+}
+
+// writeGoFileLowMemory is the convertLowMemory counterpart of writeGoFile
+func writeGoFileLowMemory(path string, ctx *java.MigrationContext, tree *tree_sitter.Tree, cfg config) error {
+	// TODO: use a proper mode
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	notes := newMigrationNotes(path, cfg)
+	err = convertLowMemory(ctx, tree, file, cfg, notes)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = notes.write()
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"slices"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+// sortedDeclarations parses src and returns its formatted top-level
+// declarations in sorted order, so outputs that only differ in declaration
+// order compare equal
+func sortedDeclarations(t *testing.T, src string) []string {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Output is not valid Go: %v\n%s", err, src)
+	}
+	var decls []string
+	for _, decl := range file.Decls {
+		var buf bytes.Buffer
+		if err := format.Node(&buf, fset, decl); err != nil {
+			t.Fatalf("Failed to format declaration: %v", err)
+		}
+		decls = append(decls, buf.String())
+	}
+	slices.Sort(decls)
+	return decls
+}
+
+// TestLowMemoryConversion checks that converting one declaration at a time
+// produces the same declarations as a regular migration. Only the order may
+// differ, since the regular emitter groups declarations by kind.
+func TestLowMemoryConversion(t *testing.T) {
+	cfg := defaultConfig()
+	for _, name := range corpusCases(t) {
+		t.Run(name, func(t *testing.T) {
+			expected := migrateCorpusCase(t, name)
+
+			fixture := java.NewFixture(readJavaCase(t, name), corpusConfig(t, name))
+			defer fixture.Close()
+			java.AnalyzeTree(fixture.Ctx, fixture.Tree)
+			var out bytes.Buffer
+			if err := convertLowMemory(fixture.Ctx, fixture.Tree, &out, cfg, nil); err != nil {
+				t.Fatalf("convertLowMemory failed: %v", err)
+			}
+			if len(fixture.Ctx.Source.Structs)+len(fixture.Ctx.Source.Functions) != 0 {
+				t.Errorf("Expected converted declarations to be released from the context")
+			}
+			if got, want := sortedDeclarations(t, out.String()), sortedDeclarations(t, expected); !slices.Equal(got, want) {
+				t.Errorf("Low memory output differs:\n--- Low memory ---\n%s\n--- Expected ---\n%s", out.String(), expected)
+			}
+		})
+	}
+}
+
+func TestConvertTreeStreaming(t *testing.T) {
+	src := "package demo;\n" + java.JavaClass("First") + java.JavaClass("Second") + "enum Third { A, B }\n"
+	fixture := java.NewFixture(src, java.Config{})
+	defer fixture.Close()
+	java.AnalyzeTree(fixture.Ctx, fixture.Tree)
+
+	var structs []string
+	err := java.ConvertTreeStreaming(fixture.Ctx, fixture.Tree, func(fragment gosrc.GoSource) error {
+		for _, strct := range fragment.Structs {
+			structs = append(structs, strct.Name)
+		}
+		if len(structs) == 2 {
+			return errors.New("stop")
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "stop" {
+		t.Fatalf("Expected the emit error to be returned, got %v", err)
+	}
+	if !slices.Equal(structs, []string{"first", "second"}) {
+		t.Errorf("Expected declarations to be emitted in order until the error, got %v", structs)
+	}
+}
@@ -1,27 +1,89 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/heshanpadmasiri/javaGo/diagnostics"
+	"github.com/heshanpadmasiri/javaGo/gosrc"
 	"github.com/heshanpadmasiri/javaGo/java"
 )
 
 func main() {
 	// Parse command-line flags
-	strictMode := flag.Bool("Werror", false, "treat migration errors as fatal (exit on first error)")
+	strictMode := flag.Bool("Werror", false, "treat migration errors of every category as fatal (exit on first error)")
+	configPath := flag.String("config", "", "path to the config file (defaults to Config.toml in the working directory)")
+	lowMemory := flag.Bool("low-memory", false, "convert and write one top-level declaration at a time to bound memory use on very large files")
+	stubOnly := flag.Bool("stub-only", false, "emit declarations and signatures only, replacing method bodies with stubs")
+	only := flag.String("only", "", "comma separated Java method names whose bodies are converted; other bodies are stubbed")
+	cacheDir := flag.String("cache-dir", "", "directory in which to keep analysis results between runs (disabled if empty)")
+	outDir := flag.String("out-dir", "", "migrate every Java file under the source directory into this directory, one Go file per Java file")
+	check := flag.Bool("check", false, "compile and vet the generated source in a temporary module, reporting diagnostics at the Java lines they come from")
+	report := flag.String("report", "", "write a migration report next to the output, as \"json\" or \"html\"")
+	noFormat := flag.Bool("no-format", false, "write the generated source as it is instead of formatting it like gofmt, for debugging the converter")
 	flag.Parse()
 
-	config := loadConfig()
 	args := flag.Args()
+	if len(args) > 0 && args[0] == "corpus" {
+		os.Exit(runCorpusCommand(args[1:]))
+	}
+	config := loadConfig()
+	if *configPath != "" {
+		var err error
+		config, err = loadConfigFrom(*configPath)
+		diagnostics.Fatal("loading config failed due to", err)
+	}
+	config.noFormat = *noFormat
+	strictness := config.strictness(*strictMode)
+	if !validReportFormat(*report) {
+		fmt.Fprintf(os.Stderr, "Fatal: unknown report format %q, expected \"json\" or \"html\"\n", *report)
+		os.Exit(1)
+	}
+	if *report != "" && *lowMemory {
+		fmt.Fprintf(os.Stderr, "Fatal: -report can't be combined with -low-memory\n")
+		os.Exit(1)
+	}
+	if len(args) > 0 && args[0] == "watch" {
+		os.Exit(runWatchCommand(args[1:], strictness, config))
+	}
 	if len(args) == 0 {
-		fmt.Fprintf(os.Stderr, "Usage: javaGo [-Werror] <source.java> [dest.go]\n")
+		fmt.Fprintf(os.Stderr, "Usage: javaGo [-Werror] [-config file] [-cache-dir dir] [-check] [-low-memory] [-no-format] [-report json|html] [-stub-only] [-only methods] <source.java> [dest.go]\n")
+		fmt.Fprintf(os.Stderr, "       javaGo [-Werror] [-config file] [-cache-dir dir] [-check] [-no-format] [-report json|html] [-stub-only] [-only methods] -out-dir dir <source dir>\n")
+		fmt.Fprintf(os.Stderr, "       javaGo [-Werror] [-config file] [-no-format] watch [-interval duration] <source.java> <dest.go>\n")
+		fmt.Fprintf(os.Stderr, "       javaGo corpus run [-snapshot file] [-update] <dir>\n")
 		os.Exit(1)
 	}
 	sourcePath := args[0]
+	if *outDir != "" {
+		opts := projectOptions{
+			strictness: strictness,
+			stubOnly:   *stubOnly,
+			only:       parseOnlyMethods(*only),
+			cacheDir:   *cacheDir,
+		}
+		if *report != "" {
+			opts.report = &migrationReport{}
+		}
+		err := migrateProject(sourcePath, *outDir, config, opts)
+		diagnostics.Fatal("migrating project failed due to", err)
+		if opts.report != nil {
+			path, err := writeReport(*outDir, *report, *opts.report)
+			diagnostics.Fatal("writing report failed due to", err)
+			fmt.Fprintf(os.Stderr, "Wrote migration report to %s\n", path)
+		}
+		if *check {
+			files, err := readGoFiles(*outDir)
+			diagnostics.Fatal("reading migrated project failed due to", err)
+			os.Exit(runCheck(files))
+		}
+		return
+	}
 	var destPath *string
 	if len(args) > 1 {
 		destPath = &args[1]
@@ -33,16 +95,103 @@ func main() {
 	defer tree.Close()
 
 	sourceFileName := filepath.Base(sourcePath)
-	ctx := java.NewMigrationContext(javaSource, sourceFileName, *strictMode, config.TypeMappings)
-	java.MigrateTree(ctx, tree)
-	goSource := ctx.Source.ToSource(config.LicenseHeader, config.PackageName)
+	ctx := java.NewMigrationContext(javaSource, sourceFileName, strictness, config.TypeMappings)
+	opts := config.options()
+	opts.StubOnly = *stubOnly
+	opts.OnlyMethods = parseOnlyMethods(*only)
+	java.ApplyOptions(ctx, opts)
+	analyzeWithCache(ctx, tree, *cacheDir)
+	// Output written to stdout is kept for -check and -report
+	var output bytes.Buffer
+	stdout := io.Writer(os.Stdout)
+	if *check || *report != "" {
+		stdout = io.MultiWriter(os.Stdout, &output)
+	}
+	switch {
+	case *lowMemory && destPath == nil:
+		notes := newMigrationNotes(goFileName(sourcePath), config)
+		err = convertLowMemory(ctx, tree, stdout, config, notes)
+		if err == nil {
+			err = notes.write()
+		}
+		diagnostics.Fatal("Failed to write output", err)
+	case *lowMemory:
+		err = writeGoFileLowMemory(*destPath, ctx, tree, config)
+		diagnostics.Fatal("Failed to write to file", err)
+	case destPath == nil:
+		java.ConvertTree(ctx, tree)
+		notes := newMigrationNotes(goFileName(sourcePath), config)
+		notes.take(&ctx.Source)
+		out := bufio.NewWriter(stdout)
+		err = writeSource(out, &ctx.Source, config)
+		if err == nil {
+			err = out.Flush()
+		}
+		if err == nil {
+			err = notes.write()
+		}
+		diagnostics.Fatal("Failed to write output", err)
+	default:
+		java.ConvertTree(ctx, tree)
+		err = writeGoFile(*destPath, &ctx.Source, config)
+		diagnostics.Fatal("Failed to write to file", err)
+	}
+	if !*check && *report == "" {
+		return
+	}
+	goFile, goSource, reportDir := goFileName(sourcePath), output.Bytes(), "."
 	if destPath != nil {
-		// TODO: use a proper mode
-		err = os.WriteFile(*destPath, []byte(goSource), 0o644)
-		if err != nil {
-			diagnostics.Fatal("Failed to write to file", err)
+		goFile, reportDir = filepath.Base(*destPath), filepath.Dir(*destPath)
+		goSource, err = os.ReadFile(*destPath)
+		diagnostics.Fatal("reading migrated source failed due to", err)
+	}
+	if *report != "" {
+		var migration migrationReport
+		migration.add(newFileReport(sourceFileName, goFile, ctx, string(goSource)))
+		path, err := writeReport(reportDir, *report, migration)
+		diagnostics.Fatal("writing report failed due to", err)
+		fmt.Fprintf(os.Stderr, "Wrote migration report to %s\n", path)
+	}
+	if *check {
+		os.Exit(runCheck(map[string][]byte{goFile: goSource}))
+	}
+}
+
+// parseOnlyMethods parses the value of the -only flag, returning nil (no
+// filter) if it is empty
+func parseOnlyMethods(only string) map[string]bool {
+	if only == "" {
+		return nil
+	}
+	methods := make(map[string]bool)
+	for name := range strings.SplitSeq(only, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			methods[name] = true
 		}
-	} else {
-		fmt.Println(goSource)
 	}
+	return methods
+}
+
+// writeGoFile streams source into the file at path, and its failed
+// migrations into the notes file next to it if cfg asks for one
+func writeGoFile(path string, source *gosrc.GoSource, cfg config) error {
+	notes := newMigrationNotes(path, cfg)
+	notes.take(source)
+	// TODO: use a proper mode
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	out := bufio.NewWriter(file)
+	err = writeSource(out, source, cfg)
+	if err == nil {
+		err = out.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = notes.write()
+	}
+	return err
 }
@@ -18,7 +18,9 @@ var update = flag.Bool("update", false, "update expected Go files")
 
 func TestMain(m *testing.M) {
 	flag.Parse()
-	os.Exit(m.Run())
+	code := m.Run()
+	cleanupCLI()
+	os.Exit(code)
 }
 
 func getGoFilePath(javaFile string) string {
@@ -73,17 +75,9 @@ func TestMigration(t *testing.T) {
 			// Get corresponding Go file path
 			goFile := getGoFilePath(javaFile)
 
-			// Run migration
-			tree := java.ParseJava(javaContent)
-			defer tree.Close()
-
-			ctx := java.NewMigrationContext(javaContent, entry.Name(), true, nil) // Use strict mode in tests
-			java.MigrateTree(ctx, tree)
-			config := config{
-				PackageName:   "converted",
-				LicenseHeader: "",
-			}
-			result := ctx.Source.ToSource(config.LicenseHeader, config.PackageName)
+			// Run migration, in strict mode unless the case is configured
+			// otherwise
+			result, _ := java.MigrateString(string(javaContent), corpusConfig(t, testName))
 
 			// Format output with go fmt
 			formatted, err := formatGoCode(result)
@@ -210,7 +204,7 @@ license_header = """// Copyright 2024 Test Company
 			tree := java.ParseJava(javaContent)
 			defer tree.Close()
 
-			ctx := java.NewMigrationContext(javaContent, "test.java", true, nil) // Use strict mode in tests
+			ctx := java.NewMigrationContext(javaContent, "test.java", java.StrictAll(), nil) // Use strict mode in tests
 			java.MigrateTree(ctx, tree)
 
 			// Load config (should read from Config.toml in current directory)
@@ -280,7 +274,7 @@ public class Calculator {
 	tree := java.ParseJava(javaSource)
 	defer tree.Close()
 
-	ctx := java.NewMigrationContext(javaSource, "test.java", true, nil) // Use strict mode in tests
+	ctx := java.NewMigrationContext(javaSource, "test.java", java.StrictAll(), nil) // Use strict mode in tests
 
 	java.MigrateTree(ctx, tree)
 
@@ -414,7 +408,7 @@ class Test {
 	// Load config
 	config := loadConfig()
 
-	ctx := java.NewMigrationContext(javaSource, "test.java", true, config.TypeMappings)
+	ctx := java.NewMigrationContext(javaSource, "test.java", java.StrictAll(), config.TypeMappings)
 	java.MigrateTree(ctx, tree)
 
 	result := ctx.Source.ToSource(config.LicenseHeader, config.PackageName)
@@ -423,7 +417,7 @@ class Test {
 	expectedMappings := []string{
 		"field1 option.Option[MappedType]",
 		"field2 result.Result[string]",
-		"field3 either.Either[MappedType,int]", // No space after comma before gofmt
+		"field3 either.Either[MappedType, int]",
 		"field4 []option.Option[MappedType]",
 	}
 
@@ -496,7 +490,7 @@ public class Outer {
 	tree := java.ParseJava(javaSource)
 	defer tree.Close()
 
-	ctx := java.NewMigrationContext(javaSource, "test.java", true, nil) // Use strict mode in tests
+	ctx := java.NewMigrationContext(javaSource, "test.java", java.StrictAll(), nil) // Use strict mode in tests
 
 	java.MigrateTree(ctx, tree)
 
@@ -610,3 +604,79 @@ public class Outer {
 		t.Errorf("Expected 0 parameters for doubled, got %d", len(doubledMethods[0].ArgumentTypes))
 	}
 }
+
+func TestMigrateString(t *testing.T) {
+	src := java.JavaClass("Counter",
+		"private int count;",
+		java.JavaMethod("public int next()", "count = count + 1;", "return count;"),
+		"@interface Marker {}",
+	)
+	goSource, errs := java.MigrateString(src, java.Config{PackageName: "counter"})
+
+	if !strings.HasPrefix(goSource, "package counter\n") {
+		t.Errorf("Expected configured package name, got:\n%s", goSource)
+	}
+	if !strings.Contains(goSource, "func (this *counter) Next() int {") {
+		t.Errorf("Expected migrated method, got:\n%s", goSource)
+	}
+	if !strings.Contains(goSource, "// migrated from input.java:") {
+		t.Errorf("Expected default file name in migration comments, got:\n%s", goSource)
+	}
+	if len(errs) != 1 || errs[0].Code != java.ErrUnhandledNode {
+		t.Errorf("Expected a single unhandled node error for the annotation, got %v", errs)
+	}
+}
+
+// failingWriter accepts limit bytes and then fails every write
+type failingWriter struct {
+	limit int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		n := w.limit
+		w.limit = 0
+		return n, fmt.Errorf("disk full")
+	}
+	w.limit -= len(p)
+	return len(p), nil
+}
+
+func TestWriteSource(t *testing.T) {
+	src := java.JavaClass("Counter",
+		"private int count;",
+		java.JavaMethod("public int next()", "count += 1;", "return count;"),
+	)
+	fixture := java.NewFixture(src, java.Config{})
+	defer fixture.Close()
+	expected := fixture.Migrate()
+
+	var sb strings.Builder
+	if err := fixture.Ctx.Source.WriteSource(&sb, "", gosrc.PackageName); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if sb.String() != expected {
+		t.Errorf("Streamed source differs from ToSource:\n--- Streamed ---\n%s\n--- ToSource ---\n%s", sb.String(), expected)
+	}
+
+	err := fixture.Ctx.Source.WriteSource(&failingWriter{limit: 20}, "", gosrc.PackageName)
+	if err == nil || err.Error() != "disk full" {
+		t.Errorf("Expected the write error to be returned, got %v", err)
+	}
+}
+
+// TestArenaPointersStayValid checks that nodes handed out by an arena are not
+// overwritten when later allocations start a new chunk
+func TestArenaPointersStayValid(t *testing.T) {
+	for _, arena := range []*gosrc.Arena{gosrc.NewArena(), nil} {
+		var refs []*gosrc.VarRef
+		for i := range 1000 {
+			refs = append(refs, arena.VarRef(gosrc.VarRef{Ref: fmt.Sprintf("v%d", i)}))
+		}
+		for i, ref := range refs {
+			if ref.Ref != fmt.Sprintf("v%d", i) {
+				t.Fatalf("Expected v%d, got %s", i, ref.Ref)
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+)
+
+func TestNamingHelpersEdgeCases(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		public      string
+		private     string
+		capitalized string
+	}{
+		{name: "empty", input: "", public: "", private: "", capitalized: ""},
+		{name: "single_lower", input: "x", public: "X", private: "x", capitalized: "X"},
+		{name: "single_upper", input: "X", public: "X", private: "x", capitalized: "X"},
+		{name: "underscore", input: "_", public: "_", private: "_", capitalized: "_"},
+		{name: "digit", input: "1a", public: "1a", private: "1a", capitalized: "1a"},
+		{name: "non_ascii", input: "élan", public: "élan", private: "élan", capitalized: "élan"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gosrc.ToIdentifier(tt.input, true); got != tt.public {
+				t.Errorf("ToIdentifier(%q, true) = %q, want %q", tt.input, got, tt.public)
+			}
+			if got := gosrc.ToIdentifier(tt.input, false); got != tt.private {
+				t.Errorf("ToIdentifier(%q, false) = %q, want %q", tt.input, got, tt.private)
+			}
+			if got := gosrc.CapitalizeFirstLetter(tt.input); got != tt.capitalized {
+				t.Errorf("CapitalizeFirstLetter(%q) = %q, want %q", tt.input, got, tt.capitalized)
+			}
+			if got := gosrc.LowercaseFirstLetter(tt.input); got != tt.private {
+				t.Errorf("LowercaseFirstLetter(%q) = %q, want %q", tt.input, got, tt.private)
+			}
+		})
+	}
+}
+
+func TestNamingHelpersProperties(t *testing.T) {
+	properties := []struct {
+		name     string
+		property any
+	}{
+		{
+			name: "only_first_byte_changes",
+			property: func(name string, public bool) bool {
+				got := gosrc.ToIdentifier(name, public)
+				return len(got) == len(name) && (len(name) == 0 || got[1:] == name[1:])
+			},
+		},
+		{
+			name: "exported_matches_visibility",
+			property: func(name string, public bool) bool {
+				got := gosrc.ToIdentifier(name, public)
+				if len(got) == 0 {
+					return true
+				}
+				first := got[0]
+				if public {
+					return first < 'a' || first > 'z'
+				}
+				return first < 'A' || first > 'Z'
+			},
+		},
+		{
+			name: "idempotent",
+			property: func(name string, public bool) bool {
+				once := gosrc.ToIdentifier(name, public)
+				return gosrc.ToIdentifier(once, public) == once
+			},
+		},
+		{
+			name: "agrees_with_case_helpers",
+			property: func(name string) bool {
+				return gosrc.ToIdentifier(name, true) == gosrc.CapitalizeFirstLetter(name) &&
+					gosrc.ToIdentifier(name, false) == gosrc.LowercaseFirstLetter(name)
+			},
+		},
+		{
+			name: "case_round_trip",
+			property: func(name string) bool {
+				return gosrc.CapitalizeFirstLetter(gosrc.LowercaseFirstLetter(name)) == gosrc.CapitalizeFirstLetter(name)
+			},
+		},
+	}
+	for _, p := range properties {
+		t.Run(p.name, func(t *testing.T) {
+			if err := quick.Check(p.property, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+)
+
+// migrationNotes collects the failed migrations of a Go file that are
+// written to a sibling Markdown file instead of being appended to the Go file
+// as comments
+type migrationNotes struct {
+	goFile string // Name of the Go file the notes are about
+	path   string // Path of the notes file
+	failed []gosrc.FailedMigration
+}
+
+// newMigrationNotes returns the notes of the Go file at goPath, or nil if cfg
+// keeps failed migrations in the Go file. The methods of migrationNotes do
+// nothing on nil.
+func newMigrationNotes(goPath string, cfg config) *migrationNotes {
+	if !cfg.MigrationNotes {
+		return nil
+	}
+	return &migrationNotes{
+		goFile: filepath.Base(goPath),
+		path:   strings.TrimSuffix(goPath, ".go") + "_migration_notes.md",
+	}
+}
+
+// take moves the details of the failed migrations of source to the notes,
+// leaving a comment pointing at the notes file in their place
+func (n *migrationNotes) take(source *gosrc.GoSource) {
+	if n == nil {
+		return
+	}
+	for i := range source.FailedMigrations {
+		n.failed = append(n.failed, source.FailedMigrations[i])
+		source.FailedMigrations[i].NotesFile = filepath.Base(n.path)
+	}
+}
+
+// write writes the notes file, or removes the one left by an earlier
+// migration if no migration failed
+func (n *migrationNotes) write() error {
+	if n == nil {
+		return nil
+	}
+	if len(n.failed) == 0 {
+		if err := os.Remove(n.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	file, err := os.OpenFile(n.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	out := bufio.NewWriter(file)
+	err = gosrc.WriteMigrationNotes(out, n.goFile, n.failed)
+	if err == nil {
+		err = out.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
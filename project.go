@@ -0,0 +1,271 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/java"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// projectFile is a Java file of a project migrated with -out-dir
+type projectFile struct {
+	path   string // Path of the Java file
+	rel    string // Path of the Java file relative to the source root
+	source []byte
+	tree   *tree_sitter.Tree
+	ctx    *java.MigrationContext
+}
+
+// projectOptions are the per-file settings of a project migration
+type projectOptions struct {
+	strictness java.Strictness
+	stubOnly   bool
+	only       map[string]bool
+	cacheDir   string
+	report     *migrationReport // Collects the report of every file if set
+}
+
+// migrateProject migrates every Java file under sourceRoot into outDir,
+// writing one Go file per Java file. Files are placed in the directory of
+// their Java package, so com.example.util ends up in outDir/com/example/util
+// with package util. Every file is analyzed before any of them is converted,
+// so references to classes declared in other files of the project resolve.
+func migrateProject(sourceRoot, outDir string, cfg config, opts projectOptions) error {
+	files, err := collectProjectFiles(sourceRoot)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, file := range files {
+			if file.tree != nil {
+				file.tree.Close()
+			}
+		}
+	}()
+
+	javaPackages := make(map[string]bool)
+	for _, file := range files {
+		file.source, err = os.ReadFile(file.path)
+		if err != nil {
+			return err
+		}
+		file.tree = java.ParseJava(file.source)
+		if javaPackage := java.PackageName(file.tree, file.source); javaPackage != "" {
+			javaPackages[javaPackage] = true
+		}
+	}
+	packageMappings, err := projectPackageMappings(cfg.PackageMappings, outDir, javaPackages)
+	if err != nil {
+		return err
+	}
+
+	migrationOpts := cfg.options()
+	migrationOpts.PackageMappings = packageMappings
+	migrationOpts.StubOnly = opts.stubOnly
+	migrationOpts.OnlyMethods = opts.only
+	symbols := java.NewSymbolTable()
+	for _, file := range files {
+		file.ctx = java.NewMigrationContext(file.source, filepath.Base(file.path), opts.strictness, cfg.TypeMappings)
+		java.ApplyOptions(file.ctx, migrationOpts)
+		file.ctx.ProjectPackages = javaPackages
+		file.ctx.SharedSupport = true
+		analyzeWithCache(file.ctx, file.tree, opts.cacheDir)
+		symbols.Add(file.ctx)
+	}
+
+	// Supports used by the files of each package, which are declared once
+	// per package
+	packageSupport := make(map[string]map[string]java.Support)
+	packageNames := make(map[string]string)
+	for _, file := range files {
+		file.ctx.ImportSymbols(symbols)
+		java.ConvertTree(file.ctx, file.tree)
+		dir, packageName := projectPackage(file, cfg)
+		destDir := filepath.Join(outDir, dir)
+		if err := os.MkdirAll(destDir, 0o755); err != nil {
+			return err
+		}
+		fileCfg := cfg
+		fileCfg.PackageName = packageName
+		dest := filepath.Join(destDir, goFileName(file.path))
+		if err := writeGoFile(dest, &file.ctx.Source, fileCfg); err != nil {
+			return fmt.Errorf("writing %s: %w", dest, err)
+		}
+		if opts.report != nil {
+			if err := addProjectReport(opts.report, file, dest, outDir); err != nil {
+				return err
+			}
+		}
+		for _, support := range java.NeededSupport(file.ctx, file.tree) {
+			if packageSupport[destDir] == nil {
+				packageSupport[destDir] = make(map[string]java.Support)
+			}
+			packageSupport[destDir][support.Name] = support
+			packageNames[destDir] = packageName
+		}
+	}
+	for destDir, supports := range packageSupport {
+		fileCfg := cfg
+		fileCfg.PackageName = packageNames[destDir]
+		for _, support := range supports {
+			dest := filepath.Join(destDir, supportFileName(support))
+			source := support.Source()
+			if err := writeGoFile(dest, &source, fileCfg); err != nil {
+				return fmt.Errorf("writing %s: %w", dest, err)
+			}
+		}
+	}
+	return nil
+}
+
+// projectPackageMappings returns the package mappings of a project migrated
+// into outDir: the configured mappings, along with the import paths the Java
+// packages of the project they leave unmapped get in the Go module outDir is
+// in, so references between the packages are qualified. Outside of a Go
+// module their import paths are unknown, and the conversion reports the
+// imports between them instead.
+func projectPackageMappings(configured map[string]string, outDir string, javaPackages map[string]bool) (map[string]string, error) {
+	root, ok, err := goImportPathOf(outDir)
+	if err != nil || !ok {
+		return configured, err
+	}
+	mappings := maps.Clone(configured)
+	if mappings == nil {
+		mappings = make(map[string]string)
+	}
+	for javaPackage := range javaPackages {
+		if mappedPackage(configured, javaPackage) {
+			continue
+		}
+		mappings[javaPackage] = path.Join(root, strings.ReplaceAll(javaPackage, ".", "/"))
+	}
+	return mappings, nil
+}
+
+// mappedPackage reports whether javaPackage or a package enclosing it is
+// mapped by mappings
+func mappedPackage(mappings map[string]string, javaPackage string) bool {
+	for each := range mappings {
+		if javaPackage == each || strings.HasPrefix(javaPackage, each+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// goImportPathOf returns the import path dir has in the Go module declared by
+// the closest go.mod in dir or a directory above it, if there is one. dir
+// doesn't need to exist yet.
+func goImportPathOf(dir string) (string, bool, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false, err
+	}
+	for moduleDir := dir; ; moduleDir = filepath.Dir(moduleDir) {
+		goMod, err := os.ReadFile(filepath.Join(moduleDir, "go.mod"))
+		switch {
+		case err == nil:
+			modulePath, ok := goModulePath(goMod)
+			if !ok {
+				return "", false, fmt.Errorf("no module path in %s", filepath.Join(moduleDir, "go.mod"))
+			}
+			rel, err := filepath.Rel(moduleDir, dir)
+			if err != nil {
+				return "", false, err
+			}
+			return path.Join(modulePath, filepath.ToSlash(rel)), true, nil
+		case !errors.Is(err, fs.ErrNotExist):
+			return "", false, err
+		}
+		if filepath.Dir(moduleDir) == moduleDir {
+			return "", false, nil
+		}
+	}
+}
+
+// goModulePath returns the module path declared by the module directive of
+// a go.mod file
+func goModulePath(goMod []byte) (string, bool) {
+	for _, line := range strings.Split(string(goMod), "\n") {
+		if modulePath, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(modulePath), `"`), true
+		}
+	}
+	return "", false
+}
+
+// supportFileName returns the name of the Go file declaring support in each
+// package of a project that uses it, such as optional_support.go
+func supportFileName(support java.Support) string {
+	return support.Name + "_support.go"
+}
+
+// collectProjectFiles lists the Java files under sourceRoot in a stable order
+func collectProjectFiles(sourceRoot string) ([]*projectFile, error) {
+	var files []*projectFile
+	err := filepath.WalkDir(sourceRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".java") {
+			return nil
+		}
+		rel, err := filepath.Rel(sourceRoot, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, &projectFile{path: path, rel: rel})
+		return nil
+	})
+	sort.Slice(files, func(i, j int) bool { return files[i].rel < files[j].rel })
+	return files, err
+}
+
+// projectPackage returns the output directory, relative to the output root,
+// and the Go package name of file. Files without a package declaration keep
+// their directory relative to the source root.
+func projectPackage(file *projectFile, cfg config) (string, string) {
+	var dir string
+	if javaPackage := java.PackageName(file.tree, file.ctx.JavaSource); javaPackage != "" {
+		dir = filepath.Join(strings.Split(javaPackage, ".")...)
+	} else {
+		dir = filepath.Dir(file.rel)
+	}
+	if dir == "." {
+		return dir, cfg.PackageName
+	}
+	return dir, strings.ToLower(filepath.Base(dir))
+}
+
+// goFileName returns the name of the Go file migrated from the Java file at
+// path, following the Go convention of lower case file names. Package
+// documentation in package-info.java goes to doc.go.
+func goFileName(path string) string {
+	if filepath.Base(path) == "package-info.java" {
+		return "doc.go"
+	}
+	return strings.ToLower(strings.TrimSuffix(filepath.Base(path), ".java")) + ".go"
+}
+
+// addProjectReport adds the report of file, migrated to dest under outDir,
+// to report
+func addProjectReport(report *migrationReport, file *projectFile, dest, outDir string) error {
+	goSource, err := os.ReadFile(dest)
+	if err != nil {
+		return err
+	}
+	goFile, err := filepath.Rel(outDir, dest)
+	if err != nil {
+		return err
+	}
+	report.add(newFileReport(filepath.ToSlash(file.rel), filepath.ToSlash(goFile), file.ctx, string(goSource)))
+	return nil
+}
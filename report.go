@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/heshanpadmasiri/javaGo/gosrc"
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+// Formats of the report written with -report
+const (
+	reportJSON = "json"
+	reportHTML = "html"
+)
+
+// reportFileName is the name of the report written next to the output,
+// without its extension
+const reportFileName = "migration_report"
+
+// migrationReport summarizes how well each file of a migration went, for
+// teams tracking a large migration
+type migrationReport struct {
+	Files  []fileReport `json:"files"`
+	Totals fileReport   `json:"totals"`
+}
+
+// fileReport records how well a single Java file migrated
+type fileReport struct {
+	JavaFile           string         `json:"java_file,omitempty"`
+	GoFile             string         `json:"go_file,omitempty"`
+	Migrated           int            `json:"migrated"`            // Number of top level Go declarations produced
+	Failed             int            `json:"failed"`              // Number of members that ended up as FailedMigrations
+	ConstructorGuesses int            `json:"constructor_guesses"` // Number of constructor calls that could not be resolved
+	Fixmes             int            `json:"fixmes"`              // Number of FIXME markers in the generated source
+	Todos              int            `json:"todos"`               // Number of TODO markers in the generated source
+	Lines              int            `json:"lines"`               // Number of lines of the generated source
+	UnhandledKinds     map[string]int `json:"unhandled_kinds,omitempty"`
+}
+
+// TodoDensity is the number of FIXME and TODO markers per 100 lines of
+// generated source
+func (r fileReport) TodoDensity() float64 {
+	if r.Lines == 0 {
+		return 0
+	}
+	return float64(r.Fixmes+r.Todos) * 100 / float64(r.Lines)
+}
+
+// MarshalJSON adds the todo density to the recorded counts
+func (r fileReport) MarshalJSON() ([]byte, error) {
+	type counts fileReport
+	return json.Marshal(struct {
+		counts
+		TodoDensity float64 `json:"todo_density"`
+	}{counts(r), r.TodoDensity()})
+}
+
+// newFileReport collects the report of the Java file javaFile, migrated by
+// ctx to goSource in goFile
+func newFileReport(javaFile, goFile string, ctx *java.MigrationContext, goSource string) fileReport {
+	report := fileReport{
+		JavaFile: javaFile,
+		GoFile:   goFile,
+		Migrated: migratedDeclarations(ctx.Source),
+		Failed:   len(ctx.Source.FailedMigrations),
+		ConstructorGuesses: strings.Count(goSource, "FIXME: failed to find constructor") +
+			strings.Count(goSource, "FIXME: more than one possible constructor"),
+		Fixmes: strings.Count(goSource, "FIXME"),
+		Todos:  strings.Count(goSource, "TODO"),
+		Lines:  strings.Count(goSource, "\n"),
+	}
+	for _, err := range ctx.Errors {
+		if err.Code != java.ErrUnhandledNode {
+			continue
+		}
+		if report.UnhandledKinds == nil {
+			report.UnhandledKinds = make(map[string]int)
+		}
+		report.UnhandledKinds[err.NodeKind]++
+	}
+	return report
+}
+
+// migratedDeclarations returns the number of top level Go declarations of
+// source
+func migratedDeclarations(source gosrc.GoSource) int {
+	return len(source.Interfaces) + len(source.Structs) + len(source.Constants) + len(source.ConstBlocks) +
+		groupedConstants(source) + len(source.Vars) + len(source.Functions) + len(source.Methods)
+}
+
+// add adds the report of another file to the totals of the report
+func (r *migrationReport) add(file fileReport) {
+	r.Files = append(r.Files, file)
+	r.Totals.Migrated += file.Migrated
+	r.Totals.Failed += file.Failed
+	r.Totals.ConstructorGuesses += file.ConstructorGuesses
+	r.Totals.Fixmes += file.Fixmes
+	r.Totals.Todos += file.Todos
+	r.Totals.Lines += file.Lines
+	for kind, count := range file.UnhandledKinds {
+		if r.Totals.UnhandledKinds == nil {
+			r.Totals.UnhandledKinds = make(map[string]int)
+		}
+		r.Totals.UnhandledKinds[kind] += count
+	}
+}
+
+// writeReport writes report in format to dir and returns the path of the
+// report
+func writeReport(dir, format string, report migrationReport) (string, error) {
+	path := filepath.Join(dir, reportFileName+"."+format)
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case reportJSON:
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	case reportHTML:
+		err = htmlReport.Execute(file, report)
+	default:
+		err = fmt.Errorf("unknown report format %q, expected %q or %q", format, reportJSON, reportHTML)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return path, err
+}
+
+// validReportFormat reports whether format is empty, for no report, or a
+// known report format
+func validReportFormat(format string) bool {
+	switch format {
+	case "", reportJSON, reportHTML:
+		return true
+	default:
+		return false
+	}
+}
+
+// sortedKinds lists unhandled node kinds with their counts, most frequent
+// first
+func sortedKinds(kinds map[string]int) []string {
+	names := make([]string, 0, len(kinds))
+	for kind := range kinds {
+		names = append(names, kind)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if kinds[names[i]] != kinds[names[j]] {
+			return kinds[names[i]] > kinds[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for i, kind := range names {
+		names[i] = fmt.Sprintf("%s (%d)", kind, kinds[kind])
+	}
+	return names
+}
+
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{
+	"kinds": func(kinds map[string]int) string { return strings.Join(sortedKinds(kinds), ", ") },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Migration report</title>
+<style>
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: right; }
+th:first-child, td:first-child, td:last-child { text-align: left; }
+tfoot { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>Migration report</h1>
+<table>
+<thead>
+<tr><th>Java file</th><th>Go file</th><th>Migrated</th><th>Failed</th><th>Constructor guesses</th><th>FIXMEs</th><th>TODOs</th><th>Lines</th><th>Markers per 100 lines</th><th>Unhandled node kinds</th></tr>
+</thead>
+<tbody>
+{{range .Files}}<tr><td>{{.JavaFile}}</td><td>{{.GoFile}}</td><td>{{.Migrated}}</td><td>{{.Failed}}</td><td>{{.ConstructorGuesses}}</td><td>{{.Fixmes}}</td><td>{{.Todos}}</td><td>{{.Lines}}</td><td>{{printf "%.1f" .TodoDensity}}</td><td>{{kinds .UnhandledKinds}}</td></tr>
+{{end}}</tbody>
+<tfoot>
+{{with .Totals}}<tr><td>Total</td><td></td><td>{{.Migrated}}</td><td>{{.Failed}}</td><td>{{.ConstructorGuesses}}</td><td>{{.Fixmes}}</td><td>{{.Todos}}</td><td>{{.Lines}}</td><td>{{printf "%.1f" .TodoDensity}}</td><td>{{kinds .UnhandledKinds}}</td></tr>{{end}}
+</tfoot>
+</table>
+</body>
+</html>
+`))
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+func TestUnsupportedStreamPipelines(t *testing.T) {
+	tests := []struct {
+		name     string
+		pipeline string
+	}{
+		{name: "unsupported_operation", pipeline: "names.stream().sorted().collect(Collectors.toList())"},
+		{name: "unsupported_collector", pipeline: "names.stream().collect(Collectors.joining(\", \"))"},
+		{name: "not_consumed", pipeline: "names.stream().filter(name -> name.isEmpty())"},
+		{name: "block_lambda", pipeline: "names.stream().filter(name -> { return name.isEmpty(); }).count()"},
+		{name: "unknown_element_type", pipeline: "names.stream().map(name -> name.length()).toList()"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := java.JavaClass("Roster",
+				"private List<String> names;",
+				java.JavaMethod("Object pipeline()", "return "+tt.pipeline+";"),
+			)
+			got, errs := java.MigrateString(src, java.Config{})
+			if len(errs) != 1 || errs[0].Code != java.ErrStreamPipeline {
+				t.Fatalf("Expected a single %s error, got: %v", java.ErrStreamPipeline, errs)
+			}
+			if !strings.Contains(got, "// "+tt.pipeline+"\n") {
+				t.Errorf("Expected the pipeline to be kept as a comment, got:\n%s", got)
+			}
+		})
+	}
+}
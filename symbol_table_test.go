@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+// migrateWithSymbols analyzes every source, then converts the last one with
+// the declarations of all of them in scope
+func migrateWithSymbols(t *testing.T, sources ...string) string {
+	t.Helper()
+	symbols := java.NewSymbolTable()
+	var ctx *java.MigrationContext
+	for i, src := range sources {
+		tree := java.ParseJava([]byte(src))
+		defer tree.Close()
+		ctx = java.NewMigrationContext([]byte(src), "File.java", nil, nil)
+		java.AnalyzeTree(ctx, tree)
+		symbols.Add(ctx)
+		if i == len(sources)-1 {
+			ctx.ImportSymbols(symbols)
+			java.ConvertTree(ctx, tree)
+		}
+	}
+	if len(ctx.Errors) != 0 {
+		t.Fatalf("Expected no migration errors, got: %v", ctx.Errors)
+	}
+	return ctx.Source.ToSource("", "converted")
+}
+
+func TestSymbolTable(t *testing.T) {
+	const point = `public class Point {
+    public int x;
+    public Point(int x, int y) { this.x = x; }
+    public Point(int x) { this.x = x; }
+    public int distanceTo(Point other) { return other.x - x; }
+}`
+	tests := []struct {
+		name     string
+		sources  []string
+		contains []string
+	}{
+		{
+			name: "other_file_declarations",
+			sources: []string{point, `class Shape {
+    int width(Point other) {
+        Point p = new Point(1, 2);
+        Point q = new Point(3);
+        return p.distanceTo(other) + other.x;
+    }
+}`},
+			contains: []string{"NewPointFromIntInt(1, 2)", "NewPointFromInt(3)", "p.DistanceTo(other)", "other.X"},
+		},
+		{
+			name: "own_declarations_take_precedence",
+			sources: []string{point, `class Shape {
+    int distanceTo(Shape other) { return 0; }
+    int width(Shape other) { return distanceTo(other); }
+}`},
+			contains: []string{"return this.distanceTo(other)"},
+		},
+		{
+			name: "other_file_static_methods",
+			sources: []string{`public class Geometry {
+    public static int square(int side) { return side * side; }
+}`, `class Shape {
+    int area(int side) { return Geometry.square(side); }
+}`},
+			contains: []string{"return Square(side)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := migrateWithSymbols(t, tt.sources...)
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+class Holder {
+    int value;
+
+    @interface Marker {
+    }
+}
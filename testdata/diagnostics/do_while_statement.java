@@ -0,0 +1,7 @@
+class Loops {
+    void spin(int n) {
+        do {
+            n = n - 1;
+        } while (n > 0);
+    }
+}
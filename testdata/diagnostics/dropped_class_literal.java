@@ -0,0 +1,5 @@
+class Registry {
+    Object kind() {
+        return Registry.class;
+    }
+}
@@ -0,0 +1,11 @@
+public class Levels {
+    enum Level { LOW, MEDIUM, HIGH }
+
+    public boolean atLeast(Level level, Level threshold) {
+        return level.ordinal() >= threshold.ordinal();
+    }
+
+    public int next(Level level) {
+        return (level.ordinal() + 1) % 3;
+    }
+}
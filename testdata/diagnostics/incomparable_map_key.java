@@ -0,0 +1,22 @@
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+class Tag {
+    String name;
+    List<String> aliases;
+}
+
+class Point {
+    int x;
+    int y;
+}
+
+class Registry {
+    Map<Tag, Integer> counts = new HashMap<>();
+    Map<Point, String> labels;
+
+    int count(Tag tag) {
+        return 0;
+    }
+}
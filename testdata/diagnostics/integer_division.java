@@ -0,0 +1,11 @@
+class Stats {
+    double mean(int sum, int count) {
+        return sum / count;
+    }
+
+    double ratio(long hits, long total) {
+        double exact = (double) hits / total;
+        double truncated = hits / total;
+        return exact - truncated;
+    }
+}
@@ -0,0 +1,5 @@
+class Limits {
+    long tooBig() {
+        return 99999999999999999999L;
+    }
+}
@@ -0,0 +1,9 @@
+class Tasks {
+    void schedule() {
+        Runnable task = () -> {};
+    }
+
+    int count() {
+        return 1;
+    }
+}
@@ -0,0 +1,7 @@
+class Broken {
+    void () {}
+
+    int ok() {
+        return 1;
+    }
+}
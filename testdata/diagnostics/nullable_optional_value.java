@@ -0,0 +1,7 @@
+import java.util.Optional;
+
+class Lookup {
+    Optional<String> named(String name) {
+        return Optional.ofNullable(name);
+    }
+}
@@ -0,0 +1,9 @@
+public class Account {
+    private int balance;
+
+    public void deposit(int amount) {
+        synchronized (this) {
+            balance += amount;
+        }
+    }
+}
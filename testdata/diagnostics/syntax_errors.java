@@ -0,0 +1,20 @@
+public class SyntaxErrors {
+    private int count;
+
+    public int good() {
+        return count + 1;
+    }
+
+    public int bad( {
+        int x = ;
+        return x
+    }
+
+    public void other() {
+        count = 2;
+    }
+}
+
+class Fine {
+    int value() { return 3; }
+}
@@ -0,0 +1,9 @@
+class Env {
+    String home() {
+        return System.getProperty("user.home");
+    }
+
+    String user() {
+        return System.getProperty("user.name", "nobody");
+    }
+}
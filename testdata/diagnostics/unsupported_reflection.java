@@ -0,0 +1,9 @@
+public class Factory {
+    Object create(String name) {
+        return Class.forName(name);
+    }
+
+    int methodCount() {
+        return this.getClass().getDeclaredMethods().length;
+    }
+}
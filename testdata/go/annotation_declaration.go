@@ -0,0 +1,15 @@
+package converted
+
+// migrated from annotation_declaration.java:4:1
+type Retry struct {
+	Attempts int
+	Reason   string
+	Tags     []string
+	Owners   []string
+	Timeout  int64
+}
+
+func NewRetry() Retry {
+	// migrated from annotation_declaration.java:4:1
+	return Retry{Attempts: 3, Reason: "flaky", Tags: []string{"io", "net"}, Owners: []string{"core"}}
+}
@@ -0,0 +1,140 @@
+package converted
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Greeter interface {
+	Greet(name string) string
+	Count() int
+}
+
+type Listener interface {
+	On(e int)
+}
+
+// taskRunnerGreeter1 is an anonymous Greeter
+// migrated from anonymous_classes.java:20:16
+type taskRunnerGreeter1 struct {
+	outer *TaskRunner
+	title string
+	times int
+	calls int
+}
+
+// taskRunnerGreeter2 is an anonymous Greeter
+// migrated from anonymous_classes.java:71:16
+type taskRunnerGreeter2 struct {
+	outer  *TaskRunner
+	inner  Greeter
+	suffix string
+}
+
+// taskRunnerListener3 is an anonymous Listener
+// migrated from anonymous_classes.java:83:16
+type taskRunnerListener3 struct {
+	outer *TaskRunner
+}
+
+// taskRunnerGreeter4 is an anonymous Greeter
+// migrated from anonymous_classes.java:91:16
+type taskRunnerGreeter4 struct {
+	outer *TaskRunner
+	k     int
+}
+
+type TaskRunner struct {
+	runs  int
+	total int
+}
+
+func NewTaskRunner() *TaskRunner {
+	this := &TaskRunner{}
+	return this
+}
+
+func (this *taskRunnerGreeter1) Greet(name string) string {
+	// migrated from anonymous_classes.java:23:13
+	this.calls++
+	return ((this.title + " ") + name)
+}
+
+func (this *taskRunnerGreeter1) Count() int {
+	// migrated from anonymous_classes.java:29:13
+	return (this.times + this.calls)
+}
+
+func (this *taskRunnerGreeter2) Greet(name string) string {
+	// migrated from anonymous_classes.java:72:13
+	return fmt.Sprintf("%v%s", this.inner.Greet(strings.ToUpper(name)), this.suffix)
+}
+
+func (this *taskRunnerGreeter2) Count() int {
+	// migrated from anonymous_classes.java:76:13
+	return this.inner.Count()
+}
+
+func (this *taskRunnerListener3) On(e int) {
+	// migrated from anonymous_classes.java:84:13
+	this.outer.total = (this.outer.total + e)
+}
+
+func (this *taskRunnerGreeter4) Greet(name string) string {
+	// migrated from anonymous_classes.java:94:13
+	return fmt.Sprintf("%s%d", name, this.outer.runs)
+}
+
+func (this *taskRunnerGreeter4) Count() int {
+	// migrated from anonymous_classes.java:98:13
+	return this.k
+}
+
+func (this *TaskRunner) PoliteGreeter(title string, times int) Greeter {
+	// migrated from anonymous_classes.java:19:5
+	return &taskRunnerGreeter1{outer: this, title: title, times: times, calls: 0}
+}
+
+func (this *TaskRunner) RunTwice(log *strings.Builder, message string) {
+	// migrated from anonymous_classes.java:36:5
+	task := func() {
+		log.WriteString(message)
+	}
+	task()
+	task()
+}
+
+func (this *TaskRunner) Longer(a string, b string) int {
+	// migrated from anonymous_classes.java:47:5
+	byLength := func(left string, right string) int {
+		return (len(left) - len(right))
+	}
+	return byLength(a, b)
+}
+
+func (this *TaskRunner) Describe(formatter func(int) string, value int) string {
+	// migrated from anonymous_classes.java:57:5
+	fallback := func() string {
+		return "none"
+	}
+	if value < 0 {
+		return fallback()
+	}
+	return formatter(value)
+}
+
+func (this *TaskRunner) ShoutingGreeter(suffix string) Greeter {
+	// migrated from anonymous_classes.java:69:5
+	inner := this.PoliteGreeter(suffix, 1)
+	return &taskRunnerGreeter2{outer: this, inner: inner, suffix: suffix}
+}
+
+func (this *TaskRunner) Summing() Listener {
+	// migrated from anonymous_classes.java:82:5
+	return &taskRunnerListener3{outer: this}
+}
+
+func (this *TaskRunner) Counting() Greeter {
+	// migrated from anonymous_classes.java:90:5
+	return &taskRunnerGreeter4{outer: this, k: 2}
+}
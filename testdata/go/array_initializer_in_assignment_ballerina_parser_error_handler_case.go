@@ -3,8 +3,8 @@ package converted
 type test struct {
 }
 
-func newTest() test {
-	this := test{}
+func newTest() *test {
+	this := &test{}
 	return this
 }
 
@@ -14,6 +14,5 @@ func (this *test) test(parentCtx ParserRuleContext) {
 	switch parentCtx {
 	case ARG_LIST:
 		alternatives = []ParserRuleContext{ParserRuleContext_COMMA, ParserRuleContext_BINARY_OPERATOR, ParserRuleContext_ARG_LIST_END}
-		break
 	}
 }
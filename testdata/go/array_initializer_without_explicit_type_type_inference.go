@@ -5,7 +5,7 @@ type test struct {
 
 var VALUES = []int{1, 2, 3}
 
-func newTest() test {
-	this := test{}
+func newTest() *test {
+	this := &test{}
 	return this
 }
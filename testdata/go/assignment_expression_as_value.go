@@ -0,0 +1,63 @@
+package converted
+
+type test struct {
+	x int
+	y int
+}
+
+func newTest() *test {
+	this := &test{}
+	return this
+}
+
+func (this *test) compute() int {
+	// migrated from assignment_expression_as_value.java:7:5
+	return 42
+}
+
+func (this *test) read() string {
+	// migrated from assignment_expression_as_value.java:11:5
+	return nil
+}
+
+func (this *test) test() {
+	// migrated from assignment_expression_as_value.java:15:5
+	this.y = this.compute()
+	this.x = this.y
+	this.x = (this.x + 2)
+	z := this.x
+	var line string
+	for {
+		line = this.read()
+		if !(line != nil) {
+			break
+		}
+		this.x = (this.x + 1)
+	}
+	i := 0
+	for ; i < 10; i = (i + 2) {
+		this.y = i
+	}
+}
+
+func (this *test) firstLarge(a *[]int, i int) int {
+	// migrated from assignment_expression_as_value.java:27:5
+	v := 0
+	cond := (i < len(*a))
+	if cond {
+		v = (*a)[i]
+		cond = (v > 1)
+	}
+	if cond {
+		return v
+	}
+	cond2 := (i >= len(*a))
+	if !cond2 {
+		v = (*a)[i]
+		cond2 = (v < 0)
+	}
+	if cond2 {
+		return (-1)
+	}
+	return v
+}
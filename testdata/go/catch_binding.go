@@ -0,0 +1,69 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type ParseFailure struct {
+	error
+	line int
+}
+
+type parser struct {
+}
+
+func NewParseFailureFromInt(line int) *ParseFailure {
+	this := &ParseFailure{}
+	this.line = line
+	return this
+}
+
+func newParser() *parser {
+	this := &parser{}
+	return this
+}
+
+func (this *ParseFailure) GetLine() int {
+	// migrated from catch_binding.java:8:5
+	return this.line
+}
+
+func (this *parser) describe(input string) string {
+	// migrated from catch_binding.java:14:5
+	result := ""
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				if failure, ok := r.(*ParseFailure); ok {
+					result = fmt.Sprintf("line %v", failure.GetLine())
+				} else if e, ok := r.(error); ok {
+					result = ("failed: " + e.Error())
+				} else {
+					panic(r) // re-panic if it's not a handled exception
+				}
+			}
+		}()
+		result = this.parse(input)
+	}()
+
+	return result
+}
+
+func (this *parser) count(input string) int {
+	// migrated from catch_binding.java:26:5
+	count := 0
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				if _, ok := r.(error); ok {
+					count = (-1)
+				} else {
+					panic(r) // re-panic if it's not a handled exception
+				}
+			}
+		}()
+		count = this.measure(input)
+	}()
+
+	return count
+}
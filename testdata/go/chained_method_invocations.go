@@ -0,0 +1,45 @@
+package converted
+
+type Chain struct {
+	next *Chain
+}
+
+func NewChain() *Chain {
+	this := &Chain{}
+	return this
+}
+
+func (this *Chain) getNext() *Chain {
+	// migrated from chained_method_invocations.java:4:5
+	return this.next
+}
+
+func (this *Chain) value() int {
+	// migrated from chained_method_invocations.java:8:5
+	return 1
+}
+
+func (this *Chain) implicitReceiver() int {
+	// migrated from chained_method_invocations.java:12:5
+	return this.getNext().getNext().value()
+}
+
+func (this *Chain) parameterReceiver(other *Chain) int {
+	// migrated from chained_method_invocations.java:16:5
+	return other.getNext().value()
+}
+
+func (this *Chain) fieldReceiver() int {
+	// migrated from chained_method_invocations.java:20:5
+	return this.next.getNext().value()
+}
+
+func (this *Chain) renamedLink(other *Chain) string {
+	// migrated from chained_method_invocations.java:24:5
+	return other.getNext().String()
+}
+
+func (this *Chain) String() string {
+	// migrated from chained_method_invocations.java:28:5
+	return "chain"
+}
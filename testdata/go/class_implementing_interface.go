@@ -1,5 +1,9 @@
 package converted
 
+import (
+	"fmt"
+)
+
 type Drawable interface {
 	Draw()
 }
@@ -10,13 +14,13 @@ type Circle struct {
 
 var _ Drawable = &Circle{}
 
-func NewCircleFromInt(radius int) Circle {
-	this := Circle{}
+func NewCircleFromInt(radius int) *Circle {
+	this := &Circle{}
 	this.radius = radius
 	return this
 }
 
 func (this *Circle) Draw() {
 	// migrated from class_implementing_interface.java:12:5
-	System.out.println(("Drawing circle with radius " + radius))
+	System.out.println(fmt.Sprintf("Drawing circle with radius %d", this.radius))
 }
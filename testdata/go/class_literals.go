@@ -0,0 +1,23 @@
+package converted
+
+import (
+	"reflect"
+)
+
+type Registry struct {
+}
+
+func NewRegistry() *Registry {
+	this := &Registry{}
+	return this
+}
+
+func (this *Registry) kind() interface{} {
+	// migrated from class_literals.java:2:5
+	return reflect.TypeFor[*Registry]()
+}
+
+func (this *Registry) name() string {
+	// migrated from class_literals.java:6:5
+	return "Registry"
+}
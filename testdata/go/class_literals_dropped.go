@@ -0,0 +1,19 @@
+package converted
+
+type registry struct {
+}
+
+func newRegistry() *registry {
+	this := &registry{}
+	return this
+}
+
+func (this *registry) kind() interface{} {
+	// migrated from class_literals_dropped.java:2:5
+	return nil
+}
+
+func (this *registry) name() string {
+	// migrated from class_literals_dropped.java:6:5
+	return "Registry"
+}
@@ -5,7 +5,7 @@ type SimpleClass struct {
 	name  string
 }
 
-func NewSimpleClass() SimpleClass {
-	this := SimpleClass{}
+func NewSimpleClass() *SimpleClass {
+	this := &SimpleClass{}
 	return this
 }
@@ -5,8 +5,8 @@ type WithDefaults struct {
 	name  string
 }
 
-func NewWithDefaults() WithDefaults {
-	this := WithDefaults{}
+func NewWithDefaults() *WithDefaults {
+	this := &WithDefaults{}
 	this.name = "default"
 	this.value = 42
 	// Default field initializations
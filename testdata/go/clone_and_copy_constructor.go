@@ -0,0 +1,33 @@
+package converted
+
+type tokens struct {
+	values []int
+	name   string
+}
+
+func newTokensFromString(name string) *tokens {
+	this := &tokens{}
+	this.name = name
+	return this
+}
+
+func newTokensFromTokens(other *tokens) *tokens {
+	this := &tokens{}
+	this.name = other.name
+	return this
+}
+
+func (this *tokens) duplicate() *tokens {
+	// migrated from clone_and_copy_constructor.java:22:5
+	return this.Clone()
+}
+
+func (this *tokens) Copy() *tokens {
+	clone := *this
+	return &clone
+}
+
+func (this *tokens) Clone() *tokens {
+	clone := *this
+	return &clone
+}
@@ -0,0 +1,24 @@
+package converted
+
+import (
+	"maps"
+	"slices"
+)
+
+type tokens struct {
+	values []int
+	index  map[string]int
+	name   string
+}
+
+func newTokens() *tokens {
+	this := &tokens{}
+	return this
+}
+
+func (this *tokens) Clone() *tokens {
+	clone := *this
+	clone.values = slices.Clone(this.values)
+	clone.index = maps.Clone(this.index)
+	return &clone
+}
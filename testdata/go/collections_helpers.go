@@ -0,0 +1,62 @@
+package converted
+
+import (
+	"math/rand"
+	"slices"
+)
+
+type Deck struct {
+	cards []string
+}
+
+func NewDeck() *Deck {
+	this := &Deck{}
+	this.cards = make([]string, 0)
+	// Default field initializations
+
+	return this
+}
+
+func (this *Deck) Find(card string) int {
+	// migrated from collections_helpers.java:9:5
+	index, found := slices.BinarySearch(this.cards, card)
+	if !found {
+		index = -index - 1
+	}
+	return index
+}
+
+func (this *Deck) FindBy(sorted *[]string, card string, order func(string, string) int) int {
+	// migrated from collections_helpers.java:13:5
+	index, found := slices.BinarySearchFunc(*sorted, card, order)
+	if !found {
+		index = -index - 1
+	}
+	return index
+}
+
+func (this *Deck) Shuffle() {
+	// migrated from collections_helpers.java:17:5
+	rand.Shuffle(len(this.cards), func(i, j int) {
+		this.cards[i], this.cards[j] = this.cards[j], this.cards[i]
+	})
+}
+
+func (this *Deck) ShuffleAll(hand *[]string) {
+	// migrated from collections_helpers.java:21:5
+	rand.Shuffle(len(*hand), func(i, j int) {
+		(*hand)[i], (*hand)[j] = (*hand)[j], (*hand)[i]
+	})
+}
+
+func (this *Deck) Copies(card string) int {
+	// migrated from collections_helpers.java:25:5
+	frequency := 0
+	for _, element := range this.cards {
+		if element == card {
+			frequency++
+		}
+	}
+	count := frequency
+	return count
+}
@@ -0,0 +1,62 @@
+package converted
+
+type offset struct {
+	outer *Point
+	dx    int
+}
+
+type Point struct {
+	x     int
+	y     int
+	label string
+}
+
+type box[T any] struct {
+	value T
+	size  int
+}
+
+func newOffsetFromInt(outer *Point, dx int) *offset {
+	this := &offset{}
+	this.outer = outer
+	this.dx = dx
+	return this
+}
+
+func newOffset(outer *Point) *offset {
+	this := newOffsetFromInt(outer, 1)
+	return this
+}
+
+func NewPointFromIntInt(x int, y int) *Point {
+	this := &Point{}
+	this.label = "origin"
+	// Default field initializations
+
+	this.x = x
+	this.y = y
+	return this
+}
+
+func NewPointFromInt(both int) *Point {
+	this := NewPointFromIntInt(both, both)
+	this.label = "diagonal"
+	return this
+}
+
+func NewPoint() *Point {
+	this := NewPointFromInt(0)
+	return this
+}
+
+func newBoxFromTInt[T any](value T, size int) *box[T] {
+	this := &box[T]{}
+	this.value = value
+	this.size = size
+	return this
+}
+
+func newBoxFromT[T any](value T) *box[T] {
+	this := newBoxFromTInt[T](value, 1)
+	return this
+}
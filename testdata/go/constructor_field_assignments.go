@@ -0,0 +1,37 @@
+package converted
+
+type Account struct {
+	Owner   string
+	Balance int
+	limit   int
+	id      int
+}
+
+func NewAccountFromStringInt(owner string, id int) *Account {
+	this := &Account{}
+	this.Balance = 10
+	// Default field initializations
+
+	this.Owner = owner
+	this.id = id
+	this.limit = 100
+	this.Balance = (this.Balance + id)
+	return this
+}
+
+func NewAccountFromInt(limit int) *Account {
+	this := &Account{}
+	this.Balance = 10
+	// Default field initializations
+
+	id := (limit * 2)
+	this.id = id
+	this.limit = limit
+	this.Owner = "nobody"
+	return this
+}
+
+func (this *Account) headroom() int {
+	// migrated from constructor_field_assignments.java:21:5
+	return (this.limit - this.Balance)
+}
@@ -0,0 +1,39 @@
+package converted
+
+type Shape interface {
+	Area() int
+}
+
+type Square struct {
+	side int
+}
+
+var _ Shape = &Square{}
+
+func NewSquareFromInt(side int) *Square {
+	this := &Square{}
+	this.side = side
+	return this
+}
+
+func Unit() Shape {
+	// migrated from constructor_interface_assignment.java:20:5
+	shape := NewSquareFromInt(1)
+	return shape
+}
+
+func Last(first *Square, second Shape) Shape {
+	// migrated from constructor_interface_assignment.java:25:5
+	shapes := []Shape{first, second, first.Grow(1)}
+	return shapes[2]
+}
+
+func (this *Square) Area() int {
+	// migrated from constructor_interface_assignment.java:12:5
+	return (this.side * this.side)
+}
+
+func (this *Square) Grow(by int) *Square {
+	// migrated from constructor_interface_assignment.java:16:5
+	return NewSquareFromInt((this.side + by))
+}
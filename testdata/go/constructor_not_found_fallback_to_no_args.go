@@ -3,8 +3,8 @@ package converted
 type testConstructorNotFound struct {
 }
 
-func newTestConstructorNotFound() testConstructorNotFound {
-	this := testConstructorNotFound{}
+func newTestConstructorNotFound() *testConstructorNotFound {
+	this := &testConstructorNotFound{}
 	return this
 }
 
@@ -4,8 +4,8 @@ type person struct {
 	name string
 }
 
-func NewPerson() person {
-	this := person{}
+func NewPerson() *person {
+	this := &person{}
 	this.name = "Unknown"
 	return this
 }
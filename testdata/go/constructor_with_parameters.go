@@ -5,8 +5,8 @@ type person struct {
 	age  int
 }
 
-func NewPersonFromStringInt(name string, age int) person {
-	this := person{}
+func NewPersonFromStringInt(name string, age int) *person {
+	this := &person{}
 	this.name = name
 	this.age = age
 	return this
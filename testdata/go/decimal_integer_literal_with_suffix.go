@@ -3,8 +3,8 @@ package converted
 type test struct {
 }
 
-func newTest() test {
-	this := test{}
+func newTest() *test {
+	this := &test{}
 	return this
 }
 
@@ -0,0 +1,68 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type AccountData interface {
+	GetTotal() int
+	SetTotal(total int)
+	GetName() string
+	SetName(name string)
+}
+
+type Account interface {
+	AccountData
+	Fee() int
+	Net() int
+	Label() string
+	Usage() string
+	Deposit(amount int)
+}
+
+type AccountBase struct {
+	Total int
+	Name  string
+}
+
+type AccountMethods struct {
+	Self Account
+}
+
+func (b *AccountBase) GetTotal() int {
+	return b.Total
+}
+
+func (b *AccountBase) SetTotal(total int) {
+	b.Total = total
+}
+
+func (b *AccountBase) GetName() string {
+	return b.Name
+}
+
+func (b *AccountBase) SetName(name string) {
+	b.Name = name
+}
+
+func (m *AccountMethods) Net() int {
+	// migrated from default_method_references.java:7:5
+	totalFee := m.Self.Fee()
+	return (m.Self.GetTotal() - totalFee)
+}
+
+func (m *AccountMethods) Label() string {
+	// migrated from default_method_references.java:12:5
+	nameAndTotal := fmt.Sprintf("%s:%d", m.Self.GetName(), m.Self.GetTotal())
+	return nameAndTotal
+}
+
+func (m *AccountMethods) Usage() string {
+	// migrated from default_method_references.java:17:5
+	return ("call this.net() for the balance of " + m.Self.GetName())
+}
+
+func (m *AccountMethods) Deposit(amount int) {
+	// migrated from default_method_references.java:21:5
+	m.Self.SetTotal((m.Self.GetTotal() + amount))
+}
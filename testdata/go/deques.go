@@ -0,0 +1,101 @@
+package converted
+
+import (
+	"slices"
+)
+
+type Scheduler struct {
+	jobs    []string
+	history []string
+	marks   []int
+}
+
+func NewScheduler() *Scheduler {
+	this := &Scheduler{}
+	this.history = make([]string, 0)
+	this.jobs = make([]string, 0)
+	this.marks = make([]int, 0)
+	// Default field initializations
+
+	return this
+}
+
+func (this *Scheduler) submit(job string, urgent bool) {
+	// migrated from deques.java:13:5
+	if urgent {
+		this.history = slices.Insert(this.history, 0, job)
+	} else {
+		this.jobs = append(this.jobs, job)
+	}
+}
+
+func (this *Scheduler) next() string {
+	// migrated from deques.java:21:5
+	var front string
+	if len(this.jobs) > 0 {
+		front = this.jobs[0]
+		this.jobs = this.jobs[1:]
+	}
+	job := front
+	this.history = slices.Insert(this.history, 0, job)
+	return job
+}
+
+func (this *Scheduler) drain() int {
+	// migrated from deques.java:27:5
+	count := 0
+	for !(len(this.jobs) == 0) {
+		this.jobs = this.jobs[1:]
+		count++
+	}
+	return count
+}
+
+func (this *Scheduler) undo() string {
+	// migrated from deques.java:36:5
+	if len(this.history) == 0 {
+		return ""
+	}
+	front := this.history[0]
+	this.history = this.history[1:]
+	latest := front
+	this.history = append(this.history, latest)
+	var front2 string
+	if len(this.history) > 0 {
+		front2 = this.history[0]
+	}
+	return (front2 + this.history[len(this.history)-1])
+}
+
+func (this *Scheduler) mark(position int) int {
+	// migrated from deques.java:45:5
+	this.marks = append(this.marks, position)
+	top := this.marks[len(this.marks)-1]
+	if len(this.marks) > 3 {
+		this.marks = this.marks[:len(this.marks)-1]
+	}
+	return top
+}
+
+func (this *Scheduler) rewind() int {
+	// migrated from deques.java:54:5
+	total := 0
+	for !(len(this.marks) == 0) {
+		back := this.marks[len(this.marks)-1]
+		this.marks = this.marks[:len(this.marks)-1]
+		total = (total + back)
+	}
+	return total
+}
+
+func (this *Scheduler) oldest(entries *[]string) string {
+	// migrated from deques.java:62:5
+	var back string
+	if len(*entries) > 0 {
+		back = (*entries)[len(*entries)-1]
+		*entries = (*entries)[:len(*entries)-1]
+	}
+	last := back
+	*entries = (*entries)[1:]
+	return last
+}
@@ -0,0 +1,49 @@
+package converted
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Operation struct {
+	symbol       string
+	applyFunc    func(*Operation, int, int) int
+	describeFunc func(*Operation) string
+	recordFunc   func(*Operation, *strings.Builder)
+}
+
+var Operation_PLUS = Operation{symbol: "+", applyFunc: func(this *Operation, a int, b int) int {
+	return (a + b)
+}}
+var Operation_MINUS = Operation{symbol: "-", applyFunc: func(this *Operation, a int, b int) int {
+	return (a - b)
+}, describeFunc: func(this *Operation) string {
+	return fmt.Sprintf("subtract with %v", this.symbol)
+}, recordFunc: func(this *Operation, log *strings.Builder) {
+	fmt.Fprint(log, this.describe())
+}}
+
+func (this *Operation) apply(a int, b int) int {
+	// migrated from enum_constant_bodies.java:31:5
+	if this.applyFunc != nil {
+		return this.applyFunc(this, a, b)
+	}
+	panic("implemented in concrete class")
+}
+
+func (this *Operation) describe() string {
+	// migrated from enum_constant_bodies.java:33:5
+	if this.describeFunc != nil {
+		return this.describeFunc(this)
+	}
+	return fmt.Sprintf("operation %v", this.symbol)
+}
+
+func (this *Operation) record(log *strings.Builder) {
+	// migrated from enum_constant_bodies.java:37:5
+	if this.recordFunc != nil {
+		this.recordFunc(this, log)
+		return
+	}
+	fmt.Fprint(log, this.symbol)
+}
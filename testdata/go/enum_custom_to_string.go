@@ -0,0 +1,46 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type Suit uint
+
+const (
+	Suit_HEARTS Suit = iota
+	Suit_SPADES
+)
+
+func SuitValues() []Suit {
+	return []Suit{Suit_HEARTS, Suit_SPADES}
+}
+
+func ParseSuit(name string) Suit {
+	switch name {
+	case "HEARTS":
+		return Suit_HEARTS
+	case "SPADES":
+		return Suit_SPADES
+	}
+	panic(fmt.Sprintf("no enum constant Suit.%s", name))
+}
+
+func (this Suit) Name() string {
+	switch this {
+	case Suit_HEARTS:
+		return "HEARTS"
+	case Suit_SPADES:
+		return "SPADES"
+	default:
+		return fmt.Sprintf("Suit(%d)", uint(this))
+	}
+}
+
+func (this Suit) Ordinal() int {
+	return int(this)
+}
+
+func (this *Suit) String() string {
+	// migrated from enum_custom_to_string.java:4:5
+	return "suit"
+}
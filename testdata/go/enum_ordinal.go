@@ -0,0 +1,85 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type Light uint
+
+type Traffic struct {
+	counts []int
+}
+
+const (
+	Light_RED Light = iota
+	Light_AMBER
+	Light_GREEN
+)
+
+func LightValues() []Light {
+	return []Light{Light_RED, Light_AMBER, Light_GREEN}
+}
+
+func ParseLight(name string) Light {
+	switch name {
+	case "RED":
+		return Light_RED
+	case "AMBER":
+		return Light_AMBER
+	case "GREEN":
+		return Light_GREEN
+	}
+	panic(fmt.Sprintf("no enum constant Light.%s", name))
+}
+
+func NewTraffic() *Traffic {
+	this := &Traffic{}
+	this.counts = []int{0, 0, 0}
+	// Default field initializations
+
+	return this
+}
+
+func (this Light) String() string {
+	switch this {
+	case Light_RED:
+		return "RED"
+	case Light_AMBER:
+		return "AMBER"
+	case Light_GREEN:
+		return "GREEN"
+	default:
+		return fmt.Sprintf("Light(%d)", uint(this))
+	}
+}
+
+func (this Light) Ordinal() int {
+	return int(this)
+}
+
+func (this *Traffic) Action(light Light) string {
+	// migrated from enum_ordinal.java:6:5
+	switch light {
+	case Light_RED:
+		return "stop"
+	case Light_AMBER:
+		return "wait"
+	default:
+		return "go"
+	}
+}
+
+func (this *Traffic) Record(light Light) {
+	// migrated from enum_ordinal.java:17:5
+	this.counts[light]++
+}
+
+func (this *Traffic) Seen(light Light) int {
+	// migrated from enum_ordinal.java:21:5
+	return this.counts[light]
+}
+
+func (this *Traffic) Index(light Light) int {
+	// migrated from enum_ordinal.java:25:5
+	return light.Ordinal()
+}
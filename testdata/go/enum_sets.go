@@ -0,0 +1,112 @@
+package converted
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+type Kind uint
+
+type Tokens struct {
+	seen   map[Kind]bool
+	counts map[Kind]int
+}
+
+const (
+	Kind_IDENT Kind = iota
+	Kind_NUMBER
+	Kind_PLUS
+	Kind_MINUS
+	Kind_EOF
+)
+
+var OPERATORS = map[Kind]bool{Kind_PLUS: true, Kind_MINUS: true}
+
+func KindValues() []Kind {
+	return []Kind{Kind_IDENT, Kind_NUMBER, Kind_PLUS, Kind_MINUS, Kind_EOF}
+}
+
+func ParseKind(name string) Kind {
+	switch name {
+	case "IDENT":
+		return Kind_IDENT
+	case "NUMBER":
+		return Kind_NUMBER
+	case "PLUS":
+		return Kind_PLUS
+	case "MINUS":
+		return Kind_MINUS
+	case "EOF":
+		return Kind_EOF
+	}
+	panic(fmt.Sprintf("no enum constant Kind.%s", name))
+}
+
+func NewTokens() *Tokens {
+	this := &Tokens{}
+	this.counts = make(map[Kind]int)
+	this.seen = make(map[Kind]bool)
+	// Default field initializations
+
+	return this
+}
+
+func (this Kind) String() string {
+	switch this {
+	case Kind_IDENT:
+		return "IDENT"
+	case Kind_NUMBER:
+		return "NUMBER"
+	case Kind_PLUS:
+		return "PLUS"
+	case Kind_MINUS:
+		return "MINUS"
+	case Kind_EOF:
+		return "EOF"
+	default:
+		return fmt.Sprintf("Kind(%d)", uint(this))
+	}
+}
+
+func (this Kind) Ordinal() int {
+	return int(this)
+}
+
+func (this *Tokens) isOperator(kind Kind) bool {
+	// migrated from enum_sets.java:10:5
+	return OPERATORS[kind]
+}
+
+func (this *Tokens) record(kind Kind) bool {
+	// migrated from enum_sets.java:14:5
+	this.counts[kind] = len(this.counts)
+	added := !this.seen[kind]
+	this.seen[kind] = true
+	return added
+}
+
+func (this *Tokens) forget(kind Kind) {
+	// migrated from enum_sets.java:19:5
+	delete(this.seen, kind)
+	delete(this.counts, kind)
+}
+
+func (this *Tokens) describe() string {
+	// migrated from enum_sets.java:24:5
+	sb := &strings.Builder{}
+	for _, kind := range KindValues() {
+		if !this.seen[kind] {
+			continue
+		}
+		sb.WriteString(kind.String())
+	}
+	return sb.String()
+}
+
+func (this *Tokens) all() map[Kind]bool {
+	// migrated from enum_sets.java:32:5
+	copy := maps.Clone(this.seen)
+	copy[Kind_EOF] = true
+	return map[Kind]bool{Kind_IDENT: true, Kind_NUMBER: true, Kind_PLUS: true, Kind_MINUS: true, Kind_EOF: true}
+}
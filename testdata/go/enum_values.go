@@ -0,0 +1,140 @@
+package converted
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Status uint
+
+type Grade uint
+
+type Inventory struct {
+}
+
+const (
+	Status_ACTIVE Status = iota
+	Status_SUSPENDED
+	Status_RETIRED
+)
+
+const (
+	Grade_LOW Grade = iota
+	Grade_HIGH
+)
+
+func StatusValues() []Status {
+	return []Status{Status_ACTIVE, Status_SUSPENDED, Status_RETIRED}
+}
+
+func ParseStatus(name string) Status {
+	switch name {
+	case "ACTIVE":
+		return Status_ACTIVE
+	case "SUSPENDED":
+		return Status_SUSPENDED
+	case "RETIRED":
+		return Status_RETIRED
+	}
+	panic(fmt.Sprintf("no enum constant Status.%s", name))
+}
+
+func GradeValues() []Grade {
+	return []Grade{Grade_LOW, Grade_HIGH}
+}
+
+func ParseGrade(name string) Grade {
+	switch name {
+	case "LOW":
+		return Grade_LOW
+	case "HIGH":
+		return Grade_HIGH
+	}
+	panic(fmt.Sprintf("no enum constant Grade.%s", name))
+}
+
+func NewInventory() *Inventory {
+	this := &Inventory{}
+	return this
+}
+
+func (this Status) String() string {
+	switch this {
+	case Status_ACTIVE:
+		return "ACTIVE"
+	case Status_SUSPENDED:
+		return "SUSPENDED"
+	case Status_RETIRED:
+		return "RETIRED"
+	default:
+		return fmt.Sprintf("Status(%d)", uint(this))
+	}
+}
+
+func (this Status) Ordinal() int {
+	return int(this)
+}
+
+func (this Grade) Name() string {
+	switch this {
+	case Grade_LOW:
+		return "LOW"
+	case Grade_HIGH:
+		return "HIGH"
+	default:
+		return fmt.Sprintf("Grade(%d)", uint(this))
+	}
+}
+
+func (this Grade) Ordinal() int {
+	return int(this)
+}
+
+func (this *Grade) String() string {
+	// migrated from enum_values.java:7:9
+	return "grade"
+}
+
+func (this *Grade) label() string {
+	// migrated from enum_values.java:12:9
+	return strings.ToLower(this.Name())
+}
+
+func (this *Inventory) IsActive(status Status) bool {
+	// migrated from enum_values.java:17:5
+	return (status == ParseStatus("ACTIVE"))
+}
+
+func (this *Inventory) Names() string {
+	// migrated from enum_values.java:21:5
+	names := &strings.Builder{}
+	for _, status := range StatusValues() {
+		names.WriteString(status.String())
+	}
+	return names.String()
+}
+
+func (this *Inventory) Describe(grade Grade) string {
+	// migrated from enum_values.java:29:5
+	return grade.Name()
+}
+
+func (this *Inventory) Parse(text string) Grade {
+	// migrated from enum_values.java:33:5
+	return ParseGrade(strings.ToUpper(text))
+}
+
+func (this *Inventory) Rank(text string) int {
+	// migrated from enum_values.java:37:5
+	return ParseStatus(text).Ordinal()
+}
+
+func (this *Inventory) FirstStatus() string {
+	// migrated from enum_values.java:41:5
+	return StatusValues()[0].String()
+}
+
+func (this *Inventory) GradeName(text string) string {
+	// migrated from enum_values.java:45:5
+	return ParseGrade(text).Name()
+}
@@ -0,0 +1,139 @@
+package converted
+
+import (
+	"errors"
+)
+
+type Loader struct {
+	path  string
+	loads int
+}
+
+func NewLoaderFromString(path string) *Loader {
+	this := &Loader{}
+	this.path = path
+	return this
+}
+
+func (this *Loader) Read() (string, error) {
+	// migrated from error_propagation.java:11:5
+	if len(this.path) == 0 {
+		return "", errors.New("no path")
+	}
+	return this.path, nil
+}
+
+func (this *Loader) Check(size int) error {
+	// migrated from error_propagation.java:18:5
+	if size < 0 {
+		return errors.New("negative size")
+	}
+	this.loads = (this.loads + 1)
+	return nil
+}
+
+func (this *Loader) Size() (int, error) {
+	// migrated from error_propagation.java:25:5
+	readResult, err := this.Read()
+	if err != nil {
+		return 0, err
+	}
+	content := readResult
+	if err := this.Check(len(content)); err != nil {
+		return 0, err
+	}
+	return (len(content) * 2), nil
+}
+
+func (this *Loader) Touch() error {
+	// migrated from error_propagation.java:31:5
+	if _, err := this.Read(); err != nil {
+		return err
+	}
+	if this.loads > 10 {
+		return nil
+	}
+	if err := this.Check(1); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (this *Loader) Copy() (*Loader, error) {
+	// migrated from error_propagation.java:39:5
+	readResult, err := this.Read()
+	if err != nil {
+		return nil, err
+	}
+	content := readResult
+	return NewLoaderFromString(content), nil
+}
+
+func (this *Loader) Count(prefix string) (int, error) {
+	// migrated from error_propagation.java:44:5
+	return (len(prefix) + this.loads), nil
+}
+
+func (this *Loader) Fits(prefix string) (bool, error) {
+	// migrated from error_propagation.java:48:5
+	countResult, err := this.Count(prefix)
+	if err != nil {
+		return false, err
+	}
+	cond := (countResult > 0)
+	if cond {
+		countResult2, err := this.Count((prefix + "x"))
+		if err != nil {
+			return false, err
+		}
+		cond = (countResult2 < 3)
+	}
+	if cond {
+		return true, nil
+	}
+	countResult3, err := this.Count(prefix)
+	if err != nil {
+		return false, err
+	}
+	cond2 := (countResult3 == 0)
+	if !cond2 {
+		countResult4, err := this.Count((prefix + "y"))
+		if err != nil {
+			return false, err
+		}
+		cond2 = (countResult4 > 5)
+	}
+	return cond2, nil
+}
+
+func (this *Loader) ReadOrPanic() string {
+	// migrated from error_propagation.java:55:5
+	readResult, err := this.Read()
+	if err != nil {
+		panic(err)
+	}
+	return readResult
+}
+
+func (this *Loader) ReadOrDefault() (string, error) {
+	// migrated from error_propagation.java:59:5
+	content := ""
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				if _, ok := r.(error); ok {
+					content = "default"
+				} else {
+					panic(r) // re-panic if it's not a handled exception
+				}
+			}
+		}()
+		readResult, err := this.Read()
+		if err != nil {
+			panic(err)
+		}
+		content = readResult
+	}()
+
+	return content, nil
+}
@@ -0,0 +1,59 @@
+package converted
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+type Loader struct {
+}
+
+func NewLoader() *Loader {
+	this := &Loader{}
+	return this
+}
+
+func (this *Loader) wrap(cause error) error {
+	// migrated from exception_causes.java:4:5
+	return fmt.Errorf("loading failed: %w", cause)
+}
+
+func (this *Loader) rethrow(cause error) error {
+	// migrated from exception_causes.java:8:5
+	return fmt.Errorf("%w", cause)
+}
+
+func (this *Loader) describe(path string, line int, cause error) error {
+	// migrated from exception_causes.java:12:5
+	return fmt.Errorf("bad entry in %s at line %d: %w", path, line, cause)
+}
+
+func (this *Loader) plain(path string) error {
+	// migrated from exception_causes.java:16:5
+	return errors.New(("unsupported: " + path))
+}
+
+func (this *Loader) unknown() error {
+	// migrated from exception_causes.java:20:5
+	return errors.New("IllegalStateException")
+}
+
+func (this *Loader) message(e error) string {
+	// migrated from exception_causes.java:24:5
+	return ("failed: " + e.Error())
+}
+
+func (this *Loader) rootCause(e error) error {
+	// migrated from exception_causes.java:28:5
+	cause := errors.Unwrap(e)
+	if cause == nil {
+		return e
+	}
+	return cause
+}
+
+func (this *Loader) report(e error) {
+	// migrated from exception_causes.java:36:5
+	fmt.Fprintln(os.Stderr, e)
+}
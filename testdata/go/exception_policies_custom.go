@@ -0,0 +1,29 @@
+package converted
+
+import (
+	"errors"
+	"log"
+)
+
+type parser struct {
+}
+
+func newParser() *parser {
+	this := &parser{}
+	return this
+}
+
+func (this *parser) parse(text string) (int, error) {
+	// migrated from exception_policies_custom.java:4:5
+	if len(text) == 0 {
+		return 0, errors.New("empty")
+	}
+	return 1, nil
+}
+
+func (this *parser) check(n int) {
+	// migrated from exception_policies_custom.java:11:5
+	if n < 0 {
+		log.Fatal(errors.New("negative"))
+	}
+}
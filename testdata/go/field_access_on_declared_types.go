@@ -0,0 +1,61 @@
+package converted
+
+type Counter struct {
+	Count int
+	label string
+}
+
+type bounds struct {
+	Start *Counter
+	End   *Counter
+}
+
+type span struct {
+	From int
+	To   int
+}
+
+func NewCounter() *Counter {
+	this := &Counter{}
+	return this
+}
+
+func newBounds() *bounds {
+	this := &bounds{}
+	return this
+}
+
+func newSpan() *span {
+	this := &span{}
+	return this
+}
+
+func (this *Counter) sameCount(other *Counter) bool {
+	// migrated from field_access_on_declared_types.java:5:5
+	return (other.Count == this.Count)
+}
+
+func (this *Counter) sameLabel(other *Counter) bool {
+	// migrated from field_access_on_declared_types.java:9:5
+	return (other.label == this.label)
+}
+
+func (this *Counter) total(counters *[]*Counter) int {
+	// migrated from field_access_on_declared_types.java:13:5
+	sum := 0
+	for _, counter := range *counters {
+		sum = (sum + counter.Count)
+	}
+	return sum
+}
+
+func (this *Counter) copyFrom(bounds *bounds) {
+	// migrated from field_access_on_declared_types.java:21:5
+	source := bounds.Start
+	source.Count = bounds.End.Count
+}
+
+func (this *span) contains(other span) bool {
+	// migrated from field_access_on_declared_types.java:33:5
+	return ((other.From >= this.From) && (other.To <= this.To))
+}
@@ -0,0 +1,57 @@
+package converted
+
+type FormatterData interface {
+	GetPrefix() string
+	SetPrefix(prefix string)
+}
+
+type Formatter interface {
+	FormatterData
+	Pad(text string, width int) string
+	Format(width int, text string) string
+	Sum(values *[]int, extra ...int) int
+}
+
+type Sink interface {
+	Write(line string)
+}
+
+type FormatterBase struct {
+	Prefix string
+}
+
+type FormatterMethods struct {
+	Self Formatter
+}
+
+type entry struct {
+	Key   string
+	Count int
+}
+
+func newEntry() *entry {
+	this := &entry{}
+	return this
+}
+
+func (b *FormatterBase) GetPrefix() string {
+	return b.Prefix
+}
+
+func (b *FormatterBase) SetPrefix(prefix string) {
+	b.Prefix = prefix
+}
+
+func (m *FormatterMethods) Format(width int, text string) string {
+	// migrated from final_and_annotated_parameters.java:10:5
+	return (m.Self.GetPrefix() + text)
+}
+
+func (m *FormatterMethods) Sum(values *[]int, extra ...int) int {
+	// migrated from final_and_annotated_parameters.java:14:5
+	total := 0
+	for _, value := range extra {
+		total = (total + value)
+	}
+	return total
+}
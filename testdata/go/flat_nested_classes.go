@@ -0,0 +1,23 @@
+package converted
+
+type Node struct {
+}
+
+type parser struct {
+	head *Node
+}
+
+func NewNode() *Node {
+	this := &Node{}
+	return this
+}
+
+func newParser() *parser {
+	this := &parser{}
+	return this
+}
+
+func (this *parser) parse() *Node {
+	// migrated from flat_nested_classes.java:9:5
+	return NewNode()
+}
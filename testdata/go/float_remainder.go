@@ -0,0 +1,35 @@
+package converted
+
+import (
+	"math"
+)
+
+type Angle struct {
+	degrees float64
+}
+
+func NewAngleFromFloat64(degrees float64) *Angle {
+	this := &Angle{}
+	this.degrees = degrees
+	return this
+}
+
+func (this *Angle) Normalized() float64 {
+	// migrated from float_remainder.java:8:5
+	return math.Mod(this.degrees, 360)
+}
+
+func (this *Angle) Wrap(limit float64) {
+	// migrated from float_remainder.java:12:5
+	this.degrees = math.Mod(this.degrees, limit)
+}
+
+func (this *Angle) Bucket(value int, size int) int {
+	// migrated from float_remainder.java:16:5
+	return ((value % size) - (value / size))
+}
+
+func (this *Angle) Scaled(value int) float64 {
+	// migrated from float_remainder.java:20:5
+	return (float64((value % 7)) * 1.5)
+}
@@ -0,0 +1,85 @@
+package converted
+
+import (
+	"cmp"
+)
+
+type Source[T any] interface {
+	Next() T
+}
+
+type Box[T any] struct {
+	value T
+}
+
+type pair[A any, B any] struct {
+	first  A
+	second B
+}
+
+type client struct {
+}
+
+func NewBoxFromT[T any](value T) *Box[T] {
+	this := &Box[T]{}
+	this.value = value
+	return this
+}
+
+func Max[T cmp.Ordered](a T, b T) T {
+	// migrated from generic_classes_and_methods.java:18:5
+	if cmp.Compare(a, b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func first[K any, V []K](key K, values V) V {
+	// migrated from generic_classes_and_methods.java:25:5
+	return values
+}
+
+func newPairFromAB[A any, B any](first A, second B) *pair[A, B] {
+	this := &pair[A, B]{}
+	this.first = first
+	this.second = second
+	return this
+}
+
+func Peek[T any](this Source[T]) T {
+	// migrated from generic_classes_and_methods.java:47:5
+	if override, ok := this.(interface{ Peek() T }); ok {
+		return override.Peek()
+	}
+	return this.Next()
+}
+
+func newClient() *client {
+	this := &client{}
+	return this
+}
+
+func (this *Box[T]) Get() T {
+	// migrated from generic_classes_and_methods.java:10:5
+	return this.value
+}
+
+func (this *Box[T]) With(other any) *Box[any] {
+	// migrated from generic_classes_and_methods.java:14:5
+	// FIXME: type parameters <R> were replaced by their constraints since Go methods can't have type parameters
+	return NewBoxFromT(other)
+}
+
+func (this *pair[A, B]) getFirst() A {
+	// migrated from generic_classes_and_methods.java:39:5
+	return this.first
+}
+
+func (this *client) run() int {
+	// migrated from generic_classes_and_methods.java:53:5
+	named := NewBoxFromT[string]("name")
+	counted := NewBoxFromT[int](1)
+	pair := newPairFromAB[string, int]("a", 1)
+	label := (named.Get() + pair.getFirst())
+	return (counted.Get() + len(label))
+}
@@ -6,7 +6,7 @@ type pairs struct {
 	either Either[int64, string]
 }
 
-func newPairs() pairs {
-	this := pairs{}
+func newPairs() *pairs {
+	this := &pairs{}
 	return this
 }
@@ -1,12 +1,12 @@
 package converted
 
 type container struct {
-	stringOpt  Optional[string]
+	stringOpt  *string
 	intResult  Result[int]
 	futureBool Future[bool]
 }
 
-func newContainer() container {
-	this := container{}
+func newContainer() *container {
+	this := &container{}
 	return this
 }
@@ -1,12 +1,12 @@
 package converted
 
 type deep struct {
-	deep        Optional[Optional[Optional[string]]]
+	deep        ***string
 	multiNested map[string]map[int][]string
-	veryComplex []Optional[map[string]Result[int]]
+	veryComplex []*map[string]Result[int]
 }
 
-func newDeep() deep {
-	this := deep{}
+func newDeep() *deep {
+	this := &deep{}
 	return this
 }
@@ -1,12 +1,12 @@
 package converted
 
 type nested struct {
-	listOfOpt     []Optional[string]
-	optOfList     Optional[[]int]
+	listOfOpt     []*string
+	optOfList     *[]int
 	listOfResults []Result[bool]
 }
 
-func newNested() nested {
-	this := nested{}
+func newNested() *nested {
+	this := &nested{}
 	return this
 }
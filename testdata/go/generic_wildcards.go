@@ -2,12 +2,12 @@ package converted
 
 type wildcards struct {
 	rawList         []any
-	rawOpt          Optional[any]
+	rawOpt          *any
 	mapWithWildcard map[string]any
 	pairOfWildcards Pair[any, any]
 }
 
-func newWildcards() wildcards {
-	this := wildcards{}
+func newWildcards() *wildcards {
+	this := &wildcards{}
 	return this
 }
@@ -8,7 +8,7 @@ func Test() {
 	map1 := make(map[interface{}]interface{})
 }
 
-func newMapExample() mapExample {
-	this := mapExample{}
+func newMapExample() *mapExample {
+	this := &mapExample{}
 	return this
 }
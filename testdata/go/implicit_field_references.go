@@ -0,0 +1,54 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type Counter struct {
+	count  int
+	label  string
+	parent *Counter
+}
+
+var created = 0
+
+func NewCounterFromStringCounter(label string, parent *Counter) *Counter {
+	this := &Counter{}
+	this.label = label
+	this.parent = parent
+	created = (created + 1)
+	return this
+}
+
+func (this *Counter) Get() int {
+	// migrated from implicit_field_references.java:13:5
+	return this.count
+}
+
+func (this *Counter) Increment() {
+	// migrated from implicit_field_references.java:17:5
+	this.count++
+}
+
+func (this *Counter) Inherited() int {
+	// migrated from implicit_field_references.java:21:5
+	return (this.parent.Get() + this.count)
+}
+
+func (this *Counter) Reset(count int) {
+	// migrated from implicit_field_references.java:25:5
+	this.count = count
+	this.label = "reset"
+}
+
+func (this *Counter) Describe() string {
+	// migrated from implicit_field_references.java:30:5
+	label := "counter"
+	return fmt.Sprintf("%s%d", label, this.count)
+}
+
+func (this *Counter) Total(extra int) int {
+	// migrated from implicit_field_references.java:35:5
+	count := extra
+	return (count + this.count)
+}
@@ -0,0 +1,46 @@
+package converted
+
+type stats struct {
+}
+
+func newStats() *stats {
+	this := &stats{}
+	return this
+}
+
+func (this *stats) sum(values *[]int) int {
+	// migrated from index_loop_range.java:2:5
+	total := 0
+	for _, value := range *values {
+		total = (total + value)
+	}
+	return total
+}
+
+func (this *stats) firstNegative(values *[]int) int {
+	// migrated from index_loop_range.java:10:5
+	for i, value := range *values {
+		if value < 0 {
+			return i
+		}
+	}
+	return (-1)
+}
+
+func (this *stats) squares() []int {
+	// migrated from index_loop_range.java:19:5
+	squares := []int{0, 0, 0, 0}
+	for i := range squares {
+		squares[i] = (i * i)
+	}
+	return squares
+}
+
+func (this *stats) count(names *[]string) int {
+	// migrated from index_loop_range.java:27:5
+	count := 0
+	for range *names {
+		count++
+	}
+	return count
+}
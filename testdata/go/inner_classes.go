@@ -0,0 +1,112 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type Slot struct {
+	outer    *Shelf
+	position int
+}
+
+type Shelf struct {
+	outer    *Library
+	capacity int
+}
+
+type libraryCatalog struct {
+	size int
+}
+
+type ledger struct {
+	outer *Library
+}
+
+type Library struct {
+	loans int
+	name  string
+}
+
+func newSlotFromInt(outer *Shelf, position int) *Slot {
+	this := &Slot{}
+	this.outer = outer
+	this.position = position
+	return this
+}
+
+func NewShelfFromInt(outer *Library, capacity int) *Shelf {
+	this := &Shelf{}
+	this.outer = outer
+	this.capacity = capacity
+	return this
+}
+
+func newLibraryCatalog() *libraryCatalog {
+	this := &libraryCatalog{}
+	return this
+}
+
+func newLedger(outer *Library) *ledger {
+	this := &ledger{}
+	this.outer = outer
+	return this
+}
+
+func NewLibraryFromString(name string) *Library {
+	this := &Library{}
+	this.name = name
+	return this
+}
+
+func (this *Slot) label() string {
+	// migrated from inner_classes.java:36:13
+	return fmt.Sprintf("%v/%d", this.outer.outer.name, this.position)
+}
+
+func (this *Slot) limit() int {
+	// migrated from inner_classes.java:40:13
+	return this.outer.capacity
+}
+
+func (this *Shelf) describe() string {
+	// migrated from inner_classes.java:16:9
+	return fmt.Sprintf("%v shelf for %d books", this.outer.name, this.capacity)
+}
+
+func (this *Shelf) lend() {
+	// migrated from inner_classes.java:20:9
+	this.outer.loans = (this.outer.loans + 1)
+	this.outer.record(this.capacity)
+}
+
+func (this *Shelf) slot(position int) *Slot {
+	// migrated from inner_classes.java:25:9
+	return newSlotFromInt(this, position)
+}
+
+func (this *ledger) total() int {
+	// migrated from inner_classes.java:51:9
+	return this.outer.loans
+}
+
+func (this *Library) record(count int) {
+	// migrated from inner_classes.java:56:5
+	this.loans = (this.loans + count)
+}
+
+func (this *Library) newShelf(capacity int) *Shelf {
+	// migrated from inner_classes.java:60:5
+	return NewShelfFromInt(this, capacity)
+}
+
+func (this *Library) loanCount() int {
+	// migrated from inner_classes.java:64:5
+	ledger := newLedger(this)
+	return ledger.total()
+}
+
+func (this *Library) firstSlot(other *Library) *Slot {
+	// migrated from inner_classes.java:69:5
+	shelf := NewShelfFromInt(other, 10)
+	return shelf.slot(0)
+}
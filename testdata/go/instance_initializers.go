@@ -0,0 +1,47 @@
+package converted
+
+type Inventory struct {
+	reserved int
+	capacity int
+	label    string
+}
+
+type counter struct {
+	count int
+}
+
+func NewInventory() *Inventory {
+	this := &Inventory{}
+	this.reserved = 2
+	// Default field initializations
+
+	this.capacity = (this.reserved + 8)
+	this.label = "inventory"
+	factor := 2
+	this.capacity = (this.capacity * factor)
+	return this
+}
+
+func NewInventoryFromInt(capacity int) *Inventory {
+	this := &Inventory{}
+	this.reserved = 2
+	// Default field initializations
+
+	this.capacity = (this.reserved + 8)
+	this.label = "inventory"
+	factor := 2
+	this.capacity = (this.capacity * factor)
+	this.capacity = capacity
+	return this
+}
+
+func newCounter() *counter {
+	this := &counter{}
+	this.count = 1
+	return this
+}
+
+func (this *Inventory) Free() int {
+	// migrated from instance_initializers.java:23:5
+	return (this.capacity - this.reserved)
+}
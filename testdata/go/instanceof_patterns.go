@@ -0,0 +1,74 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type shapesCircle struct {
+	radius float64
+}
+
+type shapesSquare struct {
+	side float64
+}
+
+type Shapes struct {
+}
+
+func newShapesCircle() *shapesCircle {
+	this := &shapesCircle{}
+	return this
+}
+
+func newShapesSquare() *shapesSquare {
+	this := &shapesSquare{}
+	return this
+}
+
+func area(shape interface{}) float64 {
+	// migrated from instanceof_patterns.java:2:5
+	if c, ok := shape.(*shapesCircle); ok {
+		return ((3.14 * c.radius) * c.radius)
+	} else if s, ok2 := shape.(*shapesSquare); ok2 && (s.side > 0) {
+		return (s.side * s.side)
+	} else if _, ok3 := shape.(string); ok3 {
+		return 0
+	}
+	return (-1)
+}
+
+func radius(shape interface{}) float64 {
+	// migrated from instanceof_patterns.java:13:5
+	c, isCircle := shape.(*shapesCircle)
+	if !isCircle {
+		return 0
+	}
+	return c.radius
+}
+
+func describe(shape interface{}) string {
+	// migrated from instanceof_patterns.java:20:5
+	if s, ok := shape.(*shapesSquare); (!ok) || (s.side == 0) {
+		return "empty"
+	} else {
+		return fmt.Sprintf("square of %v", s.side)
+	}
+}
+
+func isRound(shape interface{}) bool {
+	// migrated from instanceof_patterns.java:28:5
+	_, isCircle := shape.(*shapesCircle)
+	round := isCircle
+	return round
+}
+
+func sameCircle(circle *shapesCircle, other interface{}) bool {
+	// migrated from instanceof_patterns.java:33:5
+	c, isCircle := other.(*shapesCircle)
+	return (isCircle && (c.radius == circle.radius))
+}
+
+func NewShapes() *Shapes {
+	this := &Shapes{}
+	return this
+}
@@ -0,0 +1,96 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type Shape interface {
+	Area() float64
+}
+
+type Solid interface {
+	Shape
+	Height() float64
+}
+
+type Source[T any] interface {
+	Next() T
+}
+
+type Square struct {
+	side  float64
+	count int
+}
+
+var _ Solid = &Square{}
+var _ Source[int] = &Square{}
+
+func Describe(this Shape, prefix string) string {
+	// migrated from interface_default_methods.java:4:5
+	if override, ok := this.(interface{ Describe(prefix string) string }); ok {
+		return override.Describe(prefix)
+	}
+	return fmt.Sprintf("%s%v", prefix, this.Area())
+}
+
+func IsLarge(this Shape) bool {
+	// migrated from interface_default_methods.java:8:5
+	if override, ok := this.(interface{ IsLarge() bool }); ok {
+		return override.IsLarge()
+	}
+	return (this.Area() > 100)
+}
+
+func Summary(this Shape) string {
+	// migrated from interface_default_methods.java:12:5
+	if override, ok := this.(interface{ Summary() string }); ok {
+		return override.Summary()
+	}
+	return Describe(this, "shape ")
+}
+
+func Skip[T any](this Source[T]) T {
+	// migrated from interface_default_methods.java:24:5
+	if override, ok := this.(interface{ Skip() T }); ok {
+		return override.Skip()
+	}
+	this.Next()
+	return this.Next()
+}
+
+func NewSquareFromFloat64(side float64) *Square {
+	this := &Square{}
+	this.side = side
+	return this
+}
+
+func Label(square *Square, shape Shape) string {
+	// migrated from interface_default_methods.java:59:5
+	return (Summary(square) + Describe(shape, "b "))
+}
+
+func (this *Square) Area() float64 {
+	// migrated from interface_default_methods.java:38:5
+	return (this.side * this.side)
+}
+
+func (this *Square) Height() float64 {
+	// migrated from interface_default_methods.java:42:5
+	return 0
+}
+
+func (this *Square) Describe(prefix string) string {
+	// migrated from interface_default_methods.java:46:5
+	return (prefix + "square")
+}
+
+func (this *Square) Next() int {
+	// migrated from interface_default_methods.java:50:5
+	this.count = (this.count + 1)
+	return this.count
+}
+
+func (this *Square) Fits() bool {
+	// migrated from interface_default_methods.java:55:5
+	return ((!IsLarge(this)) && (Skip[int](this) > 1))
+}
@@ -0,0 +1,127 @@
+package converted
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Iterator iterates over a sequence of values, migrated from java.util.Iterator
+type Iterator[T any] interface {
+	HasNext() bool
+	Next() T
+}
+
+// Iterable is a sequence of values that can be iterated over, migrated from java.lang.Iterable
+type Iterable[T any] interface {
+	Iterator() Iterator[T]
+}
+
+type Playlist struct {
+	songs   []string
+	artists map[string]bool
+	titles  string
+}
+
+type songIterator struct {
+	remaining string
+}
+
+type sliceIterator[T any] struct {
+	items []T
+	next  int
+}
+
+var _ Iterable[string] = &Playlist{}
+var _ Iterator[string] = &songIterator{}
+
+func NewPlaylist() *Playlist {
+	this := &Playlist{}
+	return this
+}
+
+func newSongIteratorFromString(songs string) *songIterator {
+	this := &songIterator{}
+	this.remaining = songs
+	return this
+}
+
+func IteratorOf[T any](items []T) Iterator[T] {
+	return &sliceIterator[T]{items: items}
+}
+
+func (this *Playlist) Iterator() Iterator[string] {
+	// migrated from iterators.java:10:5
+	return newSongIteratorFromString(this.titles)
+}
+
+func (this *Playlist) totalLength() int {
+	// migrated from iterators.java:14:5
+	total := 0
+	for _, song := range this.songs {
+		total = (total + len(song))
+	}
+	return total
+}
+
+func (this *Playlist) countArtists(prefix string) int {
+	// migrated from iterators.java:24:5
+	count := 0
+	for artist := range this.artists {
+		if strings.HasPrefix(artist, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+func (this *Playlist) firstArtist() string {
+	// migrated from iterators.java:35:5
+	it := IteratorOf(slices.Collect(maps.Keys(this.artists)))
+	if it.HasNext() {
+		return it.Next()
+	}
+	return ""
+}
+
+func (this *Playlist) countAll() int {
+	// migrated from iterators.java:43:5
+	count := 0
+	for iterator := this.Iterator(); iterator.HasNext(); {
+		song := iterator.Next()
+		count = (count + len(song))
+	}
+	return count
+}
+
+func (this *songIterator) HasNext() bool {
+	// migrated from iterators.java:59:5
+	return (!(len(this.remaining) == 0))
+}
+
+func (this *songIterator) Next() string {
+	// migrated from iterators.java:63:5
+	end := strings.Index(this.remaining, ";")
+	if end < 0 {
+		end = len(this.remaining)
+	}
+	song := this.remaining[0:end]
+	var ternaryResult string
+	if end < len(this.remaining) {
+		ternaryResult = this.remaining[(end + 1):]
+	} else {
+		ternaryResult = ""
+	}
+	this.remaining = ternaryResult
+	return song
+}
+
+func (it *sliceIterator[T]) HasNext() bool {
+	return it.next < len(it.items)
+}
+
+func (it *sliceIterator[T]) Next() T {
+	item := it.items[it.next]
+	it.next++
+	return item
+}
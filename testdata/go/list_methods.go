@@ -0,0 +1,99 @@
+package converted
+
+import (
+	"maps"
+	"slices"
+)
+
+type Inventory struct {
+	items  []string
+	counts []int
+}
+
+func NewInventory() *Inventory {
+	this := &Inventory{}
+	this.counts = make([]int, 0)
+	this.items = make([]string, 0)
+	// Default field initializations
+
+	return this
+}
+
+func (this *Inventory) first() string {
+	// migrated from list_methods.java:9:5
+	if len(this.items) == 0 {
+		return ""
+	}
+	return this.items[0]
+}
+
+func (this *Inventory) rename(index int, name string) string {
+	// migrated from list_methods.java:16:5
+	previous2 := this.items[index]
+	this.items[index] = name
+	previous := previous2
+	this.counts[index] = 0
+	return previous
+}
+
+func (this *Inventory) drop(name string) int {
+	// migrated from list_methods.java:22:5
+	index := slices.Index(this.items, name)
+	if index >= 0 {
+		this.items = slices.Delete(this.items, index, index+1)
+		this.counts = slices.Delete(this.counts, index, index+1)
+	}
+	return index
+}
+
+func (this *Inventory) discard(name string) bool {
+	// migrated from list_methods.java:31:5
+	index := slices.Index(this.items, name)
+	removed := index >= 0
+	if removed {
+		this.items = slices.Delete(this.items, index, index+1)
+	}
+	return removed
+}
+
+func (this *Inventory) take(index int) int {
+	// migrated from list_methods.java:35:5
+	removed := this.items[index]
+	this.items = slices.Delete(this.items, index, index+1)
+	taken := removed
+	index2 := slices.Index(this.counts, int(index))
+	if index2 >= 0 {
+		this.counts = slices.Delete(this.counts, index2, index2+1)
+	}
+	return len(taken)
+}
+
+func (this *Inventory) has(name string) bool {
+	// migrated from list_methods.java:41:5
+	return slices.Contains(this.items, name)
+}
+
+func (this *Inventory) merge(others *[]string, extra map[string]bool) {
+	// migrated from list_methods.java:45:5
+	this.items = append(this.items, *others...)
+	this.items = append(this.items, slices.Collect(maps.Keys(extra))...)
+	*others = (*others)[:0]
+}
+
+func (this *Inventory) last(names *[]string) int {
+	// migrated from list_methods.java:51:5
+	*names = append(*names, "end")
+	return ((len(*names) - 1) + len(this.items))
+}
+
+func (this *Inventory) page(from int, to int) []string {
+	// migrated from list_methods.java:56:5
+	result := this.items[from:to]
+	return result
+}
+
+func (this *Inventory) reset() {
+	// migrated from list_methods.java:61:5
+	this.items = this.items[:0]
+	this.counts = this.counts[:0]
+}
@@ -0,0 +1,67 @@
+package converted
+
+import (
+	"slices"
+)
+
+type Roster struct {
+}
+
+func NewRoster() *Roster {
+	this := &Roster{}
+	return this
+}
+
+func (this *Roster) first(names *[]string) string {
+	// migrated from list_parameter_methods.java:5:5
+	if len(*names) == 0 {
+		return ""
+	}
+	return (*names)[0]
+}
+
+func (this *Roster) rename(names *[]string, index int, name string) string {
+	// migrated from list_parameter_methods.java:12:5
+	previous := (*names)[index]
+	(*names)[index] = name
+	return previous
+}
+
+func (this *Roster) drop(names *[]string, name string) bool {
+	// migrated from list_parameter_methods.java:16:5
+	index := slices.Index(*names, name)
+	if index >= 0 {
+		*names = slices.Delete(*names, index, index+1)
+	}
+	return slices.Contains(*names, name)
+}
+
+func (this *Roster) cycle(names *[]string, name string) {
+	// migrated from list_parameter_methods.java:24:5
+	index := slices.Index(*names, name)
+	if index >= 0 {
+		*names = slices.Delete(*names, index, index+1)
+	}
+	*names = append(*names, name)
+}
+
+func (this *Roster) merge(names *[]string, others *[]string, extra *[]string) {
+	// migrated from list_parameter_methods.java:29:5
+	*names = append(*names, *others...)
+	*names = append(*names, *extra...)
+	*others = (*others)[:0]
+}
+
+func (this *Roster) page(names *[]string, from int, to int) []string {
+	// migrated from list_parameter_methods.java:35:5
+	return (*names)[from:to]
+}
+
+func (this *Roster) total(names *[]string) int {
+	// migrated from list_parameter_methods.java:39:5
+	total := 0
+	for _, name := range *names {
+		total = (total + len(name))
+	}
+	return total
+}
@@ -0,0 +1,115 @@
+package converted
+
+import (
+	"maps"
+	"slices"
+)
+
+type WordCounter struct {
+	counts map[string]int
+}
+
+func NewWordCounter() *WordCounter {
+	this := &WordCounter{}
+	this.counts = make(map[string]int)
+	// Default field initializations
+
+	return this
+}
+
+func (this *WordCounter) add(word string) {
+	// migrated from map_method_calls.java:8:5
+	_, hasKey := this.counts[word]
+	if hasKey {
+		this.counts[word] = (this.counts[word] + 1)
+	} else {
+		this.counts[word] = 1
+	}
+}
+
+func (this *WordCounter) isNew(word string) bool {
+	// migrated from map_method_calls.java:16:5
+	_, hasKey := this.counts[word]
+	if !hasKey {
+		return true
+	}
+	_, hasKey2 := this.counts[(word + "s")]
+	return hasKey2
+}
+
+func (this *WordCounter) countOf(word string) int {
+	// migrated from map_method_calls.java:23:5
+	valueOrDefault := 0
+	if v, ok := this.counts[word]; ok {
+		valueOrDefault = v
+	}
+	return valueOrDefault
+}
+
+func (this *WordCounter) lookup(word string) int {
+	// migrated from map_method_calls.java:27:5
+	count, hasCount := this.counts[word]
+	if !hasCount {
+		return 0
+	}
+	return count
+}
+
+func (this *WordCounter) forget(word string) int {
+	// migrated from map_method_calls.java:35:5
+	previous := this.counts[word]
+	delete(this.counts, word)
+	count := previous
+	return count
+}
+
+func (this *WordCounter) drop(word string) {
+	// migrated from map_method_calls.java:40:5
+	delete(this.counts, word)
+}
+
+func (this *WordCounter) total() int {
+	// migrated from map_method_calls.java:44:5
+	sum := 0
+	for _, count := range this.counts {
+		sum = (sum + count)
+	}
+	return sum
+}
+
+func (this *WordCounter) longest() string {
+	// migrated from map_method_calls.java:52:5
+	result := ""
+	for word := range this.counts {
+		if this.counts[word] > this.counts[result] {
+			result = word
+		}
+	}
+	return result
+}
+
+func (this *WordCounter) weighted(weights map[string]int) int {
+	// migrated from map_method_calls.java:62:5
+	sum := 0
+	for entryKey, entryValue := range weights {
+		sum = (sum + (entryValue * this.countOf(entryKey)))
+	}
+	return sum
+}
+
+func (this *WordCounter) merge(other map[string]int) {
+	// migrated from map_method_calls.java:70:5
+	if !(len(other) == 0) {
+		maps.Copy(this.counts, other)
+	}
+}
+
+func (this *WordCounter) words() []string {
+	// migrated from map_method_calls.java:76:5
+	return slices.Collect(maps.Keys(this.counts))
+}
+
+func (this *WordCounter) reset() {
+	// migrated from map_method_calls.java:80:5
+	clear(this.counts)
+}
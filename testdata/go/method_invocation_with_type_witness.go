@@ -0,0 +1,22 @@
+package converted
+
+type test struct {
+}
+
+func newTest() *test {
+	this := &test{}
+	return this
+}
+
+func (this *test) test() {
+	// migrated from method_invocation_with_type_witness.java:2:5
+	names := []string{}
+	counts := map[string]int{}
+	box := Factory.create[int](1)
+	value := this.helper()
+}
+
+func (this *test) helper() interface{} {
+	// migrated from method_invocation_with_type_witness.java:9:5
+	return nil
+}
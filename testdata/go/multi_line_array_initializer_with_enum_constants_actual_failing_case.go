@@ -5,7 +5,7 @@ type test struct {
 
 var FUNC_TYPE_OR_DEF = []ParserRuleContext{ParserRuleContext_RETURNS_KEYWORD, ParserRuleContext_FUNC_BODY}
 
-func newTest() test {
-	this := test{}
+func newTest() *test {
+	this := &test{}
 	return this
 }
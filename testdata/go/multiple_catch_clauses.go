@@ -3,8 +3,8 @@ package converted
 type test struct {
 }
 
-func newTest() test {
-	this := test{}
+func newTest() *test {
+	this := &test{}
 	return this
 }
 
@@ -13,9 +13,10 @@ func (this *test) test() {
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				if _, ok := r.(IllegalArgumentException); ok {
+				if e, ok := r.(error); ok {
 					this.handleIllegal(e)
-				} else if _, ok := r.(IllegalStateException); ok {
+				} else if e, ok := r.(error); ok {
+					// FIXME: IllegalStateException is an error like the exceptions caught before, so this clause is never reached
 					this.handleState(e)
 				} else {
 					panic(r) // re-panic if it's not a handled exception
@@ -0,0 +1,22 @@
+package converted
+
+type grid struct {
+	cells [][]int
+}
+
+var NAMES = [][]string{{"a"}, {"b", "c"}}
+
+func newGrid() *grid {
+	this := &grid{}
+	this.cells = [][]int{{1, 2}, {3, 4}}
+	// Default field initializations
+
+	return this
+}
+
+func (this *grid) fill() {
+	// migrated from nested_array_initializers.java:5:5
+	local := [][]int{{1}, {2, 3}}
+	cube := [][][]int{{{1}}, {{2, 3}}}
+	mixed := []interface{}{[]int{1}, "x"}
+}
@@ -1,5 +1,9 @@
 package converted
 
+import (
+	"fmt"
+)
+
 type BaseData interface {
 }
 
@@ -20,3 +24,32 @@ const (
 	Type_TYPE_A Type = iota
 	Type_TYPE_B
 )
+
+func TypeValues() []Type {
+	return []Type{Type_TYPE_A, Type_TYPE_B}
+}
+
+func ParseType(name string) Type {
+	switch name {
+	case "TYPE_A":
+		return Type_TYPE_A
+	case "TYPE_B":
+		return Type_TYPE_B
+	}
+	panic(fmt.Sprintf("no enum constant Type.%s", name))
+}
+
+func (this Type) String() string {
+	switch this {
+	case Type_TYPE_A:
+		return "TYPE_A"
+	case Type_TYPE_B:
+		return "TYPE_B"
+	default:
+		return fmt.Sprintf("Type(%d)", uint(this))
+	}
+}
+
+func (this Type) Ordinal() int {
+	return int(this)
+}
@@ -1,5 +1,9 @@
 package converted
 
+import (
+	"fmt"
+)
+
 type Inner uint
 
 type Outer struct {
@@ -10,7 +14,36 @@ const (
 	Inner_SECOND
 )
 
-func NewOuter() Outer {
-	this := Outer{}
+func InnerValues() []Inner {
+	return []Inner{Inner_FIRST, Inner_SECOND}
+}
+
+func ParseInner(name string) Inner {
+	switch name {
+	case "FIRST":
+		return Inner_FIRST
+	case "SECOND":
+		return Inner_SECOND
+	}
+	panic(fmt.Sprintf("no enum constant Inner.%s", name))
+}
+
+func NewOuter() *Outer {
+	this := &Outer{}
 	return this
 }
+
+func (this Inner) String() string {
+	switch this {
+	case Inner_FIRST:
+		return "FIRST"
+	case Inner_SECOND:
+		return "SECOND"
+	default:
+		return fmt.Sprintf("Inner(%d)", uint(this))
+	}
+}
+
+func (this Inner) Ordinal() int {
+	return int(this)
+}
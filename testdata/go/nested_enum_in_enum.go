@@ -1,5 +1,9 @@
 package converted
 
+import (
+	"fmt"
+)
+
 type Outer uint
 
 type Inner uint
@@ -13,3 +17,61 @@ const (
 	Inner_INNER_A Inner = iota
 	Inner_INNER_B
 )
+
+func OuterValues() []Outer {
+	return []Outer{Outer_VALUE1, Outer_VALUE2}
+}
+
+func ParseOuter(name string) Outer {
+	switch name {
+	case "VALUE1":
+		return Outer_VALUE1
+	case "VALUE2":
+		return Outer_VALUE2
+	}
+	panic(fmt.Sprintf("no enum constant Outer.%s", name))
+}
+
+func InnerValues() []Inner {
+	return []Inner{Inner_INNER_A, Inner_INNER_B}
+}
+
+func ParseInner(name string) Inner {
+	switch name {
+	case "INNER_A":
+		return Inner_INNER_A
+	case "INNER_B":
+		return Inner_INNER_B
+	}
+	panic(fmt.Sprintf("no enum constant Inner.%s", name))
+}
+
+func (this Outer) String() string {
+	switch this {
+	case Outer_VALUE1:
+		return "VALUE1"
+	case Outer_VALUE2:
+		return "VALUE2"
+	default:
+		return fmt.Sprintf("Outer(%d)", uint(this))
+	}
+}
+
+func (this Outer) Ordinal() int {
+	return int(this)
+}
+
+func (this Inner) String() string {
+	switch this {
+	case Inner_INNER_A:
+		return "INNER_A"
+	case Inner_INNER_B:
+		return "INNER_B"
+	default:
+		return fmt.Sprintf("Inner(%d)", uint(this))
+	}
+}
+
+func (this Inner) Ordinal() int {
+	return int(this)
+}
@@ -1,5 +1,9 @@
 package converted
 
+import (
+	"fmt"
+)
+
 type Container interface {
 }
 
@@ -9,3 +13,32 @@ const (
 	Item_A Item = iota
 	Item_B
 )
+
+func ItemValues() []Item {
+	return []Item{Item_A, Item_B}
+}
+
+func ParseItem(name string) Item {
+	switch name {
+	case "A":
+		return Item_A
+	case "B":
+		return Item_B
+	}
+	panic(fmt.Sprintf("no enum constant Item.%s", name))
+}
+
+func (this Item) String() string {
+	switch this {
+	case Item_A:
+		return "A"
+	case Item_B:
+		return "B"
+	default:
+		return fmt.Sprintf("Item(%d)", uint(this))
+	}
+}
+
+func (this Item) Ordinal() int {
+	return int(this)
+}
@@ -1,5 +1,9 @@
 package converted
 
+import (
+	"fmt"
+)
+
 type Inner uint
 
 type Outer struct {
@@ -11,7 +15,36 @@ const (
 	Inner_TWO
 )
 
-func NewOuter() Outer {
-	this := Outer{}
+func InnerValues() []Inner {
+	return []Inner{Inner_ONE, Inner_TWO}
+}
+
+func ParseInner(name string) Inner {
+	switch name {
+	case "ONE":
+		return Inner_ONE
+	case "TWO":
+		return Inner_TWO
+	}
+	panic(fmt.Sprintf("no enum constant Inner.%s", name))
+}
+
+func NewOuter() *Outer {
+	this := &Outer{}
 	return this
 }
+
+func (this Inner) String() string {
+	switch this {
+	case Inner_ONE:
+		return "ONE"
+	case Inner_TWO:
+		return "TWO"
+	default:
+		return fmt.Sprintf("Inner(%d)", uint(this))
+	}
+}
+
+func (this Inner) Ordinal() int {
+	return int(this)
+}
@@ -7,12 +7,12 @@ type Inner struct {
 type Outer struct {
 }
 
-func NewInner() Inner {
-	this := Inner{}
+func NewInner() *Inner {
+	this := &Inner{}
 	return this
 }
 
-func NewOuter() Outer {
-	this := Outer{}
+func NewOuter() *Outer {
+	this := &Outer{}
 	return this
 }
@@ -7,7 +7,7 @@ type item struct {
 	Name string
 }
 
-func newItem() Item {
-	this := Item{}
+func newItem() *item {
+	this := &item{}
 	return this
 }
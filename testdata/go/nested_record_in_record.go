@@ -8,12 +8,12 @@ type Outer struct {
 	X int
 }
 
-func NewInner() Inner {
-	this := Inner{}
+func NewInner() *Inner {
+	this := &Inner{}
 	return this
 }
 
-func NewOuter() Outer {
-	this := Outer{}
+func NewOuter() *Outer {
+	this := &Outer{}
 	return this
 }
@@ -0,0 +1,60 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type Kind uint
+
+type Parser struct {
+	current Kind
+}
+
+const (
+	Kind_TOKEN Kind = iota
+	Kind_NODE
+)
+
+func KindValues() []Kind {
+	return []Kind{Kind_TOKEN, Kind_NODE}
+}
+
+func ParseKind(name string) Kind {
+	switch name {
+	case "TOKEN":
+		return Kind_TOKEN
+	case "NODE":
+		return Kind_NODE
+	}
+	panic(fmt.Sprintf("no enum constant Kind.%s", name))
+}
+
+func NewParser() *Parser {
+	this := &Parser{}
+	return this
+}
+
+func (this Kind) String() string {
+	switch this {
+	case Kind_TOKEN:
+		return "TOKEN"
+	case Kind_NODE:
+		return "NODE"
+	default:
+		return fmt.Sprintf("Kind(%d)", uint(this))
+	}
+}
+
+func (this Kind) Ordinal() int {
+	return int(this)
+}
+
+func (this *Parser) isToken(kind Kind) bool {
+	// migrated from nested_type_qualified_references.java:9:5
+	return (kind == Kind_TOKEN)
+}
+
+func (this *Parser) parse(text string) Kind {
+	// migrated from nested_type_qualified_references.java:13:5
+	return ParseKind(text)
+}
@@ -0,0 +1,35 @@
+package converted
+
+type test struct {
+}
+
+func newTest() *test {
+	this := &test{}
+	return this
+}
+
+func (this *test) pick(given interface{}, other interface{}) interface{} {
+	// migrated from null_coalescing_and_null_guards.java:2:5
+	givenOrDefault := given
+	if givenOrDefault == nil {
+		givenOrDefault = other
+	}
+	first := givenOrDefault
+	givenOrDefault2 := given
+	if givenOrDefault2 == nil {
+		givenOrDefault2 = this.lookup()
+	}
+	second := givenOrDefault2
+	if other == nil {
+		other = first
+	}
+	if given == nil {
+		return second
+	}
+	return given
+}
+
+func (this *test) lookup() interface{} {
+	// migrated from null_coalescing_and_null_guards.java:9:5
+	return nil
+}
@@ -0,0 +1,23 @@
+package converted
+
+type test struct {
+}
+
+func newTest() *test {
+	this := &test{}
+	return this
+}
+
+func (this *test) test() {
+	// migrated from numeric_literals_with_bases_and_separators.java:2:5
+	million := 1000000
+	mask := 0xFF_FF
+	upper := 0xCAFE
+	allBits := -1
+	wide := int64(0x7fff_ffff_ffff)
+	flags := 0b1010
+	binaryLong := int64(0b1)
+	permissions := 0o777
+	octalLong := int64(0o17)
+	zero := 0
+}
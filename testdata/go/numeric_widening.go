@@ -0,0 +1,60 @@
+package converted
+
+type Account struct {
+	balance float64
+	total   int64
+	visits  int
+}
+
+var RATE = float64(2)
+
+func NewAccountFromFloat64(balance float64) *Account {
+	this := &Account{}
+	this.balance = balance
+	return this
+}
+
+func NewAccountOpen(deposit int) *Account {
+	// migrated from numeric_widening.java:11:5
+	return NewAccountFromFloat64(float64(deposit))
+}
+
+func (this *Account) Average(sum int, count int) float64 {
+	// migrated from numeric_widening.java:15:5
+	result := float64(sum)
+	result = (float64(sum) / 2.0)
+	if count > 0 {
+		return (float64(sum) / float64(count))
+	}
+	return result
+}
+
+func (this *Account) Widen(x int) int64 {
+	// migrated from numeric_widening.java:24:5
+	y := int64(x)
+	z := int64(5)
+	f := float64(3)
+	this.total = (this.total + int64(x))
+	this.balance = (this.balance - f)
+	return ((int64(x) + y) + z)
+}
+
+func (this *Account) Deposit(amount int) {
+	// migrated from numeric_widening.java:33:5
+	this.balance = float64(amount)
+	this.balance = (this.balance + (float64(amount) * RATE))
+	this.visits = int((float64(this.visits) + 0.5))
+	this.total = int64(amount)
+	this.Scale(float64(amount))
+}
+
+func (this *Account) Scale(factor float64) float64 {
+	// migrated from numeric_widening.java:41:5
+	return (factor * float64(this.visits))
+}
+
+func (this *Account) Shifted(bits int) int64 {
+	// migrated from numeric_widening.java:45:5
+	mask := (int64(1) << bits)
+	return (mask | int64(bits))
+}
@@ -0,0 +1,70 @@
+package converted
+
+type lookup struct {
+	label   *string
+	measure func(string) int
+	counts  map[string]int
+}
+
+func newLookup() *lookup {
+	this := &lookup{}
+	return this
+}
+
+func (this *lookup) find(key int) *int {
+	// migrated from optional_values.java:10:5
+	if key < 0 {
+		return nil
+	}
+	value := key
+	return &value
+}
+
+func (this *lookup) orZero(key int) int {
+	// migrated from optional_values.java:17:5
+	optional := this.find(key)
+	valueOrElse := 0
+	if optional != nil {
+		valueOrElse = *optional
+	}
+	return valueOrElse
+}
+
+func (this *lookup) labelled() bool {
+	// migrated from optional_values.java:21:5
+	return (this.label != nil)
+}
+
+func (this *lookup) labelText() string {
+	// migrated from optional_values.java:25:5
+	return *this.label
+}
+
+func (this *lookup) labelLength() *int {
+	// migrated from optional_values.java:29:5
+	var mapped *int
+	if this.label != nil {
+		mappedValue := this.measure(*this.label)
+		mapped = &mappedValue
+	}
+	return mapped
+}
+
+func (this *lookup) self() *lookup {
+	// migrated from optional_values.java:33:5
+	return this
+}
+
+func (this *lookup) count(key string) *int {
+	// migrated from optional_values.java:37:5
+	var value *int
+	if element, found := this.counts[key]; found {
+		value = &element
+	}
+	return value
+}
+
+func (this *lookup) none() *int {
+	// migrated from optional_values.java:41:5
+	return nil
+}
@@ -0,0 +1,124 @@
+package converted
+
+type lookup struct {
+	label   Optional[string]
+	measure func(string) int
+	counts  map[string]int
+}
+
+// Optional is a value that may be absent, migrated from java.util.Optional
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
+func newLookup() *lookup {
+	this := &lookup{}
+	return this
+}
+
+func OptionalOf[T any](value T) Optional[T] {
+	return Optional[T]{value: value, present: true}
+}
+
+func OptionalOfNullable[T any](value *T) Optional[*T] {
+	return Optional[*T]{value: value, present: value != nil}
+}
+
+func OptionalEmpty[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+func OptionalMap[T any, R any](o Optional[T], mapper func(T) R) Optional[R] {
+	if !o.present {
+		return Optional[R]{}
+	}
+	return OptionalOf(mapper(o.value))
+}
+
+func (this *lookup) find(key int) Optional[int] {
+	// migrated from optionals_generic.java:10:5
+	if key < 0 {
+		return OptionalEmpty[int]()
+	}
+	return OptionalOf(key)
+}
+
+func (this *lookup) orZero(key int) int {
+	// migrated from optionals_generic.java:17:5
+	return this.find(key).OrElse(0)
+}
+
+func (this *lookup) labelled() bool {
+	// migrated from optionals_generic.java:21:5
+	return this.label.IsPresent()
+}
+
+func (this *lookup) labelText() string {
+	// migrated from optionals_generic.java:25:5
+	return this.label.Get()
+}
+
+func (this *lookup) labelLength() Optional[int] {
+	// migrated from optionals_generic.java:29:5
+	return OptionalMap(this.label, this.measure)
+}
+
+func (this *lookup) self() Optional[*lookup] {
+	// migrated from optionals_generic.java:33:5
+	return OptionalOf(this)
+}
+
+func (this *lookup) count(key string) Optional[int] {
+	// migrated from optionals_generic.java:37:5
+	value := OptionalEmpty[int]()
+	if element, found := this.counts[key]; found {
+		value = OptionalOf(element)
+	}
+	return value
+}
+
+func (this *lookup) none() Optional[int] {
+	// migrated from optionals_generic.java:41:5
+	return OptionalEmpty[int]()
+}
+
+func (this *lookup) named(name string) Optional[string] {
+	// migrated from optionals_generic.java:45:5
+	return OptionalOf(name)
+}
+
+func (o Optional[T]) IsPresent() bool {
+	return o.present
+}
+
+func (o Optional[T]) IsEmpty() bool {
+	return !o.present
+}
+
+func (o Optional[T]) Get() T {
+	if !o.present {
+		panic("no value present")
+	}
+	return o.value
+}
+
+func (o Optional[T]) OrElse(other T) T {
+	if !o.present {
+		return other
+	}
+	return o.value
+}
+
+func (o Optional[T]) OrElseGet(other func() T) T {
+	if !o.present {
+		return other()
+	}
+	return o.value
+}
+
+func (o Optional[T]) IfPresent(action func(T)) {
+	if o.present {
+		action(o.value)
+	}
+}
@@ -3,8 +3,8 @@ package converted
 type calculator struct {
 }
 
-func newCalculator() calculator {
-	this := calculator{}
+func newCalculator() *calculator {
+	this := &calculator{}
 	return this
 }
 
@@ -25,13 +25,7 @@ func (this *calculator) AddWithStringString(a string, b string) string {
 
 func (this *calculator) Test() {
 	// migrated from overloaded_methods_different_types.java:14:5
-	// FIXME: more than one possible method for add with 2 arguments
-
 	x := this.Add(1, 2)
-	// FIXME: more than one possible method for add with 2 arguments
-
-	y := this.Add(1.0, 2.0)
-	// FIXME: more than one possible method for add with 2 arguments
-
-	z := this.Add("Hello", "World")
+	y := this.AddWithFloat64Float64(1.0, 2.0)
+	z := this.AddWithStringString("Hello", "World")
 }
@@ -1,10 +1,14 @@
 package converted
 
+import (
+	"fmt"
+)
+
 type processor struct {
 }
 
-func newProcessor() processor {
-	this := processor{}
+func newProcessor() *processor {
+	this := &processor{}
 	return this
 }
 
@@ -15,12 +19,10 @@ func (this *processor) Process(s string) {
 
 func (this *processor) ProcessWithInt(i int) {
 	// migrated from overloaded_methods_same_param_count.java:6:5
-	System.out.println(("Integer: " + i))
+	System.out.println(fmt.Sprintf("Integer: %d", i))
 }
 
 func (this *processor) Test() {
 	// migrated from overloaded_methods_same_param_count.java:10:5
-	// FIXME: more than one possible method for process with 1 arguments
-
 	this.Process("test")
 }
@@ -1,10 +1,14 @@
 package converted
 
+import (
+	"fmt"
+)
+
 type runner struct {
 }
 
-func newRunner() runner {
-	this := runner{}
+func newRunner() *runner {
+	this := &runner{}
 	return this
 }
 
@@ -17,7 +21,7 @@ func (this *runner) RunWithInt(times int) {
 	// migrated from overloaded_methods_with_zero_args.java:6:5
 	i := 0
 	for ; i < times; i++ {
-		System.out.println(("Running iteration " + i))
+		System.out.println(fmt.Sprintf("Running iteration %d", i))
 	}
 }
 
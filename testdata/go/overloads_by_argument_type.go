@@ -0,0 +1,93 @@
+package converted
+
+type Shape interface {
+	Area() float64
+}
+
+type PrinterSquare struct {
+}
+
+type Printer struct {
+	total int
+}
+
+var _ Shape = &PrinterSquare{}
+
+func NewPrinterSquare() *PrinterSquare {
+	this := &PrinterSquare{}
+	return this
+}
+
+func NewPrinterFromInt(total int) *Printer {
+	this := &Printer{}
+	this.total = total
+	return this
+}
+
+func NewPrinterFromString(text string) *Printer {
+	this := &Printer{}
+	this.total = len(text)
+	return this
+}
+
+func scale(value int64) int64 {
+	// migrated from overloads_by_argument_type.java:46:5
+	return (value * 2)
+}
+
+func scaleWithFloat64(value float64) float64 {
+	// migrated from overloads_by_argument_type.java:50:5
+	return (value * 2)
+}
+
+func doubled(small int) int64 {
+	// migrated from overloads_by_argument_type.java:54:5
+	return (scale(int64(small)) + scale(1))
+}
+
+func (this *PrinterSquare) Area() float64 {
+	// migrated from overloads_by_argument_type.java:7:9
+	return 1
+}
+
+func (this *Printer) Print(value int) {
+	// migrated from overloads_by_argument_type.java:22:5
+	this.total = (this.total + value)
+}
+
+func (this *Printer) PrintWithString(text string) {
+	// migrated from overloads_by_argument_type.java:26:5
+	this.total = (this.total + len(text))
+}
+
+func (this *Printer) PrintWithFloat64(value float64) {
+	// migrated from overloads_by_argument_type.java:30:5
+	this.total = (this.total + 1)
+}
+
+func (this *Printer) PrintWithShape(shape Shape) {
+	// migrated from overloads_by_argument_type.java:34:5
+	this.total = (this.total + 2)
+}
+
+func (this *Printer) Label(value int) string {
+	// migrated from overloads_by_argument_type.java:38:5
+	return "int"
+}
+
+func (this *Printer) LabelWithBool(flag bool) string {
+	// migrated from overloads_by_argument_type.java:42:5
+	return "boolean"
+}
+
+func (this *Printer) Run(other *Printer, square *PrinterSquare, big int64) {
+	// migrated from overloads_by_argument_type.java:58:5
+	this.Print(3)
+	this.PrintWithString("three")
+	other.PrintWithFloat64(3.5)
+	this.PrintWithShape(square)
+	this.PrintWithFloat64(float64(big))
+	this.PrintWithString(this.LabelWithBool(true))
+	copy := NewPrinterFromString("copy")
+	copy.Print(this.total)
+}
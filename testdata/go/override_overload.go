@@ -1,19 +1,21 @@
 package converted
 
 type child struct {
-	Parent
+	parent
+	outer *parent
 }
 
 type parent struct {
 }
 
-func newChild() child {
-	this := child{}
+func newChild(outer *parent) *child {
+	this := &child{}
+	this.outer = outer
 	return this
 }
 
-func newParent() parent {
-	this := parent{}
+func newParent() *parent {
+	this := &parent{}
 	return this
 }
 
@@ -45,7 +47,5 @@ func (this *parent) fooWithInt(a int) {
 func (this *parent) bar() {
 	// migrated from override_overload.java:10:3
 	this.foo()
-	// FIXME: more than one possible method for foo with 1 arguments
-
 	this.fooWithInt(5)
 }
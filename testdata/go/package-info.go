@@ -0,0 +1,5 @@
+// Geometry primitives used by the renderer.
+//
+// Shapes are immutable; use Shape.scale to derive new ones.
+// See Point for the coordinate system.
+package converted
@@ -0,0 +1,26 @@
+package converted
+
+import (
+	"github.com/example/circles"
+	"github.com/example/geo"
+)
+
+type Shape struct {
+	origin geo.Point
+}
+
+func NewShapeFromPoint(origin geo.Point) *Shape {
+	this := &Shape{}
+	this.origin = origin
+	return this
+}
+
+func (this *Shape) Around(radius int) circles.Circle {
+	// migrated from package_mappings_longest_prefix.java:15:5
+	return circles.Of(this.origin, geo.Clamp(radius))
+}
+
+func (this *Shape) Distance(other geo.Point) int {
+	// migrated from package_mappings_longest_prefix.java:19:5
+	return geo.Manhattan(this.origin, other)
+}
@@ -0,0 +1,26 @@
+package converted
+
+import (
+	"github.com/example/geo"
+	"github.com/example/geo/shapes"
+)
+
+type Shape struct {
+	origin geo.Point
+}
+
+func NewShapeFromPoint(origin geo.Point) *Shape {
+	this := &Shape{}
+	this.origin = origin
+	return this
+}
+
+func (this *Shape) Around(radius int) shapes.Circle {
+	// migrated from package_mappings_own_package.java:15:5
+	return shapes.Of(this.origin, geo.Clamp(radius))
+}
+
+func (this *Shape) Distance(other geo.Point) int {
+	// migrated from package_mappings_own_package.java:19:5
+	return geo.Manhattan(this.origin, other)
+}
@@ -0,0 +1,58 @@
+package converted
+
+type geometry struct {
+	scale float64
+}
+
+func product(a float64, b float64) float64 {
+	// migrated from private_helper_functions.java:12:5
+	return (a * b)
+}
+
+func distance(a float64, b float64) float64 {
+	// migrated from private_helper_functions.java:16:5
+	return abs((a - b))
+}
+
+func abs(x float64) float64 {
+	// migrated from private_helper_functions.java:20:5
+	if x < 0 {
+		return (-x)
+	}
+	return x
+}
+
+func newGeometry() *geometry {
+	this := &geometry{}
+	return this
+}
+
+func (this *geometry) Area(w float64, h float64) float64 {
+	// migrated from private_helper_functions.java:4:5
+	return (this.scale * product(w, h))
+}
+
+func (this *geometry) Gap(a float64, b float64) float64 {
+	// migrated from private_helper_functions.java:8:5
+	return distance(a, b)
+}
+
+func (this *geometry) scaled(x float64) float64 {
+	// migrated from private_helper_functions.java:25:5
+	return (x * this.scale)
+}
+
+func (this *geometry) twiceScaled(x float64) float64 {
+	// migrated from private_helper_functions.java:29:5
+	return (this.scaled(x) * 2)
+}
+
+func (this *geometry) shared(x float64) float64 {
+	// migrated from private_helper_functions.java:34:5
+	return x
+}
+
+func (this *geometry) Other(g *geometry) float64 {
+	// migrated from private_helper_functions.java:38:5
+	return g.shared(1)
+}
@@ -0,0 +1,33 @@
+package converted
+
+type Geometry struct {
+	scale float64
+}
+
+func NewGeometry() *Geometry {
+	this := &Geometry{}
+	return this
+}
+
+func (this *Geometry) Area(w float64, h float64) float64 {
+	// migrated from private_helper_methods.java:4:5
+	return (this.scale * this.product(w, h))
+}
+
+func (this *Geometry) Gap(a float64, b float64) float64 {
+	// migrated from private_helper_methods.java:8:5
+	return this.distance(a, b)
+}
+
+func (this *Geometry) product(a float64, b float64) float64 {
+	// migrated from private_helper_methods.java:12:5
+	return (a * b)
+}
+
+func (this *Geometry) distance(a float64, b float64) float64 {
+	// migrated from private_helper_methods.java:16:5
+	if a < b {
+		return (b - a)
+	}
+	return (a - b)
+}
@@ -5,7 +5,7 @@ type privatePoint struct {
 	Y int
 }
 
-func newPrivatePoint() PrivatePoint {
-	this := PrivatePoint{}
+func newPrivatePoint() *privatePoint {
+	this := &privatePoint{}
 	return this
 }
@@ -1,5 +1,9 @@
 package converted
 
+import (
+	"fmt"
+)
+
 type Printable interface {
 	Print()
 }
@@ -11,12 +15,12 @@ type Person struct {
 
 var _ Printable = &Person{}
 
-func NewPerson() Person {
-	this := Person{}
+func NewPerson() *Person {
+	this := &Person{}
 	return this
 }
 
 func (this *Person) Print() {
 	// migrated from record_implementing_interface.java:6:5
-	System.out.println(((("Person: " + name) + ", Age: ") + age))
+	System.out.println(fmt.Sprintf("Person: %v, Age: %v", this.Name, this.Age))
 }
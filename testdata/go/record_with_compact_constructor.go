@@ -1,5 +1,9 @@
 package converted
 
+import (
+	"errors"
+)
+
 type Rational struct {
 	Num   int
 	Denom int
@@ -8,7 +12,7 @@ type Rational struct {
 func newRationalFromNumDenom(num int, denom int) Rational {
 	this := Rational{}
 	if denom == 0 {
-		panic(("Denominator cannot be zero"))
+		panic(errors.New("Denominator cannot be zero"))
 	}
 	if (num < 0) && (denom < 0) {
 		num = (-num)
@@ -19,7 +23,7 @@ func newRationalFromNumDenom(num int, denom int) Rational {
 	return this
 }
 
-func NewRational() Rational {
-	this := Rational{}
+func NewRational() *Rational {
+	this := &Rational{}
 	return this
 }
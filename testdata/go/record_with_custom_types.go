@@ -6,7 +6,7 @@ type Person struct {
 	Active bool
 }
 
-func NewPerson() Person {
-	this := Person{}
+func NewPerson() *Person {
+	this := &Person{}
 	return this
 }
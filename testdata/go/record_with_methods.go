@@ -5,8 +5,8 @@ type Point struct {
 	Y int
 }
 
-func NewPoint() Point {
-	this := Point{}
+func NewPoint() *Point {
+	this := &Point{}
 	return this
 }
 
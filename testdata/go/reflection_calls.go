@@ -0,0 +1,43 @@
+package converted
+
+import (
+	"reflect"
+)
+
+type Shape struct {
+	other interface{}
+}
+
+func NewShape() *Shape {
+	this := &Shape{}
+	return this
+}
+
+func (this *Shape) kind() string {
+	// migrated from reflection_calls.java:4:5
+	return reflect.TypeOf(*this).Name()
+}
+
+func (this *Shape) qualifiedKind() string {
+	// migrated from reflection_calls.java:8:5
+	return reflect.TypeOf(*this).String()
+}
+
+func (this *Shape) sameKind(shape *Shape) bool {
+	// migrated from reflection_calls.java:12:5
+	return ((reflect.TypeOf(*this) == reflect.TypeOf(shape)) && (reflect.TypeOf(*this) == reflect.TypeOf(this.other)))
+}
+
+func (this *Shape) isShape(value interface{}) bool {
+	// migrated from reflection_calls.java:16:5
+	_, isInstance := any(value).(*Shape)
+	if isInstance {
+		return true
+	}
+	return reflect.TypeOf(value).AssignableTo(reflect.TypeOf(*this))
+}
+
+func (this *Shape) widens(shape *Shape) bool {
+	// migrated from reflection_calls.java:23:5
+	return reflect.TypeOf(shape).AssignableTo(reflect.TypeFor[*Shape]())
+}
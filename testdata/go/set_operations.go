@@ -0,0 +1,96 @@
+package converted
+
+import (
+	"maps"
+)
+
+type Visitors struct {
+	seen    map[string]bool
+	blocked map[string]bool
+}
+
+func NewVisitors() *Visitors {
+	this := &Visitors{}
+	this.blocked = map[string]bool{"root": true, "admin": true}
+	this.seen = make(map[string]bool)
+	// Default field initializations
+
+	return this
+}
+
+func (this *Visitors) visit(name string) bool {
+	// migrated from set_operations.java:9:5
+	if this.blocked[name] {
+		return false
+	}
+	added := !this.seen[name]
+	this.seen[name] = true
+	return added
+}
+
+func (this *Visitors) forget(name string) {
+	// migrated from set_operations.java:16:5
+	delete(this.seen, name)
+}
+
+func (this *Visitors) count() int {
+	// migrated from set_operations.java:20:5
+	if len(this.seen) == 0 {
+		return 0
+	}
+	return len(this.seen)
+}
+
+func (this *Visitors) totalLength() int {
+	// migrated from set_operations.java:27:5
+	total := 0
+	for name := range this.seen {
+		total = (total + len(name))
+	}
+	return total
+}
+
+func (this *Visitors) known(names *[]string) map[string]bool {
+	// migrated from set_operations.java:35:5
+	set := make(map[string]bool)
+	for _, element := range *names {
+		set[element] = true
+	}
+	result := set
+	maps.DeleteFunc(result, func(element2 string, _ bool) bool {
+		return !this.seen[element2]
+	})
+	return result
+}
+
+func (this *Visitors) merge(others map[string]bool, extra *[]string) bool {
+	// migrated from set_operations.java:41:5
+	size := len(this.seen)
+	for element := range others {
+		this.seen[element] = true
+	}
+	changed := (len(this.seen) != size)
+	for _, element2 := range *extra {
+		this.seen[element2] = true
+	}
+	for element3 := range this.blocked {
+		delete(this.seen, element3)
+	}
+	cond := changed
+	if cond {
+		containsAll := true
+		for _, element4 := range *extra {
+			if !this.seen[element4] {
+				containsAll = false
+				break
+			}
+		}
+		cond = containsAll
+	}
+	return cond
+}
+
+func (this *Visitors) snapshot() map[string]bool {
+	// migrated from set_operations.java:48:5
+	return maps.Clone(this.seen)
+}
@@ -1,5 +1,9 @@
 package converted
 
+import (
+	"fmt"
+)
+
 type Color uint
 
 const (
@@ -8,7 +12,40 @@ const (
 	Color_GREEN
 )
 
+func ColorValues() []Color {
+	return []Color{Color_RED, Color_BLUE, Color_GREEN}
+}
+
+func ParseColor(name string) Color {
+	switch name {
+	case "RED":
+		return Color_RED
+	case "BLUE":
+		return Color_BLUE
+	case "GREEN":
+		return Color_GREEN
+	}
+	panic(fmt.Sprintf("no enum constant Color.%s", name))
+}
+
+func (this Color) String() string {
+	switch this {
+	case Color_RED:
+		return "RED"
+	case Color_BLUE:
+		return "BLUE"
+	case Color_GREEN:
+		return "GREEN"
+	default:
+		return fmt.Sprintf("Color(%d)", uint(this))
+	}
+}
+
+func (this Color) Ordinal() int {
+	return int(this)
+}
+
 func (this *Color) GetName() string {
 	// migrated from simple_enum_with_methods.java:6:5
-	return this.Name()
+	return this.String()
 }
@@ -5,7 +5,7 @@ type Point struct {
 	Y int
 }
 
-func NewPoint() Point {
-	this := Point{}
+func NewPoint() *Point {
+	this := &Point{}
 	return this
 }
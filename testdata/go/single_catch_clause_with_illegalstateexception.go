@@ -3,8 +3,8 @@ package converted
 type test struct {
 }
 
-func newTest() test {
-	this := test{}
+func newTest() *test {
+	this := &test{}
 	return this
 }
 
@@ -16,7 +16,7 @@ func (this *test) getCompletion(context ParserRuleContext, nextToken Token) Solu
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				if _, ok := r.(IllegalStateException); ok {
+				if _, ok := r.(error); ok {
 					if false {
 						panic("assertion failed")
 					}
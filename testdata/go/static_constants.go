@@ -0,0 +1,48 @@
+package converted
+
+import (
+	"fmt"
+	"math"
+)
+
+type Limits struct {
+}
+
+const (
+	MAX_DEPTH = 64
+	MAX_SIZE  = (int64(1) << 40)
+	MIN_DEPTH = (-MAX_DEPTH)
+	SEPARATOR = ','
+	DEEP      = ((MAX_DEPTH > 32) && (!false))
+	PREFIX    = "limits"
+	NAME      = (PREFIX + ".depth")
+	WIDE      = int(MAX_SIZE)
+)
+
+var RATIO = (float64(MAX_DEPTH) / 2.0)
+var NARROW = int(RATIO)
+var HALF = int(math.Trunc((float64(MAX_DEPTH) / 2.5)))
+var LABEL = fmt.Sprintf("%s%d", PREFIX, MAX_DEPTH)
+var STEPS = []int{1, 2, 4}
+var counter = 0
+
+func NewLimits() *Limits {
+	this := &Limits{}
+	return this
+}
+
+func (this *Limits) clamp(depth int) int {
+	// migrated from static_constants.java:17:5
+	const floor = (MIN_DEPTH + 1)
+	const unit = (PREFIX + " units")
+	current := depth
+	MAX_DEPTH := (depth * 2)
+	doubled := MAX_DEPTH
+	if depth < floor {
+		return (floor + len(unit))
+	}
+	if current < doubled {
+		return current
+	}
+	return doubled
+}
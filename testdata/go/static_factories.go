@@ -0,0 +1,58 @@
+package converted
+
+type Point struct {
+	x int
+	y int
+}
+
+type segment struct {
+	start *Point
+	end   *Point
+}
+
+func NewPointFromIntInt(x int, y int) *Point {
+	this := &Point{}
+	this.x = x
+	this.y = y
+	return this
+}
+
+func NewPointOf(x int, y int) *Point {
+	// migrated from static_factories.java:10:5
+	return NewPointFromIntInt(x, y)
+}
+
+func NewPointOfWithInt(value int) *Point {
+	// migrated from static_factories.java:14:5
+	return NewPointFromIntInt(value, value)
+}
+
+func NewPointFrom(text string) *Point {
+	// migrated from static_factories.java:18:5
+	return NewPointFromIntInt(len(text), 0)
+}
+
+func newPointOrigin() *Point {
+	// migrated from static_factories.java:22:5
+	return NewPointOfWithInt(0)
+}
+
+func NewSegmentFromPointPoint(start *Point, end *Point) *segment {
+	this := &segment{}
+	this.start = start
+	this.end = end
+	return this
+}
+
+func (this *Point) Translate(dx int, dy int) *Point {
+	// migrated from static_factories.java:26:5
+	return NewPointOf((this.x + dx), (this.y + dy))
+}
+
+func (this *segment) ParseEnd(text string) *Point {
+	// migrated from static_factories.java:40:5
+	if len(text) == 0 {
+		return newPointOrigin()
+	}
+	return NewPointFrom(text)
+}
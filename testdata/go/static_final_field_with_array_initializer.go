@@ -5,7 +5,7 @@ type test struct {
 
 var CONSTANTS = []int{10, 20, 30}
 
-func newTest() test {
-	this := test{}
+func newTest() *test {
+	this := &test{}
 	return this
 }
@@ -0,0 +1,35 @@
+package converted
+
+type Units struct {
+}
+
+const (
+	BASE   = 10
+	SCALED = (LIMIT * BASE)
+	LIMIT  = 100
+)
+
+var FACTORS = make(map[string]int)
+
+// Assigned in init to keep the initialization order of Java
+var COUNT int
+
+// Assigned in init to keep the initialization order of Java
+var FIRST int
+
+// Assigned in init to keep the initialization order of Java
+var SECOND int
+
+func init() {
+	// migrated from static_initialization_order.java:10:5
+	FACTORS["base"] = BASE
+	FACTORS["limit"] = LIMIT
+	COUNT = len(FACTORS)
+	FIRST = (SECOND + 1)
+	SECOND = (FIRST + 1)
+}
+
+func NewUnits() *Units {
+	this := &Units{}
+	return this
+}
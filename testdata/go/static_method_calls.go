@@ -0,0 +1,71 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type TemperatureScale struct {
+}
+
+type TemperatureReport struct {
+}
+
+type Temperature struct {
+	celsius float64
+}
+
+func unit() string {
+	// migrated from static_method_calls.java:29:9
+	return "F"
+}
+
+func NewTemperatureScale() *TemperatureScale {
+	this := &TemperatureScale{}
+	return this
+}
+
+func NewTemperatureReport() *TemperatureReport {
+	this := &TemperatureReport{}
+	return this
+}
+
+func NewTemperatureFromFloat64(celsius float64) *Temperature {
+	this := &Temperature{}
+	this.celsius = celsius
+	return this
+}
+
+func ToFahrenheit(celsius float64) float64 {
+	// migrated from static_method_calls.java:8:5
+	return (((celsius * 9) / 5) + 32)
+}
+
+func ToFahrenheitWithInt(celsius int) float64 {
+	// migrated from static_method_calls.java:12:5
+	return ToFahrenheit(float64(celsius))
+}
+
+func freezing(celsius float64) bool {
+	// migrated from static_method_calls.java:16:5
+	return (celsius <= 0)
+}
+
+func (this *TemperatureReport) difference(temperature *Temperature) float64 {
+	// migrated from static_method_calls.java:35:9
+	return (temperature.Fahrenheit() - ToFahrenheitWithInt(1))
+}
+
+func (this *TemperatureReport) units() string {
+	// migrated from static_method_calls.java:39:9
+	return fmt.Sprintf("%v/%v", unit(), unit())
+}
+
+func (this *Temperature) Fahrenheit() float64 {
+	// migrated from static_method_calls.java:20:5
+	return ToFahrenheit(this.celsius)
+}
+
+func (this *Temperature) IsFreezing() bool {
+	// migrated from static_method_calls.java:24:5
+	return freezing(this.celsius)
+}
@@ -0,0 +1,67 @@
+package converted
+
+type ParserNode struct {
+	text string
+}
+
+type parserCursor struct {
+	position int
+}
+
+type Parser struct {
+	head   *ParserNode
+	cursor *parserCursor
+}
+
+type lexerNode struct {
+	value int
+}
+
+type lexer struct {
+}
+
+func NewParserNodeFromString(text string) *ParserNode {
+	this := &ParserNode{}
+	this.text = text
+	return this
+}
+
+func newParserCursorFromInt(position int) *parserCursor {
+	this := &parserCursor{}
+	this.position = position
+	return this
+}
+
+func NewParser() *Parser {
+	this := &Parser{}
+	return this
+}
+
+func newLexerNodeFromInt(value int) *lexerNode {
+	this := &lexerNode{}
+	this.value = value
+	return this
+}
+
+func newLexer() *lexer {
+	this := &lexer{}
+	return this
+}
+
+func (this *ParserNode) copy() *ParserNode {
+	// migrated from static_nested_class_hoisting.java:9:9
+	node := NewParserNodeFromString(this.text)
+	return node
+}
+
+func (this *Parser) parse(text string) *ParserNode {
+	// migrated from static_nested_class_hoisting.java:26:5
+	this.head = NewParserNodeFromString(text)
+	this.cursor = newParserCursorFromInt(0)
+	return this.head.copy()
+}
+
+func (this *lexer) first() *lexerNode {
+	// migrated from static_nested_class_hoisting.java:42:5
+	return newLexerNodeFromInt('a')
+}
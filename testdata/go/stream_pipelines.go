@@ -0,0 +1,119 @@
+package converted
+
+import (
+	"strings"
+)
+
+type Roster struct {
+	names []string
+	tags  map[string]bool
+	score func(string) int
+}
+
+func NewRoster() *Roster {
+	this := &Roster{}
+	return this
+}
+
+func (this *Roster) longNames(min int) []string {
+	// migrated from stream_pipelines.java:11:5
+	var collected []string
+	for _, name := range this.names {
+		if len(name) >= min {
+			collected = append(collected, name)
+		}
+	}
+	return collected
+}
+
+func (this *Roster) scores() []int {
+	// migrated from stream_pipelines.java:15:5
+	var collected []int
+	for _, element := range this.names {
+		mapped := this.score(element)
+		collected = append(collected, mapped)
+	}
+	result := collected
+	return result
+}
+
+func (this *Roster) doubledLengths() map[int]bool {
+	// migrated from stream_pipelines.java:20:5
+	collected := make(map[int]bool)
+	for _, name := range this.names {
+		mapped := len(name)
+		length := mapped
+		mapped2 := (length * 2)
+		collected[mapped2] = true
+	}
+	return collected
+}
+
+func (this *Roster) countTags(prefix string) int64 {
+	// migrated from stream_pipelines.java:24:5
+	var count int64
+	for tag := range this.tags {
+		if strings.HasPrefix(tag, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+func (this *Roster) positiveTotal(values *[]int) int {
+	// migrated from stream_pipelines.java:28:5
+	var sum int
+	for _, v := range *values {
+		if v > 0 {
+			sum += v
+		}
+	}
+	return sum
+}
+
+func (this *Roster) totalLength() int {
+	// migrated from stream_pipelines.java:32:5
+	var sum int
+	for _, name := range this.names {
+		mapped := len(name)
+		sum += mapped
+	}
+	return sum
+}
+
+func (this *Roster) anyEmpty() bool {
+	// migrated from stream_pipelines.java:36:5
+	anyMatches := false
+	for _, name := range this.names {
+		if len(name) == 0 {
+			anyMatches = true
+			break
+		}
+	}
+	return anyMatches
+}
+
+func (this *Roster) allShort() bool {
+	// migrated from stream_pipelines.java:40:5
+	allMatches := true
+	for _, name := range this.names {
+		if !(len(name) < 10) {
+			allMatches = false
+			break
+		}
+	}
+	return allMatches
+}
+
+func (this *Roster) register(name string) {
+	// migrated from stream_pipelines.java:44:5
+}
+
+func (this *Roster) registerAll() {
+	// migrated from stream_pipelines.java:47:5
+	for _, name := range this.names {
+		if !(len(name) == 0) {
+			this.register(name)
+		}
+	}
+}
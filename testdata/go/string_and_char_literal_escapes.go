@@ -0,0 +1,23 @@
+package converted
+
+type test struct {
+}
+
+func newTest() *test {
+	this := &test{}
+	return this
+}
+
+func (this *test) test() {
+	// migrated from string_and_char_literal_escapes.java:2:5
+	plain := "tab\tnewline\n"
+	quotes := "say \"hi\" and 'bye'"
+	space := "a b"
+	octal := "\000\007\012\101\u00ff"
+	unicode := "caf\u00e9 \U0001f600"
+	quote := '\''
+	doubleQuote := '"'
+	nul := '\000'
+	accent := '\u00e9'
+	highSurrogate := rune(0xD83D)
+}
@@ -0,0 +1,82 @@
+package converted
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type Printer struct {
+	out   *strings.Builder
+	items []string
+}
+
+func NewPrinter() *Printer {
+	this := &Printer{}
+	this.out = &strings.Builder{}
+	// Default field initializations
+
+	return this
+}
+
+func (this *Printer) line(text string, indent int) {
+	// migrated from string_builder.java:7:5
+	i := 0
+	for ; i < indent; i++ {
+		this.out.WriteRune(' ')
+	}
+	this.out.WriteString(text)
+	this.out.WriteRune('\n')
+}
+
+func (this *Printer) join(separator int) string {
+	// migrated from string_builder.java:14:5
+	sb := &strings.Builder{}
+	for _, part := range this.items {
+		if sb.Len() > 0 {
+			sb.WriteRune(rune(separator))
+		}
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
+
+func (this *Printer) describe(name string, count int, done bool) string {
+	// migrated from string_builder.java:25:5
+	builder := &strings.Builder{}
+	builder.WriteString("item ")
+	sb := builder
+	sb.WriteString(name)
+	sb.WriteString(": ")
+	fmt.Fprint(sb, count)
+	sb.WriteString(", done=")
+	fmt.Fprint(sb, done)
+	current := sb.String()
+	sb.Reset()
+	sb.WriteString(current[:0] + "[" + current[0:])
+	sb.WriteString("]")
+	return sb.String()
+}
+
+func (this *Printer) reversed(text string) string {
+	// migrated from string_builder.java:32:5
+	builder := &strings.Builder{}
+	builder.WriteString(text)
+	sb := builder
+	reversed := []rune(sb.String())
+	slices.Reverse(reversed)
+	sb.Reset()
+	sb.WriteString(string(reversed))
+	return sb.String()
+}
+
+func (this *Printer) reset() *strings.Builder {
+	// migrated from string_builder.java:37:5
+	this.out.Reset()
+	return this.out
+}
+
+func (this *Printer) output() string {
+	// migrated from string_builder.java:42:5
+	return (this.out.String() + "!")
+}
@@ -0,0 +1,44 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type Parser struct {
+	position int
+	source   string
+}
+
+func NewParser() *Parser {
+	this := &Parser{}
+	return this
+}
+
+func (this *Parser) unexpected(found int, line int, expected string) string {
+	// migrated from string_concat_sprintf.java:5:5
+	return fmt.Sprintf("unexpected '%c' at line %d, expected %s (100%% sure)", found, line, expected)
+}
+
+func (this *Parser) describe(weight float64, done bool) string {
+	// migrated from string_concat_sprintf.java:9:5
+	prefix := fmt.Sprintf("weight=%v", weight)
+	return fmt.Sprintf("%s; done=%v; sum=%v\n", prefix, done, (1 + 2))
+}
+
+func (this *Parser) leadingSum(a int, b int) string {
+	// migrated from string_concat_sprintf.java:14:5
+	return fmt.Sprintf("%v items", (a + b))
+}
+
+func (this *Parser) check(token string) string {
+	// migrated from string_concat_sprintf.java:18:5
+	if token == fmt.Sprintf("end%d", this.position) {
+		return fmt.Sprintf("token %s at %d of %s", token, this.position, this.source)
+	}
+	return ""
+}
+
+func (this *Parser) join(a string, b string) string {
+	// migrated from string_concat_sprintf.java:25:5
+	return (((a + ", ") + b) + "!")
+}
@@ -0,0 +1,28 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type point struct {
+	x int
+	y int
+}
+
+func newPoint() *point {
+	this := &point{}
+	return this
+}
+
+func (this *point) String() string {
+	// migrated from string_conversions.java:5:5
+	return (((("(" + fmt.Sprint(this.x)) + ", ") + fmt.Sprint(this.y)) + ")")
+}
+
+func (this *point) describe(other *point) string {
+	// migrated from string_conversions.java:9:5
+	mine := fmt.Sprint(this)
+	theirs := other.String()
+	count := fmt.Sprint(this.x)
+	return (((mine + theirs) + count) + this.String())
+}
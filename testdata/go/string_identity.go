@@ -0,0 +1,48 @@
+package converted
+
+type Status struct {
+	state string
+}
+
+const (
+	ACTIVE = "active"
+	CLOSED = "closed"
+)
+
+func NewStatusFromString(state string) *Status {
+	this := &Status{}
+	this.state = state
+	return this
+}
+
+func (this *Status) IsActive() bool {
+	// migrated from string_identity.java:10:5
+	return (this.state == ACTIVE)
+}
+
+func (this *Status) IsClosed(other string) bool {
+	// migrated from string_identity.java:14:5
+	if other != CLOSED {
+		return false
+	}
+	return (this.state == CLOSED)
+}
+
+func (this *Status) SameConstants() bool {
+	// migrated from string_identity.java:21:5
+	return (ACTIVE == "active")
+}
+
+func (this *Status) SameState(other string) bool {
+	// migrated from string_identity.java:25:5
+	// NOTE: this.state == other compared the identity of the strings in Java, Go compares their contents
+
+	return (this.state == other)
+}
+
+func (this *Status) Unset() bool {
+	// migrated from string_identity.java:29:5
+	// NOTE: this.state == null compared with null in Java, Go strings can't be nil
+
+	return (this.state == "")
+}
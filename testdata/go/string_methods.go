@@ -0,0 +1,85 @@
+package converted
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type Tokenizer struct {
+	text string
+}
+
+func NewTokenizer() *Tokenizer {
+	this := &Tokenizer{}
+	return this
+}
+
+func (this *Tokenizer) width(word string) int {
+	// migrated from string_methods.java:4:5
+	return len(word)
+}
+
+func (this *Tokenizer) isKeyword(word string) bool {
+	// migrated from string_methods.java:8:5
+	return ((strings.HasPrefix(word, "k_") && (!strings.HasSuffix(word, "_"))) && strings.Contains(word, "w"))
+}
+
+func (this *Tokenizer) normalize(word string) string {
+	// migrated from string_methods.java:12:5
+	return strings.ToLower(strings.TrimSpace(word))
+}
+
+func (this *Tokenizer) first() int {
+	// migrated from string_methods.java:16:5
+	return int(this.text[0])
+}
+
+func (this *Tokenizer) tail(from int) string {
+	// migrated from string_methods.java:20:5
+	return this.text[from:]
+}
+
+func (this *Tokenizer) slice(from int, to int) string {
+	// migrated from string_methods.java:24:5
+	return this.text[from:(to + 1)]
+}
+
+func (this *Tokenizer) find(needle string) int {
+	// migrated from string_methods.java:28:5
+	dot := strings.Index(this.text, ".")
+	if dot < 0 {
+		return strings.LastIndex(this.text, needle)
+	}
+	return dot
+}
+
+func (this *Tokenizer) words(line string) []string {
+	// migrated from string_methods.java:36:5
+	return strings.Split(line, " ")
+}
+
+func (this *Tokenizer) fields(line string) []string {
+	// migrated from string_methods.java:40:5
+	return regexp.MustCompile("\\s+").Split(line, -1)
+}
+
+func (this *Tokenizer) quoted(word string) string {
+	// migrated from string_methods.java:44:5
+	return strings.ReplaceAll(strings.ReplaceAll(word, "\"", "'"), "\\", "/")
+}
+
+func (this *Tokenizer) describe(name string, count int) string {
+	// migrated from string_methods.java:48:5
+	return fmt.Sprintf("%s has %d entries\n", name, count)
+}
+
+func (this *Tokenizer) pair(left string, right string) string {
+	// migrated from string_methods.java:52:5
+	return strings.Join([]string{left, right}, ", ")
+}
+
+func (this *Tokenizer) same(a string, b string) bool {
+	// migrated from string_methods.java:56:5
+	return (strings.EqualFold(a, b) || (len(a) == 0))
+}
@@ -24,8 +24,8 @@ type Bar struct {
 	FooMethods
 }
 
-func newBar() Bar {
-	this := Bar{}
+func newBar() *Bar {
+	this := &Bar{}
 	return this
 }
 
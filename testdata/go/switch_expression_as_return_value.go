@@ -0,0 +1,48 @@
+package converted
+
+import (
+	"errors"
+)
+
+type test struct {
+}
+
+func newTest() *test {
+	this := &test{}
+	return this
+}
+
+func (this *test) arrow(k int) int {
+	// migrated from switch_expression_as_return_value.java:2:5
+	switch k {
+	case 1:
+		return 10
+	case 2, 3:
+		t := (k * 2)
+		return t
+	default:
+		panic(errors.New("bad"))
+	}
+}
+
+func (this *test) colon(k int) int {
+	// migrated from switch_expression_as_return_value.java:13:5
+	switch k {
+	case 1:
+		return 10
+	default:
+		return 0
+	}
+}
+
+func (this *test) noDefault(c int) int {
+	// migrated from switch_expression_as_return_value.java:22:5
+	switch c {
+	case 0:
+		return 1
+	case 1:
+		return 2
+	default:
+		panic("unreachable: unhandled switch case")
+	}
+}
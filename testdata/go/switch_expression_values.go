@@ -0,0 +1,120 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type Level uint
+
+type Dispatcher struct {
+	limit int
+}
+
+const (
+	Level_LOW Level = iota
+	Level_HIGH
+	Level_CRITICAL
+)
+
+const MODE = 2
+
+var MODE_NAME = func() string {
+	switch MODE {
+	case 1:
+		return "single"
+	default:
+		return "multi"
+	}
+}()
+
+func LevelValues() []Level {
+	return []Level{Level_LOW, Level_HIGH, Level_CRITICAL}
+}
+
+func ParseLevel(name string) Level {
+	switch name {
+	case "LOW":
+		return Level_LOW
+	case "HIGH":
+		return Level_HIGH
+	case "CRITICAL":
+		return Level_CRITICAL
+	}
+	panic(fmt.Sprintf("no enum constant Level.%s", name))
+}
+
+func NewDispatcher() *Dispatcher {
+	this := &Dispatcher{}
+	this.limit = func() int {
+		switch MODE {
+		case 1:
+			return 10
+		default:
+			return 20
+		}
+	}()
+	// Default field initializations
+
+	return this
+}
+
+func (this Level) String() string {
+	switch this {
+	case Level_LOW:
+		return "LOW"
+	case Level_HIGH:
+		return "HIGH"
+	case Level_CRITICAL:
+		return "CRITICAL"
+	default:
+		return fmt.Sprintf("Level(%d)", uint(this))
+	}
+}
+
+func (this Level) Ordinal() int {
+	return int(this)
+}
+
+func (this *Dispatcher) weight(level Level) int {
+	// migrated from switch_expression_values.java:15:5
+	switch level {
+	case Level_LOW:
+		return 1
+	case Level_HIGH, Level_CRITICAL:
+		base := (this.limit * 2)
+		return base
+	default:
+		panic("unreachable: unhandled switch case")
+	}
+}
+
+func (this *Dispatcher) budget(level Level, days int) int64 {
+	// migrated from switch_expression_values.java:25:5
+	switch level {
+	case Level_LOW:
+		return int64(days)
+	default:
+		return int64((days * 2))
+	}
+}
+
+func (this *Dispatcher) describe(level Level) string {
+	// migrated from switch_expression_values.java:32:5
+	return fmt.Sprintf("level %v", level)
+}
+
+func (this *Dispatcher) report(level Level) {
+	// migrated from switch_expression_values.java:36:5
+	var switchResult string
+	switch level {
+	case Level_LOW:
+		switchResult = this.describe(Level_LOW)
+	default:
+		switchResult = this.describe(level)
+	}
+	this.log(switchResult)
+}
+
+func (this *Dispatcher) log(message string) {
+	// migrated from switch_expression_values.java:43:5
+}
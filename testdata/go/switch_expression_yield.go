@@ -0,0 +1,59 @@
+package converted
+
+type grader struct {
+	bonus int
+}
+
+func newGrader() *grader {
+	this := &grader{}
+	return this
+}
+
+func (this *grader) compute(k int) int {
+	// migrated from switch_expression_yield.java:4:5
+	return (k * 3)
+}
+
+func (this *grader) score(k int) int {
+	// migrated from switch_expression_yield.java:8:5
+	var base int
+	switch k {
+	case 1:
+		base = 10
+	case 2, 3:
+		t := this.compute(k)
+		base = (t + 1)
+	default:
+		base = 0
+	}
+	return base
+}
+
+func (this *grader) update(k int) {
+	// migrated from switch_expression_yield.java:20:5
+	var switchResult int
+	switch k {
+	case 0:
+		switchResult = 5
+	default:
+		doubled := (k * 2)
+		switchResult = doubled
+	}
+	this.bonus = switchResult
+}
+
+func (this *grader) label(k int) string {
+	// migrated from switch_expression_yield.java:30:5
+	prefix := "grade "
+	var switchResult string
+	switch k {
+	case 1:
+		switchResult = "A"
+	case 2:
+		this.compute(k)
+		switchResult = "B"
+	default:
+		switchResult = "C"
+	}
+	return (prefix + switchResult)
+}
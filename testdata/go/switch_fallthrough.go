@@ -0,0 +1,48 @@
+package converted
+
+type tally struct {
+	total int
+}
+
+func newTally() *tally {
+	this := &tally{}
+	return this
+}
+
+func (this *tally) add(kind int) {
+	// migrated from switch_fallthrough.java:4:5
+	switch kind {
+	case 1, 2:
+		this.total = (this.total + 1)
+	case 3, 4:
+		this.total = (this.total + 3)
+		fallthrough
+	case 5:
+		this.total = (this.total + 5)
+	default:
+		this.total = 0
+		fallthrough
+	case 6:
+		this.total = (this.total + 6)
+	}
+}
+
+func (this *tally) weight(kind int) int {
+	// migrated from switch_fallthrough.java:22:5
+	switch kind {
+	case 1:
+		if this.total > 10 {
+			return 2
+		} else {
+			return 1
+		}
+	case 2:
+		if this.total > 10 {
+			break
+		}
+		this.total = (this.total - 1)
+	default:
+		return 0
+	}
+	return this.total
+}
@@ -0,0 +1,42 @@
+package converted
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+type Platform struct {
+}
+
+func NewPlatform() *Platform {
+	this := &Platform{}
+	return this
+}
+
+func (this *Platform) Describe() string {
+	// migrated from system_properties.java:2:5
+	name := runtime.GOOS
+	arch := runtime.GOARCH
+	return (((name + "/") + arch) + "\n")
+}
+
+func (this *Platform) JoinPath(dir string, file string) string {
+	// migrated from system_properties.java:8:5
+	return ((dir + string(filepath.Separator)) + file)
+}
+
+func (this *Platform) Classpath(first string, second string) string {
+	// migrated from system_properties.java:12:5
+	return ((first + string(filepath.ListSeparator)) + second)
+}
+
+func (this *Platform) TempDir() string {
+	// migrated from system_properties.java:16:5
+	return os.TempDir()
+}
+
+func (this *Platform) Home() string {
+	// migrated from system_properties.java:20:5
+	return os.Getenv("HOME")
+}
@@ -0,0 +1,28 @@
+package converted
+
+import (
+	"os"
+)
+
+type env struct {
+}
+
+func newEnv() *env {
+	this := &env{}
+	return this
+}
+
+func (this *env) home() string {
+	// migrated from system_property_mappings.java:2:5
+	return os.Getenv("HOME")
+}
+
+func (this *env) user() string {
+	// migrated from system_property_mappings.java:6:5
+	return os.Getenv("USER")
+}
+
+func (this *env) newline() string {
+	// migrated from system_property_mappings.java:10:5
+	return "\r\n"
+}
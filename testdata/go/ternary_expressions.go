@@ -0,0 +1,103 @@
+package converted
+
+import (
+	"fmt"
+)
+
+type Pricing struct {
+	discount int
+}
+
+func NewPricing() *Pricing {
+	this := &Pricing{}
+	return this
+}
+
+func (this *Pricing) price(base int, member bool) int {
+	// migrated from ternary_expressions.java:4:5
+	var total int
+	if member {
+		total = (base - this.discount)
+	} else {
+		total = base
+	}
+	return total
+}
+
+func (this *Pricing) label(count int) string {
+	// migrated from ternary_expressions.java:9:5
+	var noun string
+	if count == 1 {
+		noun = "item"
+	} else {
+		noun = "items"
+	}
+	return fmt.Sprintf("%d %s", count, noun)
+}
+
+func (this *Pricing) grade(score int) string {
+	// migrated from ternary_expressions.java:14:5
+	var result string
+	if score >= 90 {
+		result = "A"
+	} else if score >= 80 {
+		result = "B"
+	} else if score >= 70 {
+		result = "C"
+	} else {
+		result = "F"
+	}
+	return result
+}
+
+func (this *Pricing) apply(base int, sale bool) {
+	// migrated from ternary_expressions.java:19:5
+	var ternaryResult int
+	if sale {
+		ternaryResult = (base / 10)
+	} else {
+		ternaryResult = 0
+	}
+	this.discount = ternaryResult
+}
+
+func (this *Pricing) clamp(value int, limit int) int {
+	// migrated from ternary_expressions.java:23:5
+	var ternaryResult int
+	if value > limit {
+		ternaryResult = limit
+	} else {
+		ternaryResult = value
+	}
+	return this.atLeast(0, ternaryResult)
+}
+
+func (this *Pricing) atLeast(low int, value int) int {
+	// migrated from ternary_expressions.java:27:5
+	if value < low {
+		return low
+	}
+	return value
+}
+
+func (this *Pricing) check(a int, b int) bool {
+	// migrated from ternary_expressions.java:31:5
+	var both bool
+	if a > 0 {
+		both = (b > 0)
+	} else {
+		both = false
+	}
+	return both
+}
+
+func (this *Pricing) sign(value int) string {
+	// migrated from ternary_expressions.java:36:5
+	var ternaryResult string
+	if value < 0 {
+		ternaryResult = "negative"
+	} else {
+		ternaryResult = "positive"
+	}
+	return ("value is " + ternaryResult)
+}
@@ -0,0 +1,65 @@
+package converted
+
+type settings struct {
+	width  int
+	margin int
+}
+
+type Mode struct {
+	speed int
+}
+
+var verbose = true
+
+// Assigned in init since its initializer needs statements
+var level int
+
+// Assigned in init to keep the initialization order of Java
+var limit int
+
+// Assigned in init since its arguments need statements
+var Mode_FAST Mode
+var Mode_SLOW = Mode{speed: 3}
+
+func init() {
+	if verbose {
+		level = 2
+	} else {
+		level = 1
+	}
+	limit = (level * 10)
+}
+
+func newSettings() *settings {
+	this := &settings{}
+	{
+		var ternaryResult int
+		if verbose {
+			ternaryResult = 2
+		} else {
+			ternaryResult = 1
+		}
+		this.margin = (ternaryResult * 4)
+	}
+	if verbose {
+		this.width = 80
+	} else {
+		this.width = 40
+	}
+	// Default field initializations
+
+	return this
+}
+
+func init() {
+	// migrated from ternary_initializers.java:12:1
+	{
+		var ternaryResult int
+		if verbose {
+			ternaryResult = 1
+		} else {
+			ternaryResult = 2
+		}
+		Mode_FAST = Mode{speed: ternaryResult}
+	}
+}
@@ -0,0 +1,57 @@
+package converted
+
+type Clamp struct {
+}
+
+func NewClamp() *Clamp {
+	this := &Clamp{}
+	return this
+}
+
+func (this *Clamp) max(a int, b int) int {
+	// migrated from ternary_returns.java:2:5
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (this *Clamp) clamp(value int, low int, high int) int {
+	// migrated from ternary_returns.java:6:5
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
+
+func (this *Clamp) sign(value int) int {
+	// migrated from ternary_returns.java:10:5
+	if value >= 0 {
+		if value == 0 {
+			return 0
+		}
+		return 1
+	}
+	return (-1)
+}
+
+func (this *Clamp) isPositive(value int) bool {
+	// migrated from ternary_returns.java:14:5
+	return (value > 0)
+}
+
+func (this *Clamp) orDefault(value int) int {
+	// migrated from ternary_returns.java:18:5
+	if this.isPositive(value) {
+		return value
+	}
+	return this.max(value, 0)
+}
+
+func (this *Clamp) nothing() {
+	// migrated from ternary_returns.java:22:5
+	return
+}
@@ -3,8 +3,8 @@ package converted
 type test struct {
 }
 
-func newTest() test {
-	this := test{}
+func newTest() *test {
+	this := &test{}
 	return this
 }
 
@@ -13,7 +13,7 @@ func (this *test) test() {
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				if _, ok := r.(Exception); ok {
+				if e, ok := r.(error); ok {
 					this.handleError(e)
 				} else {
 					panic(r) // re-panic if it's not a handled exception
@@ -3,8 +3,8 @@ package converted
 type test struct {
 }
 
-func newTest() test {
-	this := test{}
+func newTest() *test {
+	this := &test{}
 	return this
 }
 
@@ -14,7 +14,7 @@ func (this *test) calculate() int {
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				if _, ok := r.(RuntimeException); ok {
+				if _, ok := r.(error); ok {
 					result = this.defaultValue()
 				} else {
 					panic(r) // re-panic if it's not a handled exception
@@ -0,0 +1,75 @@
+package converted
+
+type resource struct {
+	name string
+}
+
+type reader struct {
+	current *resource
+}
+
+func NewResourceFromString(name string) *resource {
+	this := &resource{}
+	this.name = name
+	return this
+}
+
+func newReader() *reader {
+	this := &reader{}
+	return this
+}
+
+func (this *resource) Read() string {
+	// migrated from try_with_resources.java:8:5
+	return this.name
+}
+
+func (this *resource) Close() {
+	// migrated from try_with_resources.java:12:5
+}
+
+func (this *reader) copy(from string, to string) string {
+	// migrated from try_with_resources.java:19:5
+	result := ""
+	func() {
+		source := NewResourceFromString(from)
+		defer source.Close()
+		target := NewResourceFromString(to)
+		defer target.Close()
+		result = (source.Read() + target.Read())
+	}()
+
+	return result
+}
+
+func (this *reader) size(path string) int {
+	// migrated from try_with_resources.java:27:5
+	length := 0
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				if _, ok := r.(error); ok {
+					length = (-1)
+				} else {
+					panic(r) // re-panic if it's not a handled exception
+				}
+			}
+		}()
+		source := NewResourceFromString(path)
+		defer source.Close()
+		text := source.Read()
+		length = len(text)
+	}()
+	length = (length + 1)
+
+	return length
+}
+
+func (this *reader) drain() {
+	// migrated from try_with_resources.java:40:5
+	func() {
+		defer this.current.Close()
+		this.current.Read()
+	}()
+
+}
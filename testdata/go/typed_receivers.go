@@ -0,0 +1,62 @@
+package converted
+
+import (
+	"slices"
+	"strings"
+)
+
+type ledgerTally struct {
+	count int
+}
+
+type Ledger struct {
+	tally   *ledgerTally
+	entries []string
+}
+
+func newLedgerTally() *ledgerTally {
+	this := &ledgerTally{}
+	return this
+}
+
+func NewLedger() *Ledger {
+	this := &Ledger{}
+	this.entries = make([]string, 0)
+	// Default field initializations
+
+	return this
+}
+
+func (this *ledgerTally) add(entry string) {
+	// migrated from typed_receivers.java:33:9
+	this.count++
+}
+
+func (this *ledgerTally) size() int {
+	// migrated from typed_receivers.java:37:9
+	return this.count
+}
+
+func (this *Ledger) record(entry string, other *ledgerTally) int {
+	// migrated from typed_receivers.java:9:5
+	recent := make([]string, 0)
+	tags := make(map[string]bool)
+	recent = append(recent, entry)
+	tags[entry] = true
+	this.entries = append(this.entries, entry)
+	this.entries = slices.Insert(this.entries, 0, entry)
+	this.tally.add(entry)
+	other.add(entry)
+	first := this.entries[0]
+	return (((((len(recent) + len(tags)) + this.tally.size()) + other.size()) + len(first)) + len(this.entries[1]))
+}
+
+func (this *Ledger) latest() string {
+	// migrated from typed_receivers.java:22:5
+	return this.entries[(len(this.entries) - 1)]
+}
+
+func (this *Ledger) latestLength() int {
+	// migrated from typed_receivers.java:26:5
+	return (len(strings.TrimSpace(this.latest())) + len(this.latest()))
+}
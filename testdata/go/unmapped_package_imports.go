@@ -0,0 +1,21 @@
+package converted
+
+type Shape struct {
+	origin Point
+}
+
+func NewShapeFromPoint(origin Point) *Shape {
+	this := &Shape{}
+	this.origin = origin
+	return this
+}
+
+func (this *Shape) Around(radius int) Circle {
+	// migrated from unmapped_package_imports.java:15:5
+	return Circle.of(this.origin, this.clamp(radius))
+}
+
+func (this *Shape) Distance(other Point) int {
+	// migrated from unmapped_package_imports.java:19:5
+	return Point.manhattan(this.origin, other)
+}
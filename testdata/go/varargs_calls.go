@@ -0,0 +1,66 @@
+package converted
+
+type Bag struct {
+	items []int
+}
+
+func newBagFromIntVarargs(items ...int) *Bag {
+	this := &Bag{}
+	this.items = items
+	return this
+}
+
+func (this *Bag) sum(values ...int) int {
+	// migrated from varargs_calls.java:11:5
+	total := 0
+	for _, value := range values {
+		total = (total + value)
+	}
+	return total
+}
+
+func (this *Bag) count(values *[]int) int {
+	// migrated from varargs_calls.java:19:5
+	return this.sum(*values...)
+}
+
+func (this *Bag) label(name string, values ...int) int {
+	// migrated from varargs_calls.java:23:5
+	return this.sum(values...)
+}
+
+func (this *Bag) total(extra *[]int) int {
+	// migrated from varargs_calls.java:27:5
+	local := []int{1, 2}
+	a := this.sum(1, 2, 3)
+	b := this.sum()
+	c := this.sum(local...)
+	d := this.sum(*extra...)
+	e := this.label("x", *extra...)
+	f := this.count(&local)
+	g := this.sum([]int{4, 5}...)
+	return ((((((a + b) + c) + d) + e) + f) + g)
+}
+
+func (this *Bag) sizes(extra *[]int, values ...int) int {
+	// migrated from varargs_calls.java:39:5
+	local := []int{1, 2}
+	lengths := make(map[string]int)
+	lengths["extra"] = len(*extra)
+	return (((lengths["extra"] + len(values)) + len(this.items)) + len(local))
+}
+
+func (this *Bag) empty() *Bag {
+	// migrated from varargs_calls.java:46:5
+	return newBagFromIntVarargs()
+}
+
+func (this *Bag) single(item int) *Bag {
+	// migrated from varargs_calls.java:50:5
+	return newBagFromIntVarargs(item)
+}
+
+func (this *Bag) of(values *[]int) *Bag {
+	// migrated from varargs_calls.java:54:5
+	return newBagFromIntVarargs(*values...)
+}
@@ -3,12 +3,13 @@ package converted
 type test struct {
 }
 
-func newTest() test {
-	this := test{}
+func newTest() *test {
+	this := &test{}
 	return this
 }
 
 func (this *test) foo() error {
 	// migrated from void_method_with_multiple_exceptions.java:2:5
 	System.out.println("test")
+	return nil
 }
@@ -0,0 +1,15 @@
+package com.example;
+
+/** Marks methods that are retried on failure. */
+public @interface Retry {
+    /** How many times to retry. */
+    int attempts() default 3;
+
+    String reason() default "flaky";
+
+    String[] tags() default {"io", "net"};
+
+    String[] owners() default "core";
+
+    long timeout();
+}
@@ -0,0 +1,3 @@
+public @interface Retry {
+    int attempts() default 3;
+}
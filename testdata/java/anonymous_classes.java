@@ -0,0 +1,103 @@
+import java.util.Comparator;
+import java.util.function.Function;
+import java.util.function.Supplier;
+
+interface Greeter {
+    public String greet(String name);
+
+    public int count();
+}
+
+interface Listener {
+    void on(int e);
+}
+
+public class TaskRunner {
+    private int runs;
+    private int total;
+
+    public Greeter politeGreeter(String title, int times) {
+        return new Greeter() {
+            private int calls = 0;
+
+            @Override
+            public String greet(String name) {
+                this.calls++;
+                return title + " " + name;
+            }
+
+            @Override
+            public int count() {
+                return times + this.calls;
+            }
+        };
+    }
+
+    public void runTwice(StringBuilder log, String message) {
+        Runnable task = new Runnable() {
+            @Override
+            public void run() {
+                log.append(message);
+            }
+        };
+        task.run();
+        task.run();
+    }
+
+    public int longer(String a, String b) {
+        Comparator<String> byLength = new Comparator<String>() {
+            @Override
+            public int compare(String left, String right) {
+                return left.length() - right.length();
+            }
+        };
+        return byLength.compare(a, b);
+    }
+
+    public String describe(Function<Integer, String> formatter, int value) {
+        Supplier<String> fallback = new Supplier<>() {
+            public String get() {
+                return "none";
+            }
+        };
+        if (value < 0) {
+            return fallback.get();
+        }
+        return formatter.apply(value);
+    }
+
+    public Greeter shoutingGreeter(String suffix) {
+        Greeter inner = politeGreeter(suffix, 1);
+        return new Greeter() {
+            public String greet(String name) {
+                return inner.greet(name.toUpperCase()) + suffix;
+            }
+
+            public int count() {
+                return inner.count();
+            }
+        };
+    }
+
+    public Listener summing() {
+        return new Listener() {
+            public void on(int e) {
+                total += e;
+            }
+        };
+    }
+
+    public Greeter counting() {
+        return new Greeter() {
+            int k = 2;
+
+            public String greet(String name) {
+                return name + runs;
+            }
+
+            public int count() {
+                return k;
+            }
+        };
+    }
+}
@@ -0,0 +1,37 @@
+import java.util.List;
+
+class Test {
+    int x;
+    int y;
+
+    int compute() {
+        return 42;
+    }
+
+    String read() {
+        return null;
+    }
+
+    void test() {
+        x = (y = compute());
+        int z = (x += 2);
+        String line;
+        while ((line = read()) != null) {
+            x = x + 1;
+        }
+        for (int i = 0; i < 10; i = i + 2) {
+            y = i;
+        }
+    }
+
+    int firstLarge(List<Integer> a, int i) {
+        int v = 0;
+        if (i < a.size() && (v = a.get(i)) > 1) {
+            return v;
+        }
+        if (i >= a.size() || (v = a.get(i)) < 0) {
+            return -1;
+        }
+        return v;
+    }
+}
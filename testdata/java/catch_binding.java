@@ -0,0 +1,35 @@
+public class ParseFailure extends RuntimeException {
+    private int line;
+
+    public ParseFailure(int line) {
+        this.line = line;
+    }
+
+    public int getLine() {
+        return line;
+    }
+}
+
+class Parser {
+    String describe(String input) {
+        String result = "";
+        try {
+            result = parse(input);
+        } catch (ParseFailure failure) {
+            result = "line " + failure.getLine();
+        } catch (IllegalArgumentException | IllegalStateException e) {
+            result = "failed: " + e.getMessage();
+        }
+        return result;
+    }
+
+    int count(String input) {
+        int count = 0;
+        try {
+            count = measure(input);
+        } catch (Exception ignored) {
+            count = -1;
+        }
+        return count;
+    }
+}
@@ -0,0 +1,31 @@
+public class Chain {
+    private Chain next;
+
+    Chain getNext() {
+        return this.next;
+    }
+
+    int value() {
+        return 1;
+    }
+
+    int implicitReceiver() {
+        return getNext().getNext().value();
+    }
+
+    int parameterReceiver(Chain other) {
+        return other.getNext().value();
+    }
+
+    int fieldReceiver() {
+        return this.next.getNext().value();
+    }
+
+    String renamedLink(Chain other) {
+        return other.getNext().toString();
+    }
+
+    public String toString() {
+        return "chain";
+    }
+}
@@ -0,0 +1,9 @@
+public class Registry {
+    Object kind() {
+        return Registry.class;
+    }
+
+    String name() {
+        return Registry.class.getSimpleName();
+    }
+}
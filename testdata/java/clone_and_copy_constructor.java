@@ -0,0 +1,25 @@
+class Tokens implements Cloneable {
+    private int[] values;
+    private String name;
+
+    Tokens(String name) {
+        this.name = name;
+    }
+
+    Tokens(Tokens other) {
+        this.name = other.name;
+    }
+
+    @Override
+    public Tokens clone() {
+        try {
+            return (Tokens) super.clone();
+        } catch (CloneNotSupportedException e) {
+            throw new AssertionError();
+        }
+    }
+
+    Tokens duplicate() {
+        return this.clone();
+    }
+}
@@ -0,0 +1,11 @@
+import java.util.Map;
+
+class Tokens {
+    private int[] values;
+    private Map<String, Integer> index;
+    private String name;
+
+    public Tokens clone() {
+        return this;
+    }
+}
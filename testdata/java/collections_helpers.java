@@ -0,0 +1,29 @@
+import java.util.ArrayList;
+import java.util.Collections;
+import java.util.Comparator;
+import java.util.List;
+
+public class Deck {
+    private List<String> cards = new ArrayList<String>();
+
+    public int find(String card) {
+        return Collections.binarySearch(cards, card);
+    }
+
+    public int findBy(List<String> sorted, String card, Comparator<String> order) {
+        return Collections.binarySearch(sorted, card, order);
+    }
+
+    public void shuffle() {
+        Collections.shuffle(cards);
+    }
+
+    public void shuffleAll(List<String> hand) {
+        Collections.shuffle(hand);
+    }
+
+    public int copies(String card) {
+        int count = Collections.frequency(cards, card);
+        return count;
+    }
+}
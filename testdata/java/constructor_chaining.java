@@ -0,0 +1,46 @@
+public class Point {
+    private int x;
+    private int y;
+    private String label = "origin";
+
+    public Point(int x, int y) {
+        this.x = x;
+        this.y = y;
+    }
+
+    public Point(int both) {
+        this(both, both);
+        label = "diagonal";
+    }
+
+    public Point() {
+        // Start at the origin
+        this(0);
+    }
+
+    class Offset {
+        private int dx;
+
+        Offset(int dx) {
+            this.dx = dx;
+        }
+
+        Offset() {
+            this(1);
+        }
+    }
+}
+
+class Box<T> {
+    private T value;
+    private int size;
+
+    Box(T value, int size) {
+        this.value = value;
+        this.size = size;
+    }
+
+    Box(T value) {
+        this(value, 1);
+    }
+}
@@ -0,0 +1,24 @@
+public class Account {
+    public String owner;
+    public int balance = 10;
+    private int limit;
+    private final int id;
+
+    public Account(String owner, int id) {
+        this.owner = owner;
+        this.id = id;
+        limit = 100;
+        balance += id;
+    }
+
+    public Account(int limit) {
+        int id = limit * 2;
+        this.id = id;
+        this.limit = limit;
+        owner = "nobody";
+    }
+
+    int headroom() {
+        return this.limit - this.balance;
+    }
+}
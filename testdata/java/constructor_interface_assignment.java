@@ -0,0 +1,29 @@
+interface Shape {
+    int area();
+}
+
+public class Square implements Shape {
+    private int side;
+
+    public Square(int side) {
+        this.side = side;
+    }
+
+    public int area() {
+        return this.side * this.side;
+    }
+
+    public Square grow(int by) {
+        return new Square(this.side + by);
+    }
+
+    public static Shape unit() {
+        Shape shape = new Square(1);
+        return shape;
+    }
+
+    public static Shape last(Square first, Shape second) {
+        Shape[] shapes = new Shape[] { first, second, first.grow(1) };
+        return shapes[2];
+    }
+}
@@ -0,0 +1,24 @@
+public abstract class Account {
+    int total;
+    String name;
+
+    abstract int fee();
+
+    int net() {
+        int totalFee = fee();
+        return total - totalFee;
+    }
+
+    String label() {
+        String nameAndTotal = name + ":" + total;
+        return nameAndTotal;
+    }
+
+    String usage() {
+        return "call this.net() for the balance of " + name;
+    }
+
+    void deposit(int amount) {
+        this.total = total + amount;
+    }
+}
@@ -0,0 +1,67 @@
+import java.util.ArrayDeque;
+import java.util.Deque;
+import java.util.LinkedList;
+import java.util.List;
+import java.util.Queue;
+import java.util.Stack;
+
+public class Scheduler {
+    private Queue<String> jobs = new LinkedList<>();
+    private Deque<String> history = new ArrayDeque<>();
+    private Stack<Integer> marks = new Stack<>();
+
+    void submit(String job, boolean urgent) {
+        if (urgent) {
+            history.addFirst(job);
+        } else {
+            jobs.offer(job);
+        }
+    }
+
+    String next() {
+        String job = jobs.poll();
+        history.push(job);
+        return job;
+    }
+
+    int drain() {
+        int count = 0;
+        while (!jobs.isEmpty()) {
+            jobs.remove();
+            count++;
+        }
+        return count;
+    }
+
+    String undo() {
+        if (history.isEmpty()) {
+            return "";
+        }
+        String latest = history.pop();
+        history.addLast(latest);
+        return history.peekFirst() + history.getLast();
+    }
+
+    int mark(int position) {
+        marks.push(position);
+        int top = marks.peek();
+        if (marks.size() > 3) {
+            marks.pop();
+        }
+        return top;
+    }
+
+    int rewind() {
+        int total = 0;
+        while (!marks.empty()) {
+            total += marks.pop();
+        }
+        return total;
+    }
+
+    String oldest(Deque<String> entries) {
+        String last = entries.pollLast();
+        entries.removeFirst();
+        return last;
+    }
+}
@@ -0,0 +1,40 @@
+public enum Operation {
+    PLUS("+") {
+        @Override
+        int apply(int a, int b) {
+            return a + b;
+        }
+    },
+    MINUS("-") {
+        @Override
+        int apply(int a, int b) {
+            return a - b;
+        }
+
+        @Override
+        String describe() {
+            return "subtract with " + this.symbol;
+        }
+
+        @Override
+        void record(StringBuilder log) {
+            log.append(describe());
+        }
+    };
+
+    private final String symbol;
+
+    Operation(String symbol) {
+        this.symbol = symbol;
+    }
+
+    abstract int apply(int a, int b);
+
+    String describe() {
+        return "operation " + this.symbol;
+    }
+
+    void record(StringBuilder log) {
+        log.append(this.symbol);
+    }
+}
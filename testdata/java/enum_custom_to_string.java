@@ -0,0 +1,8 @@
+enum Suit {
+    HEARTS, SPADES;
+
+    @Override
+    public String toString() {
+        return "suit";
+    }
+}
@@ -0,0 +1,28 @@
+public class Traffic {
+    enum Light { RED, AMBER, GREEN }
+
+    private int[] counts = new int[] {0, 0, 0};
+
+    public String action(Light light) {
+        switch (light.ordinal()) {
+            case 0:
+                return "stop";
+            case 1:
+                return "wait";
+            default:
+                return "go";
+        }
+    }
+
+    public void record(Light light) {
+        counts[light.ordinal()]++;
+    }
+
+    public int seen(Light light) {
+        return counts[light.ordinal()];
+    }
+
+    public int index(Light light) {
+        return light.ordinal();
+    }
+}
@@ -0,0 +1,37 @@
+import java.util.*;
+
+public class Tokens {
+    enum Kind { IDENT, NUMBER, PLUS, MINUS, EOF }
+
+    private static final Set<Kind> OPERATORS = EnumSet.of(Kind.PLUS, Kind.MINUS);
+    private final EnumSet<Kind> seen = EnumSet.noneOf(Kind.class);
+    private final EnumMap<Kind, Integer> counts = new EnumMap<>(Kind.class);
+
+    boolean isOperator(Kind kind) {
+        return OPERATORS.contains(kind);
+    }
+
+    boolean record(Kind kind) {
+        counts.put(kind, counts.size());
+        return seen.add(kind);
+    }
+
+    void forget(Kind kind) {
+        seen.remove(kind);
+        counts.remove(kind);
+    }
+
+    String describe() {
+        StringBuilder sb = new StringBuilder();
+        for (Kind kind : seen) {
+            sb.append(kind.name());
+        }
+        return sb.toString();
+    }
+
+    Set<Kind> all() {
+        EnumSet<Kind> copy = EnumSet.copyOf(seen);
+        copy.add(Kind.EOF);
+        return EnumSet.allOf(Kind.class);
+    }
+}
@@ -0,0 +1,48 @@
+public class Inventory {
+    public enum Status { ACTIVE, SUSPENDED, RETIRED }
+
+    public enum Grade {
+        LOW, HIGH;
+
+        @Override
+        public String toString() {
+            return "grade";
+        }
+
+        String label() {
+            return name().toLowerCase();
+        }
+    }
+
+    public boolean isActive(Status status) {
+        return status == Status.valueOf("ACTIVE");
+    }
+
+    public String names() {
+        StringBuilder names = new StringBuilder();
+        for (Status status : Status.values()) {
+            names.append(status.name());
+        }
+        return names.toString();
+    }
+
+    public String describe(Grade grade) {
+        return grade.name();
+    }
+
+    public Grade parse(String text) {
+        return Grade.valueOf(text.toUpperCase());
+    }
+
+    public int rank(String text) {
+        return Status.valueOf(text).ordinal();
+    }
+
+    public String firstStatus() {
+        return Status.values()[0].name();
+    }
+
+    public String gradeName(String text) {
+        return Grade.valueOf(text).name();
+    }
+}
@@ -0,0 +1,68 @@
+import java.io.IOException;
+
+public class Loader {
+    private String path;
+    private int loads;
+
+    public Loader(String path) {
+        this.path = path;
+    }
+
+    public String read() throws IOException {
+        if (this.path.isEmpty()) {
+            throw new IOException("no path");
+        }
+        return this.path;
+    }
+
+    public void check(int size) throws IOException {
+        if (size < 0) {
+            throw new IOException("negative size");
+        }
+        this.loads = this.loads + 1;
+    }
+
+    public int size() throws IOException {
+        String content = read();
+        check(content.length());
+        return content.length() * 2;
+    }
+
+    public void touch() throws IOException {
+        read();
+        if (this.loads > 10) {
+            return;
+        }
+        check(1);
+    }
+
+    public Loader copy() throws IOException {
+        String content = read();
+        return new Loader(content);
+    }
+
+    public int count(String prefix) throws IOException {
+        return prefix.length() + this.loads;
+    }
+
+    public boolean fits(String prefix) throws IOException {
+        if (count(prefix) > 0 && count(prefix + "x") < 3) {
+            return true;
+        }
+        return count(prefix) == 0 || count(prefix + "y") > 5;
+    }
+
+    public String readOrPanic() {
+        return read();
+    }
+
+    public String readOrDefault() throws IOException {
+        String content = "";
+        try {
+            content = read();
+        } catch (RuntimeException e) {
+            content = "default";
+        }
+        return content;
+    }
+}
@@ -0,0 +1,39 @@
+import java.io.IOException;
+
+public class Loader {
+    RuntimeException wrap(IOException cause) {
+        return new RuntimeException("loading failed", cause);
+    }
+
+    RuntimeException rethrow(Exception cause) {
+        return new IllegalStateException(cause);
+    }
+
+    RuntimeException describe(String path, int line, Throwable cause) {
+        return new IllegalArgumentException("bad entry in " + path + " at line " + line, cause);
+    }
+
+    Exception plain(String path) {
+        return new UnsupportedOperationException("unsupported: " + path);
+    }
+
+    Exception unknown() {
+        return new IllegalStateException();
+    }
+
+    String message(Exception e) {
+        return "failed: " + e.getMessage();
+    }
+
+    Throwable rootCause(Exception e) {
+        Throwable cause = e.getCause();
+        if (cause == null) {
+            return e;
+        }
+        return cause;
+    }
+
+    void report(Exception e) {
+        e.printStackTrace();
+    }
+}
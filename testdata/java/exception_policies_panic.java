@@ -0,0 +1,16 @@
+import java.io.IOException;
+
+class Parser {
+    int parse(String text) throws IOException {
+        if (text.isEmpty()) {
+            throw new IOException("empty");
+        }
+        return 1;
+    }
+
+    void check(int n) {
+        if (n < 0) {
+            throw new IllegalStateException("negative");
+        }
+    }
+}
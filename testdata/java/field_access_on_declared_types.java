@@ -0,0 +1,36 @@
+public class Counter {
+    public int count;
+    private String label;
+
+    boolean sameCount(Counter other) {
+        return other.count == count;
+    }
+
+    boolean sameLabel(Counter other) {
+        return other.label.equals(label);
+    }
+
+    int total(Counter[] counters) {
+        int sum = 0;
+        for (Counter counter : counters) {
+            sum += counter.count;
+        }
+        return sum;
+    }
+
+    void copyFrom(Bounds bounds) {
+        Counter source = bounds.start;
+        source.count = bounds.end.count;
+    }
+}
+
+class Bounds {
+    public Counter start;
+    public Counter end;
+}
+
+record Span(int from, int to) {
+    boolean contains(Span other) {
+        return other.from >= from && other.to <= to;
+    }
+}
@@ -0,0 +1,30 @@
+import java.util.List;
+
+public abstract class Formatter {
+    private final String prefix;
+
+    Formatter(final String prefix) {
+        this.prefix = prefix;
+    }
+
+    String format(final int width, @Nullable String text) {
+        return prefix + text;
+    }
+
+    int sum(@SuppressWarnings("unused") final List<Integer> values, final int... extra) {
+        int total = 0;
+        for (final int value : extra) {
+            total += value;
+        }
+        return total;
+    }
+
+    abstract String pad(final @NonNull String text, final int width);
+}
+
+interface Sink {
+    void write(@Nullable final String line);
+}
+
+record Entry(@Nullable String key, final int count) {
+}
@@ -0,0 +1,12 @@
+class Parser {
+    public static class Node {
+        public Node() {
+        }
+    }
+
+    private Node head;
+
+    Node parse() {
+        return new Node();
+    }
+}
@@ -0,0 +1,23 @@
+public class Angle {
+    private double degrees;
+
+    public Angle(double degrees) {
+        this.degrees = degrees;
+    }
+
+    public double normalized() {
+        return this.degrees % 360;
+    }
+
+    public void wrap(double limit) {
+        this.degrees %= limit;
+    }
+
+    public int bucket(int value, int size) {
+        return value % size - value / size;
+    }
+
+    public double scaled(int value) {
+        return value % 7 * 1.5;
+    }
+}
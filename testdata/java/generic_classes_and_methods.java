@@ -0,0 +1,60 @@
+import java.util.List;
+
+public class Box<T> {
+    private T value;
+
+    public Box(T value) {
+        this.value = value;
+    }
+
+    public T get() {
+        return this.value;
+    }
+
+    public <R> Box<R> with(R other) {
+        return new Box<>(other);
+    }
+
+    public static <T extends Comparable<T>> T max(T a, T b) {
+        if (a.compareTo(b) >= 0) {
+            return a;
+        }
+        return b;
+    }
+
+    static <K, V extends List<K>> V first(K key, V values) {
+        return values;
+    }
+}
+
+class Pair<A, B> {
+    A first;
+    B second;
+
+    Pair(A first, B second) {
+        this.first = first;
+        this.second = second;
+    }
+
+    A getFirst() {
+        return this.first;
+    }
+}
+
+interface Source<T> {
+    T next();
+
+    default T peek() {
+        return next();
+    }
+}
+
+class Client {
+    int run() {
+        Box<String> named = new Box<>("name");
+        Box<Integer> counted = new Box<Integer>(1);
+        Pair<String, Integer> pair = new Pair<>("a", 1);
+        String label = named.get() + pair.getFirst();
+        return counted.get() + label.length();
+    }
+}
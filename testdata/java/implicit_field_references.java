@@ -0,0 +1,39 @@
+public class Counter {
+    private static int created = 0;
+    private int count;
+    private String label;
+    private Counter parent;
+
+    public Counter(String label, Counter parent) {
+        this.label = label;
+        this.parent = parent;
+        created = created + 1;
+    }
+
+    public int get() {
+        return count;
+    }
+
+    public void increment() {
+        count++;
+    }
+
+    public int inherited() {
+        return parent.get() + count;
+    }
+
+    public void reset(int count) {
+        this.count = count;
+        label = "reset";
+    }
+
+    public String describe() {
+        String label = "counter";
+        return label + count;
+    }
+
+    public int total(int extra) {
+        int count = extra;
+        return count + this.count;
+    }
+}
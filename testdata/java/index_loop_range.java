@@ -0,0 +1,34 @@
+class Stats {
+    int sum(int[] values) {
+        int total = 0;
+        for (int i = 0; i < values.length; i++) {
+            total += values[i];
+        }
+        return total;
+    }
+
+    int firstNegative(int[] values) {
+        for (int i = 0; i < values.length; ++i) {
+            if (values[i] < 0) {
+                return i;
+            }
+        }
+        return -1;
+    }
+
+    int[] squares() {
+        int[] squares = {0, 0, 0, 0};
+        for (int i = 0; i < squares.length; i++) {
+            squares[i] = i * i;
+        }
+        return squares;
+    }
+
+    int count(String[] names) {
+        int count = 0;
+        for (int i = 0; i < names.length; i++) {
+            count++;
+        }
+        return count;
+    }
+}
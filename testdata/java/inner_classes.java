@@ -0,0 +1,73 @@
+public class Library {
+    private int loans;
+    private String name;
+
+    public Library(String name) {
+        this.name = name;
+    }
+
+    public class Shelf {
+        private int capacity;
+
+        public Shelf(int capacity) {
+            this.capacity = capacity;
+        }
+
+        String describe() {
+            return name + " shelf for " + this.capacity + " books";
+        }
+
+        void lend() {
+            Library.this.loans = Library.this.loans + 1;
+            record(this.capacity);
+        }
+
+        Slot slot(int position) {
+            return new Slot(position);
+        }
+
+        public class Slot {
+            int position;
+
+            Slot(int position) {
+                this.position = position;
+            }
+
+            String label() {
+                return name + "/" + this.position;
+            }
+
+            int limit() {
+                return capacity;
+            }
+        }
+    }
+
+    static class Catalog {
+        int size;
+    }
+
+    class Ledger {
+        int total() {
+            return loans;
+        }
+    }
+
+    void record(int count) {
+        this.loans = this.loans + count;
+    }
+
+    Shelf newShelf(int capacity) {
+        return new Shelf(capacity);
+    }
+
+    int loanCount() {
+        Ledger ledger = new Ledger();
+        return ledger.total();
+    }
+
+    Shelf.Slot firstSlot(Library other) {
+        Shelf shelf = other.new Shelf(10);
+        return shelf.slot(0);
+    }
+}
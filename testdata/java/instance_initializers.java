@@ -0,0 +1,34 @@
+public class Inventory {
+    private int reserved = 2;
+    private int capacity;
+    private String label;
+
+    {
+        this.capacity = this.reserved + 8;
+        this.label = "inventory";
+    }
+
+    public Inventory() {
+    }
+
+    public Inventory(int capacity) {
+        this.capacity = capacity;
+    }
+
+    {
+        int factor = 2;
+        this.capacity = this.capacity * factor;
+    }
+
+    public int free() {
+        return this.capacity - this.reserved;
+    }
+}
+
+class Counter {
+    private int count;
+
+    {
+        this.count = 1;
+    }
+}
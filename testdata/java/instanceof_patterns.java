@@ -0,0 +1,44 @@
+public class Shapes {
+    static double area(Object shape) {
+        if (shape instanceof Circle c) {
+            return 3.14 * c.radius * c.radius;
+        } else if (shape instanceof Square s && s.side > 0) {
+            return s.side * s.side;
+        } else if (shape instanceof String) {
+            return 0;
+        }
+        return -1;
+    }
+
+    static double radius(Object shape) {
+        if (!(shape instanceof Circle c)) {
+            return 0;
+        }
+        return c.radius;
+    }
+
+    static String describe(Object shape) {
+        if (!(shape instanceof Square s) || s.side == 0) {
+            return "empty";
+        } else {
+            return "square of " + s.side;
+        }
+    }
+
+    static boolean isRound(Object shape) {
+        boolean round = shape instanceof Circle;
+        return round;
+    }
+
+    static boolean sameCircle(Circle circle, Object other) {
+        return other instanceof Circle c && c.radius == circle.radius;
+    }
+
+    static class Circle {
+        double radius;
+    }
+
+    static class Square {
+        double side;
+    }
+}
@@ -0,0 +1,62 @@
+interface Shape {
+    double area();
+
+    default String describe(String prefix) {
+        return prefix + area();
+    }
+
+    default boolean isLarge() {
+        return area() > 100;
+    }
+
+    default String summary() {
+        return describe("shape ");
+    }
+}
+
+interface Solid extends Shape {
+    double height();
+}
+
+interface Source<T> {
+    T next();
+
+    default T skip() {
+        next();
+        return next();
+    }
+}
+
+public class Square implements Solid, Source<Integer> {
+    private double side;
+    private int count;
+
+    public Square(double side) {
+        this.side = side;
+    }
+
+    public double area() {
+        return side * side;
+    }
+
+    public double height() {
+        return 0;
+    }
+
+    public String describe(String prefix) {
+        return prefix + "square";
+    }
+
+    public Integer next() {
+        count = count + 1;
+        return count;
+    }
+
+    public boolean fits() {
+        return !isLarge() && skip() > 1;
+    }
+
+    public static String label(Square square, Shape shape) {
+        return square.summary() + shape.describe("b ");
+    }
+}
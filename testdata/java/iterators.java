@@ -0,0 +1,72 @@
+import java.util.Iterator;
+import java.util.List;
+import java.util.Set;
+
+public class Playlist implements Iterable<String> {
+    private List<String> songs;
+    private Set<String> artists;
+    private String titles;
+
+    public Iterator<String> iterator() {
+        return new SongIterator(titles);
+    }
+
+    int totalLength() {
+        int total = 0;
+        Iterator<String> it = songs.iterator();
+        while (it.hasNext()) {
+            String song = it.next();
+            total += song.length();
+        }
+        return total;
+    }
+
+    int countArtists(String prefix) {
+        int count = 0;
+        for (Iterator<String> it = artists.iterator(); it.hasNext();) {
+            String artist = it.next();
+            if (artist.startsWith(prefix)) {
+                count++;
+            }
+        }
+        return count;
+    }
+
+    String firstArtist() {
+        Iterator<String> it = artists.iterator();
+        if (it.hasNext()) {
+            return it.next();
+        }
+        return "";
+    }
+
+    int countAll() {
+        int count = 0;
+        for (String song : this) {
+            count += song.length();
+        }
+        return count;
+    }
+}
+
+class SongIterator implements Iterator<String> {
+    private String remaining;
+
+    SongIterator(String songs) {
+        this.remaining = songs;
+    }
+
+    public boolean hasNext() {
+        return !remaining.isEmpty();
+    }
+
+    public String next() {
+        int end = remaining.indexOf(";");
+        if (end < 0) {
+            end = remaining.length();
+        }
+        String song = remaining.substring(0, end);
+        remaining = end < remaining.length() ? remaining.substring(end + 1) : "";
+        return song;
+    }
+}
@@ -0,0 +1,65 @@
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Set;
+
+public class Inventory {
+    private List<String> items = new ArrayList<>();
+    private List<Integer> counts = new ArrayList<>();
+
+    String first() {
+        if (items.isEmpty()) {
+            return "";
+        }
+        return items.get(0);
+    }
+
+    String rename(int index, String name) {
+        String previous = items.set(index, name);
+        counts.set(index, 0);
+        return previous;
+    }
+
+    int drop(String name) {
+        int index = items.indexOf(name);
+        if (index >= 0) {
+            items.remove(index);
+            counts.remove(index);
+        }
+        return index;
+    }
+
+    boolean discard(String name) {
+        return items.remove(name);
+    }
+
+    int take(int index) {
+        String taken = items.remove(index);
+        counts.remove((Integer) index);
+        return taken.length();
+    }
+
+    boolean has(String name) {
+        return items.contains(name);
+    }
+
+    void merge(List<String> others, Set<String> extra) {
+        items.addAll(others);
+        items.addAll(extra);
+        others.clear();
+    }
+
+    int last(List<String> names) {
+        names.add("end");
+        return names.size() - 1 + items.size();
+    }
+
+    List<String> page(int from, int to) {
+        List<String> result = items.subList(from, to);
+        return result;
+    }
+
+    void reset() {
+        items.clear();
+        counts.clear();
+    }
+}
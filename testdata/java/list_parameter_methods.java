@@ -0,0 +1,46 @@
+import java.util.Arrays;
+import java.util.List;
+
+public class Roster {
+    String first(List<String> names) {
+        if (names.isEmpty()) {
+            return "";
+        }
+        return names.get(0);
+    }
+
+    String rename(List<String> names, int index, String name) {
+        return names.set(index, name);
+    }
+
+    boolean drop(List<String> names, String name) {
+        int index = names.indexOf(name);
+        if (index >= 0) {
+            names.remove(index);
+        }
+        return names.contains(name);
+    }
+
+    void cycle(List<String> names, String name) {
+        names.remove(name);
+        names.add(name);
+    }
+
+    void merge(List<String> names, List<String> others, String[] extra) {
+        names.addAll(others);
+        names.addAll(Arrays.asList(extra));
+        others.clear();
+    }
+
+    List<String> page(List<String> names, int from, int to) {
+        return names.subList(from, to);
+    }
+
+    int total(List<String> names) {
+        int total = 0;
+        for (String name : names) {
+            total += name.length();
+        }
+        return total;
+    }
+}
@@ -0,0 +1,83 @@
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+public class WordCounter {
+    private Map<String, Integer> counts = new HashMap<>();
+
+    void add(String word) {
+        if (this.counts.containsKey(word)) {
+            this.counts.put(word, this.counts.get(word) + 1);
+        } else {
+            this.counts.put(word, 1);
+        }
+    }
+
+    boolean isNew(String word) {
+        if (this.counts.get(word) == null) {
+            return true;
+        }
+        return null != this.counts.get(word + "s");
+    }
+
+    int countOf(String word) {
+        return this.counts.getOrDefault(word, 0);
+    }
+
+    int lookup(String word) {
+        Integer count = this.counts.get(word);
+        if (count == null) {
+            return 0;
+        }
+        return count;
+    }
+
+    Integer forget(String word) {
+        Integer count = this.counts.remove(word);
+        return count;
+    }
+
+    void drop(String word) {
+        this.counts.remove(word);
+    }
+
+    int total() {
+        int sum = 0;
+        for (int count : this.counts.values()) {
+            sum += count;
+        }
+        return sum;
+    }
+
+    String longest() {
+        String result = "";
+        for (String word : this.counts.keySet()) {
+            if (this.counts.get(word) > this.counts.get(result)) {
+                result = word;
+            }
+        }
+        return result;
+    }
+
+    int weighted(Map<String, Integer> weights) {
+        int sum = 0;
+        for (Map.Entry<String, Integer> entry : weights.entrySet()) {
+            sum += entry.getValue() * this.countOf(entry.getKey());
+        }
+        return sum;
+    }
+
+    void merge(Map<String, Integer> other) {
+        if (!other.isEmpty()) {
+            this.counts.putAll(other);
+        }
+    }
+
+    List<String> words() {
+        return this.counts.keySet();
+    }
+
+    void reset() {
+        this.counts.clear();
+    }
+}
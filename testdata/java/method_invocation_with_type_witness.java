@@ -0,0 +1,12 @@
+class Test {
+    void test() {
+        List<String> names = Collections.<String>emptyList();
+        Map<String, Integer> counts = Collections.<String, Integer>emptyMap();
+        Box<Integer> box = Factory.<Integer>create(1);
+        Object value = this.<String>helper();
+    }
+
+    Object helper() {
+        return null;
+    }
+}
@@ -0,0 +1,10 @@
+class Grid {
+    int[][] cells = {{1, 2}, {3, 4}};
+    static final String[][] NAMES = new String[][]{{"a"}, {"b", "c"}};
+
+    void fill() {
+        int[][] local = {{1}, {2, 3}};
+        int[][][] cube = {{{1}}, {{2, 3}}};
+        Object[] mixed = {new int[]{1}, "x"};
+    }
+}
@@ -0,0 +1,3 @@
+public class Outer {
+    public record Inner(int value) {}
+}
@@ -0,0 +1,3 @@
+public interface Container {
+    record Item(String name) {}
+}
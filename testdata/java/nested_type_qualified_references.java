@@ -0,0 +1,16 @@
+public class Parser {
+    public enum Kind {
+        TOKEN,
+        NODE
+    }
+
+    Parser.Kind current;
+
+    boolean isToken(Parser.Kind kind) {
+        return kind == Parser.Kind.TOKEN;
+    }
+
+    Parser.Kind parse(String text) {
+        return Parser.Kind.valueOf(text);
+    }
+}
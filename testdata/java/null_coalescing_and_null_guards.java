@@ -0,0 +1,12 @@
+class Test {
+    Object pick(Object given, Object other) {
+        Object first = given != null ? given : other;
+        Object second = null == given ? lookup() : given;
+        if (other == null) other = first;
+        return (given == null) ? second : given;
+    }
+
+    Object lookup() {
+        return null;
+    }
+}
@@ -0,0 +1,14 @@
+class Test {
+    void test() {
+        int million = 1_000_000;
+        int mask = 0xFF_FF;
+        int upper = 0XCAFE;
+        int allBits = 0xFFFFFFFF;
+        long wide = 0x7fff_ffff_ffffL;
+        int flags = 0b1010;
+        long binaryLong = 0B1L;
+        int permissions = 0777;
+        long octalLong = 017L;
+        int zero = 0;
+    }
+}
@@ -0,0 +1,49 @@
+public class Account {
+    private static final double RATE = 2;
+    private double balance;
+    private long total;
+    private int visits;
+
+    public Account(double balance) {
+        this.balance = balance;
+    }
+
+    public static Account open(int deposit) {
+        return new Account(deposit);
+    }
+
+    public double average(int sum, int count) {
+        double result = sum;
+        result = sum / 2.0;
+        if (count > 0) {
+            return (double) sum / count;
+        }
+        return result;
+    }
+
+    public long widen(int x) {
+        long y = x;
+        long z = 5L;
+        float f = 3;
+        this.total += x;
+        this.balance -= f;
+        return x + y + z;
+    }
+
+    public void deposit(int amount) {
+        this.balance = amount;
+        this.balance += amount * RATE;
+        this.visits += 0.5;
+        this.total = amount;
+        scale(amount);
+    }
+
+    public double scale(double factor) {
+        return factor * this.visits;
+    }
+
+    public long shifted(int bits) {
+        long mask = 1L << bits;
+        return mask | bits;
+    }
+}
@@ -0,0 +1,44 @@
+import java.util.Map;
+import java.util.Optional;
+import java.util.function.Function;
+
+class Lookup {
+    private Optional<String> label;
+    private Function<String, Integer> measure;
+    private Map<String, Integer> counts;
+
+    Optional<Integer> find(int key) {
+        if (key < 0) {
+            return Optional.empty();
+        }
+        return Optional.of(key);
+    }
+
+    int orZero(int key) {
+        return find(key).orElse(0);
+    }
+
+    boolean labelled() {
+        return label.isPresent();
+    }
+
+    String labelText() {
+        return label.get();
+    }
+
+    Optional<Integer> labelLength() {
+        return label.map(measure);
+    }
+
+    Optional<Lookup> self() {
+        return Optional.of(this);
+    }
+
+    Optional<Integer> count(String key) {
+        return Optional.ofNullable(counts.get(key));
+    }
+
+    Optional<Integer> none() {
+        return Optional.ofNullable(null);
+    }
+}
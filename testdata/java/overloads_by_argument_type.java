@@ -0,0 +1,68 @@
+interface Shape {
+    double area();
+}
+
+public class Printer {
+    public static class Square implements Shape {
+        public double area() {
+            return 1;
+        }
+    }
+
+    private int total;
+
+    public Printer(int total) {
+        this.total = total;
+    }
+
+    public Printer(String text) {
+        this.total = text.length();
+    }
+
+    public void print(int value) {
+        total = total + value;
+    }
+
+    public void print(String text) {
+        total = total + text.length();
+    }
+
+    public void print(double value) {
+        total = total + 1;
+    }
+
+    public void print(Shape shape) {
+        total = total + 2;
+    }
+
+    public String label(int value) {
+        return "int";
+    }
+
+    public String label(boolean flag) {
+        return "boolean";
+    }
+
+    static long scale(long value) {
+        return value * 2;
+    }
+
+    static double scale(double value) {
+        return value * 2;
+    }
+
+    static long doubled(int small) {
+        return scale(small) + scale(1);
+    }
+
+    public void run(Printer other, Square square, long big) {
+        print(3);
+        this.print("three");
+        other.print(3.5);
+        print(square);
+        print(big);
+        print(label(true));
+        Printer copy = new Printer("copy");
+        copy.print(total);
+    }
+}
@@ -0,0 +1,7 @@
+/**
+ * Geometry primitives used by the renderer.
+ *
+ * <p>Shapes are immutable; use {@code Shape.scale} to derive new ones.
+ * See {@link Point} for the coordinate system.
+ */
+package com.example.geometry;
@@ -0,0 +1,22 @@
+package com.example.app;
+
+import com.example.geo.Point;
+import com.example.geo.shapes.Circle;
+import static com.example.geo.Util.clamp;
+import com.example.geo.*;
+
+public class Shape {
+    private Point origin;
+
+    public Shape(Point origin) {
+        this.origin = origin;
+    }
+
+    public Circle around(int radius) {
+        return Circle.of(origin, clamp(radius));
+    }
+
+    public int distance(Point other) {
+        return Point.manhattan(origin, other);
+    }
+}
@@ -0,0 +1,41 @@
+class Geometry {
+    private double scale;
+
+    public double area(double w, double h) {
+        return this.scale * product(w, h);
+    }
+
+    public double gap(double a, double b) {
+        return this.distance(a, b);
+    }
+
+    private double product(double a, double b) {
+        return a * b;
+    }
+
+    private double distance(double a, double b) {
+        return abs(a - b);
+    }
+
+    private static double abs(double x) {
+        return x < 0 ? -x : x;
+    }
+
+    // Methods using the instance, directly or not, stay methods
+    private double scaled(double x) {
+        return x * this.scale;
+    }
+
+    private double twiceScaled(double x) {
+        return scaled(x) * 2;
+    }
+
+    // Methods called on other instances stay methods
+    private double shared(double x) {
+        return x;
+    }
+
+    public double other(Geometry g) {
+        return g.shared(1);
+    }
+}
@@ -0,0 +1,19 @@
+public class Geometry {
+    private double scale;
+
+    public double area(double w, double h) {
+        return this.scale * product(w, h);
+    }
+
+    public double gap(double a, double b) {
+        return this.distance(a, b);
+    }
+
+    private double product(double a, double b) {
+        return a * b;
+    }
+
+    private double distance(double a, double b) {
+        return a < b ? b - a : a - b;
+    }
+}
@@ -0,0 +1,26 @@
+public class Shape {
+    private Object other;
+
+    String kind() {
+        return getClass().getSimpleName();
+    }
+
+    String qualifiedKind() {
+        return this.getClass().getName();
+    }
+
+    boolean sameKind(Shape shape) {
+        return this.getClass() == shape.getClass() && this.getClass().equals(this.other.getClass());
+    }
+
+    boolean isShape(Object value) {
+        if (Shape.class.isInstance(value)) {
+            return true;
+        }
+        return this.getClass().isInstance(value);
+    }
+
+    boolean widens(Shape shape) {
+        return Shape.class.isAssignableFrom(shape.getClass());
+    }
+}
@@ -0,0 +1,51 @@
+import java.util.HashSet;
+import java.util.List;
+import java.util.Set;
+
+public class Visitors {
+    private Set<String> seen = new HashSet<>();
+    private Set<String> blocked = Set.of("root", "admin");
+
+    boolean visit(String name) {
+        if (blocked.contains(name)) {
+            return false;
+        }
+        return seen.add(name);
+    }
+
+    void forget(String name) {
+        seen.remove(name);
+    }
+
+    int count() {
+        if (seen.isEmpty()) {
+            return 0;
+        }
+        return seen.size();
+    }
+
+    int totalLength() {
+        int total = 0;
+        for (String name : seen) {
+            total += name.length();
+        }
+        return total;
+    }
+
+    Set<String> known(List<String> names) {
+        Set<String> result = new HashSet<>(names);
+        result.retainAll(seen);
+        return result;
+    }
+
+    boolean merge(Set<String> others, List<String> extra) {
+        boolean changed = seen.addAll(others);
+        seen.addAll(extra);
+        seen.removeAll(blocked);
+        return changed && seen.containsAll(extra);
+    }
+
+    Set<String> snapshot() {
+        return new HashSet<>(seen);
+    }
+}
@@ -0,0 +1,28 @@
+public class Limits {
+    public static final int MAX_DEPTH = 64;
+    public static final long MAX_SIZE = 1L << 40;
+    public static final int MIN_DEPTH = -Limits.MAX_DEPTH;
+    public static final double RATIO = MAX_DEPTH / 2.0;
+    public static final char SEPARATOR = ',';
+    public static final boolean DEEP = MAX_DEPTH > 32 && !false;
+    public static final String PREFIX = "limits";
+    public static final String NAME = PREFIX + ".depth";
+    public static final int NARROW = (int) RATIO;
+    public static final int HALF = (int) (MAX_DEPTH / 2.5);
+    public static final int WIDE = (int) MAX_SIZE;
+    public static final String LABEL = PREFIX + MAX_DEPTH;
+    public static final int[] STEPS = { 1, 2, 4 };
+    public static int counter = 0;
+
+    int clamp(int depth) {
+        final int floor = MIN_DEPTH + 1;
+        final String unit = PREFIX + " units";
+        final int current = depth;
+        int MAX_DEPTH = depth * 2;
+        final int doubled = MAX_DEPTH;
+        if (depth < floor) {
+            return floor + unit.length();
+        }
+        return current < doubled ? current : doubled;
+    }
+}
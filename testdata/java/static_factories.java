@@ -0,0 +1,46 @@
+public class Point {
+    private int x;
+    private int y;
+
+    public Point(int x, int y) {
+        this.x = x;
+        this.y = y;
+    }
+
+    public static Point of(int x, int y) {
+        return new Point(x, y);
+    }
+
+    public static Point of(int value) {
+        return new Point(value, value);
+    }
+
+    public static Point from(String text) {
+        return new Point(text.length(), 0);
+    }
+
+    static Point origin() {
+        return of(0);
+    }
+
+    public Point translate(int dx, int dy) {
+        return Point.of(this.x + dx, this.y + dy);
+    }
+}
+
+class Segment {
+    private Point start;
+    private Point end;
+
+    public Segment(Point start, Point end) {
+        this.start = start;
+        this.end = end;
+    }
+
+    public Point parseEnd(String text) {
+        if (text.isEmpty()) {
+            return Point.origin();
+        }
+        return Point.from(text);
+    }
+}
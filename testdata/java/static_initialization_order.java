@@ -0,0 +1,18 @@
+import java.util.HashMap;
+import java.util.Map;
+
+public class Units {
+    public static final int BASE = 10;
+    public static final int SCALED = Units.LIMIT * BASE;
+    public static final int LIMIT = 100;
+    public static final Map<String, Integer> FACTORS = new HashMap<>();
+
+    static {
+        FACTORS.put("base", BASE);
+        FACTORS.put("limit", LIMIT);
+    }
+
+    public static final int COUNT = FACTORS.size();
+    public static final int FIRST = Units.SECOND + 1;
+    public static final int SECOND = Units.FIRST + 1;
+}
@@ -0,0 +1,43 @@
+public class Temperature {
+    private final double celsius;
+
+    public Temperature(double celsius) {
+        this.celsius = celsius;
+    }
+
+    public static double toFahrenheit(double celsius) {
+        return celsius * 9 / 5 + 32;
+    }
+
+    public static double toFahrenheit(int celsius) {
+        return toFahrenheit((double) celsius);
+    }
+
+    static boolean freezing(double celsius) {
+        return celsius <= 0;
+    }
+
+    public double fahrenheit() {
+        return toFahrenheit(this.celsius);
+    }
+
+    public boolean isFreezing() {
+        return this.freezing(celsius);
+    }
+
+    public static class Scale {
+        static String unit() {
+            return "F";
+        }
+    }
+
+    public static class Report {
+        double difference(Temperature temperature) {
+            return temperature.fahrenheit() - Temperature.toFahrenheit(1);
+        }
+
+        String units() {
+            return Temperature.Scale.unit() + "/" + Scale.unit();
+        }
+    }
+}
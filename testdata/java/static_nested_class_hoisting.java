@@ -0,0 +1,45 @@
+public class Parser {
+    public static class Node {
+        private final String text;
+
+        public Node(String text) {
+            this.text = text;
+        }
+
+        Node copy() {
+            Node node = new Node(text);
+            return node;
+        }
+    }
+
+    static class Cursor {
+        int position;
+
+        Cursor(int position) {
+            this.position = position;
+        }
+    }
+
+    private Node head;
+    private Parser.Cursor cursor;
+
+    Node parse(String text) {
+        this.head = new Node(text);
+        this.cursor = new Cursor(0);
+        return this.head.copy();
+    }
+}
+
+class Lexer {
+    static class Node {
+        char value;
+
+        Node(char value) {
+            this.value = value;
+        }
+    }
+
+    Node first() {
+        return new Lexer.Node('a');
+    }
+}
@@ -0,0 +1,50 @@
+import java.util.Arrays;
+import java.util.List;
+import java.util.Set;
+import java.util.stream.Collectors;
+
+public class Roster {
+    private List<String> names;
+    private Set<String> tags;
+    private Function<String, Integer> score;
+
+    List<String> longNames(int min) {
+        return names.stream().filter(name -> name.length() >= min).collect(Collectors.toList());
+    }
+
+    List<Integer> scores() {
+        List<Integer> result = names.stream().map(score).toList();
+        return result;
+    }
+
+    Set<Integer> doubledLengths() {
+        return names.stream().map(name -> name.length()).map(length -> length * 2).collect(Collectors.toSet());
+    }
+
+    long countTags(String prefix) {
+        return tags.stream().filter(tag -> tag.startsWith(prefix)).count();
+    }
+
+    int positiveTotal(int[] values) {
+        return Arrays.stream(values).filter(v -> v > 0).sum();
+    }
+
+    int totalLength() {
+        return names.stream().mapToInt(name -> name.length()).sum();
+    }
+
+    boolean anyEmpty() {
+        return names.stream().anyMatch(name -> name.isEmpty());
+    }
+
+    boolean allShort() {
+        return names.stream().allMatch(name -> name.length() < 10);
+    }
+
+    void register(String name) {
+    }
+
+    void registerAll() {
+        names.stream().filter(name -> !name.isEmpty()).forEach(name -> register(name));
+    }
+}
@@ -0,0 +1,14 @@
+class Test {
+    void test() {
+        String plain = "tab\tnewline\n";
+        String quotes = "say \"hi\" and \'bye\'";
+        String space = "a\sb";
+        String octal = "\0\7\12\101\377";
+        String unicode = "caf\u00e9 \uD83D\uDE00";
+        char quote = '\'';
+        char doubleQuote = '\"';
+        char nul = '\0';
+        char accent = '\u00e9';
+        char highSurrogate = '\uD83D';
+    }
+}
@@ -0,0 +1,45 @@
+import java.util.List;
+
+public class Printer {
+    private StringBuilder out = new StringBuilder();
+    private List<String> items;
+
+    void line(String text, int indent) {
+        for (int i = 0; i < indent; i++) {
+            this.out.append(' ');
+        }
+        this.out.append(text).append('\n');
+    }
+
+    String join(char separator) {
+        StringBuilder sb = new StringBuilder(16);
+        for (String part : this.items) {
+            if (sb.length() > 0) {
+                sb.append(separator);
+            }
+            sb.append(part);
+        }
+        return sb.toString();
+    }
+
+    String describe(String name, int count, boolean done) {
+        StringBuilder sb = new StringBuilder("item ");
+        sb.append(name).append(": ").append(count).append(", done=").append(done);
+        sb.insert(0, "[").append("]");
+        return sb.toString();
+    }
+
+    String reversed(String text) {
+        StringBuilder sb = new StringBuilder(text);
+        return sb.reverse().toString();
+    }
+
+    StringBuilder reset() {
+        this.out.setLength(0);
+        return this.out;
+    }
+
+    String output() {
+        return this.out.toString() + "!";
+    }
+}
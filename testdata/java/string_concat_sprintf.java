@@ -0,0 +1,28 @@
+public class Parser {
+    private int position;
+    private String source;
+
+    String unexpected(char found, int line, String expected) {
+        return "unexpected '" + found + "' at line " + line + ", expected " + expected + " (100% sure)";
+    }
+
+    String describe(double weight, boolean done) {
+        String prefix = "weight=" + weight;
+        return prefix + "; done=" + done + "; sum=" + (1 + 2) + "\n";
+    }
+
+    String leadingSum(int a, int b) {
+        return a + b + " items";
+    }
+
+    String check(String token) {
+        if (token.equals("end" + this.position)) {
+            return "token " + token + " at " + this.position + " of " + this.source;
+        }
+        return "";
+    }
+
+    String join(String a, String b) {
+        return a + ", " + b + "!";
+    }
+}
@@ -0,0 +1,15 @@
+class Point {
+    private int x;
+    private int y;
+
+    public String toString() {
+        return "(" + String.valueOf(x) + ", " + String.valueOf(y) + ")";
+    }
+
+    String describe(Point other) {
+        String mine = "" + this;
+        String theirs = other.toString();
+        String count = x + "";
+        return mine + theirs + count + toString();
+    }
+}
@@ -0,0 +1,32 @@
+public class Status {
+    private static final String ACTIVE = "active";
+    private static final String CLOSED = "closed";
+    private String state;
+
+    public Status(String state) {
+        this.state = state;
+    }
+
+    public boolean isActive() {
+        return this.state == ACTIVE;
+    }
+
+    public boolean isClosed(String other) {
+        if (other.intern() != CLOSED) {
+            return false;
+        }
+        return this.state.intern() == CLOSED.intern();
+    }
+
+    public boolean sameConstants() {
+        return ACTIVE == "active";
+    }
+
+    public boolean sameState(String other) {
+        return this.state == other;
+    }
+
+    public boolean unset() {
+        return this.state == null;
+    }
+}
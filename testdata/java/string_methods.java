@@ -0,0 +1,59 @@
+public class Tokenizer {
+    private String text;
+
+    int width(String word) {
+        return word.length();
+    }
+
+    boolean isKeyword(String word) {
+        return word.startsWith("k_") && !word.endsWith("_") && word.contains("w");
+    }
+
+    String normalize(String word) {
+        return word.trim().toLowerCase();
+    }
+
+    char first() {
+        return this.text.charAt(0);
+    }
+
+    String tail(int from) {
+        return this.text.substring(from);
+    }
+
+    String slice(int from, int to) {
+        return this.text.substring(from, to + 1);
+    }
+
+    int find(String needle) {
+        int dot = this.text.indexOf('.');
+        if (dot < 0) {
+            return this.text.lastIndexOf(needle);
+        }
+        return dot;
+    }
+
+    String[] words(String line) {
+        return line.split(" ");
+    }
+
+    String[] fields(String line) {
+        return line.split("\\s+");
+    }
+
+    String quoted(String word) {
+        return word.replace('"', '\'').replace("\\", "/");
+    }
+
+    String describe(String name, int count) {
+        return String.format("%s has %d entries%n", name, count);
+    }
+
+    String pair(String left, String right) {
+        return String.join(", ", left, right);
+    }
+
+    boolean same(String a, String b) {
+        return a.equalsIgnoreCase(b) || a.isEmpty();
+    }
+}
@@ -0,0 +1,28 @@
+class Test {
+    int arrow(int k) {
+        return switch (k) {
+            case 1 -> 10;
+            case 2, 3 -> {
+                int t = k * 2;
+                yield t;
+            }
+            default -> throw new IllegalArgumentException("bad");
+        };
+    }
+
+    int colon(int k) {
+        return switch (k) {
+            case 1:
+                yield 10;
+            default:
+                yield 0;
+        };
+    }
+
+    int noDefault(int c) {
+        return switch (c) {
+            case 0 -> 1;
+            case 1 -> 2;
+        };
+    }
+}
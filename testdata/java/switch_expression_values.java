@@ -0,0 +1,45 @@
+public class Dispatcher {
+    enum Level { LOW, HIGH, CRITICAL }
+
+    static final int MODE = 2;
+    static final String MODE_NAME = switch (MODE) {
+        case 1 -> "single";
+        default -> "multi";
+    };
+
+    private int limit = switch (MODE) {
+        case 1 -> 10;
+        default -> 20;
+    };
+
+    int weight(Level level) {
+        return switch (level) {
+            case LOW -> 1;
+            case HIGH, CRITICAL -> {
+                int base = this.limit * 2;
+                yield base;
+            }
+        };
+    }
+
+    long budget(Level level, int days) {
+        return switch (level) {
+            case LOW -> days;
+            default -> days * 2;
+        };
+    }
+
+    String describe(Level level) {
+        return "level " + level;
+    }
+
+    void report(Level level) {
+        log(switch (level) {
+            case LOW -> describe(Level.LOW);
+            default -> describe(level);
+        });
+    }
+
+    void log(String message) {
+    }
+}
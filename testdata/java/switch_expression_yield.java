@@ -0,0 +1,41 @@
+class Grader {
+    int bonus;
+
+    int compute(int k) {
+        return k * 3;
+    }
+
+    int score(int k) {
+        int base = switch (k) {
+            case 1 -> 10;
+            case 2, 3 -> {
+                int t = compute(k);
+                yield t + 1;
+            }
+            default -> 0;
+        };
+        return base;
+    }
+
+    void update(int k) {
+        this.bonus = switch (k) {
+            case 0:
+                yield 5;
+            default:
+                int doubled = k * 2;
+                yield doubled;
+        };
+    }
+
+    String label(int k) {
+        String prefix = "grade ";
+        return prefix + switch (k) {
+            case 1 -> "A";
+            case 2 -> {
+                compute(k);
+                yield "B";
+            }
+            default -> "C";
+        };
+    }
+}
@@ -0,0 +1,42 @@
+class Tally {
+    int total;
+
+    void add(int kind) {
+        switch (kind) {
+            case 1:
+            case 2:
+                this.total += 1;
+                break;
+            case 3, 4:
+                this.total += 3;
+            case 5:
+                this.total += 5;
+                break;
+            default:
+                this.total = 0;
+            case 6:
+                this.total += 6;
+        }
+    }
+
+    int weight(int kind) {
+        switch (kind) {
+            case 1:
+                if (this.total > 10) {
+                    return 2;
+                } else {
+                    return 1;
+                }
+            case 2:
+                if (this.total > 10) {
+                    break;
+                }
+                this.total -= 1;
+                break;
+            case 3:
+            default:
+                return 0;
+        }
+        return this.total;
+    }
+}
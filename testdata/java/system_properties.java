@@ -0,0 +1,23 @@
+public class Platform {
+    public String describe() {
+        String name = System.getProperty("os.name");
+        String arch = System.getProperty("os.arch");
+        return name + "/" + arch + System.lineSeparator();
+    }
+
+    public String joinPath(String dir, String file) {
+        return dir + System.getProperty("file.separator") + file;
+    }
+
+    public String classpath(String first, String second) {
+        return first + System.getProperty("path.separator") + second;
+    }
+
+    public String tempDir() {
+        return System.getProperty("java.io.tmpdir");
+    }
+
+    public String home() {
+        return System.getenv("HOME");
+    }
+}
@@ -0,0 +1,13 @@
+class Env {
+    String home() {
+        return System.getProperty("user.home");
+    }
+
+    String user() {
+        return System.getProperty("user.name", "nobody");
+    }
+
+    String newline() {
+        return System.getProperty("line.separator");
+    }
+}
@@ -0,0 +1,39 @@
+public class Pricing {
+    private int discount;
+
+    int price(int base, boolean member) {
+        int total = member ? base - this.discount : base;
+        return total;
+    }
+
+    String label(int count) {
+        String noun = count == 1 ? "item" : "items";
+        return count + " " + noun;
+    }
+
+    String grade(int score) {
+        String result = score >= 90 ? "A" : score >= 80 ? "B" : (score >= 70 ? "C" : "F");
+        return result;
+    }
+
+    void apply(int base, boolean sale) {
+        this.discount = sale ? base / 10 : 0;
+    }
+
+    int clamp(int value, int limit) {
+        return atLeast(0, value > limit ? limit : value);
+    }
+
+    int atLeast(int low, int value) {
+        return value < low ? low : value;
+    }
+
+    boolean check(int a, int b) {
+        boolean both = a > 0 ? b > 0 : false;
+        return both;
+    }
+
+    String sign(int value) {
+        return "value is " + (value < 0 ? "negative" : "positive");
+    }
+}
@@ -0,0 +1,21 @@
+class Settings {
+    static boolean verbose = true;
+    static int level = verbose ? 2 : 1;
+    static int limit = level * 10;
+    private int width = verbose ? 80 : 40;
+    private int margin = (verbose ? 2 : 1) * 4;
+
+    Settings() {
+    }
+}
+
+enum Mode {
+    FAST(Settings.verbose ? 1 : 2),
+    SLOW(3);
+
+    private final int speed;
+
+    Mode(int speed) {
+        this.speed = speed;
+    }
+}
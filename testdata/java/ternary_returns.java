@@ -0,0 +1,25 @@
+public class Clamp {
+    int max(int a, int b) {
+        return a > b ? a : b;
+    }
+
+    int clamp(int value, int low, int high) {
+        return value < low ? low : value > high ? high : value;
+    }
+
+    int sign(int value) {
+        return (value >= 0 ? (value == 0 ? 0 : 1) : -1);
+    }
+
+    boolean isPositive(int value) {
+        return value > 0;
+    }
+
+    int orDefault(int value) {
+        return isPositive(value) ? value : max(value, 0);
+    }
+
+    void nothing() {
+        return;
+    }
+}
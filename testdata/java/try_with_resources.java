@@ -0,0 +1,45 @@
+class Resource {
+    private String name;
+
+    public Resource(String name) {
+        this.name = name;
+    }
+
+    public String read() {
+        return name;
+    }
+
+    public void close() {
+    }
+}
+
+class Reader {
+    private Resource current;
+
+    String copy(String from, String to) {
+        String result = "";
+        try (Resource source = new Resource(from); Resource target = new Resource(to)) {
+            result = source.read() + target.read();
+        }
+        return result;
+    }
+
+    int size(String path) {
+        int length = 0;
+        try (var source = new Resource(path)) {
+            String text = source.read();
+            length = text.length();
+        } catch (Exception e) {
+            length = -1;
+        } finally {
+            length += 1;
+        }
+        return length;
+    }
+
+    void drain() {
+        try (this.current) {
+            this.current.read();
+        }
+    }
+}
@@ -0,0 +1,41 @@
+import java.util.ArrayList;
+import java.util.HashSet;
+import java.util.List;
+
+public class Ledger {
+    private Tally tally;
+    private List<String> entries = new ArrayList<>();
+
+    int record(String entry, Tally other) {
+        var recent = new ArrayList<String>();
+        var tags = new HashSet<String>();
+        recent.add(entry);
+        tags.add(entry);
+        entries.add(entry);
+        entries.add(0, entry);
+        tally.add(entry);
+        other.add(entry);
+        var first = entries.get(0);
+        return recent.size() + tags.size() + tally.size() + other.size() + first.length() + entries.get(1).length();
+    }
+
+    String latest() {
+        return entries.get(entries.size() - 1);
+    }
+
+    int latestLength() {
+        return latest().trim().length() + latest().length();
+    }
+
+    static class Tally {
+        private int count;
+
+        void add(String entry) {
+            count++;
+        }
+
+        int size() {
+            return count;
+        }
+    }
+}
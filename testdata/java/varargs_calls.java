@@ -0,0 +1,57 @@
+import java.util.HashMap;
+import java.util.Map;
+
+public class Bag {
+    private int[] items;
+
+    Bag(int... items) {
+        this.items = items;
+    }
+
+    int sum(int... values) {
+        int total = 0;
+        for (int value : values) {
+            total += value;
+        }
+        return total;
+    }
+
+    int count(int[] values) {
+        return sum(values);
+    }
+
+    int label(String name, int... values) {
+        return sum(values);
+    }
+
+    int total(int[] extra) {
+        int[] local = {1, 2};
+        int a = sum(1, 2, 3);
+        int b = sum();
+        int c = sum(local);
+        int d = sum(extra);
+        int e = label("x", extra);
+        int f = count(local);
+        int g = this.sum(new int[]{4, 5});
+        return a + b + c + d + e + f + g;
+    }
+
+    int sizes(int[] extra, int... values) {
+        int[] local = {1, 2};
+        Map<String, Integer> lengths = new HashMap<>();
+        lengths.put("extra", extra.length);
+        return lengths.get("extra") + values.length + items.length + local.length;
+    }
+
+    Bag empty() {
+        return new Bag();
+    }
+
+    Bag single(int item) {
+        return new Bag(item);
+    }
+
+    Bag of(int[] values) {
+        return new Bag(values);
+    }
+}
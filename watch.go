@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/heshanpadmasiri/javaGo/java"
+)
+
+// runWatchCommand implements `javaGo watch [flags] <source.java> <dest.go>`.
+// It migrates the source whenever it changes, reparsing incrementally, and
+// only returns (with an exit code) if the arguments are invalid.
+func runWatchCommand(args []string, strictness java.Strictness, cfg config) int {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	interval := flags.Duration("interval", 500*time.Millisecond, "how often to check the source file for changes")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if flags.NArg() != 2 {
+		fmt.Fprintf(os.Stderr, "Usage: javaGo watch [-interval duration] <source.java> <dest.go>\n")
+		return 1
+	}
+	sourcePath, destPath := flags.Arg(0), flags.Arg(1)
+
+	migrator := java.NewIncrementalMigrator(filepath.Base(sourcePath), strictness, cfg.TypeMappings, cfg.options())
+	defer migrator.Close()
+	var lastSource []byte
+	for {
+		javaSource, err := os.ReadFile(sourcePath)
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Error: reading source file failed due to: %v\n", err)
+		case lastSource == nil || !bytes.Equal(javaSource, lastSource):
+			lastSource = javaSource
+			if err := migrateWatched(migrator, javaSource, destPath, cfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: writing %s failed due to: %v\n", destPath, err)
+			}
+		}
+		time.Sleep(*interval)
+	}
+}
+
+// migrateWatched migrates a new version of the watched file into destPath
+func migrateWatched(migrator *java.IncrementalMigrator, javaSource []byte, destPath string, cfg config) error {
+	start := time.Now()
+	ctx := migrator.Migrate(javaSource)
+	if err := writeGoFile(destPath, &ctx.Source, cfg); err != nil {
+		return err
+	}
+	reused, parsed := migrator.LastAnalysis()
+	fmt.Fprintf(os.Stderr, "Migrated %s in %v (%d signatures reused, %d parsed)\n", destPath, time.Since(start).Round(time.Millisecond), reused, parsed)
+	return nil
+}